@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/rpcs"
+)
+
+const (
+	// catchpointDiscoveryMaxPeers is the maximal number of relays queried for their catchpoint labels
+	catchpointDiscoveryMaxPeers = 16
+	// catchpointDiscoveryRequestTimeout is the time we would wait for a single relay to respond with its catchpoint labels
+	catchpointDiscoveryRequestTimeout = 10 * time.Second
+	// catchpointLabelsResponseMaxBytes is the maximal size of the encoded catchpoint labels response
+	catchpointLabelsResponseMaxBytes = 64 * 1024
+)
+
+// DiscoverCatchpointLabel queries the relays for the labels of the catchpoint files they serve, and returns the latest
+// label advertised by at least CatchpointDiscoveryQuorum of them.
+func DiscoverCatchpointLabel(ctx context.Context, log logging.Logger, net network.GossipNode, cfg config.Local) (string, error) {
+	peers := net.GetPeers(network.PeersPhonebookRelays)
+	if len(peers) > catchpointDiscoveryMaxPeers {
+		peers = peers[:catchpointDiscoveryMaxPeers]
+	}
+	quorum := cfg.CatchpointDiscoveryQuorum
+	if quorum == 0 {
+		quorum = 1
+	}
+	if uint64(len(peers)) < quorum {
+		return "", fmt.Errorf("DiscoverCatchpointLabel: only %d relays are available while a quorum of %d is needed", len(peers), quorum)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	counts := make(map[string]uint64)
+	for _, peer := range peers {
+		httpPeer, ok := peer.(network.HTTPPeer)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(httpPeer network.HTTPPeer) {
+			defer wg.Done()
+			labels, err := getPeerCatchpointLabels(ctx, net, httpPeer)
+			if err != nil {
+				log.Debugf("DiscoverCatchpointLabel: unable to retrieve catchpoint labels from %s : %v", httpPeer.GetAddress(), err)
+				return
+			}
+			// count each label once per peer, so that a single peer cannot make up a quorum by repeating a label.
+			seen := make(map[string]bool, len(labels))
+			mu.Lock()
+			defer mu.Unlock()
+			for _, label := range labels {
+				if seen[label] {
+					continue
+				}
+				seen[label] = true
+				counts[label]++
+			}
+		}(httpPeer)
+	}
+	wg.Wait()
+
+	bestLabel := ""
+	var bestRound basics.Round
+	for label, count := range counts {
+		if count < quorum {
+			continue
+		}
+		round, _, err := ledgercore.ParseCatchpointLabel(label)
+		if err != nil {
+			log.Debugf("DiscoverCatchpointLabel: relays advertised an invalid catchpoint label '%s' : %v", label, err)
+			continue
+		}
+		if bestLabel == "" || round > bestRound {
+			bestLabel, bestRound = label, round
+		}
+	}
+	if bestLabel == "" {
+		return "", fmt.Errorf("DiscoverCatchpointLabel: none of the catchpoint labels was advertised by a quorum of %d relays out of %d", quorum, len(peers))
+	}
+	return bestLabel, nil
+}
+
+// getPeerCatchpointLabels retrieves the labels of the catchpoint files the given peer serves.
+func getPeerCatchpointLabels(ctx context.Context, net network.GossipNode, peer network.HTTPPeer) ([]string, error) {
+	parsedURL, err := network.ParseHostOrURL(peer.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	parsedURL.Path = net.SubstituteGenesisID(path.Join(parsedURL.Path, "/v1/{genesisID}/catchpoints"))
+	request, err := http.NewRequest(http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	timeoutContext, timeoutContextCancel := context.WithTimeout(ctx, catchpointDiscoveryRequestTimeout)
+	defer timeoutContextCancel()
+	request = request.WithContext(timeoutContext)
+	network.SetUserAgentHeader(request.Header)
+	response, err := peer.GetHTTPClient().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("getPeerCatchpointLabels error response status code %d", response.StatusCode)
+	}
+	contentTypes := response.Header["Content-Type"]
+	if len(contentTypes) != 1 || contentTypes[0] != rpcs.CatchpointLabelsResponseContentType {
+		response.Body.Close()
+		return nil, fmt.Errorf("getPeerCatchpointLabels : response has an invalid content type : %v", contentTypes)
+	}
+	data, err := rpcs.ResponseBytes(response, logging.Base(), catchpointLabelsResponseMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	var labelsResponse rpcs.CatchpointLabelsResponse
+	err = protocol.Decode(data, &labelsResponse)
+	if err != nil {
+		return nil, err
+	}
+	return labelsResponse.Labels, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/rpcs"
+)
+
+func makeCatchpointLabelsServer(t *testing.T, labels ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/test genesisID/catchpoints", r.URL.Path)
+		w.Header().Set("Content-Type", rpcs.CatchpointLabelsResponseContentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write(protocol.Encode(&rpcs.CatchpointLabelsResponse{Labels: labels}))
+	}))
+}
+
+func TestDiscoverCatchpointLabel(t *testing.T) {
+	makeLabel := func(round basics.Round) string {
+		return ledgercore.MakeCatchpointLabel(round, crypto.Hash([]byte{byte(round)}), crypto.Digest{}, ledgercore.AccountTotals{}).String()
+	}
+	label1000 := makeLabel(1000)
+	label2000 := makeLabel(2000)
+	label3000 := makeLabel(3000)
+
+	net := &httpTestPeerSource{}
+	for _, labels := range [][]string{
+		{label3000, label3000, label2000, label1000},
+		{label2000, label1000},
+		{label2000, label1000},
+		{"invalid label"},
+	} {
+		server := makeCatchpointLabelsServer(t, labels...)
+		defer server.Close()
+		net.addPeer(server.URL)
+	}
+	// a peer which doesn't serve catchpoint labels at all
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	net.addPeer(server.URL)
+
+	cfg := config.GetDefaultLocal()
+	cfg.CatchpointDiscoveryQuorum = 3
+	label, err := DiscoverCatchpointLabel(context.Background(), logging.TestingLog(t), net, cfg)
+	require.NoError(t, err)
+	require.Equal(t, label2000, label)
+
+	cfg.CatchpointDiscoveryQuorum = 1
+	label, err = DiscoverCatchpointLabel(context.Background(), logging.TestingLog(t), net, cfg)
+	require.NoError(t, err)
+	require.Equal(t, label3000, label)
+
+	// a label repeated by a single peer doesn't count towards the quorum more than once.
+	cfg.CatchpointDiscoveryQuorum = 2
+	label, err = DiscoverCatchpointLabel(context.Background(), logging.TestingLog(t), net, cfg)
+	require.NoError(t, err)
+	require.Equal(t, label2000, label)
+
+	cfg.CatchpointDiscoveryQuorum = 4
+	_, err = DiscoverCatchpointLabel(context.Background(), logging.TestingLog(t), net, cfg)
+	require.Error(t, err)
+
+	cfg.CatchpointDiscoveryQuorum = 6
+	_, err = DiscoverCatchpointLabel(context.Background(), logging.TestingLog(t), net, cfg)
+	require.Error(t, err)
+}
@@ -86,6 +86,8 @@ type CatchpointCatchupService struct {
 	abortCtxFunc context.CancelFunc
 	// blocksDownloadPeerSelector is the peer selector used for downloading blocks.
 	blocksDownloadPeerSelector *peerSelector
+	// staticFetchers are the static catchup sources, which are tried before the network peers.
+	staticFetchers []*StaticFetcher
 }
 
 // MakeResumedCatchpointCatchupService creates a catchpoint catchup service for a node that is already in catchpoint catchup mode
@@ -107,6 +109,7 @@ func MakeResumedCatchpointCatchupService(ctx context.Context, node CatchpointCat
 				{initialRank: peerRankInitialFirstPriority, peerClass: network.PeersPhonebookArchivers},
 				{initialRank: peerRankInitialSecondPriority, peerClass: network.PeersPhonebookRelays},
 			}),
+		staticFetchers: MakeStaticFetchers(log, net, &cfg),
 	}
 	service.lastBlockHeader, err = l.BlockHdr(l.Latest())
 	if err != nil {
@@ -144,6 +147,7 @@ func MakeNewCatchpointCatchupService(catchpoint string, node CatchpointCatchupNo
 				{initialRank: peerRankInitialFirstPriority, peerClass: network.PeersPhonebookArchivers},
 				{initialRank: peerRankInitialSecondPriority, peerClass: network.PeersPhonebookRelays},
 			}),
+		staticFetchers: MakeStaticFetchers(log, net, &cfg),
 	}
 	service.lastBlockHeader, err = l.BlockHdr(l.Latest())
 	if err != nil {
@@ -279,10 +283,12 @@ func (cs *CatchpointCatchupService) processStageLedgerDownload() (err error) {
 
 	// download balances file.
 	peerSelector := makePeerSelector(cs.net, []peerClass{{initialRank: peerRankInitialFirstPriority, peerClass: network.PeersPhonebookRelays}})
-	ledgerFetcher := makeLedgerFetcher(cs.net, cs.ledgerAccessor, cs.log, cs, cs.config)
+	ledgerFetcher := makeLedgerFetcher(cs.net, cs.ledgerAccessor, cs.log, cs, cs.config, cs.ledger.CatchpointDownloadDirectory())
 	attemptsCount := 0
 
-	for {
+	// the static catchup sources are tried first; the network peers are used only if none of them served a valid catchpoint file.
+	downloaded := cs.downloadStaticLedger(ledgerFetcher, round)
+	for !downloaded {
 		attemptsCount++
 
 		err = cs.ledgerAccessor.ResetStagingBalances(cs.ctx, true)
@@ -292,21 +298,36 @@ func (cs *CatchpointCatchupService) processStageLedgerDownload() (err error) {
 			}
 			return cs.abort(fmt.Errorf("processStageLedgerDownload failed to reset staging balances : %v", err))
 		}
-		peer, err := peerSelector.GetNextPeer()
+		peers, err := selectLedgerPeers(peerSelector)
 		if err != nil {
 			err = fmt.Errorf("processStageLedgerDownload: catchpoint catchup was unable to obtain a list of peers to retrieve the catchpoint file from")
 			return cs.abort(err)
 		}
-		err = ledgerFetcher.downloadLedger(cs.ctx, peer, round)
+		failedPeers, err := ledgerFetcher.downloadLedgerRanges(cs.ctx, peers, round)
+		if err == errRangesNotSupported {
+			// the peers don't serve byte ranges of the catchpoint file; stream the whole file from one of them instead.
+			failedPeers = nil
+			err = ledgerFetcher.downloadLedger(cs.ctx, peers[0], round)
+			if err != nil {
+				failedPeers = peers[:1]
+			}
+		}
+		for _, peer := range failedPeers {
+			peerSelector.RankPeer(peer, peerRankDownloadFailed)
+		}
 		if err == nil {
 			err = cs.ledgerAccessor.BuildMerkleTrie(cs.ctx, cs.updateVerifiedAccounts)
 			if err == nil {
+				ledgerFetcher.discardStagedLedger()
 				break
 			}
-			// failed to build the merkle trie for the above catchpoint file.
-			peerSelector.RankPeer(peer, peerRankInvalidDownload)
-		} else {
-			peerSelector.RankPeer(peer, peerRankDownloadFailed)
+			if cs.ctx.Err() == nil {
+				// failed to build the merkle trie for the above catchpoint file.
+				ledgerFetcher.discardStagedLedger()
+				for _, peer := range peers {
+					peerSelector.RankPeer(peer, peerRankInvalidDownload)
+				}
+			}
 		}
 
 		// instead of testing for err == cs.ctx.Err() , we'll check on the context itself.
@@ -330,6 +351,53 @@ func (cs *CatchpointCatchupService) processStageLedgerDownload() (err error) {
 	return nil
 }
 
+// downloadStaticLedger attempts to download the catchpoint file of the given round from each of the static catchup sources
+// in turn, and returns true once one of them has been downloaded and verified.
+func (cs *CatchpointCatchupService) downloadStaticLedger(ledgerFetcher *ledgerFetcher, round basics.Round) bool {
+	for _, fetcher := range cs.staticFetchers {
+		err := cs.ledgerAccessor.ResetStagingBalances(cs.ctx, true)
+		if err == nil {
+			err = ledgerFetcher.downloadStaticLedger(cs.ctx, fetcher, round)
+		}
+		if err == nil {
+			err = cs.ledgerAccessor.BuildMerkleTrie(cs.ctx, cs.updateVerifiedAccounts)
+			if err == nil {
+				return true
+			}
+		}
+		if cs.ctx.Err() != nil {
+			return false
+		}
+		cs.log.Infof("unable to download ledger from static source %s : %v", fetcher.Address(), err)
+	}
+	return false
+}
+
+// selectLedgerPeers returns the peers to download the catchpoint file from; up to
+// catchpointDownloadParallelism distinct peers out of the best ranked ones.
+func selectLedgerPeers(peerSelector *peerSelector) (peers []network.Peer, err error) {
+	for i := 0; i < 2*catchpointDownloadParallelism && len(peers) < catchpointDownloadParallelism; i++ {
+		peer, err := peerSelector.GetNextPeer()
+		if err != nil {
+			if len(peers) > 0 {
+				break
+			}
+			return nil, err
+		}
+		duplicate := false
+		for _, selected := range peers {
+			if peerAddress(selected) == peerAddress(peer) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			peers = append(peers, peer)
+		}
+	}
+	return peers, nil
+}
+
 // updateVerifiedAccounts update the user's statistics for the given verified accounts
 func (cs *CatchpointCatchupService) updateVerifiedAccounts(verifiedAccounts uint64) {
 	cs.statsMu.Lock()
@@ -579,6 +647,14 @@ func (cs *CatchpointCatchupService) processStageBlocksDownload() (err error) {
 // The method return stop=true if the caller should exit the current operation
 // If the method return a nil block, the caller is expected to retry the operation, increasing the retry counter as needed.
 func (cs *CatchpointCatchupService) fetchBlock(round basics.Round, retryCount uint64) (blk *bookkeeping.Block, downloadDuration time.Duration, peer network.Peer, stop bool, err error) {
+	if retryCount <= 1 {
+		// the first attempt of each block goes to the static catchup sources; retries are left to the network peers.
+		blockDownloadStartTime := time.Now()
+		if blk = cs.fetchStaticBlock(round); blk != nil {
+			return blk, time.Now().Sub(blockDownloadStartTime), nil, false, nil
+		}
+	}
+
 	peer, err = cs.blocksDownloadPeerSelector.GetNextPeer()
 	if err != nil {
 		err = fmt.Errorf("fetchBlock: unable to obtain a list of peers to retrieve the latest block from")
@@ -616,6 +692,22 @@ func (cs *CatchpointCatchupService) fetchBlock(round basics.Round, retryCount ui
 	return blk, downloadDuration, peer, false, nil
 }
 
+// fetchStaticBlock attempts to fetch the block of the given round from each of the static catchup sources in turn,
+// and returns nil if none of them has it.
+func (cs *CatchpointCatchupService) fetchStaticBlock(round basics.Round) *bookkeeping.Block {
+	for _, fetcher := range cs.staticFetchers {
+		blk, _, err := fetcher.FetchBlock(cs.ctx, round)
+		if err == nil {
+			return blk
+		}
+		if cs.ctx.Err() != nil {
+			return nil
+		}
+		cs.log.Debugf("fetchStaticBlock: unable to download block %d : %v", round, err)
+	}
+	return nil
+}
+
 // processStageLedgerDownload is the fifth catchpoint catchup stage. It completes the catchup process, swap the new tables and restart the node functionality.
 func (cs *CatchpointCatchupService) processStageSwitch() (err error) {
 	err = cs.ledgerAccessor.CompleteCatchup(cs.ctx)
@@ -63,6 +63,9 @@ type NetworkFetcherFactory struct {
 	peerLimit int
 	cfg       *config.Local
 
+	// staticClients are the fetcher clients of the static catchup sources, which are used alongside the network peers
+	staticClients []FetcherClient
+
 	log logging.Logger
 }
 
@@ -83,6 +86,9 @@ func MakeNetworkFetcherFactory(net network.GossipNode, peerLimit int, cfg *confi
 	factory.peerLimit = peerLimit
 	factory.log = logging.Base()
 	factory.cfg = cfg
+	for _, staticFetcher := range MakeStaticFetchers(factory.log, net, cfg) {
+		factory.staticClients = append(factory.staticClients, staticFetcher)
+	}
 	return factory
 }
 
@@ -90,18 +96,18 @@ func MakeNetworkFetcherFactory(net network.GossipNode, peerLimit int, cfg *confi
 func (factory NetworkFetcherFactory) BuildFetcherClients() []FetcherClient {
 	peers := factory.net.GetPeers(network.PeersPhonebookRelays)
 	factory.log.Debugf("%d outgoing peers", len(peers))
-	if len(peers) == 0 {
+	if len(peers) == 0 && len(factory.staticClients) == 0 {
 		factory.log.Warn("no outgoing peers for BuildFetcherClients")
 		return nil
 	}
-	out := make([]FetcherClient, 0, len(peers))
+	out := make([]FetcherClient, 0, len(peers)+len(factory.staticClients))
 	for _, peer := range peers {
 		fetcher := factory.makeHTTPFetcherFromPeer(factory.log, peer)
 		if fetcher != nil {
 			out = append(out, fetcher)
 		}
 	}
-	return out
+	return append(out, factory.staticClients...)
 }
 
 // New returns a new fetcher
@@ -18,15 +18,23 @@ package catchup
 
 import (
 	"archive/tar"
+	"bufio"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/algorand/go-deadlock"
+
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/ledger"
@@ -45,10 +53,19 @@ const (
 	defaultMinCatchpointFileDownloadBytesPerSecond = 20 * 1024
 	// catchpointFileStreamReadSize defines the number of bytes we would attempt to read at each itration from the incoming http data stream
 	catchpointFileStreamReadSize = 4096
+	// catchpointDownloadRangeSize is the size of the byte ranges the compressed catchpoint file is downloaded in
+	catchpointDownloadRangeSize = 4 * 1024 * 1024
+	// catchpointDownloadParallelism is the number of byte ranges of the catchpoint file being downloaded concurrently, each from a different peer
+	catchpointDownloadParallelism = 4
+	// catchpointStagedSizeFile is the name of the file holding the size of the catchpoint file being staged
+	catchpointStagedSizeFile = "size"
 )
 
 var errNonHTTPPeer = fmt.Errorf("downloadLedger : non-HTTPPeer encountered")
 
+// errRangesNotSupported is returned when none of the peers serves byte ranges of the catchpoint file.
+var errRangesNotSupported = errors.New("downloadLedgerRanges : none of the peers support downloading byte ranges of the catchpoint file")
+
 type ledgerFetcherReporter interface {
 	updateLedgerFetcherProgress(*ledger.CatchpointCatchupAccessorProgress)
 }
@@ -60,15 +77,19 @@ type ledgerFetcher struct {
 
 	reporter ledgerFetcherReporter
 	config   config.Local
+
+	// stagingDir is the directory the ranges of the catchpoint file are staged in while downloading it
+	stagingDir string
 }
 
-func makeLedgerFetcher(net network.GossipNode, accessor ledger.CatchpointCatchupAccessor, log logging.Logger, reporter ledgerFetcherReporter, cfg config.Local) *ledgerFetcher {
+func makeLedgerFetcher(net network.GossipNode, accessor ledger.CatchpointCatchupAccessor, log logging.Logger, reporter ledgerFetcherReporter, cfg config.Local, stagingDir string) *ledgerFetcher {
 	return &ledgerFetcher{
-		net:      net,
-		accessor: accessor,
-		log:      log,
-		reporter: reporter,
-		config:   cfg,
+		net:        net,
+		accessor:   accessor,
+		log:        log,
+		reporter:   reporter,
+		config:     cfg,
+		stagingDir: stagingDir,
 	}
 }
 
@@ -80,14 +101,21 @@ func (lf *ledgerFetcher) downloadLedger(ctx context.Context, peer network.Peer,
 	return lf.getPeerLedger(ctx, httpPeer, round)
 }
 
-func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round) error {
+// ledgerURL returns the url of the catchpoint file for the given round on the given peer.
+func (lf *ledgerFetcher) ledgerURL(peer network.HTTPPeer, round basics.Round) (string, error) {
 	parsedURL, err := network.ParseHostOrURL(peer.GetAddress())
 	if err != nil {
-		return err
+		return "", err
 	}
-
 	parsedURL.Path = lf.net.SubstituteGenesisID(path.Join(parsedURL.Path, "/v1/{genesisID}/ledger/"+strconv.FormatUint(uint64(round), 36)))
-	ledgerURL := parsedURL.String()
+	return parsedURL.String(), nil
+}
+
+func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPeer, round basics.Round) error {
+	ledgerURL, err := lf.ledgerURL(peer, round)
+	if err != nil {
+		return err
+	}
 	lf.log.Debugf("ledger GET %#v peer %#v %T", ledgerURL, peer, peer)
 	request, err := http.NewRequest(http.MethodGet, ledgerURL, nil)
 	if err != nil {
@@ -127,6 +155,24 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 		return err
 	}
 
+	return lf.processLedgerStream(ctx, response.Body)
+}
+
+// downloadStaticLedger streams the catchpoint file of the given round off a static catchup source into the staging balances.
+func (lf *ledgerFetcher) downloadStaticLedger(ctx context.Context, fetcher *StaticFetcher, round basics.Round) error {
+	timeoutContext, timeoutContextCancel := context.WithTimeout(ctx, lf.config.MaxCatchpointDownloadDuration)
+	defer timeoutContextCancel()
+	lf.log.Debugf("ledger %d from static source %s", round, fetcher.Address())
+	stream, err := fetcher.GetLedger(timeoutContext, round)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	return lf.processLedgerStream(timeoutContext, stream)
+}
+
+// processLedgerStream reads the uncompressed catchpoint file off the given stream, and processes each of its chunks into the staging balances.
+func (lf *ledgerFetcher) processLedgerStream(ctx context.Context, stream io.Reader) error {
 	// maxCatchpointFileChunkDownloadDuration is the maximum amount of time we would wait to download a single chunk off a catchpoint file
 	maxCatchpointFileChunkDownloadDuration := 2 * time.Minute
 	if lf.config.MinCatchpointFileDownloadBytesPerSecond > 0 {
@@ -135,7 +181,7 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 		maxCatchpointFileChunkDownloadDuration += maxCatchpointFileChunkSize * time.Second / defaultMinCatchpointFileDownloadBytesPerSecond
 	}
 
-	watchdogReader := util.MakeWatchdogStreamReader(response.Body, catchpointFileStreamReadSize, 2*maxCatchpointFileChunkSize, maxCatchpointFileChunkDownloadDuration)
+	watchdogReader := util.MakeWatchdogStreamReader(stream, catchpointFileStreamReadSize, 2*maxCatchpointFileChunkSize, maxCatchpointFileChunkDownloadDuration)
 	defer watchdogReader.Close()
 	tarReader := tar.NewReader(watchdogReader)
 	var downloadProgress ledger.CatchpointCatchupAccessorProgress
@@ -148,7 +194,7 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 			return err
 		}
 		if header.Size > maxCatchpointFileChunkSize || header.Size < 1 {
-			return fmt.Errorf("getPeerLedger received a tar header with data size of %d", header.Size)
+			return fmt.Errorf("processLedgerStream received a tar header with data size of %d", header.Size)
 		}
 		balancesBlockBytes := make([]byte, header.Size)
 		readComplete := int64(0)
@@ -161,7 +207,7 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 					if readComplete == header.Size {
 						break
 					}
-					err = fmt.Errorf("getPeerLedger received io.EOF while reading from tar file stream prior of reaching chunk size %d / %d", readComplete, header.Size)
+					err = fmt.Errorf("processLedgerStream received io.EOF while reading from tar file stream prior of reaching chunk size %d / %d", readComplete, header.Size)
 				}
 				return err
 			}
@@ -177,7 +223,7 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 			if err == io.EOF {
 				return nil
 			}
-			err = fmt.Errorf("getPeerLedger received the following error while reading the catchpoint file : %v", err)
+			err = fmt.Errorf("processLedgerStream received the following error while reading the catchpoint file : %v", err)
 			return err
 		}
 	}
@@ -186,3 +232,509 @@ func (lf *ledgerFetcher) getPeerLedger(ctx context.Context, peer network.HTTPPee
 func (lf *ledgerFetcher) processBalancesBlock(ctx context.Context, sectionName string, bytes []byte, downloadProgress *ledger.CatchpointCatchupAccessorProgress) error {
 	return lf.accessor.ProgressStagingBalances(ctx, sectionName, bytes, downloadProgress)
 }
+
+// downloadLedgerRanges downloads the compressed catchpoint file for the given round in byte ranges,
+// several of them at a time, each from a different peer. Every completed range is staged on disk so
+// that an interrupted download, including one interrupted by a restart of the node, resumes from the
+// ranges already downloaded. The staged file is verified as its ranges arrive, and only once all of them
+// are there and valid its balances are passed on to the accessor. The peers that failed to provide a valid
+// range are returned. Catchpoint files are generated deterministically, so the ranges from different peers fit together.
+func (lf *ledgerFetcher) downloadLedgerRanges(ctx context.Context, peers []network.Peer, round basics.Round) (failedPeers []network.Peer, err error) {
+	httpPeers := make([]network.HTTPPeer, 0, len(peers))
+	for _, peer := range peers {
+		if httpPeer, ok := peer.(network.HTTPPeer); ok {
+			httpPeers = append(httpPeers, httpPeer)
+		}
+	}
+	if len(httpPeers) == 0 {
+		return nil, errNonHTTPPeer
+	}
+	if lf.stagingDir == "" {
+		return nil, errRangesNotSupported
+	}
+	dir, err := lf.makeStagingDir(round)
+	if err != nil {
+		return nil, err
+	}
+	failed := make([]bool, len(httpPeers))
+	defer func() {
+		for i, peer := range httpPeers {
+			if failed[i] {
+				failedPeers = append(failedPeers, peer)
+			}
+		}
+	}()
+
+	size, err := readStagedSize(dir)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		size, err = lf.stageFirstRange(ctx, dir, httpPeers, failed, round)
+		if err != nil {
+			return
+		}
+	}
+
+	err = lf.downloadStagedRanges(ctx, dir, httpPeers, failed, round, size)
+	if err != nil {
+		return
+	}
+
+	var downloadProgress ledger.CatchpointCatchupAccessorProgress
+	_, err = readStagedLedger(dir, size, nil, func(sectionName string, bytes []byte) error {
+		err := lf.processBalancesBlock(ctx, sectionName, bytes, &downloadProgress)
+		if err == nil && lf.reporter != nil {
+			lf.reporter.updateLedgerFetcherProgress(&downloadProgress)
+		}
+		return err
+	})
+	if err != nil && ctx.Err() == nil {
+		// the file can't be processed even though it's intact, so it has to be downloaded anew.
+		lf.discardStagedLedger()
+	}
+	return
+}
+
+// stageFirstRange downloads the first range of the catchpoint file, which tells the size of the whole
+// file, from the first peer that provides it. It returns errRangesNotSupported if none of the peers
+// failed other than by not supporting byte ranges.
+func (lf *ledgerFetcher) stageFirstRange(ctx context.Context, dir string, peers []network.HTTPPeer, failed []bool, round basics.Round) (int64, error) {
+	rangesSupported := false
+	for i, peer := range peers {
+		data, size, err := lf.getPeerLedgerRange(ctx, peer, round, 0, catchpointDownloadRangeSize)
+		if err == nil {
+			err = writeStagedRange(dir, 0, data)
+			if err != nil {
+				return 0, err
+			}
+			err = ioutil.WriteFile(filepath.Join(dir, catchpointStagedSizeFile), []byte(strconv.FormatInt(size, 10)), 0600)
+			return size, err
+		}
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		lf.log.Infof("downloadLedgerRanges: unable to download the first range of the catchpoint file for round %d from %s : %v", round, peer.GetAddress(), err)
+		if err != errRangesNotSupported {
+			rangesSupported = true
+			failed[i] = true
+		}
+	}
+	if !rangesSupported {
+		return 0, errRangesNotSupported
+	}
+	return 0, fmt.Errorf("downloadLedgerRanges: no peer provided the first range of the catchpoint file for round %d", round)
+}
+
+// downloadStagedRanges downloads the ranges of the catchpoint file that haven't been staged yet, and
+// verifies the staged file as its ranges arrive. The workers start with different peers, and move on to
+// the next peer whenever a peer fails. A range that fails the verification is dropped and downloaded
+// again, and the peer it came from isn't asked for any more ranges.
+func (lf *ledgerFetcher) downloadStagedRanges(ctx context.Context, dir string, peers []network.HTTPPeer, failed []bool, round basics.Round, size int64) error {
+	rangesCount := stagedRangesCount(size)
+	// staged has a channel for each range which is closed once the range is staged, and source the
+	// index of the peer each range came from, or -1 for ranges staged by an earlier download.
+	staged := make([]chan struct{}, rangesCount)
+	source := make([]int, rangesCount)
+	// every range is pending at most once at a time, so adding a range never blocks.
+	pending := make(chan int, rangesCount)
+	for i := 0; i < rangesCount; i++ {
+		staged[i] = make(chan struct{})
+		source[i] = -1
+		if _, err := os.Stat(stagedRangePath(dir, i)); err == nil {
+			close(staged[i])
+		} else {
+			pending <- i
+		}
+	}
+
+	downloadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mu deadlock.Mutex
+	var downloadErr error
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if downloadErr == nil {
+			downloadErr = err
+		}
+		cancel()
+	}
+	// nextPeer returns the index of the first peer which haven't failed yet, starting at the given index.
+	nextPeer := func(start int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := 0; i < len(peers); i++ {
+			if !failed[(start+i)%len(peers)] {
+				return (start + i) % len(peers)
+			}
+		}
+		return -1
+	}
+
+	workers := catchpointDownloadParallelism
+	if workers > len(peers) {
+		workers = len(peers)
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(peerIdx int) {
+			defer wg.Done()
+			for {
+				var rangeIdx int
+				select {
+				case rangeIdx = <-pending:
+				case <-downloadCtx.Done():
+					return
+				}
+				for {
+					if downloadCtx.Err() != nil {
+						return
+					}
+					peerIdx = nextPeer(peerIdx)
+					if peerIdx < 0 {
+						setErr(fmt.Errorf("downloadLedgerRanges: no peer provided range %d of the catchpoint file for round %d", rangeIdx, round))
+						return
+					}
+					err := lf.downloadStagedRange(downloadCtx, dir, peers[peerIdx], round, rangeIdx, size)
+					if err == nil {
+						mu.Lock()
+						source[rangeIdx] = peerIdx
+						close(staged[rangeIdx])
+						mu.Unlock()
+						break
+					}
+					if downloadCtx.Err() != nil {
+						return
+					}
+					lf.log.Infof("downloadLedgerRanges: unable to download range %d of the catchpoint file for round %d from %s : %v", rangeIdx, round, peers[peerIdx].GetAddress(), err)
+					mu.Lock()
+					failed[peerIdx] = true
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+
+	waitStaged := func(rangeIdx int) error {
+		mu.Lock()
+		rangeStaged := staged[rangeIdx]
+		mu.Unlock()
+		select {
+		case <-rangeStaged:
+			return nil
+		case <-downloadCtx.Done():
+			return downloadCtx.Err()
+		}
+	}
+	for downloadCtx.Err() == nil {
+		badRange, err := readStagedLedger(dir, size, waitStaged, func(string, []byte) error { return nil })
+		if err == nil || downloadCtx.Err() != nil {
+			break
+		}
+		if badRange < 0 {
+			// a failed checksum can't be attributed to any particular range, so all of them are dropped.
+			for i := 0; i < rangesCount; i++ {
+				lf.dropStagedRange(dir, i)
+			}
+			setErr(fmt.Errorf("downloadLedgerRanges: the staged catchpoint file for round %d failed verification : %v", round, err))
+			break
+		}
+		mu.Lock()
+		badSource := source[badRange]
+		if badSource >= 0 {
+			failed[badSource] = true
+		}
+		source[badRange] = -1
+		staged[badRange] = make(chan struct{})
+		mu.Unlock()
+		if badSource >= 0 {
+			lf.log.Infof("downloadLedgerRanges: range %d of the catchpoint file for round %d from %s failed verification : %v", badRange, round, peers[badSource].GetAddress(), err)
+		} else {
+			lf.log.Infof("downloadLedgerRanges: staged range %d of the catchpoint file for round %d failed verification : %v", badRange, round, err)
+		}
+		lf.dropStagedRange(dir, badRange)
+		pending <- badRange
+	}
+	cancel()
+	wg.Wait()
+
+	if downloadErr != nil {
+		return downloadErr
+	}
+	return ctx.Err()
+}
+
+// downloadStagedRange downloads a single range of the catchpoint file from the given peer, and stages it.
+func (lf *ledgerFetcher) downloadStagedRange(ctx context.Context, dir string, peer network.HTTPPeer, round basics.Round, rangeIdx int, size int64) error {
+	offset := int64(rangeIdx) * catchpointDownloadRangeSize
+	data, peerSize, err := lf.getPeerLedgerRange(ctx, peer, round, offset, catchpointDownloadRangeSize)
+	if err != nil {
+		return err
+	}
+	if peerSize != size {
+		return fmt.Errorf("peer has a catchpoint file of %d bytes rather than %d bytes", peerSize, size)
+	}
+	return writeStagedRange(dir, rangeIdx, data)
+}
+
+// getPeerLedgerRange downloads length bytes starting at offset of the compressed catchpoint file for
+// the given round, or less if the file ends before that. It returns the bytes along with the size of the file.
+func (lf *ledgerFetcher) getPeerLedgerRange(ctx context.Context, peer network.HTTPPeer, round basics.Round, offset, length int64) (data []byte, size int64, err error) {
+	ledgerURL, err := lf.ledgerURL(peer, round)
+	if err != nil {
+		return nil, 0, err
+	}
+	request, err := http.NewRequest(http.MethodGet, ledgerURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	minBytesPerSecond := lf.config.MinCatchpointFileDownloadBytesPerSecond
+	if minBytesPerSecond == 0 {
+		minBytesPerSecond = defaultMinCatchpointFileDownloadBytesPerSecond
+	}
+	timeoutContext, timeoutContextCancel := context.WithTimeout(ctx, 2*time.Minute+time.Duration(length)*time.Second/time.Duration(minBytesPerSecond))
+	defer timeoutContextCancel()
+	request = request.WithContext(timeoutContext)
+	network.SetUserAgentHeader(request.Header)
+	request.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	response, err := peer.GetHTTPClient().Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// the peer ignored the range, and sends the whole file.
+		return nil, 0, errRangesNotSupported
+	case http.StatusNotFound:
+		return nil, 0, errNoLedgerForRound
+	default:
+		return nil, 0, fmt.Errorf("getPeerLedgerRange error response status code %d", response.StatusCode)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != rpcs.LedgerResponseContentType {
+		return nil, 0, fmt.Errorf("getPeerLedgerRange : http ledger fetcher response has an invalid content type : %s", contentType)
+	}
+
+	var first, last int64
+	contentRange := response.Header.Get("Content-Range")
+	_, err = fmt.Sscanf(contentRange, "bytes %d-%d/%d", &first, &last, &size)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getPeerLedgerRange : invalid content range '%s' : %v", contentRange, err)
+	}
+	expectedLast := offset + length - 1
+	if expectedLast >= size {
+		expectedLast = size - 1
+	}
+	if first != offset || last != expectedLast {
+		return nil, 0, fmt.Errorf("getPeerLedgerRange : content range '%s' doesn't match the requested range %d-%d", contentRange, offset, offset+length-1)
+	}
+
+	data = make([]byte, last-first+1)
+	_, err = io.ReadFull(response.Body, data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, size, nil
+}
+
+// makeStagingDir creates the staging directory for the catchpoint file of the given round, and
+// removes the ones left by downloads of other rounds.
+func (lf *ledgerFetcher) makeStagingDir(round basics.Round) (string, error) {
+	dirName := strconv.FormatUint(uint64(round), 10)
+	entries, err := ioutil.ReadDir(lf.stagingDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.Name() != dirName {
+			err = os.RemoveAll(filepath.Join(lf.stagingDir, entry.Name()))
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	dir := filepath.Join(lf.stagingDir, dirName)
+	return dir, os.MkdirAll(dir, 0700)
+}
+
+// discardStagedLedger removes the staged catchpoint files.
+func (lf *ledgerFetcher) discardStagedLedger() {
+	if lf.stagingDir == "" {
+		return
+	}
+	err := os.RemoveAll(lf.stagingDir)
+	if err != nil {
+		lf.log.Warnf("unable to remove the staged catchpoint files : %v", err)
+	}
+}
+
+// dropStagedRange removes a staged range of the catchpoint file.
+func (lf *ledgerFetcher) dropStagedRange(dir string, rangeIdx int) {
+	err := os.Remove(stagedRangePath(dir, rangeIdx))
+	if err != nil && !os.IsNotExist(err) {
+		lf.log.Warnf("unable to remove staged range %d of the catchpoint file : %v", rangeIdx, err)
+	}
+}
+
+// readStagedLedger reads the staged catchpoint file, calling process with each of its entries. When
+// wait isn't nil, it's called before reading each of the ranges, to wait for the range to be staged.
+// On error, it returns the index of the range being read when the error occurred, or -1 if the error
+// can't be attributed to any particular range.
+func readStagedLedger(dir string, size int64, wait func(rangeIdx int) error, process func(sectionName string, bytes []byte) error) (badRange int, err error) {
+	ranges := &stagedRangesReader{dir: dir, count: stagedRangesCount(size), wait: wait, current: -1}
+	defer ranges.Close()
+	gzipReader, err := gzip.NewReader(ranges)
+	if err != nil {
+		return ranges.current, err
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ranges.badRange(err), err
+		}
+		if header.Size > maxCatchpointFileChunkSize || header.Size < 1 {
+			return ranges.current, fmt.Errorf("readStagedLedger found a tar header with data size of %d", header.Size)
+		}
+		bytes := make([]byte, header.Size)
+		_, err = io.ReadFull(tarReader, bytes)
+		if err != nil {
+			return ranges.badRange(err), err
+		}
+		err = process(header.Name, bytes)
+		if err != nil {
+			return ranges.current, err
+		}
+	}
+	// the gzip checksum is only verified once its stream is read to the end.
+	_, err = io.Copy(ioutil.Discard, gzipReader)
+	if err != nil {
+		return ranges.badRange(err), err
+	}
+	return -1, nil
+}
+
+// readStagedSize returns the size of the staged catchpoint file, or -1 if it isn't known yet.
+func readStagedSize(dir string) (int64, error) {
+	sizeBytes, err := ioutil.ReadFile(filepath.Join(dir, catchpointStagedSizeFile))
+	if os.IsNotExist(err) {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	size, err := strconv.ParseInt(string(sizeBytes), 10, 64)
+	if err != nil || size <= 0 {
+		// start over if the size file is corrupted.
+		return -1, os.Remove(filepath.Join(dir, catchpointStagedSizeFile))
+	}
+	return size, nil
+}
+
+// writeStagedRange writes a downloaded range to the staging directory. The range is
+// written to a temporary file first, so that partially written ranges are never used.
+func writeStagedRange(dir string, rangeIdx int, data []byte) error {
+	rangePath := stagedRangePath(dir, rangeIdx)
+	err := ioutil.WriteFile(rangePath+".tmp", data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(rangePath+".tmp", rangePath)
+}
+
+func stagedRangePath(dir string, rangeIdx int) string {
+	return filepath.Join(dir, fmt.Sprintf("range-%06d", rangeIdx))
+}
+
+func stagedRangesCount(size int64) int {
+	return int((size + catchpointDownloadRangeSize - 1) / catchpointDownloadRangeSize)
+}
+
+// stagedRangesReader reads the staged ranges of the catchpoint file one after the other. It's an
+// io.ByteReader, so that the decompressor doesn't read ahead of the data it needs, and a decoding
+// error could be attributed to the range being read when it occurs.
+type stagedRangesReader struct {
+	dir   string
+	count int
+	wait  func(rangeIdx int) error
+	// current is the index of the range being read, or -1 before the first one.
+	current  int
+	file     *os.File
+	buffered *bufio.Reader
+}
+
+func (r *stagedRangesReader) Read(p []byte) (int, error) {
+	for {
+		if r.buffered != nil {
+			n, err := r.buffered.Read(p)
+			if n > 0 || err != io.EOF {
+				return n, err
+			}
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (r *stagedRangesReader) ReadByte() (byte, error) {
+	for {
+		if r.buffered != nil {
+			b, err := r.buffered.ReadByte()
+			if err != io.EOF {
+				return b, err
+			}
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// next opens the next staged range, waiting for it to be staged first.
+func (r *stagedRangesReader) next() error {
+	if r.current+1 >= r.count {
+		return io.EOF
+	}
+	r.Close()
+	r.current++
+	if r.wait != nil {
+		if err := r.wait(r.current); err != nil {
+			return err
+		}
+	}
+	file, err := os.Open(stagedRangePath(r.dir, r.current))
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.buffered = bufio.NewReader(file)
+	return nil
+}
+
+// badRange returns the range the given reading error is attributed to.
+func (r *stagedRangesReader) badRange(err error) int {
+	if err == gzip.ErrChecksum {
+		return -1
+	}
+	return r.current
+}
+
+func (r *stagedRangesReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	r.buffered = nil
+	return err
+}
@@ -17,20 +17,30 @@
 package catchup
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/algorand/go-algorand/components/mocks"
 	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/rpcs"
 )
 
 type dummyLedgerFetcherReporter struct {
@@ -40,7 +50,7 @@ func (lf *dummyLedgerFetcherReporter) updateLedgerFetcherProgress(*ledger.Catchp
 }
 
 func TestNoPeersAvailable(t *testing.T) {
-	lf := makeLedgerFetcher(&mocks.MockNetwork{}, &mocks.MockCatchpointCatchupAccessor{}, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal())
+	lf := makeLedgerFetcher(&mocks.MockNetwork{}, &mocks.MockCatchpointCatchupAccessor{}, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal(), "")
 	var peer network.Peer
 	peer = &lf // The peer is an opaque interface.. we can add anything as a Peer.
 	err := lf.downloadLedger(context.Background(), peer, basics.Round(0))
@@ -48,7 +58,7 @@ func TestNoPeersAvailable(t *testing.T) {
 }
 
 func TestNonParsableAddress(t *testing.T) {
-	lf := makeLedgerFetcher(&mocks.MockNetwork{}, &mocks.MockCatchpointCatchupAccessor{}, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal())
+	lf := makeLedgerFetcher(&mocks.MockNetwork{}, &mocks.MockCatchpointCatchupAccessor{}, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal(), "")
 	peer := testHTTPPeer(":def")
 	err := lf.getPeerLedger(context.Background(), &peer, basics.Round(0))
 	require.Error(t, err)
@@ -76,7 +86,7 @@ func TestLedgerFetcherErrorResponseHandling(t *testing.T) {
 		w.WriteHeader(httpServerResponse)
 	})
 
-	lf := makeLedgerFetcher(&mocks.MockNetwork{}, &mocks.MockCatchpointCatchupAccessor{}, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal())
+	lf := makeLedgerFetcher(&mocks.MockNetwork{}, &mocks.MockCatchpointCatchupAccessor{}, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal(), "")
 	peer := testHTTPPeer(listener.Addr().String())
 	err = lf.getPeerLedger(context.Background(), &peer, basics.Round(0))
 	require.Equal(t, errNoLedgerForRound, err)
@@ -97,3 +107,145 @@ func TestLedgerFetcherErrorResponseHandling(t *testing.T) {
 	err = lf.getPeerLedger(context.Background(), &peer, basics.Round(0))
 	require.Equal(t, fmt.Errorf("getPeerLedger : http ledger fetcher response has an invalid content type : %s", contentTypes[0]), err)
 }
+
+type stagingBalancesRecorder struct {
+	mocks.MockCatchpointCatchupAccessor
+	sections []string
+}
+
+func (r *stagingBalancesRecorder) ProgressStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *ledger.CatchpointCatchupAccessorProgress) error {
+	r.sections = append(r.sections, sectionName)
+	return nil
+}
+
+// makeTestCatchpointFile returns a compressed tar file spanning a few download ranges, along with its section names.
+func makeTestCatchpointFile(t *testing.T) ([]byte, []string) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	var sections []string
+	for i := 0; i < 3; i++ {
+		// random data doesn't compress, so the file is about as large as its content.
+		content := make([]byte, catchpointDownloadRangeSize*3/4)
+		crypto.RandBytes(content)
+		name := fmt.Sprintf("balances.%d.3.msgpack", i+1)
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+		_, err := tarWriter.Write(content)
+		require.NoError(t, err)
+		sections = append(sections, name)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes(), sections
+}
+
+// startLedgerRangeServer serves the given file the way the ledger service does, counting the requests it gets.
+func startLedgerRangeServer(t *testing.T, file []byte, supportRanges bool, requests *int32) (network.HTTPPeer, func()) {
+	mux := http.NewServeMux()
+	s := &http.Server{Handler: mux}
+	listener, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	go s.Serve(listener)
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", rpcs.LedgerResponseContentType)
+		if !supportRanges {
+			w.Write(file)
+			return
+		}
+		http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(file))
+	})
+	peer := testHTTPPeer(listener.Addr().String())
+	return &peer, func() {
+		s.Close()
+		listener.Close()
+	}
+}
+
+func TestLedgerFetcherRanges(t *testing.T) {
+	file, sections := makeTestCatchpointFile(t)
+	require.Equal(t, 3, stagedRangesCount(int64(len(file))))
+
+	var goodRequests, otherRequests, fullRequests int32
+	goodPeer, closeGood := startLedgerRangeServer(t, file, true, &goodRequests)
+	defer closeGood()
+	// a peer with a different file for the same round.
+	otherPeer, closeOther := startLedgerRangeServer(t, file[:len(file)-1], true, &otherRequests)
+	defer closeOther()
+	fullPeer, closeFull := startLedgerRangeServer(t, file, false, &fullRequests)
+	defer closeFull()
+
+	stagingDir, err := ioutil.TempDir("", "catchpointdownload")
+	require.NoError(t, err)
+	defer os.RemoveAll(stagingDir)
+	accessor := &stagingBalancesRecorder{}
+	lf := makeLedgerFetcher(&mocks.MockNetwork{}, accessor, logging.TestingLog(t), &dummyLedgerFetcherReporter{}, config.GetDefaultLocal(), stagingDir)
+
+	_, err = lf.downloadLedgerRanges(context.Background(), []network.Peer{fullPeer}, basics.Round(1))
+	require.Equal(t, errRangesNotSupported, err)
+
+	failed, err := lf.downloadLedgerRanges(context.Background(), []network.Peer{goodPeer, otherPeer}, basics.Round(1))
+	require.NoError(t, err)
+	require.Equal(t, []network.Peer{otherPeer}, failed)
+	require.Equal(t, sections, accessor.sections)
+
+	// an interrupted download only gets the missing ranges.
+	dir := filepath.Join(stagingDir, "1")
+	require.NoError(t, os.Remove(stagedRangePath(dir, 1)))
+	atomic.StoreInt32(&goodRequests, 0)
+	accessor.sections = nil
+	_, err = lf.downloadLedgerRanges(context.Background(), []network.Peer{goodPeer}, basics.Round(1))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&goodRequests))
+	require.Equal(t, sections, accessor.sections)
+
+	// a corrupted staged range is dropped before anything gets processed, and only it is downloaded again.
+	rangeBytes, err := ioutil.ReadFile(stagedRangePath(dir, 1))
+	require.NoError(t, err)
+	for i := range rangeBytes {
+		rangeBytes[i] ^= 0xff
+	}
+	require.NoError(t, ioutil.WriteFile(stagedRangePath(dir, 1), rangeBytes, 0600))
+	atomic.StoreInt32(&goodRequests, 0)
+	accessor.sections = nil
+	failed, err = lf.downloadLedgerRanges(context.Background(), []network.Peer{goodPeer}, basics.Round(1))
+	require.NoError(t, err)
+	require.Empty(t, failed)
+	require.Equal(t, int32(1), atomic.LoadInt32(&goodRequests))
+	require.Equal(t, sections, accessor.sections)
+
+	// a peer serving a corrupted range is dropped, while the ranges it served correctly are kept.
+	corruptedFile := append([]byte{}, file...)
+	for i := 2 * catchpointDownloadRangeSize; i < len(corruptedFile); i++ {
+		corruptedFile[i] ^= 0xff
+	}
+	var badRequests int32
+	badPeer, closeBad := startLedgerRangeServer(t, corruptedFile, true, &badRequests)
+	defer closeBad()
+	lf.discardStagedLedger()
+	accessor.sections = nil
+	failed, err = lf.downloadLedgerRanges(context.Background(), []network.Peer{badPeer}, basics.Round(1))
+	require.Error(t, err)
+	require.Equal(t, []network.Peer{badPeer}, failed)
+	require.Empty(t, accessor.sections)
+	for i := 0; i < 2; i++ {
+		_, err = os.Stat(stagedRangePath(dir, i))
+		require.NoError(t, err)
+	}
+	atomic.StoreInt32(&goodRequests, 0)
+	failed, err = lf.downloadLedgerRanges(context.Background(), []network.Peer{goodPeer}, basics.Round(1))
+	require.NoError(t, err)
+	require.Empty(t, failed)
+	require.Equal(t, int32(1), atomic.LoadInt32(&goodRequests))
+	require.Equal(t, sections, accessor.sections)
+
+	// the staged files of other rounds are removed.
+	_, err = lf.downloadLedgerRanges(context.Background(), []network.Peer{fullPeer}, basics.Round(2))
+	require.Equal(t, errRangesNotSupported, err)
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+
+	lf.discardStagedLedger()
+	_, err = os.Stat(stagingDir)
+	require.True(t, os.IsNotExist(err))
+}
@@ -129,6 +129,12 @@ func (ps *peerSelector) RankPeer(peer network.Peer, rank int) bool {
 	if peer == nil {
 		return false
 	}
+	if rank == peerRankInvalidDownload {
+		// let the network know, so that peers which keep serving invalid content would get banned.
+		if reporter, ok := ps.net.(network.PeerReporter); ok {
+			reporter.ReportPeer(peer, network.PeerOffenseBadBlock)
+		}
+	}
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 
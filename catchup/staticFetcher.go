@@ -0,0 +1,284 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/util/s3"
+)
+
+const (
+	// staticBlockNameMinLength is the minimal length of the base-36 round number used as the name of a block file
+	// in the static layout; shorter round numbers are padded with zeros.
+	staticBlockNameMinLength = 6
+	// staticSourcesSeparator separates the static sources listed in the CatchupStaticSources config field
+	staticSourcesSeparator = ";"
+)
+
+// errStaticFileNotFound is returned by the static sources when the requested file doesn't exist.
+var errStaticFileNotFound = errors.New("static file not found")
+
+// staticBlockPath returns the path of the file holding the block and certificate of the given round, relative to the
+// root of a static catchup source. The path contains a {genesisID} placeholder. The file name is the base-36 round number
+// padded with zeros, nested under two directory levels so that no single directory grows too large;
+// e.g. round 0bcdef is stored at v1/{genesisID}/block/0b/cd/0bcdef. This is the same layout catchupsrv uses for its block directory.
+func staticBlockPath(round basics.Round) string {
+	name := strconv.FormatUint(uint64(round), 36)
+	if len(name) < staticBlockNameMinLength {
+		name = strings.Repeat("0", staticBlockNameMinLength-len(name)) + name
+	}
+	prefixLen := len(name) + 2 - staticBlockNameMinLength
+	return path.Join("v1", "{genesisID}", "block", name[:prefixLen], name[prefixLen:prefixLen+2], name)
+}
+
+// staticLedgerPath returns the path of the catchpoint file of the given round, relative to the root of a static catchup
+// source. The path contains a {genesisID} placeholder.
+func staticLedgerPath(round basics.Round) string {
+	return path.Join("v1", "{genesisID}", "ledger", strconv.FormatUint(uint64(round), 36))
+}
+
+// StaticBlockPath returns the path of the file holding the block and certificate of the given round, relative to the
+// root of a static catchup source.
+func StaticBlockPath(genesisID string, round basics.Round) string {
+	return strings.Replace(staticBlockPath(round), "{genesisID}", genesisID, -1)
+}
+
+// StaticLedgerPath returns the path of the catchpoint file of the given round, relative to the root of a static catchup source.
+func StaticLedgerPath(genesisID string, round basics.Round) string {
+	return strings.Replace(staticLedgerPath(round), "{genesisID}", genesisID, -1)
+}
+
+// staticSource abstracts the storage the static layout is served from.
+type staticSource interface {
+	// get returns a stream of the content of the file at the given path, or errStaticFileNotFound if there is no such file.
+	get(ctx context.Context, filePath string) (io.ReadCloser, error)
+	// address returns a description of the source, for logging purposes.
+	address() string
+}
+
+// httpStaticSource serves the static layout off an HTTP(S) server
+type httpStaticSource struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+func (src *httpStaticSource) get(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	fileURL := *src.baseURL
+	fileURL.Path = path.Join(fileURL.Path, filePath)
+	request, err := http.NewRequest(http.MethodGet, fileURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	network.SetUserAgentHeader(request.Header)
+	response, err := src.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound, http.StatusForbidden: // buckets served over HTTP respond to missing files with 403
+		response.Body.Close()
+		return nil, errStaticFileNotFound
+	default:
+		response.Body.Close()
+		return nil, fmt.Errorf("error response status code %d when requesting '%s'", response.StatusCode, fileURL.String())
+	}
+}
+
+func (src *httpStaticSource) address() string {
+	return src.baseURL.String()
+}
+
+// s3StaticSource serves the static layout off an S3-compatible bucket
+type s3StaticSource struct {
+	helper s3.Helper
+	bucket string
+	prefix string
+}
+
+func (src *s3StaticSource) get(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	reader, err := src.helper.GetObject(ctx, path.Join(src.prefix, filePath))
+	if err == s3.ErrObjectNotFound {
+		return nil, errStaticFileNotFound
+	}
+	return reader, err
+}
+
+func (src *s3StaticSource) address() string {
+	return "s3://" + path.Join(src.bucket, src.prefix)
+}
+
+// makeStaticSource creates the static source for the given address; either an http(s):// base URL or an s3://bucket/prefix.
+func makeStaticSource(address string) (staticSource, error) {
+	parsedURL, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	switch parsedURL.Scheme {
+	case "http", "https":
+		return &httpStaticSource{baseURL: parsedURL, client: &http.Client{}}, nil
+	case "s3":
+		helper, err := s3.MakeS3SessionForDownloadWithBucket(parsedURL.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &s3StaticSource{helper: helper, bucket: parsedURL.Host, prefix: strings.TrimPrefix(parsedURL.Path, "/")}, nil
+	default:
+		return nil, fmt.Errorf("unsupported static catchup source '%s'", address)
+	}
+}
+
+// StaticFetcher implements FetcherClient, fetching blocks, certificates and catchpoint files out of the static
+// layout written by "catchupsrv export" and hosted on an HTTP server or an S3-compatible bucket.
+type StaticFetcher struct {
+	source staticSource
+	net    network.GossipNode
+
+	log    logging.Logger
+	config *config.Local
+}
+
+// MakeStaticFetchers creates a StaticFetcher for each of the sources listed in the CatchupStaticSources config field.
+// Sources which cannot be parsed are logged and skipped.
+func MakeStaticFetchers(log logging.Logger, net network.GossipNode, cfg *config.Local) (fetchers []*StaticFetcher) {
+	for _, address := range strings.Split(cfg.CatchupStaticSources, staticSourcesSeparator) {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		source, err := makeStaticSource(address)
+		if err != nil {
+			log.Warnf("MakeStaticFetchers: unable to use static catchup source '%s' : %v", address, err)
+			continue
+		}
+		fetchers = append(fetchers, &StaticFetcher{
+			source: source,
+			net:    net,
+			log:    log,
+			config: cfg,
+		})
+	}
+	return
+}
+
+// GetBlockBytes gets a block.
+// Core piece of FetcherClient interface
+func (sf *StaticFetcher) GetBlockBytes(ctx context.Context, r basics.Round) (data []byte, err error) {
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Duration(sf.config.CatchupHTTPBlockFetchTimeoutSec)*time.Second)
+	defer requestCancel()
+	reader, err := sf.source.get(requestCtx, sf.net.SubstituteGenesisID(staticBlockPath(r)))
+	if err != nil {
+		if err == errStaticFileNotFound {
+			return nil, errNoBlockForRound
+		}
+		sf.log.Debugf("StaticFetcher.GetBlockBytes: unable to get block %d from %s : %v", r, sf.Address(), err)
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err = ioutil.ReadAll(io.LimitReader(reader, fetcherMaxBlockBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > fetcherMaxBlockBytes {
+		return nil, fmt.Errorf("StaticFetcher.GetBlockBytes: block %d from %s exceeds the maximal block size of %d bytes", r, sf.Address(), fetcherMaxBlockBytes)
+	}
+	return data, nil
+}
+
+// Address is part of FetcherClient interface.
+// Returns the address of the static source.
+func (sf *StaticFetcher) Address() string {
+	return sf.source.address()
+}
+
+// Close is part of FetcherClient interface
+func (sf *StaticFetcher) Close() error {
+	return nil
+}
+
+// FetchBlock fetches and decodes the block and certificate of the given round
+func (sf *StaticFetcher) FetchBlock(ctx context.Context, r basics.Round) (blk *bookkeeping.Block, cert *agreement.Certificate, err error) {
+	fetchedBuf, err := sf.GetBlockBytes(ctx, r)
+	if err != nil {
+		err = fmt.Errorf("Static source %v: %v", sf.Address(), err)
+		return
+	}
+	return processBlockBytes(fetchedBuf, r, sf.Address())
+}
+
+// GetLedger returns a stream of the uncompressed catchpoint file of the given round; the caller is expected to close it.
+// Catchpoint files are stored compressed as they are written by the ledger, but uncompressed files are accepted as well.
+func (sf *StaticFetcher) GetLedger(ctx context.Context, round basics.Round) (io.ReadCloser, error) {
+	reader, err := sf.source.get(ctx, sf.net.SubstituteGenesisID(staticLedgerPath(round)))
+	if err != nil {
+		if err == errStaticFileNotFound {
+			return nil, errNoLedgerForRound
+		}
+		return nil, err
+	}
+	bufferedReader := bufio.NewReader(reader)
+	magic, err := bufferedReader.Peek(2)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	if magic[0] != 0x1f || magic[1] != 0x8b {
+		return &staticLedgerReader{Reader: bufferedReader, body: reader}, nil
+	}
+	gzipReader, err := gzip.NewReader(bufferedReader)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	return &staticLedgerReader{Reader: gzipReader, body: reader, gzipReader: gzipReader}, nil
+}
+
+// staticLedgerReader reads the uncompressed catchpoint file, and closes the underlying stream once done.
+type staticLedgerReader struct {
+	io.Reader
+	body       io.Closer
+	gzipReader *gzip.Reader
+}
+
+func (r *staticLedgerReader) Close() error {
+	if r.gzipReader != nil {
+		r.gzipReader.Close()
+	}
+	return r.body.Close()
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package catchup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/rpcs"
+)
+
+func writeStaticFile(t *testing.T, dir string, filePath string, data []byte) {
+	fullPath := filepath.Join(dir, filepath.FromSlash(filePath))
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0700))
+	require.NoError(t, ioutil.WriteFile(fullPath, data, 0600))
+}
+
+func TestStaticBlockPath(t *testing.T) {
+	require.Equal(t, "v1/test/block/00/00/000000", StaticBlockPath("test", 0))
+	require.Equal(t, "v1/test/block/0b/cd/0bcdef", StaticBlockPath("test", 19053015))
+	require.Equal(t, "v1/test/block/abc/de/abcdefg", StaticBlockPath("test", 22453731916))
+	require.Equal(t, "v1/test/ledger/2n9c", StaticLedgerPath("test", 123456))
+}
+
+func TestStaticFetcherBlocks(t *testing.T) {
+	ledger, next, b, err := buildTestLedger(t)
+	require.NoError(t, err)
+	blockBytes, err := rpcs.RawBlockBytes(ledger, next)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "staticfetcher")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeStaticFile(t, dir, StaticBlockPath("test genesisID", next), blockBytes)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	net := &httpTestPeerSource{}
+	cfg := config.GetDefaultLocal()
+	cfg.CatchupStaticSources = server.URL + "; ftp://unsupported"
+	fetchers := MakeStaticFetchers(logging.TestingLog(t), net, &cfg)
+	require.Equal(t, 1, len(fetchers))
+	require.Equal(t, server.URL, fetchers[0].Address())
+
+	block, cert, err := fetchers[0].FetchBlock(context.Background(), next)
+	require.NoError(t, err)
+	require.Equal(t, &b, block)
+	require.Equal(t, next, cert.Round)
+
+	_, err = fetchers[0].GetBlockBytes(context.Background(), next+1)
+	require.Equal(t, errNoBlockForRound, err)
+
+	// with no network peers, the network fetcher relies on the static sources alone.
+	factory := MakeNetworkFetcherFactory(net, numberOfPeers, &cfg)
+	fetcher := factory.New()
+	require.Equal(t, 1, fetcher.NumPeers())
+	block, _, client, err := fetcher.FetchBlock(context.Background(), next)
+	require.NoError(t, err)
+	require.Equal(t, &b, block)
+	require.Equal(t, server.URL, client.Address())
+}
+
+func TestStaticFetcherLedger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "staticfetcher")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err = gzipWriter.Write([]byte("compressed catchpoint"))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	writeStaticFile(t, dir, StaticLedgerPath("test genesisID", 1000), compressed.Bytes())
+	writeStaticFile(t, dir, StaticLedgerPath("test genesisID", 2000), []byte("uncompressed catchpoint"))
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	cfg := config.GetDefaultLocal()
+	cfg.CatchupStaticSources = server.URL
+	fetchers := MakeStaticFetchers(logging.TestingLog(t), &httpTestPeerSource{}, &cfg)
+	require.Equal(t, 1, len(fetchers))
+
+	for round, expected := range map[basics.Round]string{1000: "compressed catchpoint", 2000: "uncompressed catchpoint"} {
+		stream, err := fetchers[0].GetLedger(context.Background(), round)
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(stream)
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+		require.Equal(t, expected, string(content))
+	}
+
+	_, err = fetchers[0].GetLedger(context.Background(), 3000)
+	require.Equal(t, errNoLedgerForRound, err)
+}
@@ -0,0 +1,453 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
+)
+
+var diffOutFileName string
+var diffOutputFormat string
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVarP(&diffOutFileName, "output", "o", "", "Specify an outfile for the differences")
+	diffCmd.Flags().StringVarP(&diffOutputFormat, "format", "f", outputFormatJSON, "Specify the output format ( json or csv )")
+}
+
+const (
+	diffChangeAdded    = "added"
+	diffChangeRemoved  = "removed"
+	diffChangeModified = "modified"
+
+	diffKindAccount        = "account"
+	diffKindAssetHolding   = "asset-holding"
+	diffKindAssetParams    = "asset-params"
+	diffKindAppLocalState  = "app-local-state"
+	diffKindAppParams      = "app-params"
+	diffKindAppGlobalState = "app-global-state"
+)
+
+// catchpointDiffEntry is a single difference between two catchpoints. Entries of an account are reported for the
+// account itself, and for each of its asset holdings, created assets, application local states and created
+// applications; application states which exist on both catchpoints are further broken down into their keys.
+type catchpointDiffEntry struct {
+	Address string `json:"address"`
+	Kind    string `json:"kind"`
+	// ID is the asset or application index, for entries other than accounts.
+	ID uint64 `json:"id,omitempty"`
+	// Key is the base64 encoded application state key, for application state entries.
+	Key    string `json:"key,omitempty"`
+	Change string `json:"change"`
+	// Before and After are the JSON encoded values before and after the change, when present.
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [from catchpoint file] [to catchpoint file]",
+	Short: "Report the differences between two catchpoint files",
+	Long:  "Report the accounts, assets and application state entries which were added, removed or modified between two catchpoint files",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !validOutputFormat(diffOutputFormat) {
+			reportErrorf("Unsupported output format '%s'", diffOutputFormat)
+		}
+
+		outFile := os.Stdout
+		var err error
+		if diffOutFileName != "" {
+			outFile, err = os.OpenFile(diffOutFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+			if err != nil {
+				reportErrorf("Unable to create file '%s' : %v", diffOutFileName, err)
+			}
+			defer outFile.Close()
+		}
+		fileWriter := bufio.NewWriterSize(outFile, 1024*1024)
+		defer fileWriter.Flush()
+
+		diffWriter := makeCatchpointDiffWriter(fileWriter, diffOutputFormat)
+		err = diffCatchpointFiles(context.Background(), args[0], args[1], diffWriter.write)
+		if err == nil {
+			err = diffWriter.close()
+		}
+		if err != nil {
+			fileWriter.Flush()
+			outFile.Close()
+			reportErrorf("Unable to diff catchpoint files '%s' and '%s' : %v", args[0], args[1], err)
+		}
+	},
+}
+
+// catchpointDiffWriter writes the diff entries either as a JSON array or as CSV rows.
+type catchpointDiffWriter struct {
+	out       io.Writer
+	format    string
+	csvWriter *csv.Writer
+	entries   int
+}
+
+func makeCatchpointDiffWriter(out io.Writer, format string) *catchpointDiffWriter {
+	writer := &catchpointDiffWriter{out: out, format: format}
+	if format == outputFormatCSV {
+		writer.csvWriter = csv.NewWriter(out)
+	}
+	return writer
+}
+
+func (w *catchpointDiffWriter) write(entry catchpointDiffEntry) error {
+	defer func() {
+		w.entries++
+	}()
+	if w.format == outputFormatCSV {
+		if w.entries == 0 {
+			w.csvWriter.Write([]string{"address", "kind", "id", "key", "change", "before", "after"})
+		}
+		id := ""
+		if entry.ID != 0 {
+			id = strconv.FormatUint(entry.ID, 10)
+		}
+		return w.csvWriter.Write([]string{entry.Address, entry.Kind, id, entry.Key, entry.Change, entry.Before, entry.After})
+	}
+	separator := ",\n  "
+	if w.entries == 0 {
+		separator = "[\n  "
+	}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w.out, separator+string(encoded))
+	return err
+}
+
+func (w *catchpointDiffWriter) close() error {
+	if w.format == outputFormatCSV {
+		if w.entries == 0 {
+			w.csvWriter.Write([]string{"address", "kind", "id", "key", "change", "before", "after"})
+		}
+		w.csvWriter.Flush()
+		return w.csvWriter.Error()
+	}
+	closing := "\n]\n"
+	if w.entries == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(w.out, closing)
+	return err
+}
+
+// stagedAccount is a single account read out of the staging balances of a catchpoint.
+type stagedAccount struct {
+	addr basics.Address
+	data basics.AccountData
+}
+
+// streamStagingBalances reads the staging balances of the accessor in the background, in address order.
+// The error channel receives the outcome once the accounts channel is closed.
+func streamStagingBalances(ctx context.Context, catchupAccessor ledger.CatchpointCatchupAccessor) (<-chan stagedAccount, <-chan error) {
+	accounts := make(chan stagedAccount, 1024)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(accounts)
+		errs <- catchupAccessor.StagingBalances(ctx, func(addr basics.Address, data basics.AccountData) error {
+			select {
+			case accounts <- stagedAccount{addr: addr, data: data}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return accounts, errs
+}
+
+// diffCatchpointFiles loads both catchpoint files into temporary ledgers, and walks their balances side by side
+// reporting every difference to the emit function.
+func diffCatchpointFiles(ctx context.Context, fromFileName, toFileName string, emit func(catchpointDiffEntry) error) error {
+	fromLedgerDir, err := ioutil.TempDir("", "catchpointdiff")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(fromLedgerDir)
+	toLedgerDir, err := ioutil.TempDir("", "catchpointdiff")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(toLedgerDir)
+
+	fromLedger, fromAccessor, _, err := loadCatchpointFile(ctx, fromFileName, fromLedgerDir)
+	if err != nil {
+		return err
+	}
+	defer fromLedger.Close()
+	toLedger, toAccessor, _, err := loadCatchpointFile(ctx, toFileName, toLedgerDir)
+	if err != nil {
+		return err
+	}
+	defer toLedger.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fromAccounts, fromErr := streamStagingBalances(ctx, fromAccessor)
+	toAccounts, toErr := streamStagingBalances(ctx, toAccessor)
+
+	from, fromOk := <-fromAccounts
+	to, toOk := <-toAccounts
+	for fromOk || toOk {
+		switch {
+		case toOk && (!fromOk || bytes.Compare(to.addr[:], from.addr[:]) < 0):
+			err = diffAccounts(to.addr, nil, &to.data, emit)
+			to, toOk = <-toAccounts
+		case fromOk && (!toOk || bytes.Compare(from.addr[:], to.addr[:]) < 0):
+			err = diffAccounts(from.addr, &from.data, nil, emit)
+			from, fromOk = <-fromAccounts
+		default:
+			err = diffAccounts(from.addr, &from.data, &to.data, emit)
+			from, fromOk = <-fromAccounts
+			to, toOk = <-toAccounts
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err = <-fromErr; err != nil {
+		return err
+	}
+	return <-toErr
+}
+
+// diffAccounts reports the differences between two versions of an account; a nil version means the account
+// doesn't exist on that catchpoint.
+func diffAccounts(addr basics.Address, before, after *basics.AccountData, emit func(catchpointDiffEntry) error) error {
+	var beforeData, afterData basics.AccountData
+	if before != nil {
+		beforeData = *before
+	}
+	if after != nil {
+		afterData = *after
+	}
+	address := addr.String()
+
+	var beforeBase, afterBase interface{}
+	if before != nil {
+		beforeBase = accountBase(beforeData)
+	}
+	if after != nil {
+		afterBase = accountBase(afterData)
+	}
+	err := diffValues(emit, catchpointDiffEntry{Address: address, Kind: diffKindAccount}, beforeBase, afterBase)
+	if err != nil {
+		return err
+	}
+
+	beforeHoldings := make(map[uint64]interface{}, len(beforeData.Assets))
+	for idx, holding := range beforeData.Assets {
+		beforeHoldings[uint64(idx)] = holding
+	}
+	afterHoldings := make(map[uint64]interface{}, len(afterData.Assets))
+	for idx, holding := range afterData.Assets {
+		afterHoldings[uint64(idx)] = holding
+	}
+	err = diffIndexed(emit, address, diffKindAssetHolding, beforeHoldings, afterHoldings)
+	if err != nil {
+		return err
+	}
+
+	beforeAssets := make(map[uint64]interface{}, len(beforeData.AssetParams))
+	for idx, params := range beforeData.AssetParams {
+		beforeAssets[uint64(idx)] = params
+	}
+	afterAssets := make(map[uint64]interface{}, len(afterData.AssetParams))
+	for idx, params := range afterData.AssetParams {
+		afterAssets[uint64(idx)] = params
+	}
+	err = diffIndexed(emit, address, diffKindAssetParams, beforeAssets, afterAssets)
+	if err != nil {
+		return err
+	}
+
+	for _, appIdx := range appIndices(beforeData.AppLocalStates, afterData.AppLocalStates, nil, nil) {
+		beforeState, beforeOk := beforeData.AppLocalStates[appIdx]
+		afterState, afterOk := afterData.AppLocalStates[appIdx]
+		entry := catchpointDiffEntry{Address: address, Kind: diffKindAppLocalState, ID: uint64(appIdx)}
+		if !beforeOk || !afterOk {
+			err = diffValues(emit, entry, optionalValue(beforeState, beforeOk), optionalValue(afterState, afterOk))
+		} else {
+			err = diffValues(emit, entry, beforeState.Schema, afterState.Schema)
+			if err == nil {
+				err = diffKeyValues(emit, entry, beforeState.KeyValue, afterState.KeyValue)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, appIdx := range appIndices(nil, nil, beforeData.AppParams, afterData.AppParams) {
+		beforeParams, beforeOk := beforeData.AppParams[appIdx]
+		afterParams, afterOk := afterData.AppParams[appIdx]
+		entry := catchpointDiffEntry{Address: address, Kind: diffKindAppParams, ID: uint64(appIdx)}
+		if !beforeOk || !afterOk {
+			err = diffValues(emit, entry, optionalValue(beforeParams, beforeOk), optionalValue(afterParams, afterOk))
+		} else {
+			beforeGlobalState, afterGlobalState := beforeParams.GlobalState, afterParams.GlobalState
+			beforeParams.GlobalState, afterParams.GlobalState = nil, nil
+			err = diffValues(emit, entry, beforeParams, afterParams)
+			if err == nil {
+				entry.Kind = diffKindAppGlobalState
+				err = diffKeyValues(emit, entry, beforeGlobalState, afterGlobalState)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountBase returns the account data without its assets and applications, which are reported separately.
+func accountBase(data basics.AccountData) basics.AccountData {
+	data.Assets = nil
+	data.AssetParams = nil
+	data.AppLocalStates = nil
+	data.AppParams = nil
+	return data
+}
+
+func optionalValue(value interface{}, ok bool) interface{} {
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+// appIndices returns the sorted union of the application indices of the given maps.
+func appIndices(beforeStates, afterStates map[basics.AppIndex]basics.AppLocalState, beforeParams, afterParams map[basics.AppIndex]basics.AppParams) []basics.AppIndex {
+	seen := make(map[basics.AppIndex]bool)
+	for idx := range beforeStates {
+		seen[idx] = true
+	}
+	for idx := range afterStates {
+		seen[idx] = true
+	}
+	for idx := range beforeParams {
+		seen[idx] = true
+	}
+	for idx := range afterParams {
+		seen[idx] = true
+	}
+	indices := make([]basics.AppIndex, 0, len(seen))
+	for idx := range seen {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+// diffIndexed reports the differences between two maps of assets or applications, in index order.
+func diffIndexed(emit func(catchpointDiffEntry) error, address string, kind string, before, after map[uint64]interface{}) error {
+	indices := make([]uint64, 0, len(before)+len(after))
+	for idx := range before {
+		indices = append(indices, idx)
+	}
+	for idx := range after {
+		if _, ok := before[idx]; !ok {
+			indices = append(indices, idx)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	for _, idx := range indices {
+		err := diffValues(emit, catchpointDiffEntry{Address: address, Kind: kind, ID: idx}, before[idx], after[idx])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffKeyValues reports the differences between two application states, in key order.
+func diffKeyValues(emit func(catchpointDiffEntry) error, entry catchpointDiffEntry, before, after basics.TealKeyValue) error {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		beforeValue, beforeOk := before[key]
+		afterValue, afterOk := after[key]
+		entry.Key = base64.StdEncoding.EncodeToString([]byte(key))
+		err := diffValues(emit, entry, optionalValue(beforeValue, beforeOk), optionalValue(afterValue, afterOk))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffValues reports a single entry when the values differ; a nil value means it doesn't exist.
+func diffValues(emit func(catchpointDiffEntry) error, entry catchpointDiffEntry, before, after interface{}) error {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		entry.Change = diffChangeAdded
+	case after == nil:
+		entry.Change = diffChangeRemoved
+	case reflect.DeepEqual(before, after):
+		return nil
+	default:
+		entry.Change = diffChangeModified
+	}
+	if before != nil {
+		encoded, err := json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		entry.Before = string(encoded)
+	}
+	if after != nil {
+		encoded, err := json.Marshal(after)
+		if err != nil {
+			return err
+		}
+		entry.After = string(encoded)
+	}
+	return emit(entry)
+}
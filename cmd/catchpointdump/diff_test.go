@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+func TestDiffCatchpointFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catchpointdump")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fromFileName := filepath.Join(dir, "from.tar")
+	writeTestCatchpointFile(t, fromFileName, testCatchpointAccounts(), "")
+
+	to := testCatchpointAccounts()
+	to[basics.Address{1}] = basics.AccountData{Status: basics.Offline, MicroAlgos: basics.MicroAlgos{Raw: 1500000}}
+	toAccount2 := to[basics.Address{2}]
+	toAccount2.Assets = map[basics.AssetIndex]basics.AssetHolding{5: {Amount: 7}}
+	to[basics.Address{2}] = toAccount2
+	to[basics.Address{3}] = basics.AccountData{Status: basics.Offline, MicroAlgos: basics.MicroAlgos{Raw: 500000}}
+	toFileName := filepath.Join(dir, "to.tar")
+	writeTestCatchpointFile(t, toFileName, to, "")
+
+	var entries []catchpointDiffEntry
+	err = diffCatchpointFiles(context.Background(), fromFileName, toFileName, func(entry catchpointDiffEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	require.NoError(t, err)
+
+	changes := make(map[string]string)
+	for _, entry := range entries {
+		changes[fmt.Sprintf("%s/%s/%d", entry.Address, entry.Kind, entry.ID)] = entry.Change
+	}
+	require.Equal(t, map[string]string{
+		fmt.Sprintf("%s/%s/0", basics.Address{1}, diffKindAccount):      diffChangeModified,
+		fmt.Sprintf("%s/%s/5", basics.Address{2}, diffKindAssetHolding): diffChangeModified,
+		fmt.Sprintf("%s/%s/0", basics.Address{3}, diffKindAccount):      diffChangeAdded,
+	}, changes)
+
+	// a file doesn't differ from itself.
+	entries = nil
+	err = diffCatchpointFiles(context.Background(), fromFileName, fromFileName, func(entry catchpointDiffEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
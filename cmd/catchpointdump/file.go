@@ -20,6 +20,7 @@ import (
 	"archive/tar"
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -27,6 +28,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -105,18 +107,70 @@ var fileCmd = &cobra.Command{
 	},
 }
 
+// readCatchpointFile reads the content of a catchpoint file, decompressing it when it was stored compressed.
+func readCatchpointFile(fileName string) ([]byte, error) {
+	fileBytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileBytes) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if len(fileBytes) < 2 || fileBytes[0] != 0x1f || fileBytes[1] != 0x8b {
+		return fileBytes, nil
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(fileBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+	return ioutil.ReadAll(gzipReader)
+}
+
+// loadCatchpointFile loads the given catchpoint file into the staging tables of a new ledger created in ledgerDir.
+// The caller is expected to close the returned ledger.
+func loadCatchpointFile(ctx context.Context, fileName string, ledgerDir string) (l *ledger.Ledger, catchupAccessor ledger.CatchpointCatchupAccessor, fileHeader ledger.CatchpointFileHeader, err error) {
+	fileBytes, err := readCatchpointFile(fileName)
+	if err != nil {
+		err = fmt.Errorf("unable to read '%s' : %v", fileName, err)
+		return
+	}
+	// the online balances are normalized using the reward unit of the genesis protocol.
+	genesisInitState := ledger.InitState{}
+	genesisInitState.Block.CurrentProtocol = protocol.ConsensusCurrentVersion
+	l, err = ledger.OpenLedger(logging.Base(), filepath.Join(ledgerDir, "ledger"), false, genesisInitState, config.GetDefaultLocal())
+	if err != nil {
+		err = fmt.Errorf("unable to open ledger : %v", err)
+		return
+	}
+
+	catchupAccessor = ledger.MakeCatchpointCatchupAccessor(l, logging.Base())
+	err = catchupAccessor.ResetStagingBalances(ctx, true)
+	if err == nil {
+		fileHeader, err = loadCatchpointIntoDatabase(ctx, catchupAccessor, fileBytes)
+	}
+	if err != nil {
+		l.Close()
+		l = nil
+		err = fmt.Errorf("unable to load catchpoint file '%s' into database : %v", fileName, err)
+	}
+	return
+}
+
+// printLoadCatchpointProgressLine prints the loading progress to the standard error, keeping the standard output
+// for the commands results.
 func printLoadCatchpointProgressLine(progress int, barLength int, dld int64) {
 	if barLength == 0 {
-		fmt.Printf(escapeCursorUp + escapeDeleteLine + "[ Done ] Loaded\n")
+		fmt.Fprintf(os.Stderr, escapeCursorUp+escapeDeleteLine+"[ Done ] Loaded\n")
 		return
 	}
 
 	outString := "[" + strings.Repeat(escapeSquare, progress) + strings.Repeat(escapeDot, barLength-progress) + "] Loading..."
-	fmt.Printf(escapeCursorUp+escapeDeleteLine+outString+" %s\n", formatSize(dld))
+	fmt.Fprintf(os.Stderr, escapeCursorUp+escapeDeleteLine+outString+" %s\n", formatSize(dld))
 }
 
 func loadCatchpointIntoDatabase(ctx context.Context, catchupAccessor ledger.CatchpointCatchupAccessor, fileBytes []byte) (fileHeader ledger.CatchpointFileHeader, err error) {
-	fmt.Printf("\n")
+	fmt.Fprintf(os.Stderr, "\n")
 	printLoadCatchpointProgressLine(0, 50, 0)
 	lastProgressUpdate := time.Now()
 	progress := uint64(0)
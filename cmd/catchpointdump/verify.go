@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+)
+
+const (
+	outputFormatJSON = "json"
+	outputFormatCSV  = "csv"
+)
+
+var verifyTarFile string
+var verifyLabel string
+var verifyOutFileName string
+var verifyOutputFormat string
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringVarP(&verifyTarFile, "tar", "t", "", "Specify the catchpoint file to verify")
+	verifyCmd.Flags().StringVarP(&verifyLabel, "label", "l", "", "Specify the catchpoint label to verify the file against, as obtained from a trusted source ( without it, the file is only checked against its own header )")
+	verifyCmd.Flags().StringVarP(&verifyOutFileName, "output", "o", "", "Specify an outfile for the verification result")
+	verifyCmd.Flags().StringVarP(&verifyOutputFormat, "format", "f", outputFormatJSON, "Specify the output format ( json or csv )")
+}
+
+// catchpointVerifyResult is the outcome of verifying a catchpoint file against its label.
+type catchpointVerifyResult struct {
+	File            string `json:"file"`
+	Label           string `json:"label"`
+	CalculatedLabel string `json:"calculated-label"`
+	BlocksRound     uint64 `json:"blocks-round"`
+	BalancesRound   uint64 `json:"balances-round"`
+	TotalAccounts   uint64 `json:"total-accounts"`
+	BalancesHash    string `json:"balances-hash"`
+	// LabelFromFile indicates that no label was given, and the file was verified against the label in its own
+	// header. That only shows the file is consistent, not that it's the catchpoint of any particular network.
+	LabelFromFile bool `json:"label-from-file"`
+	Verified      bool `json:"verified"`
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a catchpoint file against its label",
+	Long:  "Rebuild the merkle trie out of the balances of a catchpoint file, and verify that the resulting account hash matches the catchpoint label",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		if verifyTarFile == "" {
+			cmd.HelpFunc()(cmd, args)
+			return
+		}
+		if !validOutputFormat(verifyOutputFormat) {
+			reportErrorf("Unsupported output format '%s'", verifyOutputFormat)
+		}
+		result, err := verifyCatchpointFile(context.Background(), verifyTarFile, verifyLabel)
+		if err != nil {
+			reportErrorf("Unable to verify catchpoint file '%s' : %v", verifyTarFile, err)
+		}
+		if result.LabelFromFile {
+			// the warning goes to the standard error, keeping the standard output for the result.
+			fmt.Fprintln(os.Stderr, "Warning: no label was given, so the file was verified against the label in its own header. This only shows the file is consistent; use --label with a label from a trusted source to verify it belongs to a network.")
+		}
+
+		outFile := os.Stdout
+		if verifyOutFileName != "" {
+			outFile, err = os.OpenFile(verifyOutFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+			if err != nil {
+				reportErrorf("Unable to create file '%s' : %v", verifyOutFileName, err)
+			}
+			defer outFile.Close()
+		}
+		err = writeVerifyResult(outFile, verifyOutputFormat, result)
+		if err != nil {
+			reportErrorf("Unable to write verification result : %v", err)
+		}
+		if !result.Verified {
+			outFile.Close()
+			reportErrorf("Catchpoint label mismatch; expected %s, calculated %s", result.Label, result.CalculatedLabel)
+		}
+	},
+}
+
+func validOutputFormat(format string) bool {
+	return format == outputFormatJSON || format == outputFormatCSV
+}
+
+// verifyCatchpointFile loads the catchpoint file into a temporary ledger, rebuilds the merkle trie out of its
+// balances and compares the catchpoint label calculated from the trie root against the expected label.
+func verifyCatchpointFile(ctx context.Context, fileName string, label string) (result catchpointVerifyResult, err error) {
+	ledgerDir, err := ioutil.TempDir("", "catchpointverify")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(ledgerDir)
+
+	l, catchupAccessor, fileHeader, err := loadCatchpointFile(ctx, fileName, ledgerDir)
+	if err != nil {
+		return
+	}
+	defer l.Close()
+
+	labelFromFile := label == ""
+	if labelFromFile {
+		label = fileHeader.Catchpoint
+	}
+	if label == "" {
+		err = fmt.Errorf("catchpoint file has no label; please provide one")
+		return
+	}
+	labelRound, _, err := ledgercore.ParseCatchpointLabel(label)
+	if err != nil {
+		return
+	}
+	if labelRound != fileHeader.BlocksRound {
+		err = fmt.Errorf("catchpoint label round %d doesn't match the file blocks round %d", labelRound, fileHeader.BlocksRound)
+		return
+	}
+
+	err = catchupAccessor.BuildMerkleTrie(ctx, nil)
+	if err != nil {
+		return
+	}
+	balancesHash, totals, err := catchupAccessor.GetVerifyData(ctx)
+	if err != nil {
+		return
+	}
+	calculatedLabel := ledgercore.MakeCatchpointLabel(fileHeader.BlocksRound, fileHeader.BlockHeaderDigest, balancesHash, totals).String()
+
+	result = catchpointVerifyResult{
+		File:            fileName,
+		Label:           label,
+		CalculatedLabel: calculatedLabel,
+		BlocksRound:     uint64(fileHeader.BlocksRound),
+		BalancesRound:   uint64(fileHeader.BalancesRound),
+		TotalAccounts:   fileHeader.TotalAccounts,
+		BalancesHash:    balancesHash.String(),
+		LabelFromFile:   labelFromFile,
+		Verified:        calculatedLabel == label,
+	}
+	return
+}
+
+func writeVerifyResult(out io.Writer, format string, result catchpointVerifyResult) error {
+	if format == outputFormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	csvWriter := csv.NewWriter(out)
+	csvWriter.Write(strings.Split("file,label,calculated-label,blocks-round,balances-round,total-accounts,balances-hash,label-from-file,verified", ","))
+	csvWriter.Write([]string{
+		result.File,
+		result.Label,
+		result.CalculatedLabel,
+		strconv.FormatUint(result.BlocksRound, 10),
+		strconv.FormatUint(result.BalancesRound, 10),
+		strconv.FormatUint(result.TotalAccounts, 10),
+		result.BalancesHash,
+		strconv.FormatBool(result.LabelFromFile),
+		strconv.FormatBool(result.Verified),
+	})
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
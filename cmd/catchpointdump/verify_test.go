@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// testBalanceRecord and testBalancesChunk are encoded the same as the balance records and chunks the ledger writes.
+type testBalanceRecord struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Address     basics.Address     `codec:"pk"`
+	AccountData basics.AccountData `codec:"ad"`
+}
+
+type testBalancesChunk struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Balances []testBalanceRecord `codec:"bl"`
+}
+
+const testCatchpointRound = basics.Round(1000)
+
+// writeTestCatchpointFile writes a small catchpoint file holding the given accounts, with the given label in its header.
+func writeTestCatchpointFile(t *testing.T, fileName string, accounts map[basics.Address]basics.AccountData, label string) {
+	var chunk testBalancesChunk
+	for addr, data := range accounts {
+		chunk.Balances = append(chunk.Balances, testBalanceRecord{Address: addr, AccountData: data})
+	}
+	header := ledger.CatchpointFileHeader{
+		Version:           0200,
+		BalancesRound:     testCatchpointRound - 320,
+		BlocksRound:       testCatchpointRound,
+		TotalAccounts:     uint64(len(accounts)),
+		TotalChunks:       1,
+		Catchpoint:        label,
+		BlockHeaderDigest: crypto.Hash([]byte("block header")),
+	}
+
+	file, err := os.Create(fileName)
+	require.NoError(t, err)
+	defer file.Close()
+	tarWriter := tar.NewWriter(file)
+	for _, section := range []struct {
+		name  string
+		bytes []byte
+	}{
+		{"content.msgpack", protocol.Encode(&header)},
+		{"balances.1.1.msgpack", protocol.EncodeReflect(&chunk)},
+	} {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: section.name, Mode: 0600, Size: int64(len(section.bytes))}))
+		_, err = tarWriter.Write(section.bytes)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+}
+
+func testCatchpointAccounts() map[basics.Address]basics.AccountData {
+	return map[basics.Address]basics.AccountData{
+		{1}: {Status: basics.Offline, MicroAlgos: basics.MicroAlgos{Raw: 1000000}},
+		{2}: {
+			Status:      basics.Offline,
+			MicroAlgos:  basics.MicroAlgos{Raw: 2000000},
+			AssetParams: map[basics.AssetIndex]basics.AssetParams{5: {Total: 10, UnitName: "test"}},
+			Assets:      map[basics.AssetIndex]basics.AssetHolding{5: {Amount: 10}},
+		},
+	}
+}
+
+func TestVerifyCatchpointFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catchpointdump")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a file whose header label has the right round, but not the right balances hash.
+	bogusLabel := ledgercore.MakeCatchpointLabel(testCatchpointRound, crypto.Digest{}, crypto.Digest{}, ledgercore.AccountTotals{}).String()
+	fileName := filepath.Join(dir, "catchpoint.tar")
+	writeTestCatchpointFile(t, fileName, testCatchpointAccounts(), bogusLabel)
+
+	// without a label, the file is verified against its own header.
+	result, err := verifyCatchpointFile(context.Background(), fileName, "")
+	require.NoError(t, err)
+	require.True(t, result.LabelFromFile)
+	require.False(t, result.Verified)
+	require.Equal(t, bogusLabel, result.Label)
+	require.Equal(t, uint64(2), result.TotalAccounts)
+	label := result.CalculatedLabel
+
+	result, err = verifyCatchpointFile(context.Background(), fileName, label)
+	require.NoError(t, err)
+	require.False(t, result.LabelFromFile)
+	require.True(t, result.Verified)
+
+	// a file with different balances doesn't match the label, even though its header claims it does.
+	tampered := testCatchpointAccounts()
+	tampered[basics.Address{1}] = basics.AccountData{Status: basics.Offline, MicroAlgos: basics.MicroAlgos{Raw: 1000001}}
+	tamperedFileName := filepath.Join(dir, "tampered.tar")
+	writeTestCatchpointFile(t, tamperedFileName, tampered, label)
+	result, err = verifyCatchpointFile(context.Background(), tamperedFileName, label)
+	require.NoError(t, err)
+	require.False(t, result.Verified)
+	require.NotEqual(t, label, result.CalculatedLabel)
+
+	// a label of another round is rejected.
+	otherRoundLabel := ledgercore.MakeCatchpointLabel(testCatchpointRound+1, crypto.Digest{}, crypto.Digest{}, ledgercore.AccountTotals{}).String()
+	_, err = verifyCatchpointFile(context.Background(), fileName, otherRoundLabel)
+	require.Error(t, err)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/algorand/go-algorand/catchup"
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/rpcs"
+	"github.com/algorand/go-algorand/util/db"
+	"github.com/algorand/go-algorand/util/s3"
+)
+
+// exportBlocksBatchSize is the number of blocks read from the blocks database in a single transaction
+const exportBlocksBatchSize = 1000
+
+// exportTarget is the destination the static catchup layout is written to
+type exportTarget interface {
+	// write stores the content of the reader as the file at the given path, relative to the root of the layout
+	write(filePath string, reader io.Reader) error
+}
+
+// dirExportTarget writes the layout into a local directory, which could then be served by any HTTP server.
+// Files which already exist are skipped, so that repeated exports only write the new blocks and catchpoints.
+type dirExportTarget struct {
+	dir string
+}
+
+func (target *dirExportTarget) write(filePath string, reader io.Reader) error {
+	fullPath := filepath.Join(target.dir, filepath.FromSlash(filePath))
+	if _, err := os.Stat(fullPath); err == nil {
+		return nil
+	}
+	err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+	if err != nil {
+		return err
+	}
+	tempPath := fullPath + ".tmp"
+	file, err := os.OpenFile(tempPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, reader)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return os.Rename(tempPath, fullPath)
+}
+
+// s3ExportTarget uploads the layout into an S3-compatible bucket
+type s3ExportTarget struct {
+	helper s3.Helper
+	prefix string
+}
+
+func (target *s3ExportTarget) write(filePath string, reader io.Reader) error {
+	return target.helper.UploadFileStream(path.Join(target.prefix, filePath), reader)
+}
+
+func makeExportTarget(out string) (exportTarget, error) {
+	if !strings.HasPrefix(out, "s3://") {
+		return &dirExportTarget{dir: out}, nil
+	}
+	parsedURL, err := url.Parse(out)
+	if err != nil {
+		return nil, err
+	}
+	helper, err := s3.MakeS3SessionForUploadWithBucket(parsedURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	return &s3ExportTarget{helper: helper, prefix: strings.TrimPrefix(parsedURL.Path, "/")}, nil
+}
+
+// export implements the "catchupsrv export" command, which writes the blocks, certificates and catchpoint files of a
+// local archival ledger in the static layout the catchup services fetch from when CatchupStaticSources is configured.
+func export(args []string) {
+	exportFlags := flag.NewFlagSet("export", flag.ExitOnError)
+	ledgerDir := exportFlags.String("ledgerdir", "", "Directory containing the ledger databases ( i.e. <datadir>/<genesisID> )")
+	genesisID := exportFlags.String("genesis", "", "Genesis ID ( defaults to the name of the ledger directory )")
+	out := exportFlags.String("out", "", "Destination directory, or s3://bucket/prefix")
+	fromRound := exportFlags.Uint64("from", 0, "First round to export")
+	toRound := exportFlags.Uint64("to", math.MaxUint64, "Last round to export")
+	exportFlags.Parse(args)
+
+	if *ledgerDir == "" || *out == "" {
+		fmt.Fprintf(os.Stderr, "Must specify -ledgerdir and -out\n")
+		exportFlags.Usage()
+		os.Exit(1)
+	}
+	if *genesisID == "" {
+		*genesisID = filepath.Base(filepath.Clean(*ledgerDir))
+	}
+
+	target, err := makeExportTarget(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: unable to open export destination, %v\n", *out, err)
+		os.Exit(1)
+	}
+
+	blocks, err := exportBlocks(filepath.Join(*ledgerDir, config.LedgerFilenamePrefix+".block.sqlite"), *genesisID, basics.Round(*fromRound), basics.Round(*toRound), target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error exporting blocks, %v\n", err)
+		os.Exit(1)
+	}
+	catchpoints, err := exportCatchpoints(*ledgerDir, *genesisID, basics.Round(*fromRound), basics.Round(*toRound), target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error exporting catchpoints, %v\n", err)
+		os.Exit(1)
+	}
+	logging.Base().Infof("exported %d blocks and %d catchpoint files to %s", blocks, catchpoints, *out)
+}
+
+// exportBlocks writes the blocks and certificates of the rounds in the range [from..to] out of the given blocks
+// database, encoded the same way the block service serves them.
+func exportBlocks(blocksDBFileName string, genesisID string, from, to basics.Round, target exportTarget) (exported int, err error) {
+	dbAccessor, err := db.MakeAccessor(blocksDBFileName, true, false)
+	if err != nil {
+		return 0, err
+	}
+	defer dbAccessor.Close()
+
+	type encodedBlock struct {
+		round basics.Round
+		bytes []byte
+	}
+	for next := from; next <= to; {
+		var batch []encodedBlock
+		err = dbAccessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+			// sqlite integers are signed; clamp the upper bound so that the default of "all the rounds" remains valid.
+			batchEnd := int64(math.MaxInt64)
+			if uint64(to) < math.MaxInt64 {
+				batchEnd = int64(to)
+			}
+			rows, err := tx.Query("SELECT rnd, blkdata, certdata FROM blocks WHERE rnd >= ? AND rnd <= ? ORDER BY rnd LIMIT ?", int64(next), batchEnd, exportBlocksBatchSize)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var round uint64
+				var blk, cert []byte
+				err = rows.Scan(&round, &blk, &cert)
+				if err != nil {
+					return err
+				}
+				if len(cert) == 0 {
+					// the genesis block has no certificate, and isn't served by the block service either.
+					continue
+				}
+				batch = append(batch, encodedBlock{
+					round: basics.Round(round),
+					bytes: protocol.EncodeReflect(rpcs.PreEncodedBlockCert{Block: blk, Certificate: cert}),
+				})
+			}
+			return rows.Err()
+		})
+		if err != nil || len(batch) == 0 {
+			return
+		}
+		for _, blk := range batch {
+			err = target.write(catchup.StaticBlockPath(genesisID, blk.round), bytes.NewReader(blk.bytes))
+			if err != nil {
+				return
+			}
+			exported++
+		}
+		lastRound := batch[len(batch)-1].round
+		logging.Base().Infof("exported blocks up to round %d", lastRound)
+		if lastRound >= to {
+			break
+		}
+		next = lastRound + 1
+	}
+	return
+}
+
+// exportCatchpoints writes the catchpoint files of the rounds in the range [from..to] which are stored by the ledger in the given directory.
+func exportCatchpoints(ledgerDir string, genesisID string, from, to basics.Round, target exportTarget) (exported int, err error) {
+	dbAccessor, err := db.MakeAccessor(filepath.Join(ledgerDir, config.LedgerFilenamePrefix+".tracker.sqlite"), true, false)
+	if err != nil {
+		return 0, err
+	}
+	defer dbAccessor.Close()
+
+	type catchpointFile struct {
+		round    basics.Round
+		fileName string
+	}
+	var catchpointFiles []catchpointFile
+	err = dbAccessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT round, filename FROM storedcatchpoints WHERE filename != '' ORDER BY round")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var round uint64
+			var fileName string
+			err = rows.Scan(&round, &fileName)
+			if err != nil {
+				return err
+			}
+			if basics.Round(round) >= from && basics.Round(round) <= to {
+				catchpointFiles = append(catchpointFiles, catchpointFile{round: basics.Round(round), fileName: fileName})
+			}
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return
+	}
+
+	for _, catchpoint := range catchpointFiles {
+		var file *os.File
+		file, err = os.Open(filepath.Join(ledgerDir, catchpoint.fileName))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// the catchpoint file was deleted by the ledger after we've listed it.
+				err = nil
+				continue
+			}
+			return
+		}
+		err = target.write(catchup.StaticLedgerPath(genesisID, catchpoint.round), file)
+		file.Close()
+		if err != nil {
+			return
+		}
+		logging.Base().Infof("exported catchpoint file for round %d", catchpoint.round)
+		exported++
+	}
+	return
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/catchup"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/rpcs"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+func TestExportLayoutMatchesBlockDir(t *testing.T) {
+	for _, round := range []uint64{0, 1000, 10000500, 10012300500} {
+		require.Equal(t, "v1/test/block/"+blockToPath(round), catchup.StaticBlockPath("test", basics.Round(round)))
+	}
+}
+
+func TestExportBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catchupsrvexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	blocksDBFileName := filepath.Join(dir, "ledger.block.sqlite")
+	dbAccessor, err := db.MakeAccessor(blocksDBFileName, false, false)
+	require.NoError(t, err)
+	err = dbAccessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.Exec("CREATE TABLE blocks (rnd integer primary key, proto text, hdrdata blob, blkdata blob, certdata blob)")
+		if err != nil {
+			return err
+		}
+		for round := basics.Round(0); round < 5; round++ {
+			var blk bookkeeping.Block
+			blk.BlockHeader.Round = round
+			var cert []byte
+			if round > 0 {
+				cert = protocol.Encode(&agreement.Certificate{Round: round})
+			}
+			_, err = tx.Exec("INSERT INTO blocks (rnd, proto, hdrdata, blkdata, certdata) VALUES (?, ?, ?, ?, ?)", round, "", []byte{}, protocol.Encode(&blk), cert)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	dbAccessor.Close()
+
+	outDir := filepath.Join(dir, "out")
+	exported, err := exportBlocks(blocksDBFileName, "test", 2, 10, &dirExportTarget{dir: outDir})
+	require.NoError(t, err)
+	require.Equal(t, 3, exported)
+
+	for round := basics.Round(0); round < 5; round++ {
+		data, err := ioutil.ReadFile(filepath.Join(outDir, filepath.FromSlash(catchup.StaticBlockPath("test", round))))
+		if round < 2 {
+			require.True(t, os.IsNotExist(err))
+			continue
+		}
+		require.NoError(t, err)
+		var decoded rpcs.EncodedBlockCert
+		require.NoError(t, protocol.Decode(data, &decoded))
+		require.Equal(t, round, decoded.Block.Round())
+		require.Equal(t, round, decoded.Certificate.Round)
+	}
+}
+
+func TestDirExportTargetSkipsExistingFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catchupsrvexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	target := &dirExportTarget{dir: dir}
+	require.NoError(t, target.write("a/b/file", strings.NewReader("first")))
+	require.NoError(t, target.write("a/b/file", strings.NewReader("second")))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "a", "b", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "first", string(data))
+}
@@ -46,6 +46,11 @@ func main() {
 	log := logging.Base()
 	log.SetLevel(logging.Info)
 
+	if flag.Arg(0) == "export" {
+		export(flag.Args()[1:])
+		return
+	}
+
 	if *dirFlag == "" && *tarDirFlag == "" {
 		panic("Must specify -dir or -tardir")
 	}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -49,6 +50,9 @@ var (
 	closeToAddress  string
 	noProgramOutput bool
 	signProgram     bool
+	writeSourceMap  bool
+	lintStateful    bool
+	includeDirs     []string
 	programSource   string
 	argB64Strings   []string
 	disassemble     bool
@@ -71,6 +75,7 @@ func init() {
 	clerkCmd.AddCommand(groupCmd)
 	clerkCmd.AddCommand(splitCmd)
 	clerkCmd.AddCommand(compileCmd)
+	clerkCmd.AddCommand(lintCmd)
 	clerkCmd.AddCommand(dryrunCmd)
 	clerkCmd.AddCommand(dryrunRemoteCmd)
 
@@ -124,7 +129,12 @@ func init() {
 	compileCmd.Flags().BoolVarP(&noProgramOutput, "no-out", "n", false, "don't write contract program binary")
 	compileCmd.Flags().BoolVarP(&signProgram, "sign", "s", false, "sign program, output is a binary signed LogicSig record")
 	compileCmd.Flags().StringVarP(&outFilename, "outfile", "o", "", "Filename to write program bytes or signed LogicSig to")
+	compileCmd.Flags().BoolVarP(&writeSourceMap, "map", "m", false, "write out source map to the output filename with a .map suffix")
 	compileCmd.Flags().StringVarP(&account, "account", "a", "", "Account address to sign the program (If not specified, uses default account)")
+	compileCmd.Flags().StringSliceVarP(&includeDirs, "include", "I", nil, "directory to search for #include files, after the directory of the program")
+
+	lintCmd.Flags().BoolVar(&lintStateful, "app", false, "lint as application programs instead of LogicSigs")
+	lintCmd.Flags().StringSliceVarP(&includeDirs, "include", "I", nil, "directory to search for #include files, after the directory of the program")
 
 	dryrunCmd.Flags().StringVarP(&txFilename, "txfile", "t", "", "transaction or transaction-group to test")
 	dryrunCmd.Flags().StringVarP(&protoVersion, "proto", "P", "", "consensus protocol version id string")
@@ -904,16 +914,26 @@ func mustReadFile(fname string) []byte {
 }
 
 func assembleFile(fname string) (program []byte) {
+	return assembleFileImpl(fname).Program
+}
+
+func assembleFileImpl(fname string) *logic.OpStream {
 	text, err := readFile(fname)
 	if err != nil {
 		reportErrorf("%s: %s", fname, err)
 	}
-	ops, err := logic.AssembleString(string(text))
+	// #include is relative to the program, then the --include directories
+	dirs := []string{"."}
+	if fname != stdinFileNameValue {
+		dirs[0] = filepath.Dir(fname)
+	}
+	dirs = append(dirs, includeDirs...)
+	ops, err := logic.AssembleStringWithIncludes(string(text), dirs)
 	if err != nil {
 		ops.ReportProblems(fname)
 		reportErrorf("%s: %s", fname, err)
 	}
-	return ops.Program
+	return ops
 }
 
 func disassembleFile(fname, outname string) {
@@ -962,7 +982,8 @@ var compileCmd = &cobra.Command{
 				disassembleFile(fname, outFilename)
 				continue
 			}
-			program := assembleFile(fname)
+			ops := assembleFileImpl(fname)
+			program := ops.Program
 			outblob := program
 			outname := outFilename
 			if outname == "" {
@@ -972,6 +993,20 @@ var compileCmd = &cobra.Command{
 					outname = fmt.Sprintf("%s.tok", fname)
 				}
 			}
+			if writeSourceMap {
+				if outname == stdoutFilenameValue {
+					reportErrorf("%s: source map requires an output filename", fname)
+				}
+				mapname := fmt.Sprintf("%s.map", outname)
+				mapblob, err := json.Marshal(ops.GetSourceMap(fname))
+				if err != nil {
+					reportErrorf("%s: %s", mapname, err)
+				}
+				err = writeFile(mapname, mapblob, 0666)
+				if err != nil {
+					reportErrorf("%s: %s", mapname, err)
+				}
+			}
 			if signProgram {
 				dataDir := ensureSingleDataDir()
 				accountList := makeAccountsList(dataDir)
@@ -1010,6 +1045,26 @@ var compileCmd = &cobra.Command{
 	},
 }
 
+var lintCmd = &cobra.Command{
+	Use:   "lint [input file 1] [input file 2]...",
+	Short: "Check a contract program for common mistakes",
+	Long:  "Reads TEAL contract programs and reports unreachable code, stack misuse and approving paths that do not check fields such as RekeyTo or CloseRemainderTo.",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		found := false
+		for _, fname := range args {
+			ops := assembleFileImpl(fname)
+			for _, finding := range logic.Lint(ops, lintStateful) {
+				fmt.Printf("%s:%s\n", fname, finding)
+				found = true
+			}
+		}
+		if found {
+			os.Exit(1)
+		}
+	},
+}
+
 var dryrunCmd = &cobra.Command{
 	Use:   "dryrun",
 	Short: "Test a program offline",
@@ -23,6 +23,13 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/libgoal"
+	"github.com/algorand/go-algorand/lightclient"
+	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/protocol/transcode"
 )
 
@@ -31,16 +38,21 @@ var (
 	rawBlock       bool
 	base32Encoding bool
 	strictJSON     bool
+
+	pendingCompactCerts bool
 )
 
 func init() {
 	ledgerCmd.AddCommand(supplyCmd)
 	ledgerCmd.AddCommand(blockCmd)
+	ledgerCmd.AddCommand(compactCertCmd)
 
 	blockCmd.Flags().StringVarP(&blockFilename, "out", "o", stdoutFilenameValue, "The filename to dump the block to (if not set, use stdout)")
 	blockCmd.Flags().BoolVarP(&rawBlock, "raw", "r", false, "Format block as msgpack")
 	blockCmd.Flags().BoolVar(&base32Encoding, "b32", false, "Encode binary blobs using base32 instead of base64")
 	blockCmd.Flags().BoolVar(&strictJSON, "strict", false, "Strict JSON decode: turn all keys into strings")
+
+	compactCertCmd.Flags().BoolVar(&pendingCompactCerts, "pending", false, "Show the signature collection progress of the compact certs which were not formed yet")
 }
 
 var ledgerCmd = &cobra.Command{
@@ -110,3 +122,149 @@ var blockCmd = &cobra.Command{
 		}
 	},
 }
+
+var compactCertCmd = &cobra.Command{
+	Use:   "compactcert [round number] [last round number]",
+	Short: "Show and verify the compact certificate covering a round",
+	Long:  "Show the compact certificate covering a round, and verify it against the voters committed to by the block header one compact cert interval before it. Given a last round too, verify the chain of the compact certificates covering the rounds in between, each one against the voters of the one before it. With --pending, show the signature collection progress of the compact certs which were not formed yet.",
+	Args:  cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataDir := ensureSingleDataDir()
+		client := ensureAlgodClient(dataDir)
+
+		if pendingCompactCerts {
+			if len(args) != 0 {
+				reportErrorf(errCompactCertArgs)
+			}
+			response, err := client.PendingCompactCerts()
+			if err != nil {
+				reportErrorf(errorRequestFail, err)
+			}
+			if len(response.PendingCerts) == 0 {
+				fmt.Printf("No pending compact certs\n")
+				return
+			}
+			for _, pc := range response.PendingCerts {
+				fmt.Printf("Round %d: %d signatures (%d from this node), signed weight %d / proven weight %d / total weight %d\n",
+					pc.Round, pc.Signatures, pc.SignaturesFromThisNode, pc.SignedWeight, pc.ProvenWeight, pc.TotalWeight)
+				for _, signer := range pc.Signers {
+					fromThisNode := ""
+					if signer.FromThisNode {
+						fromThisNode = " (this node)"
+					}
+					fmt.Printf("  %s: weight %d%s\n", signer.Address, signer.Weight, fromThisNode)
+				}
+			}
+			return
+		}
+
+		if len(args) == 0 {
+			reportErrorf(errCompactCertArgs)
+		}
+		round, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			reportErrorf(errParsingRoundNumber, err)
+		}
+		if len(args) == 2 {
+			lastRound, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				reportErrorf(errParsingRoundNumber, err)
+			}
+			verifyCompactCertChain(client, round, lastRound)
+			return
+		}
+		response, err := client.CompactCert(round)
+		if err != nil {
+			reportErrorf(errorRequestFail, err)
+		}
+		var cc transactions.CompactCertTxnFields
+		err = protocol.Decode(response.Cert, &cc)
+		if err != nil {
+			reportErrorf(errDecodingCompactCert, err)
+		}
+		var votersCommitment crypto.Digest
+		copy(votersCommitment[:], response.VotersCommitment)
+
+		fmt.Printf("Cert round: %d\n", response.CertRound)
+		fmt.Printf("Confirmed round: %d\n", response.ConfirmedRound)
+		fmt.Printf("Voters commitment: %s\n", votersCommitment)
+		fmt.Printf("Signed weight: %d / voters total weight: %d\n", response.SignedWeight, response.VotersTotalWeight)
+		fmt.Printf("Revealed signers: %d\n", len(response.Reveals))
+		for _, reveal := range response.Reveals {
+			fmt.Printf("  Position %d: weight %d\n", reveal.Position, reveal.Weight)
+		}
+
+		err = verifyCompactCert(client, basics.Round(response.CertRound), cc)
+		if err != nil {
+			reportErrorf(errVerifyingCert, response.CertRound, err)
+		}
+		fmt.Printf("Verified: yes\n")
+	},
+}
+
+// verifyCompactCertChain verifies the compact certs covering the rounds from
+// round to lastRound, starting from the voters of the first one, and then each
+// cert against the voters of the header certified by the one before it.
+func verifyCompactCertChain(client libgoal.Client, round, lastRound uint64) {
+	var lc *lightclient.Client
+	for round <= lastRound {
+		response, err := client.CompactCerts(round, lastRound)
+		if err != nil {
+			reportErrorf(errorRequestFail, err)
+		}
+		if len(response.Certs) == 0 {
+			break
+		}
+		for _, cert := range response.Certs {
+			var cc transactions.CompactCertTxnFields
+			err = protocol.Decode(cert.Cert, &cc)
+			if err != nil {
+				reportErrorf(errDecodingCompactCert, err)
+			}
+			certBlock, err := client.BookkeepingBlock(cert.CertRound)
+			if err != nil {
+				reportErrorf(errorRequestFail, err)
+			}
+			if lc == nil {
+				proto := config.Consensus[certBlock.CurrentProtocol]
+				votersBlock, err := client.BookkeepingBlock(cert.CertRound - proto.CompactCertRounds)
+				if err != nil {
+					reportErrorf(errorRequestFail, err)
+				}
+				lc, err = lightclient.MakeClient(votersBlock.BlockHeader)
+				if err != nil {
+					reportErrorf(errVerifyingCert, cert.CertRound, err)
+				}
+			}
+			err = lc.AddCert(certBlock.BlockHeader, cc.Cert)
+			if err != nil {
+				reportErrorf(errVerifyingCert, cert.CertRound, err)
+			}
+			fmt.Printf("Cert round %d: confirmed in round %d, signed weight %d / voters total weight %d, verified\n",
+				cert.CertRound, cert.ConfirmedRound, cert.SignedWeight, cert.VotersTotalWeight)
+		}
+		round = response.Certs[len(response.Certs)-1].CertRound + 1
+	}
+	if lc == nil {
+		fmt.Printf("No compact certs for rounds %d to %d yet\n", round, lastRound)
+	}
+}
+
+// verifyCompactCert verifies the cert against the voters committed to by the
+// block header one compact cert interval before certRound.
+func verifyCompactCert(client libgoal.Client, certRound basics.Round, cc transactions.CompactCertTxnFields) error {
+	certBlock, err := client.BookkeepingBlock(uint64(certRound))
+	if err != nil {
+		return err
+	}
+	proto := config.Consensus[certBlock.CurrentProtocol]
+	votersBlock, err := client.BookkeepingBlock(uint64(certRound.SubSaturate(basics.Round(proto.CompactCertRounds))))
+	if err != nil {
+		return err
+	}
+	lc, err := lightclient.MakeClient(votersBlock.BlockHeader)
+	if err != nil {
+		return err
+	}
+	return lc.AddCert(certBlock.BlockHeader, cc.Cert)
+}
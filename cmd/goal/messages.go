@@ -78,9 +78,13 @@ const (
 	errorKill                         = "Cannot kill node: %s"
 	errorCloningNode                  = "Error cloning the node: %s"
 	infoNodeCloned                    = "Node cloned successfully to: %s"
+	infoDevModeRoundsAdvanced         = "Last committed block: %d"
+	infoDevModeTimeStampOffset        = "Block timestamp offset: %d seconds"
+	errorDevModeTimeStampOffset       = "Invalid block timestamp offset '%s': %v"
 	infoNodeWroteToken                = "Successfully wrote new API token: %s"
 	infoNodePendingTxnsDescription    = "Pending Transactions (Truncated max=%d, Total in pool=%d): "
 	infoNodeNoPendingTxnsDescription  = "None"
+	infoNodeNoPeers                   = "The node is not connected to any peer"
 	infoDataDir                       = "[Data Directory: %s]"
 	errLoadingConfig                  = "Error loading Config file from '%s': %v"
 	errorNodeFailedToShutdown         = "Unable to shut down node: %v"
@@ -132,6 +136,7 @@ const (
 	infoNetworkAlreadyExists = "Network Root Directory '%s' already exists"
 	errorCreateNetwork       = "Error creating private network: %s"
 	infoNetworkCreated       = "Network %s created under %s"
+	infoNetworkForked        = "Network %s forked off catchpoint file '%s' created under %s"
 	errorLoadingNetwork      = "Error loading deployed network: %s"
 	errorStartingNetwork     = "Error starting deployed network: %s"
 	infoNetworkStarted       = "Network Started under %s"
@@ -195,4 +200,7 @@ const (
 	errParsingRoundNumber  = "Error parsing round number: %s"
 	errBadBlockArgs        = "Cannot combine --b32=true or --strict=true with --raw"
 	errEncodingBlockAsJSON = "Error encoding block as json: %s"
+	errDecodingCompactCert = "Error decoding compact cert: %s"
+	errVerifyingCert       = "Compact cert for round %d does not verify: %s"
+	errCompactCertArgs     = "Either a round number or --pending must be specified"
 )
@@ -34,6 +34,7 @@ var networkTemplateFile string
 var startNode string
 var noImportKeys bool
 var noClean bool
+var forkCatchpointFile string
 
 func init() {
 	networkCmd.AddCommand(networkCreateCmd)
@@ -47,8 +48,17 @@ func init() {
 	networkCreateCmd.Flags().BoolVarP(&noImportKeys, "noimportkeys", "K", false, "Do not import root keys when creating the network (by default will import)")
 	networkCreateCmd.Flags().BoolVar(&noClean, "noclean", false, "Prevents auto-cleanup on error - for diagnosing problems")
 
+	networkForkCmd.Flags().StringVarP(&networkName, "network", "n", "", "Specify the name to use for the private network")
+	networkForkCmd.MarkFlagRequired("network")
+	networkForkCmd.Flags().StringVarP(&forkCatchpointFile, "catchpoint", "c", "", "Specify the path to the catchpoint file of the network to fork")
+	networkForkCmd.MarkFlagRequired("catchpoint")
+	networkForkCmd.Flags().StringVarP(&networkTemplateFile, "template", "t", "", "Specify the path to the template file for the network (by default a single relay holding all the online stake)")
+	networkForkCmd.Flags().BoolVarP(&noImportKeys, "noimportkeys", "K", false, "Do not import root keys when creating the network (by default will import)")
+	networkForkCmd.Flags().BoolVar(&noClean, "noclean", false, "Prevents auto-cleanup on error - for diagnosing problems")
+
 	networkStartCmd.Flags().StringVarP(&startNode, "node", "n", "", "Specify the name of a specific node to start")
 
+	networkCmd.AddCommand(networkForkCmd)
 	networkCmd.AddCommand(networkStartCmd)
 	networkCmd.AddCommand(networkRestartCmd)
 	networkCmd.AddCommand(networkStopCmd)
@@ -115,6 +125,56 @@ var networkCreateCmd = &cobra.Command{
 	},
 }
 
+var networkForkCmd = &cobra.Command{
+	Use:   "fork",
+	Short: "Create a private named network from the state of another network",
+	Long: `Creates a private network, the same way 'create' does, whose genesis holds all the accounts, assets and applications of the catchpoint file of another network, such as MainNet or TestNet.
+
+The online accounts of the forked network are taken offline, and the online wallets of the template get their stake instead, with locally generated keys.`,
+	Args: validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, _ []string) {
+		networkRootDir, err := filepath.Abs(networkRootDir)
+		if err != nil {
+			panic(err)
+		}
+		if networkTemplateFile != "" {
+			networkTemplateFile, err = filepath.Abs(networkTemplateFile)
+			if err != nil {
+				panic(err)
+			}
+		}
+		// Make sure target directory doesn't already exist
+		exists := util.FileExists(networkRootDir)
+		if exists {
+			reportErrorf(infoNetworkAlreadyExists, networkRootDir)
+		}
+
+		binDir, err := util.ExeDir()
+		if err != nil {
+			panic(err)
+		}
+
+		dataDir := maybeSingleDataDir()
+		var consensus config.ConsensusProtocols
+		if dataDir != "" {
+			// try to load the consensus from there. If there is none, we can just use the built in one.
+			consensus, _ = config.PreloadConfigurableConsensusProtocols(dataDir)
+		}
+
+		network, err := netdeploy.CreateForkedNetworkFromTemplate(networkName, networkRootDir, networkTemplateFile, forkCatchpointFile, binDir, !noImportKeys, nil, consensus)
+		if err != nil {
+			if noClean {
+				reportInfof(" ** failed ** - Preserving network rootdir '%s'", networkRootDir)
+			} else {
+				os.RemoveAll(networkRootDir) // Don't leave partial network directory if create failed
+			}
+			reportErrorf(errorCreateNetwork, err)
+		}
+
+		reportInfof(infoNetworkForked, network.Name(), forkCatchpointFile, networkRootDir)
+	},
+}
+
 func getNetworkAndBinDir() (netdeploy.Network, string) {
 	networkRootDir, err := filepath.Abs(networkRootDir)
 	if err != nil {
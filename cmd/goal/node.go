@@ -24,12 +24,15 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	generatedV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	privateV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/private"
 
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
@@ -55,6 +58,7 @@ var newNodeIndexer bool
 var newNodeRelay string
 var watchMillisecond uint64
 var abortCatchup bool
+var devModeRounds uint64
 
 func init() {
 	nodeCmd.AddCommand(startCmd)
@@ -68,6 +72,10 @@ func init() {
 	nodeCmd.AddCommand(waitCmd)
 	nodeCmd.AddCommand(createCmd)
 	nodeCmd.AddCommand(catchupCmd)
+	nodeCmd.AddCommand(peersCmd)
+	nodeCmd.AddCommand(devModeCmd)
+	devModeCmd.AddCommand(devModeAdvanceCmd)
+	devModeCmd.AddCommand(devModeOffsetCmd)
 	// Once the server-side implementation of the shutdown command is ready, we should enable this one.
 	//nodeCmd.AddCommand(shutdownCmd)
 
@@ -101,6 +109,8 @@ func init() {
 
 	catchupCmd.Flags().BoolVarP(&abortCatchup, "abort", "x", false, "Aborts the current catchup process")
 
+	devModeAdvanceCmd.Flags().Uint64VarP(&devModeRounds, "rounds", "r", 1, "Number of rounds to advance")
+
 }
 
 var nodeCmd = &cobra.Command{
@@ -129,6 +139,65 @@ var catchupCmd = &cobra.Command{
 	},
 }
 
+var devModeCmd = &cobra.Command{
+	Use:   "devmode",
+	Short: "Control a node running a development mode network",
+	Long:  "Collection of commands to control a node running a development mode network, which writes a block for every transaction group it receives instead of running the agreement.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		//Fall back
+		cmd.HelpFunc()(cmd, args)
+	},
+}
+
+var devModeAdvanceCmd = &cobra.Command{
+	Use:   "advance",
+	Short: "Advance the rounds of a development mode node",
+	Long:  "Writes blocks to the ledger of a development mode node; the first block holds the transactions still pending in its transaction pool.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		onDataDirs(func(dataDir string) {
+			client := ensureAlgodClient(dataDir)
+			lastRound, err := client.AdvanceDevModeRounds(devModeRounds)
+			if err != nil {
+				reportErrorf(errorNodeStatus, err)
+			}
+			reportInfof(infoDevModeRoundsAdvanced, lastRound)
+		})
+	},
+}
+
+var devModeOffsetCmd = &cobra.Command{
+	Use:     "offset [seconds]",
+	Short:   "Get or set the block timestamp offset of a development mode node",
+	Long:    "A development mode node timestamps its blocks with the wall clock shifted by this number of seconds. Without an argument, the current offset is displayed.",
+	Example: "goal node devmode offset\t\tDisplay the current block timestamp offset\ngoal node devmode offset 3600\tTimestamp the blocks an hour ahead of the wall clock",
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var offset uint64
+		if len(args) > 0 {
+			var err error
+			offset, err = strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				reportErrorf(errorDevModeTimeStampOffset, args[0], err)
+			}
+		}
+		onDataDirs(func(dataDir string) {
+			client := ensureAlgodClient(dataDir)
+			var err error
+			if len(args) > 0 {
+				err = client.SetBlockTimeStampOffset(offset)
+			} else {
+				offset, err = client.GetBlockTimeStampOffset()
+			}
+			if err != nil {
+				reportErrorf(errorNodeStatus, err)
+			}
+			reportInfof(infoDevModeTimeStampOffset, offset)
+		})
+	},
+}
+
 func catchpointCmdArgument(cmd *cobra.Command, args []string) error {
 	catchpointsCount := 0
 	for _, arg := range args {
@@ -506,6 +575,47 @@ var pendingTxnsCmd = &cobra.Command{
 	},
 }
 
+var peersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "List the peers the node is connected to",
+	Long:  "List the peers the node is connected to, along with the connection age, the ping round trip time, the traffic exchanged with each peer and the number of messages queued for sending to it.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, _ []string) {
+		onDataDirs(func(dataDir string) {
+			client := ensureAlgodClient(dataDir)
+			response, err := client.NetworkPeers()
+			if err != nil {
+				reportErrorf(errorNodeStatus, err)
+			}
+			if len(response.Peers) == 0 {
+				reportInfoln(infoNodeNoPeers)
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ADDRESS\tDIRECTION\tVERSION\tAGE\tPING\tMSGS IN\tMSGS OUT\tBYTES IN\tBYTES OUT\tQUEUED")
+			for _, peer := range response.Peers {
+				ping := "-"
+				if peer.PingRoundTripTime != nil {
+					ping = time.Duration(*peer.PingRoundTripTime).String()
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\n",
+					peer.Address, peer.Direction, peer.Version,
+					time.Duration(peer.ConnectionAge).Round(time.Second), ping,
+					tagCountsTotal(peer.MessagesIn), tagCountsTotal(peer.MessagesOut),
+					peer.BytesIn, peer.BytesOut, peer.HighPriorityQueueLength+peer.BulkQueueLength)
+			}
+			w.Flush()
+		})
+	},
+}
+
+func tagCountsTotal(counts []privateV2.NetworkPeerTagCount) (total uint64) {
+	for _, count := range counts {
+		total += count.Count
+	}
+	return
+}
+
 var waitCmd = &cobra.Command{
 	Use:   "wait",
 	Short: "Waits for the node to make progress",
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/go-algorand/network/messagetracer"
+)
+
+var latencyCmd = &cobra.Command{
+	Use:   "latency",
+	Short: "Print the latency percentiles of the traced messages per relay",
+	Long:  "Print the latency percentiles of the traced messages per relay. The arrival latency is the time from the origin sending a message to the node receiving it, and is affected by the clock skew between them. The relay latency is the time the node took to relay the messages it received, by its own clock.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		writeLatencies(os.Stdout, messagetracer.Latencies(loadTrees(cmd, args)))
+	},
+}
+
+func writeLatencies(out io.Writer, latencies []messagetracer.NodeLatency) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tMESSAGES\tHOPS\tARRIVAL P50\tP90\tP99\tRELAYED\tRELAY P50\tP90\tP99")
+	for _, latency := range latencies {
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%v\t%v\t%v\t%d\t%v\t%v\t%v\n", nodeName(latency.Node),
+			latency.Arrival.Samples, latency.Hops, latency.Arrival.P50, latency.Arrival.P90, latency.Arrival.P99,
+			latency.Forwarding.Samples, latency.Forwarding.P50, latency.Forwarding.P90, latency.Forwarding.P99)
+	}
+	w.Flush()
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/network/messagetracer"
+)
+
+func TestLatencyOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filenames, names := writeTestStores(t, dir, time.Now().Add(-time.Minute))
+	defer func() {
+		storeFilenames = nil
+	}()
+
+	storeFilenames = filenames
+	var out bytes.Buffer
+	writeLatencies(&out, messagetracer.Latencies(loadTrees(latencyCmd, nil)))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"NODE", "MESSAGES", "HOPS", "ARRIVAL", "P50", "P90", "P99", "RELAYED", "RELAY", "P50", "P90", "P99"}, strings.Fields(lines[0]))
+	// relay1 received both messages from the origin, and relayed the proposal to relay2.
+	require.Equal(t, []string{names[1], "2", "1.0", "4ms", "10ms", "10ms", "1", "2ms", "2ms", "2ms"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{names[2], "1", "2.0", "20ms", "20ms", "20ms", "0", "0s", "0s", "0s"}, strings.Fields(lines[2]))
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/network/messagetracer"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+var storeFilenames []string
+
+var digestFilter string
+
+var tagFilter string
+
+var versionCheck bool
+
+func init() {
+	rootCmd.PersistentFlags().StringSliceVarP(&storeFilenames, "store", "s", nil, "Specify the message trace store files ( i.e. ./messagetrace.sqlite ), typically gathered from several nodes")
+	rootCmd.PersistentFlags().StringVarP(&digestFilter, "digest", "d", "", "Only include the traces of the message of the given digest")
+	rootCmd.PersistentFlags().StringVarP(&tagFilter, "tag", "t", "", "Only include the traces of the messages of the given tag ( i.e. PP, AV or TX )")
+	rootCmd.Flags().BoolVarP(&versionCheck, "version", "v", false, "Display and write current build version and exit")
+
+	rootCmd.AddCommand(treesCmd)
+	rootCmd.AddCommand(latencyCmd)
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "msgtrace",
+	Short: "Message trace query utility",
+	Long:  "Message trace query utility. It reconstructs the propagation of the sampled messages from the trace stores of the nodes with MessageTraceSampleRate enabled. Each node stores the trace records which reached it, so the more stores are given, the more complete the propagation trees are.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		if versionCheck {
+			fmt.Println(config.FormatVersionAndLicense())
+			return
+		}
+		//If no arguments passed, we should fallback to help
+		cmd.HelpFunc()(cmd, args)
+	},
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// loadTrees reads the traces from all the stores, and builds the propagation trees of the messages matching the filters.
+func loadTrees(cmd *cobra.Command, args []string) []messagetracer.Tree {
+	if len(storeFilenames) == 0 {
+		cmd.HelpFunc()(cmd, args)
+		os.Exit(1)
+	}
+	var digest *crypto.Digest
+	if digestFilter != "" {
+		d, err := crypto.DigestFromString(digestFilter)
+		if err != nil {
+			reportErrorf("Invalid message digest '%s' : %v", digestFilter, err)
+		}
+		digest = &d
+	}
+	var traces []messagetracer.Trace
+	for _, filename := range storeFilenames {
+		if _, err := os.Stat(filename); err != nil {
+			reportErrorf("Unable to open the message trace store '%s' : %v", filename, err)
+		}
+		store, err := messagetracer.OpenStore(filename, true)
+		if err != nil {
+			reportErrorf("Unable to open the message trace store '%s' : %v", filename, err)
+		}
+		storeTraces, err := store.Traces(digest)
+		store.Close()
+		if err != nil {
+			reportErrorf("Unable to read the message trace store '%s' : %v", filename, err)
+		}
+		for _, trace := range storeTraces {
+			if tagFilter == "" || trace.Record.Tag == protocol.Tag(tagFilter) {
+				traces = append(traces, trace)
+			}
+		}
+	}
+	return messagetracer.BuildTrees(traces)
+}
+
+func nodeName(node crypto.SignatureVerifier) string {
+	return base64.StdEncoding.EncodeToString(node[:])
+}
+
+func reportErrorf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+// validateNoPosArgsFn is a reusable cobra positional argument validation function
+// for generating proper error messages when commands see unexpected arguments when they expect no args.
+// We don't use cobra.NoArgs directly, in case we want to customize behavior later.
+var validateNoPosArgsFn = cobra.NoArgs
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/algorand/go-algorand/network/messagetracer"
+)
+
+var treesCmd = &cobra.Command{
+	Use:   "trees",
+	Short: "Print the propagation trees of the traced messages",
+	Long:  "Print the propagation trees of the traced messages. Every node is listed under the node it first received the message from, along with the time it took the message to reach it from the origin, and the time it took the node to relay it.",
+	Args:  validateNoPosArgsFn,
+	Run: func(cmd *cobra.Command, args []string) {
+		writeTrees(os.Stdout, loadTrees(cmd, args))
+	},
+}
+
+func writeTrees(w io.Writer, trees []messagetracer.Tree) {
+	for _, tree := range trees {
+		fmt.Fprintf(w, "%s %s sent at %s, %d hops\n", tree.Tag, tree.Digest.String(), time.Unix(0, tree.Root.Sent).UTC().Format(time.RFC3339Nano), tree.Root.Hops())
+		writeTreeNode(w, tree.Root, tree.Root, 1)
+	}
+}
+
+func writeTreeNode(w io.Writer, origin *messagetracer.TreeNode, node *messagetracer.TreeNode, depth int) {
+	line := strings.Repeat("  ", depth) + nodeName(node.Node)
+	if node != origin {
+		line += fmt.Sprintf(" +%v", time.Duration(node.Received-origin.Sent))
+	}
+	if node.Sent != 0 && node != origin {
+		line += fmt.Sprintf(" relayed in %v", time.Duration(node.Sent-node.Received))
+	}
+	fmt.Fprintln(w, line)
+	for _, child := range node.Children {
+		writeTreeNode(w, origin, child, depth+1)
+	}
+}
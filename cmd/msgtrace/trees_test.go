@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/network/messagetracer"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// writeTestStores stores the traces of a proposal sent by an origin through relay1 and then relay2 in the stores of
+// the relays, and the traces of a transaction sent by the origin to relay1 in the store of relay1. It returns the
+// names of the nodes, in that order.
+func writeTestStores(t *testing.T, dir string, start time.Time) (filenames []string, names []string) {
+	var secrets []*crypto.SignatureSecrets
+	for i := 0; i < 3; i++ {
+		s := crypto.GenerateSignatureSecrets(crypto.Seed{byte(i + 1)})
+		secrets = append(secrets, s)
+		names = append(names, nodeName(s.SignatureVerifier))
+	}
+	origin, relay1, relay2 := secrets[0], secrets[1], secrets[2]
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	proposal0 := messagetracer.Record{Digest: crypto.Hash([]byte("proposal")), Tag: protocol.ProposalPayloadTag}.Extend(origin, time.Time{}, at(0))
+	proposal1 := proposal0.Extend(relay1, at(10), at(12))
+	txn0 := messagetracer.Record{Digest: crypto.Hash([]byte("txn")), Tag: protocol.TxnTag}.Extend(origin, time.Time{}, at(100))
+	stores := [][]messagetracer.Trace{
+		{
+			{Observer: relay1.SignatureVerifier, Received: at(10).UnixNano(), Record: proposal0},
+			{Observer: relay1.SignatureVerifier, Received: at(104).UnixNano(), Record: txn0},
+		},
+		{
+			{Observer: relay2.SignatureVerifier, Received: at(20).UnixNano(), Record: proposal1},
+		},
+	}
+	for i, traces := range stores {
+		filename := filepath.Join(dir, "messagetrace"+string(rune('1'+i))+".sqlite")
+		store, err := messagetracer.OpenStore(filename, false)
+		require.NoError(t, err)
+		require.NoError(t, store.Add(traces))
+		store.Close()
+		filenames = append(filenames, filename)
+	}
+	return
+}
+
+func TestTreesOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	// the stores prune the traces older than their retention.
+	start := time.Now().Add(-time.Minute).Truncate(time.Second)
+	filenames, names := writeTestStores(t, dir, start)
+	defer func() {
+		storeFilenames, digestFilter, tagFilter = nil, "", ""
+	}()
+
+	// the traces from all the stores are merged into the propagation trees.
+	storeFilenames = filenames
+	var out bytes.Buffer
+	writeTrees(&out, loadTrees(treesCmd, nil))
+	expected := []string{
+		"PP " + crypto.Hash([]byte("proposal")).String() + " sent at " + start.UTC().Format(time.RFC3339Nano) + ", 2 hops",
+		"  " + names[0],
+		"    " + names[1] + " +10ms relayed in 2ms",
+		"      " + names[2] + " +20ms",
+		"TX " + crypto.Hash([]byte("txn")).String() + " sent at " + start.Add(100*time.Millisecond).UTC().Format(time.RFC3339Nano) + ", 1 hops",
+		"  " + names[0],
+		"    " + names[1] + " +4ms",
+	}
+	require.Equal(t, strings.Join(expected, "\n")+"\n", out.String())
+
+	// the traces are filtered by tag and by digest.
+	tagFilter = string(protocol.TxnTag)
+	out.Reset()
+	writeTrees(&out, loadTrees(treesCmd, nil))
+	require.Equal(t, strings.Join(expected[4:], "\n")+"\n", out.String())
+
+	tagFilter = ""
+	digestFilter = crypto.Hash([]byte("proposal")).String()
+	out.Reset()
+	writeTrees(&out, loadTrees(treesCmd, nil))
+	require.Equal(t, strings.Join(expected[:4], "\n")+"\n", out.String())
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"path/filepath"
 	"time"
 
 	"github.com/algorand/go-algorand/config"
@@ -335,7 +336,9 @@ func (r *LocalRunner) Setup(dp *DebugParams) (err error) {
 			r.runs[i].program = data
 			if IsTextFile(data) {
 				source := string(data)
-				ops, err := logic.AssembleStringWithVersion(source, r.proto.LogicSigVersion)
+				// #include is relative to the program, then the --include directories
+				dirs := append([]string{filepath.Dir(dp.ProgramNames[i])}, dp.IncludeDirs...)
+				ops, err := logic.AssembleStringWithVersionAndIncludes(source, r.proto.LogicSigVersion, dirs)
 				if err != nil {
 					return err
 				}
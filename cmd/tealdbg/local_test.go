@@ -18,8 +18,11 @@ package main
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
@@ -596,6 +599,47 @@ func TestDebugFromPrograms(t *testing.T) {
 	a.Empty(l.runs[1].aidx)
 }
 
+func TestDebugFromProgramWithIncludes(t *testing.T) {
+	a := require.New(t)
+
+	dir, err := ioutil.TempDir("", "TestDebugFromProgramWithIncludes")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	lib := filepath.Join(dir, "lib")
+	a.NoError(os.Mkdir(lib, 0700))
+
+	// fee.teal is next to the program, and one.teal is in the include directory
+	a.NoError(ioutil.WriteFile(filepath.Join(dir, "fee.teal"), []byte("#define MAXFEE 2000\n"), 0600))
+	a.NoError(ioutil.WriteFile(filepath.Join(lib, "one.teal"), []byte("#define ONE int 1\n"), 0600))
+	source := `#include "fee.teal"
+#include "one.teal"
+txn Fee
+int MAXFEE
+<=
+ONE
+&&
+`
+	program := filepath.Join(dir, "program.teal")
+	a.NoError(ioutil.WriteFile(program, []byte(source), 0600))
+
+	l := LocalRunner{}
+	dp := DebugParams{
+		ProgramNames: []string{program},
+		ProgramBlobs: [][]byte{[]byte(source)},
+		TxnBlob:      []byte(txnSample),
+		RunMode:      "signature",
+	}
+	err = l.Setup(&dp)
+	a.Error(err)
+
+	dp.IncludeDirs = []string{lib}
+	err = l.Setup(&dp)
+	a.NoError(err)
+	pass, err := l.Run()
+	a.NoError(err)
+	a.True(pass)
+}
+
 func TestRunMode(t *testing.T) {
 	a := require.New(t)
 
@@ -142,6 +142,7 @@ var verbose bool
 var painless bool
 var appID uint64
 var listenForDrReq bool
+var includeDirs []string
 
 func init() {
 	rootCmd.PersistentFlags().VarP(&frontend, "frontend", "f", "Frontend to use: "+frontend.AllowedString())
@@ -165,6 +166,7 @@ func init() {
 	debugCmd.Flags().BoolVar(&painless, "painless", false, "Automatically create balance record for all accounts and applications")
 	debugCmd.Flags().StringVarP(&indexerURL, "indexer-url", "i", "", "URL for indexer to fetch Balance records from to evaluate stateful TEAL")
 	debugCmd.Flags().StringVarP(&indexerToken, "indexer-token", "", "", "API token for indexer to fetch Balance records from to evaluate stateful TEAL")
+	debugCmd.Flags().StringSliceVarP(&includeDirs, "include", "I", nil, "directory to search for #include files, after the directory of the program")
 	debugCmd.Flags().BoolVarP(&listenForDrReq, "listen-dr-req", "q", false, "Listen for upcoming debugging dryrun request objects instead of taking program(s) from command line")
 
 	rootCmd.AddCommand(debugCmd)
@@ -261,6 +263,7 @@ func debugLocal(args []string) {
 	dp := DebugParams{
 		ProgramNames:     programNames,
 		ProgramBlobs:     programBlobs,
+		IncludeDirs:      includeDirs,
 		Proto:            proto,
 		TxnBlob:          txnBlob,
 		GroupIndex:       groupIndex,
@@ -69,6 +69,7 @@ type spinoffMsg struct {
 type DebugParams struct {
 	ProgramNames     []string
 	ProgramBlobs     [][]byte
+	IncludeDirs      []string
 	Proto            string
 	TxnBlob          []byte
 	GroupIndex       int
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+// This tool assembles TEAL programs and reports unreachable code, stack
+// misuse and approving paths that do not check commonly exploited fields
+// such as RekeyTo or CloseRemainderTo.
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/algorand/go-algorand/data/transactions/logic"
+)
+
+var stateful = flag.Bool("app", false, "Lint as application programs instead of LogicSigs")
+
+// includeDirList collects the directories of the repeated -I flags.
+type includeDirList []string
+
+func (l *includeDirList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *includeDirList) Set(dir string) error {
+	*l = append(*l, dir)
+	return nil
+}
+
+var includeDirs includeDirList
+
+func init() {
+	flag.Var(&includeDirs, "I", "Directory to search for #include files, after the directory of the program (may be repeated)")
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [-app] [-I dir]... program.teal...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, fname := range flag.Args() {
+		text, err := ioutil.ReadFile(fname)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", fname, err)
+			failed = true
+			continue
+		}
+		// #include is relative to the program, then the -I directories
+		dirs := append([]string{filepath.Dir(fname)}, includeDirs...)
+		ops, err := logic.AssembleStringWithIncludes(string(text), dirs)
+		if err != nil {
+			ops.ReportProblems(fname)
+			fmt.Fprintf(os.Stderr, "%s: %v\n", fname, err)
+			failed = true
+			continue
+		}
+		for _, finding := range logic.Lint(ops, *stateful) {
+			fmt.Printf("%s:%s\n", fname, finding)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -17,16 +17,19 @@
 package compactcert
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/crypto/compactcert"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/protocol"
 )
@@ -56,7 +59,7 @@ func (ccw *Worker) builderForRound(rnd basics.Round) (builder, error) {
 		return builder{}, fmt.Errorf("voters not tracked for lookback round %d", lookback)
 	}
 
-	p, err := ledger.CompactCertParams(votersHdr, hdr)
+	p, err := ledgercore.CompactCertParams(votersHdr, hdr)
 	if err != nil {
 		return builder{}, err
 	}
@@ -315,6 +318,93 @@ func (ccw *Worker) deleteOldSigs(nextCert basics.Round) {
 	}
 }
 
+// PendingCert describes the progress of collecting the signatures for the
+// compact certificate of a round, which was not formed yet.
+type PendingCert struct {
+	// Round is the round of the block being certified.
+	Round basics.Round
+
+	// Signatures is the number of signatures collected so far, and
+	// SignaturesFromThisNode is the number of those that were produced
+	// using this node's participation keys.
+	Signatures             uint64
+	SignaturesFromThisNode uint64
+
+	// SignedWeight is the total weight of the collected signatures, which
+	// has to exceed ProvenWeight for the certificate to be built.
+	SignedWeight uint64
+	ProvenWeight uint64
+
+	// TotalWeight is the total weight of the voters for the certificate.
+	TotalWeight uint64
+
+	// Signers are the accounts whose signatures were collected, ordered
+	// by address.
+	Signers []PendingCertSigner
+}
+
+// PendingCertSigner describes a signature collected for a pending
+// compact certificate.
+type PendingCertSigner struct {
+	Signer       basics.Address
+	FromThisNode bool
+
+	// Weight is the weight the signature adds to the certificate, or
+	// zero if the node isn't building this certificate.
+	Weight uint64
+}
+
+// PendingCerts returns the progress of collecting signatures for the
+// compact certificates that were not formed yet, ordered by round.
+func (ccw *Worker) PendingCerts() ([]PendingCert, error) {
+	ccw.mu.Lock()
+	defer ccw.mu.Unlock()
+
+	var roundSigs map[basics.Round][]pendingSig
+	err := ccw.db.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		roundSigs, err = getPendingSigs(tx)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]PendingCert, 0, len(roundSigs))
+	for rnd, sigs := range roundSigs {
+		pc := PendingCert{
+			Round:      rnd,
+			Signatures: uint64(len(sigs)),
+		}
+		b, hasBuilder := ccw.builders[rnd]
+		for _, sig := range sigs {
+			if sig.fromThisNode {
+				pc.SignaturesFromThisNode++
+			}
+			signer := PendingCertSigner{
+				Signer:       sig.signer,
+				FromThisNode: sig.fromThisNode,
+			}
+			if hasBuilder {
+				if pos, ok := b.voters.AddrToPos[sig.signer]; ok && b.Present(pos) {
+					signer.Weight = b.voters.Participants[pos].Weight
+				}
+			}
+			pc.Signers = append(pc.Signers, signer)
+		}
+		sort.Slice(pc.Signers, func(i, j int) bool {
+			return bytes.Compare(pc.Signers[i].Signer[:], pc.Signers[j].Signer[:]) < 0
+		})
+		if hasBuilder {
+			pc.SignedWeight = b.SignedWeight()
+			pc.ProvenWeight = b.ProvenWeight
+			pc.TotalWeight = b.voters.TotalWeight.Raw
+		}
+		res = append(res, pc)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Round < res[j].Round })
+	return res, nil
+}
+
 func (ccw *Worker) tryBuilding() {
 	ccw.mu.Lock()
 	defer ccw.mu.Unlock()
@@ -455,3 +455,71 @@ func TestWorkerHandleSig(t *testing.T) {
 		require.Equal(t, res.Action, network.Ignore)
 	}
 }
+
+func TestWorkerPendingCerts(t *testing.T) {
+	var keys []account.Participation
+	for i := 0; i < 2; i++ {
+		var parent basics.Address
+		crypto.RandBytes(parent[:])
+		keys = append(keys, newPartKey(t, parent))
+	}
+
+	s := newWorkerStubs(t, keys, 10)
+	w := newTestWorker(t, s)
+	w.Start()
+	defer w.Shutdown()
+
+	proto := config.Consensus[protocol.ConsensusFuture]
+	s.advanceLatest(3 * proto.CompactCertRounds)
+
+	for i := 0; i < len(keys); i++ {
+		_ = <-s.sigmsg
+	}
+
+	// the rounds are signed and added to the builders in the background, so poll
+	// each of them until all of its signatures are in, up to a deadline.
+	deadline := time.Now().Add(10 * time.Second)
+	for _, rnd := range []basics.Round{2 * basics.Round(proto.CompactCertRounds), 3 * basics.Round(proto.CompactCertRounds)} {
+		for {
+			pending, err := w.PendingCerts()
+			require.NoError(t, err)
+			var pc *PendingCert
+			for i := range pending {
+				if pending[i].Round == rnd {
+					pc = &pending[i]
+				}
+			}
+			if pc != nil && pc.Signatures == uint64(len(keys)) && pc.SignedWeight > 0 {
+				break
+			}
+			require.True(t, time.Now().Before(deadline), "round %d is pending with %+v", rnd, pc)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	pending, err := w.PendingCerts()
+	require.NoError(t, err)
+	require.NotEmpty(t, pending)
+	for i, pc := range pending {
+		if i > 0 {
+			require.True(t, pending[i-1].Round < pc.Round)
+		}
+		require.Equal(t, basics.Round(0), pc.Round%basics.Round(proto.CompactCertRounds))
+		require.Equal(t, uint64(len(keys)), pc.Signatures)
+		require.Equal(t, uint64(len(keys)), pc.SignaturesFromThisNode)
+		require.Equal(t, uint64(s.totalWeight), pc.TotalWeight)
+		// not enough signatures to form the cert
+		require.True(t, pc.SignedWeight > 0)
+		require.True(t, pc.SignedWeight <= pc.ProvenWeight)
+
+		// the signed weight is made of the weights of the signers.
+		require.Len(t, pc.Signers, len(keys))
+		var signersWeight uint64
+		for _, signer := range pc.Signers {
+			require.True(t, signer.FromThisNode)
+			require.True(t, signer.Weight > 0)
+			signersWeight += signer.Weight
+		}
+		require.Equal(t, pc.SignedWeight, signersWeight)
+	}
+}
@@ -19,9 +19,11 @@ package mocks
 import (
 	"context"
 
+	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
 )
 
 // MockCatchpointCatchupAccessor is a dummy CatchpointCatchupAccessor implementation which doesn't do anything.
@@ -57,6 +59,11 @@ func (m *MockCatchpointCatchupAccessor) ProgressStagingBalances(ctx context.Cont
 	return nil
 }
 
+// StagingBalances calls the given function with each of the staging balances, ordered by address
+func (m *MockCatchpointCatchupAccessor) StagingBalances(ctx context.Context, fn func(addr basics.Address, data basics.AccountData) error) (err error) {
+	return nil
+}
+
 // BuildMerkleTrie inserts the account hashes into the merkle trie
 func (m *MockCatchpointCatchupAccessor) BuildMerkleTrie(ctx context.Context, progressUpdates func(uint64)) (err error) {
 	return nil
@@ -72,6 +79,11 @@ func (m *MockCatchpointCatchupAccessor) VerifyCatchpoint(ctx context.Context, bl
 	return nil
 }
 
+// GetVerifyData returns the balances hash and the account totals used to verify the catchpoint
+func (m *MockCatchpointCatchupAccessor) GetVerifyData(ctx context.Context) (balancesHash crypto.Digest, totals ledgercore.AccountTotals, err error) {
+	return crypto.Digest{}, ledgercore.AccountTotals{}, nil
+}
+
 // StoreBalancesRound calculates the balances round based on the first block and the associated consensus parametets, and
 // store that to the database
 func (m *MockCatchpointCatchupAccessor) StoreBalancesRound(ctx context.Context, blk *bookkeeping.Block) (err error) {
@@ -63,7 +63,7 @@ type Local struct {
 	// Version tracks the current version of the defaults so we can migrate old -> new
 	// This is specifically important whenever we decide to change the default value
 	// for an existing parameter. This field tag must be updated any time we add a new version.
-	Version uint32 `version[0]:"0" version[1]:"1" version[2]:"2" version[3]:"3" version[4]:"4" version[5]:"5" version[6]:"6" version[7]:"7" version[8]:"8" version[9]:"9" version[10]:"10" version[11]:"11" version[12]:"12" version[13]:"13" version[14]:"14" version[15]:"15"`
+	Version uint32 `version[0]:"0" version[1]:"1" version[2]:"2" version[3]:"3" version[4]:"4" version[5]:"5" version[6]:"6" version[7]:"7" version[8]:"8" version[9]:"9" version[10]:"10" version[11]:"11" version[12]:"12" version[13]:"13" version[14]:"14" version[15]:"15" version[16]:"16"`
 
 	// environmental (may be overridden)
 	// When enabled, stores blocks indefinitally, otherwise, only the most recents blocks
@@ -115,7 +115,8 @@ type Local struct {
 	// additional information to allow them to prioritize our connection.
 	AnnounceParticipationKey bool `version[4]:"true"`
 
-	// PriorityPeers specifies peer IP addresses that should always get
+	// PriorityPeers specifies peer IP addresses, or node identities of
+	// peers which authenticated with them, that should always get
 	// outgoing broadcast messages from this node.
 	PriorityPeers map[string]bool `version[4]:""`
 
@@ -372,6 +373,108 @@ type Local struct {
 	// On networks that doesn't have archive servers, this becomes a no-op, as the catchup service would have no
 	// archive server to pick from, and therefore automatically selects one of the relay nodes.
 	EnableCatchupFromArchiveServers bool `version[15]:"false"`
+
+	// CatchupStaticSources is a semicolon-separated list of static catchup sources the catchup services would fetch blocks
+	// and catchpoint files from, in addition to the network peers. Each source is either the base URL of an HTTP(S) server
+	// ( i.e. http://host/path ) or an S3-compatible bucket ( i.e. s3://bucket/prefix ) hosting the layout written by "catchupsrv export".
+	CatchupStaticSources string `version[16]:""`
+
+	// EnableAutomaticFastCatchup controls whether the node would start a catchpoint catchup on its own when it starts up far behind the network.
+	// The catchpoint label is discovered by querying the relays for the catchpoint files they serve, and is used only if at least
+	// CatchpointDiscoveryQuorum relays agree on it.
+	EnableAutomaticFastCatchup bool `version[16]:"false"`
+
+	// AutomaticFastCatchupMinRounds is the minimal number of rounds the node needs to be behind the discovered catchpoint
+	// for the automatic fast catchup to start a catchpoint catchup.
+	AutomaticFastCatchupMinRounds uint64 `version[16]:"100000"`
+
+	// CatchpointDiscoveryQuorum is the number of relays which need to advertise the same catchpoint label before it would be used
+	// by the automatic fast catchup.
+	CatchpointDiscoveryQuorum uint64 `version[16]:"3"`
+
+	// CatchpointGenerationSnapshot makes the catchpoint file generation read from a snapshot copy of the accounts database, taken
+	// once the catchpoint round is committed. The snapshot is copied and the catchpoint file is written in the background, allowing
+	// the ledger to keep committing blocks meanwhile, at the cost of the disk space needed for the snapshot. A catchpoint round
+	// committed while the catchpoint file of the previous one is still being generated gets no catchpoint file.
+	CatchpointGenerationSnapshot bool `version[16]:"false"`
+
+	// CatchpointGenerationMaxBytesPerSecond limits the rate at which a catchpoint file generated off a database snapshot is written
+	// to disk. A value of zero disables the limit.
+	CatchpointGenerationMaxBytesPerSecond uint64 `version[16]:"0"`
+
+	// EnableTxAnnounceGossip asks the peers to announce the digests of the transaction groups they relay instead of sending
+	// them in full, so that only the transaction groups this node doesn't have yet are requested. It also makes this node
+	// announce the transaction groups it relays to the peers asking for it. Peers running an older network protocol version
+	// keep flooding the transaction groups in full.
+	EnableTxAnnounceGossip bool `version[16]:"false"`
+
+	// EnableGossipCompression offers the peers to compress the proposal payloads and the transaction groups sent over the
+	// gossip network. Compression is used only on the connections where both peers support a common codec.
+	EnableGossipCompression bool `version[16]:"false"`
+
+	// EnablePeerBanning bans the peers whose reputation score, which grows with every offense such as sending invalid messages
+	// or serving invalid blocks and decays over time, reaches PeerBanThreshold. The bans persist across restarts.
+	EnablePeerBanning bool `version[16]:"false"`
+
+	// PeerBanThreshold is the reputation score at which a peer gets banned, if EnablePeerBanning is set.
+	PeerBanThreshold uint64 `version[16]:"100"`
+
+	// PeerBanDurationSeconds is the duration of the first ban of a peer. Every repeated ban of the same peer doubles
+	// the duration, up to PeerMaxBanDurationSeconds.
+	PeerBanDurationSeconds uint64 `version[16]:"3600"`
+
+	// PeerMaxBanDurationSeconds is the maximal duration of a peer ban.
+	PeerMaxBanDurationSeconds uint64 `version[16]:"86400"`
+
+	// EnablePeerExchange enables the peer exchange protocol, which lets nodes discover relays without relying on DNS.
+	// Relays with a PublicAddress announce themselves, and share the announcements of the relays they know of with their
+	// peers once these relays proved, when they were connected to, that they hold the keys they announced themselves
+	// with. The relays announced with one of the PeerExchangeTrustedKeys are connected to like the relays from the DNS
+	// bootstrap once they proved it, and the others only if PeerExchangeDialUntrusted is set.
+	EnablePeerExchange bool `version[16]:"false"`
+
+	// PeerExchangeTrustedKeys is a semicolon-separated list of base64-encoded relay announcement public keys. The relays
+	// announced with these keys are trusted as much as the relays from the DNS bootstrap.
+	PeerExchangeTrustedKeys string `version[16]:""`
+
+	// EnableNodeIdentity makes the node prove its node identity, an ed25519 key kept in the genesis directory, to the
+	// peers it connects with, and verify the identities of the peers which have one too. The identities are bound to the
+	// connection only over TLS: over plain connections, a node in the middle relaying the handshake isn't detected.
+	EnableNodeIdentity bool `version[16]:"false"`
+
+	// AllowedPeerIdentities is a semicolon-separated list of base64-encoded node identities. When set, only the peers
+	// proving one of these identities are connected with, in either direction. It requires EnableNodeIdentity.
+	AllowedPeerIdentities string `version[16]:""`
+
+	// PeerIncomingBytesPerSecond limits the rate of the gossip messages received from each peer. The messages beyond
+	// the limit are dropped, except for the agreement votes and proposals, which are only counted against it.
+	// Zero disables the limit.
+	PeerIncomingBytesPerSecond uint64 `version[16]:"0"`
+
+	// PeerOutgoingBytesPerSecond limits the rate of the gossip messages sent to each peer. The messages beyond the limit
+	// are dropped, except for the agreement votes and proposals, which are only counted against it. Zero disables the limit.
+	PeerOutgoingBytesPerSecond uint64 `version[16]:"0"`
+
+	// PeerTagBytesPerSecond is a semicolon-separated list of tag:rate pairs, such as "TX:100000;TA:10000", limiting the
+	// rate of the gossip messages of each listed tag, in bytes per second, in either direction of every peer connection.
+	PeerTagBytesPerSecond string `version[16]:""`
+
+	// GossipTransports is a semicolon-separated list of the transports the gossip connections can be made over, in order
+	// of preference. Outgoing connections are made over websockets until the peer has reported the transports it enabled
+	// in a handshake, and over the preferred one both peers enabled after that, falling back to the next ones when the
+	// peer can't be reached over it. Incoming connections are accepted over any of them which the connecting peer enabled
+	// too. The only transport currently supported is "websocket".
+	GossipTransports string `version[16]:"websocket"`
+
+	// MessageTraceSampleRate enables the tracing of one in every MessageTraceSampleRate proposals, votes and transactions
+	// across the relays, storing the signed trace records which reach the node in its genesis directory. The messages are
+	// sampled by their digest, so that all the nodes trace the same ones. It requires EnableNodeIdentity, and 0 disables it.
+	MessageTraceSampleRate uint64 `version[16]:"0"`
+
+	// PeerExchangeDialUntrusted lets the node connect to the relays learned through the peer exchange which weren't
+	// announced with one of the PeerExchangeTrustedKeys, when the relays from the DNS bootstrap and the phonebook aren't
+	// enough to reach the GossipFanout.
+	PeerExchangeDialUntrusted bool `version[16]:"false"`
 }
 
 // Filenames of config files within the configdir (e.g. ~/.algorand)
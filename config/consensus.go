@@ -348,6 +348,11 @@ type ConsensusParams struct {
 
 	// update the initial rewards rate calculation to take the reward pool minimum balance into account
 	InitialRewardsRateCalculation bool
+
+	// pool the MaxAppProgramCost budget of all application calls in a group
+	// and enforce it during evaluation instead of checking each program's
+	// static cost when it is installed
+	EnableAppCostPooling bool
 }
 
 // PaysetCommitType enumerates possible ways for the block header to commit to
@@ -876,6 +881,12 @@ func initConsensusProtocols() {
 	// Enable transaction Merkle tree.
 	vFuture.PaysetCommit = PaysetCommitMerkle
 
+	// Enable TEAL 3
+	vFuture.LogicSigVersion = 3
+
+	// Enable application opcode budget pooling across a group
+	vFuture.EnableAppCostPooling = true
+
 	Consensus[protocol.ConsensusFuture] = vFuture
 }
 
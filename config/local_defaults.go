@@ -20,14 +20,19 @@
 package config
 
 var defaultLocal = Local{
-	Version:                                 15,
+	Version:                                 16,
 	AccountsRebuildSynchronousMode:          1,
+	AllowedPeerIdentities:                   "",
 	AnnounceParticipationKey:                true,
 	Archival:                                false,
+	AutomaticFastCatchupMinRounds:           100000,
 	BaseLoggerDebugLevel:                    4,
 	BroadcastConnectionsLimit:               -1,
 	CadaverSizeTarget:                       1073741824,
+	CatchpointDiscoveryQuorum:               3,
 	CatchpointFileHistoryLength:             365,
+	CatchpointGenerationMaxBytesPerSecond:   0,
+	CatchpointGenerationSnapshot:            false,
 	CatchpointInterval:                      10000,
 	CatchpointTracking:                      0,
 	CatchupBlockDownloadRetryAttempts:       1000,
@@ -36,6 +41,7 @@ var defaultLocal = Local{
 	CatchupHTTPBlockFetchTimeoutSec:         4,
 	CatchupLedgerDownloadRetryAttempts:      50,
 	CatchupParallelBlocks:                   16,
+	CatchupStaticSources:                    "",
 	ConnectionsRateLimitingCount:            60,
 	ConnectionsRateLimitingWindowSeconds:    1,
 	DNSBootstrapID:                          "<network>.algorand.network",
@@ -45,23 +51,30 @@ var defaultLocal = Local{
 	EnableAgreementReporting:                false,
 	EnableAgreementTimeMetrics:              false,
 	EnableAssembleStats:                     false,
+	EnableAutomaticFastCatchup:              false,
 	EnableBlockService:                      false,
 	EnableCatchupFromArchiveServers:         false,
 	EnableDeveloperAPI:                      false,
 	EnableGossipBlockService:                true,
+	EnableGossipCompression:                 false,
 	EnableIncomingMessageFilter:             false,
 	EnableLedgerService:                     false,
 	EnableMetricReporting:                   false,
+	EnableNodeIdentity:                      false,
 	EnableOutgoingNetworkMessageFiltering:   true,
+	EnablePeerBanning:                       false,
+	EnablePeerExchange:                      false,
 	EnablePingHandler:                       true,
 	EnableProcessBlockStats:                 false,
 	EnableProfiler:                          false,
 	EnableRequestLogger:                     false,
 	EnableTopAccountsReporting:              false,
+	EnableTxAnnounceGossip:                  false,
 	EndpointAddress:                         "127.0.0.1:0",
 	FallbackDNSResolverAddress:              "",
 	ForceRelayMessages:                      false,
 	GossipFanout:                            4,
+	GossipTransports:                        "websocket",
 	IncomingConnectionsLimit:                10000,
 	IncomingMessageFilterBucketCount:        5,
 	IncomingMessageFilterBucketSize:         512,
@@ -72,6 +85,7 @@ var defaultLocal = Local{
 	LogSizeLimit:                            1073741824,
 	MaxCatchpointDownloadDuration:           7200000000000,
 	MaxConnectionsPerIP:                     30,
+	MessageTraceSampleRate:                  0,
 	MinCatchpointFileDownloadBytesPerSecond: 20480,
 	NetAddress:                              "",
 	NetworkMessageTraceServer:               "",
@@ -81,8 +95,16 @@ var defaultLocal = Local{
 	OptimizeAccountsDatabaseOnStartup:       false,
 	OutgoingMessageFilterBucketCount:        3,
 	OutgoingMessageFilterBucketSize:         128,
+	PeerBanDurationSeconds:                  3600,
+	PeerBanThreshold:                        100,
 	PeerConnectionsUpdateInterval:           3600,
+	PeerExchangeDialUntrusted:               false,
+	PeerExchangeTrustedKeys:                 "",
+	PeerIncomingBytesPerSecond:              0,
+	PeerMaxBanDurationSeconds:               86400,
+	PeerOutgoingBytesPerSecond:              0,
 	PeerPingPeriodSeconds:                   0,
+	PeerTagBytesPerSecond:                   "",
 	PriorityPeers:                           map[string]bool{},
 	PublicAddress:                           "",
 	ReconnectTime:                           60000000000,
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package merkletrie
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/algorand/go-algorand/crypto"
+)
+
+// ErrInvalidProof is returned when a proof does not match the element or the root hash it is verified against.
+var ErrInvalidProof = errors.New("invalid merkle trie proof")
+
+// ProofChild is a child of a non-leaf node along the path of a proof
+type ProofChild struct {
+	// Leaf is set if the child is a leaf node
+	Leaf bool
+	// Index is the byte of the element selecting the child
+	Index byte
+	// Hash is the hash of the child, or the remainder of the element for a leaf child.
+	// It is nil for the child leading toward the proven element, whose hash the verifier computes.
+	Hash []byte
+}
+
+// Proof shows that an element is included in, or excluded from, a trie with a given root hash.
+type Proof struct {
+	// Path holds the children of each non-leaf node on the way from the root toward the element.
+	// The node at depth i selects its child using byte i of the element.
+	Path [][]ProofChild
+	// Leaf is the remainder of another element, when an exclusion proof ends at a leaf node
+	// holding a different element than the one searched.
+	Leaf []byte
+}
+
+// Prove returns a proof that the given element is included in the trie, or that it is not.
+func (mt *Trie) Prove(d []byte) (proof Proof, err error) {
+	if mt.root == storedNodeIdentifierNull {
+		return Proof{}, nil
+	}
+	if len(d) != mt.elementLength {
+		return Proof{}, ErrMismatchingElementLength
+	}
+	if mt.cache.modified {
+		if _, err = mt.Commit(); err != nil {
+			return Proof{}, err
+		}
+	}
+	pnode, err := mt.cache.getNode(mt.root)
+	if err != nil {
+		return Proof{}, err
+	}
+	for depth := 0; !pnode.leaf(); depth++ {
+		children := make([]ProofChild, len(pnode.children))
+		var next *node
+		for i, child := range pnode.children {
+			childNode, err := mt.cache.getNode(child.id)
+			if err != nil {
+				return Proof{}, err
+			}
+			children[i] = ProofChild{Leaf: childNode.leaf(), Index: child.hashIndex}
+			if child.hashIndex == d[depth] {
+				next = childNode
+				continue
+			}
+			children[i].Hash = append([]byte{}, childNode.hash...)
+		}
+		proof.Path = append(proof.Path, children)
+		if next == nil {
+			// the element would have been under a child this node doesn't have.
+			return proof, nil
+		}
+		pnode = next
+	}
+	if !bytes.Equal(pnode.hash, d[len(proof.Path):]) {
+		proof.Leaf = append([]byte{}, pnode.hash...)
+	}
+	return proof, nil
+}
+
+// VerifyProof checks the proof for the element d against the root hash of a trie. It returns
+// whether the proof shows the element is included, or ErrInvalidProof if the proof is not valid.
+func VerifyProof(root crypto.Digest, d []byte, proof Proof) (included bool, err error) {
+	if len(proof.Path) == 0 && proof.Leaf == nil && root == (crypto.Digest{}) {
+		// an empty trie contains nothing.
+		return false, nil
+	}
+	if len(proof.Path) >= len(d) {
+		return false, ErrInvalidProof
+	}
+
+	// the walk ends at a leaf unless the last node is missing the child for the element.
+	endsAtLeaf := len(proof.Path) == 0
+	for i, children := range proof.Path {
+		found := false
+		for _, child := range children {
+			if child.Hash != nil {
+				continue
+			}
+			if found || child.Index != d[i] {
+				return false, ErrInvalidProof
+			}
+			found = true
+		}
+		if !found {
+			if i != len(proof.Path)-1 {
+				return false, ErrInvalidProof
+			}
+			for _, child := range children {
+				if child.Index == d[i] {
+					return false, ErrInvalidProof
+				}
+			}
+		}
+		endsAtLeaf = found
+	}
+
+	var hash []byte
+	leaf := false
+	if endsAtLeaf {
+		hash = d[len(proof.Path):]
+		included = true
+		if proof.Leaf != nil {
+			if len(proof.Leaf) != len(hash) || bytes.Equal(proof.Leaf, hash) {
+				return false, ErrInvalidProof
+			}
+			hash = proof.Leaf
+			included = false
+		}
+		leaf = true
+	} else if proof.Leaf != nil {
+		return false, ErrInvalidProof
+	}
+
+	// compute the hashes from the bottom up the same way node.calculateHash does.
+	for i := len(proof.Path) - 1; i >= 0; i-- {
+		accumulator := []byte{byte(i)}
+		accumulator = append(accumulator, d[:i]...)
+		for _, child := range proof.Path[i] {
+			childLeaf, childHash := child.Leaf, child.Hash
+			if childHash == nil {
+				if child.Leaf != leaf {
+					return false, ErrInvalidProof
+				}
+				childHash = hash
+			}
+			if childLeaf {
+				accumulator = append(accumulator, byte(0))
+			} else {
+				accumulator = append(accumulator, byte(1))
+			}
+			accumulator = append(accumulator, byte(len(childHash)), child.Index)
+			accumulator = append(accumulator, childHash...)
+		}
+		digest := crypto.Hash(accumulator)
+		hash = digest[:]
+		leaf = false
+	}
+
+	var computed crypto.Digest
+	if leaf {
+		computed = crypto.Hash(append([]byte{0}, hash...))
+	} else {
+		computed = crypto.Hash(append([]byte{1}, hash...))
+	}
+	if computed != root {
+		return false, ErrInvalidProof
+	}
+	return included, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package merkletrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+)
+
+func TestProofs(t *testing.T) {
+	mt, err := MakeTrie(nil, defaultTestMemoryConfig)
+	require.NoError(t, err)
+
+	// an empty trie excludes everything.
+	missing := crypto.Hash([]byte("missing"))
+	proof, err := mt.Prove(missing[:])
+	require.NoError(t, err)
+	included, err := VerifyProof(crypto.Digest{}, missing[:], proof)
+	require.NoError(t, err)
+	require.False(t, included)
+
+	hashes := make([]crypto.Digest, 1000)
+	for i := range hashes {
+		hashes[i] = crypto.Hash([]byte{byte(i % 256), byte(i / 256)})
+		_, err = mt.Add(hashes[i][:])
+		require.NoError(t, err)
+		if i == 0 {
+			// a trie with a single leaf as its root.
+			root, err := mt.RootHash()
+			require.NoError(t, err)
+			proof, err = mt.Prove(hashes[0][:])
+			require.NoError(t, err)
+			included, err = VerifyProof(root, hashes[0][:], proof)
+			require.NoError(t, err)
+			require.True(t, included)
+			proof, err = mt.Prove(missing[:])
+			require.NoError(t, err)
+			included, err = VerifyProof(root, missing[:], proof)
+			require.NoError(t, err)
+			require.False(t, included)
+		}
+	}
+	root, err := mt.RootHash()
+	require.NoError(t, err)
+
+	for _, h := range hashes {
+		proof, err := mt.Prove(h[:])
+		require.NoError(t, err)
+		included, err := VerifyProof(root, h[:], proof)
+		require.NoError(t, err)
+		require.True(t, included)
+
+		// the proof does not hold for another root.
+		_, err = VerifyProof(crypto.Hash(root[:]), h[:], proof)
+		require.Equal(t, ErrInvalidProof, err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		other := crypto.Hash([]byte{byte(i % 256), byte(i / 256), 1})
+		proof, err := mt.Prove(other[:])
+		require.NoError(t, err)
+		included, err := VerifyProof(root, other[:], proof)
+		require.NoError(t, err)
+		require.False(t, included)
+	}
+
+	// an inclusion proof can not be used for another element.
+	proof, err = mt.Prove(hashes[0][:])
+	require.NoError(t, err)
+	_, err = VerifyProof(root, hashes[1][:], proof)
+	require.Equal(t, ErrInvalidProof, err)
+
+	// an element can not be shown excluded by tampering with the proof.
+	last := proof.Path[len(proof.Path)-1]
+	for i := range last {
+		if last[i].Hash == nil {
+			last = append(last[:i], last[i+1:]...)
+			break
+		}
+	}
+	proof.Path[len(proof.Path)-1] = last
+	_, err = VerifyProof(root, hashes[0][:], proof)
+	require.Equal(t, ErrInvalidProof, err)
+
+	_, err = mt.Prove(hashes[0][:10])
+	require.Equal(t, ErrMismatchingElementLength, err)
+}
@@ -396,6 +396,36 @@ func (client RestClient) AccountInformationV2(address string) (response generate
 	return
 }
 
+// AccountProof gets the account data as of the last catchpoint label, with a proof of its inclusion in the accounts merkle trie committed to by the label
+func (client RestClient) AccountProof(address string) (response generatedV2.AccountProofResponse, err error) {
+	err = client.get(&response, fmt.Sprintf("/v2/accounts/%s/proof", address), nil)
+	return
+}
+
+// CompactCert gets the compact certificate covering the given round
+func (client RestClient) CompactCert(round uint64) (response generatedV2.CompactCertResponse, err error) {
+	err = client.get(&response, fmt.Sprintf("/v2/compactcert/%d", round), nil)
+	return
+}
+
+type compactCertsParams struct {
+	MinRound uint64 `url:"min-round"`
+	MaxRound uint64 `url:"max-round"`
+}
+
+// CompactCerts gets the compact certificates covering the rounds from minRound to maxRound. The response stops at the
+// first certificate that was not formed yet, and may hold only a part of the range.
+func (client RestClient) CompactCerts(minRound, maxRound uint64) (response generatedV2.CompactCertsResponse, err error) {
+	err = client.get(&response, "/v2/compactcerts", compactCertsParams{MinRound: minRound, MaxRound: maxRound})
+	return
+}
+
+// PendingCompactCerts gets the signature collection progress of the compact certificates that were not formed yet
+func (client RestClient) PendingCompactCerts() (response generatedV2.PendingCompactCertsResponse, err error) {
+	err = client.get(&response, "/v2/compactcert/pending", nil)
+	return
+}
+
 // Blob represents arbitrary blob of data satisfying v1.RawResponse interface
 type Blob []byte
 
@@ -505,6 +535,34 @@ func (client RestClient) Catchup(catchpointLabel string) (response privateV2.Cat
 	return
 }
 
+type devModeRoundsParams struct {
+	Count uint64 `url:"count"`
+}
+
+// AdvanceDevModeRounds writes count blocks to the ledger of a development mode node
+func (client RestClient) AdvanceDevModeRounds(count uint64) (response privateV2.DevModeRoundsResponse, err error) {
+	err = client.submitForm(&response, "/v2/devmode/blocks", devModeRoundsParams{Count: count}, "POST", false, true)
+	return
+}
+
+// SetBlockTimeStampOffset sets the number of seconds added to the wall clock when a development mode node timestamps its blocks
+func (client RestClient) SetBlockTimeStampOffset(offset uint64) (response privateV2.BlockTimeStampOffsetResponse, err error) {
+	err = client.submitForm(&response, fmt.Sprintf("/v2/devmode/blocks/offset/%d", offset), nil, "POST", false, true)
+	return
+}
+
+// GetBlockTimeStampOffset gets the number of seconds added to the wall clock when a development mode node timestamps its blocks
+func (client RestClient) GetBlockTimeStampOffset() (response privateV2.BlockTimeStampOffsetResponse, err error) {
+	err = client.get(&response, "/v2/devmode/blocks/offset", nil)
+	return
+}
+
+// NetworkPeers gets the peers the node is connected to
+func (client RestClient) NetworkPeers() (response privateV2.NetworkPeersResponse, err error) {
+	err = client.get(&response, "/v2/network/peers", nil)
+	return
+}
+
 // GetGoRoutines gets a dump of the goroutines from pprof
 // Not supported
 func (client RestClient) GetGoRoutines(ctx context.Context) (goRoutines string, err error) {
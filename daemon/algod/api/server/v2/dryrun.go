@@ -56,6 +56,9 @@ type DryrunRequest struct {
 	LatestTimestamp int64 `codec:"latest-timestamp"`
 
 	Sources []generated.DryrunSource `codec:"sources"`
+
+	// sourceLines maps programs assembled from Sources to their pc to source line mapping
+	sourceLines map[string]map[int]int
 }
 
 // DryrunRequestFromGenerated converts generated.DryrunRequest to DryrunRequest field by fields
@@ -89,6 +92,10 @@ func (dr *DryrunRequest) ExpandSources() error {
 		if err != nil {
 			return fmt.Errorf("Dryrun Source[%d]: %v", i, err)
 		}
+		if dr.sourceLines == nil {
+			dr.sourceLines = make(map[string]map[int]int)
+		}
+		dr.sourceLines[string(ops.Program)] = ops.OffsetToLine
 		switch s.FieldName {
 		case "lsig":
 			dr.Txns[s.TxnIndex].Lsig.Logic = ops.Program
@@ -115,6 +122,9 @@ type dryrunDebugReceiver struct {
 	lines         []string
 	history       []generated.DryrunState
 	scratchActive []bool
+
+	// pc to source line mapping, if the program was assembled from source
+	sourceLines map[int]int
 }
 
 func (ddr *dryrunDebugReceiver) updateScratch() {
@@ -169,6 +179,10 @@ func (ddr *dryrunDebugReceiver) stateToState(state *logic.DebugState) generated.
 		Line: uint64(state.Line),
 		Pc:   uint64(state.PC),
 	}
+	if line, ok := ddr.sourceLines[state.PC]; ok {
+		sourceLine := uint64(line)
+		st.SourceLine = &sourceLine
+	}
 	st.Stack = make([]generated.TealValue, len(state.Stack))
 	for i, v := range state.Stack {
 		st.Stack[i] = generated.TealValue{
@@ -408,7 +422,7 @@ func doDryrunRequest(dr *DryrunRequest, response *generated.DryrunResponse) {
 		}
 		var result generated.DryrunTxnResult
 		if len(stxn.Lsig.Logic) > 0 {
-			var debug dryrunDebugReceiver
+			debug := dryrunDebugReceiver{sourceLines: dr.sourceLines[string(stxn.Lsig.Logic)]}
 			ep.Debugger = &debug
 			pass, err := logic.Eval(stxn.Lsig.Logic, ep)
 			var messages []string
@@ -501,6 +515,7 @@ func doDryrunRequest(dr *DryrunRequest, response *generated.DryrunResponse) {
 					program = app.ApprovalProgram
 					messages[0] = "ApprovalProgram"
 				}
+				debug.sourceLines = dr.sourceLines[string(program)]
 				pass, delta, err := ba.StatefulEval(ep, appIdx, program)
 				result.Disassembly = debug.lines
 				result.AppCallTrace = &debug.history
@@ -176,6 +176,40 @@ func TestDryrunLogicSigSource(t *testing.T) {
 	}
 }
 
+func TestDryrunSourceLines(t *testing.T) {
+	t.Parallel()
+
+	var dr DryrunRequest
+	var response generated.DryrunResponse
+
+	dr.ProtocolVersion = string(dryrunProtoVersion)
+
+	dr.Txns = []transactions.SignedTxn{{}, {Lsig: transactions.LogicSig{Logic: unB64("AiABASI=")}}}
+	dr.Sources = []generated.DryrunSource{
+		{
+			Source:    "// approve everything\nint 1",
+			FieldName: "lsig",
+			TxnIndex:  0,
+		},
+	}
+	doDryrunRequest(&dr, &response)
+	require.Empty(t, response.Error)
+	require.Len(t, response.Txns, 2)
+
+	// the trace of a program from source refers to its source lines
+	// (the first step is the intcblock added by the assembler)
+	trace := *response.Txns[0].LogicSigTrace
+	require.Greater(t, len(trace), 1)
+	require.Nil(t, trace[0].SourceLine)
+	require.NotNil(t, trace[1].SourceLine)
+	require.Equal(t, uint64(1), *trace[1].SourceLine)
+
+	// but not if the program was supplied already assembled
+	trace = *response.Txns[1].LogicSigTrace
+	require.Greater(t, len(trace), 1)
+	require.Nil(t, trace[1].SourceLine)
+}
+
 const globalTestSource = `#pragma version 2
 // This program approves all transactions whose first arg is "hello"
 // Then, accounts can write "foo": "bar" to the GlobalState by
@@ -38,4 +38,13 @@ var (
 	errFailedToAbortCatchup                    = "failed to abort catchup : %v"
 	errFailedToStartCatchup                    = "failed to start catchup : %v"
 	errOperationNotAvailableDuringCatchup      = "operation not available during catchup"
+	errFailedToAdvanceDevModeRounds            = "failed to advance development mode rounds : %v"
+	errFailedRetrievingCompactCerts            = "failed retrieving pending compact certs"
+	errCompactCertNotAvailable                 = "the compact cert for round %d is not available on this node"
+	errFailedRetrievingPeerBans                = "failed retrieving peer bans"
+	errFailedRetrievingNetworkPeers            = "failed retrieving network peers"
+	errFailedToBanPeer                         = "failed to update the peer bans : %v"
+	errMissingPeerHost                         = "no peer host was specified"
+	errInvalidPeerBanDuration                  = "the ban duration must be positive"
+	errPeerNotBanned                           = "the peer is not banned"
 )
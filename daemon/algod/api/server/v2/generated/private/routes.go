@@ -23,6 +23,27 @@ type ServerInterface interface {
 	// Starts a catchpoint catchup.
 	// (POST /v2/catchup/{catchpoint})
 	StartCatchup(ctx echo.Context, catchpoint string) error
+	// Advances the rounds of a development mode node.
+	// (POST /v2/devmode/blocks)
+	AdvanceDevModeRounds(ctx echo.Context, params AdvanceDevModeRoundsParams) error
+	// Returns the block timestamp offset of a development mode node.
+	// (GET /v2/devmode/blocks/offset)
+	GetBlockTimeStampOffset(ctx echo.Context) error
+	// Sets the block timestamp offset of a development mode node.
+	// (POST /v2/devmode/blocks/offset/{offset})
+	SetBlockTimeStampOffset(ctx echo.Context, offset uint64) error
+	// Returns the banned peers.
+	// (GET /v2/network/bans)
+	GetPeerBans(ctx echo.Context) error
+	// Unbans a peer.
+	// (DELETE /v2/network/bans/{host})
+	UnbanPeer(ctx echo.Context, host string) error
+	// Bans a peer.
+	// (POST /v2/network/bans/{host})
+	BanPeer(ctx echo.Context, host string, params BanPeerParams) error
+	// Returns the connected peers.
+	// (GET /v2/network/peers)
+	GetNetworkPeers(ctx echo.Context) error
 
 	// (POST /v2/register-participation-keys/{address})
 	RegisterParticipationKeys(ctx echo.Context, address string, params RegisterParticipationKeysParams) error
@@ -96,6 +117,214 @@ func (w *ServerInterfaceWrapper) StartCatchup(ctx echo.Context) error {
 	return err
 }
 
+// AdvanceDevModeRounds converts echo context to params.
+func (w *ServerInterfaceWrapper) AdvanceDevModeRounds(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+		"count":  true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdvanceDevModeRoundsParams
+	// ------------- Optional query parameter "count" -------------
+	if paramValue := ctx.QueryParam("count"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "count", ctx.QueryParams(), &params.Count)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter count: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AdvanceDevModeRounds(ctx, params)
+	return err
+}
+
+// GetBlockTimeStampOffset converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBlockTimeStampOffset(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetBlockTimeStampOffset(ctx)
+	return err
+}
+
+// SetBlockTimeStampOffset converts echo context to params.
+func (w *ServerInterfaceWrapper) SetBlockTimeStampOffset(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "offset" -------------
+	var offset uint64
+
+	err = runtime.BindStyledParameter("simple", false, "offset", ctx.Param("offset"), &offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SetBlockTimeStampOffset(ctx, offset)
+	return err
+}
+
+// GetPeerBans converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPeerBans(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPeerBans(ctx)
+	return err
+}
+
+// UnbanPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) UnbanPeer(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "host" -------------
+	var host string
+
+	err = runtime.BindStyledParameter("simple", false, "host", ctx.Param("host"), &host)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter host: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.UnbanPeer(ctx, host)
+	return err
+}
+
+// BanPeer converts echo context to params.
+func (w *ServerInterfaceWrapper) BanPeer(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":   true,
+		"duration": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "host" -------------
+	var host string
+
+	err = runtime.BindStyledParameter("simple", false, "host", ctx.Param("host"), &host)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter host: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BanPeerParams
+	// ------------- Optional query parameter "duration" -------------
+	if paramValue := ctx.QueryParam("duration"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "duration", ctx.QueryParams(), &params.Duration)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter duration: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.BanPeer(ctx, host, params)
+	return err
+}
+
+// GetNetworkPeers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNetworkPeers(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetNetworkPeers(ctx)
+	return err
+}
+
 // RegisterParticipationKeys converts echo context to params.
 func (w *ServerInterfaceWrapper) RegisterParticipationKeys(ctx echo.Context) error {
 
@@ -227,6 +456,13 @@ func RegisterHandlers(router interface {
 
 	router.DELETE("/v2/catchup/:catchpoint", wrapper.AbortCatchup, m...)
 	router.POST("/v2/catchup/:catchpoint", wrapper.StartCatchup, m...)
+	router.POST("/v2/devmode/blocks", wrapper.AdvanceDevModeRounds, m...)
+	router.GET("/v2/devmode/blocks/offset", wrapper.GetBlockTimeStampOffset, m...)
+	router.POST("/v2/devmode/blocks/offset/:offset", wrapper.SetBlockTimeStampOffset, m...)
+	router.GET("/v2/network/bans", wrapper.GetPeerBans, m...)
+	router.DELETE("/v2/network/bans/:host", wrapper.UnbanPeer, m...)
+	router.POST("/v2/network/bans/:host", wrapper.BanPeer, m...)
+	router.GET("/v2/network/peers", wrapper.GetNetworkPeers, m...)
 	router.POST("/v2/register-participation-keys/:address", wrapper.RegisterParticipationKeys, m...)
 	router.POST("/v2/shutdown", wrapper.ShutdownNode, m...)
 
@@ -235,134 +471,177 @@ func RegisterHandlers(router interface {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9/XPcNpLov4I3u1X+eMMZ+Su7VlVqn2I7iV4cx2Upubu1fAmG7JlBRAIMAUqa+PS/",
-	"X3UDIEESnBnJWt+ldn+yNQQbjUZ/obvR/DhJVVEqCdLoyeHHSckrXoCBiv7iaapqaRKR4V8Z6LQSpRFK",
-	"Tg79M6ZNJeRqMp0I/LXkZj2ZTiQvoB2D708nFfxWiwqyyaGpaphOdLqGgiNgsylxdAPpKlmpxIE4siCO",
-	"X06utzzgWVaB1kMsf5D5hgmZ5nUGzFRcap7iI80uhVkzsxaauZeZkExJYGrJzLozmC0F5Jme+UX+VkO1",
-	"CVbpJh9f0nWLYlKpHIZ4vlDFQkjwWEGDVLMhzCiWwZIGrblhOAPi6gcaxTTwKl2zpap2oGqRCPEFWReT",
-	"w/cTDTKDinYrBXFB/11WAL9DYni1AjP5MI0tbmmgSowoIks7dtSvQNe50YzG0hpX4gIkw7dm7PtaG7YA",
-	"xiV79/UL9uTJk+e4kIIbA5ljstFVtbOHa7KvTw4nGTfgHw95jecrVXGZJc34d1+/oPlP3AL3HcW1hriw",
-	"HOETdvxybAH+xQgLCWlgRfvQ4X58IyIU7c8LWKoK9twTO/hONyWc/390V1Ju0nWphDSRfWH0lNnHUR0W",
-	"vL5NhzUIdMaXSKkKgb4/SJ5/+Pho+ujg+k/vj5K/uz+fPbnec/kvGrg7KBAdmNZVBTLdJKsKOEnLmssh",
-	"Pd45ftBrVecZW/ML2nxekKp37zJ816rOC57XyCcirdRRvlKaccdGGSx5nRvmJ2a1zFFNITTH7UxoVlbq",
-	"QmSQTVH7Xq5FumYp1xYEjWOXIs+RB2sN2RivxVe3RZiuQ5IgXreiBy3ofy8x2nXtoARckTZI0lxpSIza",
-	"YZ68xeEyY6FBaW2VvpmxYqdrYDQ5PrDGlmgnkafzfMMM7WvGuGacedM0ZWLJNqpml7Q5uTin991qkGoF",
-	"Q6LR5nTsKArvGPkGxIgQb6FUDlwS8bzcDUkml2JVV6DZ5RrM2tm8CnSppAamFr9CanDb///JD2+Yqtj3",
-	"oDVfwVuenjOQqcrG99hNGrPgv2qFG17oVcnT87i5zkUhIih/z69EURdM1sUCKtwvbx+MYhWYupJjCFmI",
-	"O/is4FfDSU+rWqa0ue20HUcNWUnoMuebGTtesoJffXkwdehoxvOclSAzIVfMXMlRJw3n3o1eUqlaZnv4",
-	"MAY3LLCauoRULAVkrIGyBRM3zS58hLwZPq1nFaDjgYyi08yyAx0JVxGeQdHFJ6zkKwhYZsZ+dJqLnhp1",
-	"DrJRcGyxoUdlBRdC1bp5aQRHmnq7ey2VgaSsYCkiPHbiyIHaw45x6rVwDk6qpOFCQoaal5BWBqwmGsUp",
-	"mHD7YWZoohdcwxdPxwx4+3TP3V+q/q5v3fG9dpsGJVYkI3YRnzqBjbtNnff3OPyFc2uxSuzPg40Uq1M0",
-	"JUuRk5n5FffPk6HWpAQ6hPCGR4uV5Kau4PBMPsS/WMJODJcZrzL8pbA/fV/nRpyIFf6U259eq5VIT8Rq",
-	"hJgNrtHTFL1W2H8QXlwdm6vooeG1Uud1GS4o7ZxKFxt2/HJsky3MmzLmUXOUDU8Vp1f+pHHTN8xVs5Ej",
-	"SI7SruQ48Bw2FSC2PF3SP1dL4ie+rH7Hf8oyj9EUGdgZWgoKuGDBO/cb/oQiD/ZMgFBEypGoczKfhx8D",
-	"hP5cwXJyOPnTvI2UzO1TPXdwccbr6eSohXP3M7Vv2vX1DjLtYyak3R0aOrVnwrvHB6FGMSFHtYfDV7lK",
-	"z2+FQ1mpEioj7D4uEM5QUgg8WwPPoGIZN3zWHqqsnzXC7/Tit/QenZKgipi4H+g/PGf4GKWQG+++oesq",
-	"NDpxKgg0ZejxWTtiZ8IB5IkqVlgnj6FzdiMsX7STWwXdaNT3jiwf+tAiu/PK+pWM3vCLwKW3p8ajhapu",
-	"xy89RpCsPQszjlAb7xdX3t1ZGlqXiaNPxJ+2A3qA2vDjUK2GFOqDj9GqQ4UTw/8BVNAI9S6o0AV011RQ",
-	"RSlyuAN5XXO9Hi4CHZwnj9nJt0fPHj3++fGzL9BCl5VaVbxgi40Bze47u8K02eTwYLgyUvB1buLQv3jq",
-	"T1BduDspRAg3sPeRqFNAzWApxmy8ALF7WW2qWt4BCaGqVBXxeYl1jEpVnlxApYWKhC/euhHMjUA9ZP3u",
-	"3u8WW3bJNcO56ThWywyqWYzyeM4ik26g0LsMhQV9eiVb2jiAvKr4ZrADdr2R1bl599mTLvG9d69ZCVVi",
-	"riTLYFGvQhvFlpUqGGcZvUgK8Y3K4MRwU+s70AItsBYZ3IgQBb5QtWGcSZWhQOPguH4YiWVSEIViPyZU",
-	"OWZt7c8C0DtOeb1aG4ZupYptbftiwlO7KQnZCj1y9GvO7HaUnc7GyfIKeLZhCwDJ1MKdr9zJjxbJKSxj",
-	"fMbFaacWreZM0MGrrFQKWkOWuPTSTtT8OLvLZgudCHFCuJmFacWWvLolskYZnu9AlMbE0G3cCXcoHWK9",
-	"3/TbNrA/ebiNvMIzpuUC9F1QunMwMEbCPWlyARUdzv6h++cnue321eVI6sRZ4FNRoPgyyaXSkCqZ6Siw",
-	"nGuT7BJbHNRxE3AFgaTEJJUAjwQIXnNt7BFdyIxcRqtuaB56h6YYR3jUoiDkn7wxGcJOUU9KXevGsui6",
-	"LFVlIIutQcLVlrnewFUzl1oGsBvzZRSrNeyCPEalAL4jll2JJRA3LkbUxLCGi6NwPNqBTZSUHSRaQmxD",
-	"5MSPCqgbho9HEMHzRfMmMY7QPc5pYtbTiTaqLFH+TFLL5r0xMp3Y0Ufmx3bskLm4afV6pgBnNx4nh/ml",
-	"paxNHKw5+nYEmRX8HG0TeWo2ljDEGYUx0UKmkGzjfBTLExwVisAOIR1xkl1qMpitJxw9/o0y3SgT7NiF",
-	"sQWPeOxvbQT8tI0O3YHT8hIMF7luHJMmzN7OQhH5frUEepEVpCBNvkFeXYqqsEktMmfa/2bdnszNYtM3",
-	"rfjJjFVwyavMjxieloLFJEJmcBXXrrwTG8ngiok40stmZmFY6lNOMgQwiwq6TeKludJCrhKbHdxl1Jqk",
-	"3j3NaimcAbuEyuG1hMqZXeOzY4lRPoO2DY9tpHDBmdsQAV+NT2uRs7ulY0lUeoCCWIi0UtzmRpGovQWy",
-	"CgqO2FGWzpn98Tm3EfuFfe5TtT5EHvJuHK7n11EN07Do5Zo2C1Vtn4gh1+PRFjSMLWSVqwXPE3T4Ickg",
-	"NztDb3iQgJc0Eu21Soevd1E+O3ufZ2dnH9hrHEtnC2DnsJlTxpqlay5X0KYRQnmxpwa4grQOTUuPjHsd",
-	"BF2stIt99yg4nZRK5Ulz5O2nPQbmpk/3c5GeQ8ZQX5GIOSt4r7tDOAm7jyyum8TQ5XrjXciyBAnZgxlj",
-	"R5JBUZqNi6/0PJ7e5PKe2Tb/Fc2a1ZSj5pLRImdnMh7asBnuT5QpD2a7JNmSr0+cygLZPpG5kiPixC8p",
-	"QYPgovK5NTp6Qm8Gpm9g0QOmsljsE0P4huqgeGeXRUbHkda66XpRCCqGCoZNUXP6/PTwhC/MjLFT0h14",
-	"wNJwARXPqdJD+8Cx0KwQeFDXdZoCZIdnMulgkqrCTXy//a9VS2f1wcETYAcP+u9og+6qO0taGei/+yU7",
-	"mNpHRC72JTubnE0GkCoo1AVk9jwW8rV9ayfY/9PAPZM/DBQzK/jGnuS8LDJdL5ciFZbouUK9vlI9r1Mq",
-	"egIVogdoZjUTZkqmjChK3rrdl1YAJ1Hv6S5iPhGo6KejKUVt57OSXd7RDK54iqvkpGQ21iNo+GzoBBlV",
-	"JiGAaAh6y4wuCaA7evyWcjfU5zYAsR2/014IokOOgF1nu333ATGiGOwj/kesVLjrwtUf+SKVXGgzQNKF",
-	"IygD1DBkxOjM2H+omqWc5LesDTRnO1XRgYkO0jgD2Vg/p/PUWgpBDgXYCBE9efiwv/CHD92eC82WcOmL",
-	"9nBgnxwPH1ohUNp8sgT0WPPqOOJAUWAerWmk0HrN9Xq2M0hPcPeKzQegj1/6CUmYtCYTgwuvlFrewWpF",
-	"dhX1WeAqtlK3cxRuu6dZyTej7nWJCEaqtaA6zymWr5Y9jmRO/61FiSDbypKNgU5V6n/e/9vh+6Pk7zz5",
-	"/SB5/n/nHz4+vX7wcPDj4+svv/yv7k9Prr988Lc/x5wXbcQinvf5lus1Yuo0x5U8ljZzi54nBew2Lg6g",
-	"lp8b7x6L4WZ6ygdL2ofp3sY2RKArQZtNPHdSl2W+uQMjYwGxCtwZQ3fCo9o+VcuwKNVxnt5oA8Uww2Bf",
-	"/Xnk9PPORycGXKpkLiQkhZKwid7DEBK+p4dR35DU0sjLZCDG3u1Hbzr499DqzrPPZn4qfWm3AzX0timR",
-	"vYPN78PtJZfCclw62UBeMs7SXFDoXEltqjo1Z5JTcK7nevfYwoccx8O1L/yQeHw4Er51oM4k10jDJmQX",
-	"TTouIRKM/xrAR211vVqB7rnibAlwJt0oISnQQnPRSSaxG1ZCRdnhmR2J3ueS5xRd/h0qxRa16Zp7qhq0",
-	"3rTNdOE0TC3PJDcsB64N+17I0ysC50/VnmckmEtVnTdUGIkKgAQtdBJXpN/Yp6RP3fLXTrfSFQ772Oub",
-	"z20APO6xmjaH+fFL5wofvyR/p81xDXD/bImPQsgkymR4RC2EpNLoHm+x++i1eQZ60GbL3K6fSXMlkZEu",
-	"eC4ybm7HDn0VN5BFKx09rulsRC+O7df6IXbEXqmk5Ok51Z9MVsKs68UsVcXcHwHmK9UcB+YZh0JJepbN",
-	"eSnmuoR0fvFohzv2CfqKRdTV9XTitI6+80o3Bzi2oP6cTQbJ/20Uu/fNq1M2dzul79kCVws6qEyMnNrc",
-	"/cpOAAEXby9o2QpfPEC/hKWQAp8fnsmMGz5fcC1SPa81VF/xnMsUZivFDpkD+ZIbTnGnXjR97A4lxQQd",
-	"NmW9yEXKzkNT3IrmWDD27Ow9MsjZ2YdBvnloON1U8QA3TZBcCrNWtUlcRmI8dtXG9wiyjQVvm3XKHGzL",
-	"kS7j4eCPBN3LUidBFDa+/LLMcfkBG2pGL1G9ItNGVV4JomZ0cTTc3zfKZdwrfulvjdQaNPul4OV7Ic0H",
-	"lriYz1FZUoiXYqy/OF2DPLkpYf84bYtiCyx2tqeFW4fqxjWsBPTEvuUTFzpOOXxEpKMxqBXaOPRt6YSg",
-	"vlU5bu6tyRTAiFKnNusEZSq6Ko2sRfIQ3PXlK9SFPu+sxUoi87m7Zwtg6RrSc8go6UZx6WnndV/a4CyL",
-	"F1mh7XUxW6pKdxooBLEAVpcZd7aXy02/uFyDMb6i/h2cw+ZUtVciblJNfj2duERSgjwzJiAl0iMwAmrZ",
-	"FRefjOptvssrUrKnLJnNp9gqYM8Whw1f+HfGBchapjsQnhhTNGTYwu8lryKEsMw/QoJbLBThfRLrR7M3",
-	"vDIiFaVd/375oLeddxDILqUeVeNq2dfWA2Ua1d52cLLgOq64AZ/gfqAM9auI/Ew2mmcTxIxaDjjGXeQQ",
-	"ZDK1k2xekbPjl23vUI+hFucSqGRrTT0aXYqEZnvtUvLiok3EU6hlHwO3MxGKXORrZUQ35SFw3hwu+Gj2",
-	"afSuz3FQABNcIW1u8njF1heGaXOry3Zz8Dd+/DUff7dnMr3RPZ3pxNVkxrZDSbLuGeSw4i7ZQtWePtFv",
-	"Ubungw1CPH5YLnMhgSWxWhqutUqFzb+3utzNAej8PWTMBlbY3hBibBygTVFqAszeqFA25eomSEoQFNbm",
-	"HjbFt4O/YXeUt22r4dzKne7fUHe0QjRtr73ZbRxGf6aTqEoa88w7o5gdsoDBUSbGoqiahvGQYdRFQw5k",
-	"jpOOZk3OY1Ey9CqA2PDEvxa46+y+WKKRfxAkKypY4dm7Pa+itPoAzOeNGVwoA8lSVNokdFSOLg8Hfa3J",
-	"Gfwah8bVT4dUzN7LF1lc+9C057BJMpHX8d128373Eqd905xbdL04hw0ZGeDpmi2ojwRaoc70OGbL1Lae",
-	"bOuCX9sFv+Z3tt79eAmH4sSVUqY3xx+Eq3r6ZJswRRgwxhzDXRsl6Rb1ElTADHVLUHtj63Sopme27bQ+",
-	"EKYbVxGNal4LKbqWwNHdugpbbGbryYI2DMO7DSMywMtSZFe9s7OFOpIuIwf+Bo669fgjKaBJA2wHBYJz",
-	"cqx8tgJ/1rdbGthM21BjUGK4mzL9wsZAIYRTCe3bQQ0JhaxNFWC7aHUKPP8ONj/hWFrO5Ho6+bQjf4zW",
-	"DuIOWr9ttjdKZ4oh2yNgJ3J2Q5LzsqzUBc8Td31sjDUrdeFYk4b722afWdXFj9+nr45ev3XoU8Uk8MoV",
-	"Cm5bFY0r/zCrwhNxrFrwNIiMkLfqz87WEQs2v7nDGwZTfHFnx5dDLeaYy4pXY+BCUXTBlWU8lbUzVBIW",
-	"hN5KMjsVpZ8amQvLS+9U5AcSFufQdod36IVwri0NQArb40YzJftFNejG0SmT2KXgG9xFG5gdKghZFwmK",
-	"QKJzkcZDB3KhUYpkXdDFqo0BRoNHHEKEWIuR8LmsRQALh+k9MkU9JIM5osSksM4W2i2Ua05YS/FbDUxk",
-	"IA0+qlyRXUdYUDZ83fjQpMVr1B1gV6begP8UO4+gxiw8IbHdyIdR3sgNCX/o8wttwtP4QxCcu0GSJpxx",
-	"YJa2JFgcfzhutpnudTdaG/YSHOogZAzbd2Z3I0MfOlhbREfmiDYmHNXYR+Pamu4e7K+nW7VM6IYK2daD",
-	"8lyrCJhaXnJp+4zhe5aG7m0N9tyOb12qii7saYhmqIVOlpX6HeKnySVuVKTuz5GSXDZ6exa5CNVXok1k",
-	"pO0g6ekb4jHK2mPeVPCQdZNoIxJOXB6Er6mQ2QeZuLRsbXuidVK3ceEIyy3mFn4rHA7nQYlKzi8XPNYe",
-	"BJ0axOmoTZR0wmFGMf+y3wXd1O873gtyLs1YYW+5lVC1xbnDG9W3dFD+WCyfQSoKnsejoxlRv3v9KRMr",
-	"YRvL1RqCzmUOkO3IabnIdX+zqaiWNMdLdjANeiO63cjEhdBikQONeGRHLLgGe8sqvHnlioIMSLPWNPzx",
-	"HsPXtcwqyMxaW8JqxRon0l6o8fHnBZhLAMkOaNyj5+w+Rd61uIAHSEXni0wOHz2nkgz7x0HM2LkOktv0",
-	"SkaK5d+cYonzMaUeLAw0Ug7qLHrj0rb9HVdhW6TJvrqPLNFIp/V2y1LBJV9BPKNa7MDJvku7SYG7Hl1k",
-	"ZntWalOpDRMmPj8YjvpppCwL1Z9Fw93PKFCAjGJaFchPbVsyO6kHZxtgulZBHi//kNIcpb9n0zu0ft4g",
-	"rbXlsVVTMuoNL6BL1inj9mIyXRVyF9qdQpyN9HSB6iI+STWywd5uunfZfalkUqDsZA/agr+A/6ItTZTh",
-	"eXRa43VXv3JlO+h9XS2EkowStu4Qlgc66dYkrqv4OnmNU/347rUzDIWqYv1JWm3ojEQFphJwEZXYfuFa",
-	"45k05sJTPuagfFWLPPupLTfttQKruEzX0fjnAl/8ue1d2JDdUj167XPNpYQ8Cs7K8s9e5iNa6Ve17zyF",
-	"kHuO7bf4ssvtLa5FvIumR8pPiOQVJscJQqp26++awpF8pTJG87QNBlpGGN7LC9od/VaDNrE7hPTA1jrR",
-	"GRv9Fdtth4HMyNrPmL1zh7h0bk2RlRVFndsbOJCtoHIBmLrMFc+mDOGcvjp6zeys2t0fp7te1O1nZe9v",
-	"dlbRO1sF3UhucqF1rDRqfzjba0Zw1dpQOwJteFHGql5xxKkfQKW1F1zkvvyAzE9InRl7aS2/9nbFTtLe",
-	"W2bNdE7XEE/gf4zh6ZpMascAjbP8/m2qPFfqoF1r0/myaShir+Ia5TtV2UZVU6bQ77kU2rachgvoFto2",
-	"VefOpfOFt93lVbWUllPi9mnLrYjbkN0jZxN7PiQVxaxH+BuaGa3qKoWbdu06obei9/r6LcAGfVrtDZ+m",
-	"T6L/lEDKpZIipVt1QZPrBmXXvnqfmOkeFxD7x2Uv4k5CI8IVbTzWlA44Ko62IvOK0BFuGDAKnuKmWu6w",
-	"fxrqk4wHwRUY7TQbZFPfXM6d44TU4BrEUCfzQE/icbyfP4ymNtoWETdkIyr/G3FXvsZn5KoIV7JzLiRd",
-	"mHZkc9VB9qRF3XUNHu+EYSsF2q2ne01Ov8d3ZnRVLIOrDzPfjZdg2BAyLtvmLIagjnwGw2UMcOwLHMso",
-	"XNz+3Ck1tJMelaWbNHrLrdnhWHu8UQJHouCJD0MGxG3gh9C2sNvW1CPZU2Q0uKDEBZRkhweMMdJ24RUe",
-	"ai1H2dvbNuUfvZohZASN10JC2ys6YiDSqEmgjSF5HXlPpxU31gXcS6edAs8pUxJTaNq40NGnguptMJGE",
-	"1ujnGN/GtkniiOJoBrSOG5ebpkU1cnfgTLyg3viOkMOWh+RVOScqo6KuXhPEmOJAxe3bh3YNwFAMhj6R",
-	"fd1U3ErOTSzRWBF6JjQeR4pFHiljedk8DBqBUr3cYkP/xi69j6/AJdZu3aSFXryxf7m9YUqOe59osbrl",
-	"rrTv3+G29GQg3KMY979CtRLe2xn0L7CKp7lWQyl85dsy06GiKUzv8iwpuuihre2wu/3QOt4rd0qqcaSQ",
-	"5117Y5Rb7Wtjg2PlPOlo9Rk3rrTUcLatc5FtcBuDYPOQtrGu/UhNNDAwlnu0qUd8PHh7P79h4IUR7K0E",
-	"9UntIULf+aoVVnLhAt+tiAwp6+rbhhWH+1S+tBvcX4SrGiMgsZXcsshrL9kbUiki2GFpwA72PO+Q1N4G",
-	"6XmSqoI7Jm1gQm9I2mHRw77Lo3UQx9QahuvcewM6tB2h/T6Eb/XCkLjj4mwW+4hzvKgeXyd9Ygnir30M",
-	"tcln0wadvtxu3tiu/zQWPbAn5JFAVY+mtcizXZvbCTu215kpsPbz4ounnejd57xQ/bNNyA/Fzd0tvYnh",
-	"728CESay1s7kwVRBQHGPWKJ7LRI5pP5jaV0Js6HaHe9pip+jdcnfgHTdyd3HHpoMqEvA2e8MudD0qhnd",
-	"fhrmG2XbtRfo/pIraKgxz6srXpQ5OLn48t7iL/Dkr0+zgyeP/rL468GzgxSePnt+cMCfP+WPnj95BI//",
-	"+uzpATxafvF88Th7/PTx4unjp188e54+efpo8fSL53+557/LYhFtv3ny79R1IDl6e5ycIrItTXgpvoON",
-	"vWeMbOxvMPOUJBEKLvLJof/p/3kJm6WqCD4l6X6duEj/ZG1MqQ/n88vLy1n4ynxFrSITo+p0PffzDPsg",
-	"vT1uArQ24U87amNvyAq0qY4VjujZu1cnp+zo7fGsZZjJ4eRgdjB7RI1CSpC8FJPDyRP6iaRnTfs+d8w2",
-	"Ofx4PZ3M18Bzs3Z/FGAqkfpH+pKvVlDN3FVu/Oni8dzHd+YfXZL7etuzbpWBuzoSvBDcPZx/7PQazUK4",
-	"dDNv/tFXYASPbC/t+UcKH43+3kXjo7kS2fXc9/xxb7ietPOPbZPoaysdOcRO/r55XTucmtLRtzO0/RUF",
-	"wucVhe72FG929zjDXcW3XjQNs8NPBL//J/2g5ofe94UeHxz8k30p5ekNV7zVn+2c/yJ9Fr7iGfO5JZr7",
-	"0eeb+1jSTQ9UaMwq7Ovp5NnnXP2xRJbnOaORQTXIcOt/lOdSXUo/Eq1rXRS82ngx1h2l4Nvgkw7nK03N",
-	"MitxgYf6D9SNNZbMG1Eu9EmaGysX+s7Ov5TL51Iuf4wPED2+oYD/8Vf8L3X6R1OnJ1bd7a9OnStnyxfm",
-	"tnVc6+H5W5PDq4Rdb3ZMJ7ujDrtPcVIJlw9cCYQFG7mW2qSbVWZjIr61kC+sClrNd3X2Owe0cwP6O9jo",
-	"XQr8dA3sFwc+EdkvVP5JyYcpUxX7hed58Bt929e77bO4vm+vKu78wmgroDG0lgC+GJWKTl3HXTRk5+Av",
-	"tVoadBKUw5x+24huCaNfmbb9ukIN5ljw0cHBQawYqI+zi99YjKn491IlOVxAPtzqMSR6d1u3fZN19Ks1",
-	"wyvJ4bk7wnX+E+bNLeXRT9R279neBLuXSt4z7JIL1/g/6GtjP2NUCOO/3myLhFwBYWMj4l/8TRDk9g+C",
-	"f6rx/uN10L3eouz0ujaZupTjiotuF/HcledSwWwTbjCKeQCNppox/znOfOO/J804lSup2nQ/8+7bVfQa",
-	"hTcNlVZC0gQk5TSLrUPnQZWn+2zMUAmeOMze2K/s9PRe9Gu3Fse43MeE/lN5aehobN0r396k8/ccWR7d",
-	"VfsVsYQoNAxpGOD53BWq9H616eTgx24z8Miv8+ZqV/RhP1ATe+riKH5QGyENI460U02s8f0HJDjVELtN",
-	"bANoh/M5pXDXSpv5BBVON7gWPvzQ0Pij33lP6+sP1/8dAAD//3s5wHVxiQAA",
+	"H4sIAAAAAAAC/+x9e3PcNvLgV8HN/qoc+4aS/Eh2ravU7xQ7yfrWSVyWdvfuLF8WQ/bMYMUBGACUNOvT",
+	"d7/qBkCCJDhDyYo3ufJftoZ4NBrdjUa/8GGWq02lJEhrZscfZhXXfAMWNP3F81zV0maiwL8KMLkWlRVK",
+	"zo7DN2asFnI1m88E/lpxu57NZ5JvYHYc95/PNPxSCw3F7NjqGuYzk69hw3Fgu62wdTPSdbZSmR/ixA3x",
+	"6uXsZscHXhQajBlC+ZMst0zIvKwLYFZzaXiOnwy7EnbN7FoY5jszIZmSwNSS2XWnMVsKKAtzEBb5Sw16",
+	"G63STz6+pJsWxEyrEoZwvlCbhZAQoIIGqGZDmFWsgCU1WnPLcAaENTS0ihngOl+zpdJ7QHVAxPCCrDez",
+	"43czA7IATbuVg7ik/y41wL8gs1yvwM7ez1OLW1rQmRWbxNJeeexrMHVpDaO2tMaVuATJsNcB+6E2li2A",
+	"ccnefveCPX369DkuZMOthcIT2eiq2tnjNbnus+NZwS2Ez0Na4+VKaS6LrGn/9rsXNP+pX+DUVtwYSDPL",
+	"CX5hr16OLSB0TJCQkBZWtA8d6sceCaZof17AUmmYuCeu8b1uSjz/v3VXcm7zdaWEtIl9YfSVuc9JGRZ1",
+	"3yXDGgA67SvElMZB3x1lz99/eDx/fHTzh3cn2f/2f3759Gbi8l804+7BQLJhXmsNMt9mKw2cuGXN5RAf",
+	"bz09mLWqy4Kt+SVtPt+QqPd9GfZ1ovOSlzXSici1OilXyjDuyaiAJa9Ly8LErJYlGEOjeWpnwrBKq0tR",
+	"QDFnQrKrtcjXLOfGDUHt2JUoS6TB2kAxRmvp1e1gppsYJQjXnfBBC/rtIqNd1x5MwDVJgywvlYHMqj3H",
+	"UzhxuCxYfKC0Z5W53WHFztbAaHL84A5bwp1Emi7LLbO0rwXjhnEWjqY5E0u2VTW7os0pxQX196tBrG0Y",
+	"Io02p3OOIvOOoW+AjATyFkqVwCUhL/DdEGVyKVa1BsOu1mDX/szTYColDTC1+CfkFrf9f5z+9CNTmv0A",
+	"xvAVvOH5BQOZq2J8j/2kqRP8n0bhhm/MquL5Rfq4LsVGJED+gV+LTb1hst4sQON+hfPBKqbB1lqOAeRG",
+	"3ENnG349nPRM1zKnzW2n7ShqSErCVCXfHrBXS7bh118fzT04hvGyZBXIQsgVs9dyVEnDufeDl2lVy2KC",
+	"DmNxw6JT01SQi6WAgjWj7IDET7MPHiFvB0+rWUXgCLkHHCGngSPhOkEzyLr4hVV8BRHJHLC/eslFX626",
+	"ANkIOLbY0qdKw6VQtWk6jcBIU+9Wr6WykFUaliJBY6ceHSg9XBsvXjdewcmVtFxIKJiQDmhlwUmiUZii",
+	"CXdfZoZH9IIb+OrZ7Gbf14m7v1T9Xd+545N2mxpljiUT5yJ+9QybVps6/Sdc/uK5jVhl7ufBRorVGR4l",
+	"S1HSMfNP3L+AhtqQEOggIhw8Rqwkt7WG43P5CP9iGTu1XBZcF/jLxv30Q11acSpW+FPpfnqtViI/FasR",
+	"ZDawJm9T1G3j/sHx0uLYXicvDa+VuqireEF551a62LJXL8c22Y15W8I8aa6y8a3i7DrcNG7bw143GzkC",
+	"5CjuKo4NL2CrAaHl+ZL+uV4SPfGl/hf+U1VlCqdIwP6gJaOANxa80Uot3/oP+DvyPbiLAQ4lco6YPaQz",
+	"9PhDBFWlVQXaCojtImlp6E/ecIQz35gV3PI544atuVmTpLHKWxoEaSOtANha6Fwe/s8X/3mMlwae/eso",
+	"e/5fD99/eHbz8NHgxyc3X3/9f7s/Pb35+uF//sfgioH3w1LlFxnCMlzGS7ECY4MlhFqGP9rrjRMyyRVF",
+	"jUq+gPLTr46mTe9PHzh/Eim1ZFfcsA0vgPEVF9LYg9TQJOfSI69FWWiQiCvg+ZpJVQBT7kTBbmyp1Yb+",
+	"0krZ1tIkSHHF/3tiIa62sCFqa/7zHxqWs+PZHw5bm92hI1FzeKYFEHm/QCBmNw3gXGu+Tf2NIKQku7K0",
+	"nwE6D5FhG9AXpYP2N7rpI8flGeEbDyy1TEPKztqFEp8yYahlYw8Uxg+xEbJ23xa85DIHVip1seD5RUQs",
+	"zWE2n1lleWluJylcn98kkm/io/xdIwg74iRw3zyoBERp80bv8Chpxba7Cjmx3bPPxHviLp+eVdXSmXWN",
+	"UDIobSlSPcBN8MPcSfLvYjo/LkKOs7Tj3P9Mbc8kntrPTEhHENR07kyC9w8PjpqEBD/0YfgGieMezl0i",
+	"siEv0fBsDbwATaRyMOvTVlp5oY5/pn4IZg46IRF/ov/wkuFnVMK4Dbd3FBzCMGGYivwMBV74HUW6mbAB",
+	"YsUqtnF3fFZ1BcZ+KF+0kw+40KFlCj996yWNP9LdIpodOhMbOLV8U/20XN6VaIZSzs1lxQYMjs0UDU7W",
+	"IFbAJZSq2oC0bIOHJZ6YiJfuvrsu6eFbq4GBXMmC/DlQhAP1Cg0EOYHgdiXAgXcFAs2kBHcPwx6AFIpv",
+	"5pHF9WSh9H3g7UTGkp7jqI3laIgealpXmSeuhC3KNegN1Lrudgv5/vB7sXBq+a+ABWN5BPxHYKE70H1j",
+	"QW0qntsXcEcM7BK60dhJ0cty16AjqbgsvMaJ6ouwpOTmaJzUG9IsiP+joc09CGoEYETpScBo5kzpAnRr",
+	"GHLA2jVsfbttRyOejKS+1tvfUwJziuAcg5zl6hKQXhhnmktvAkPoTYNXUcI9oDR9U0Nb0dMn7PTPJ18+",
+	"fvLzky+/wvkrrVaabxjqg4Z94a/ozNhtCQ+TijNZUNKjf/Ws0U8746bGMarWOWx4NRzKGbmd/u2aMWw3",
+	"RH13h7xC6QGctFOAR7VDO3P+GwTtJVz+oAogw5W5p5Ot5MbfgtmVFtYC2WQmn2pTrys0jTtF/TQTTiw3",
+	"+IiQeqm3upb3QJSgtdIJgywt1apcldklaCNUwrf2xrdgvkW4c1X93x20JLdwbvIV1LIAnbyeoxNg8q3Z",
+	"DX12LVtC2Skw3HoTq/PzTiHQLvKD6dmwCnRmryUrYFGvYg3aWQ04K6gjiZUfwV4pffEGQN8XMedKSsgR",
+	"txWOOiRX+nkyZiMI92LVjTxCqz+qApVSW9/HQtvBWrwjzcXY5gtVW8ad8cZQ47SuMRJTQMgkH6yN1Re7",
+	"dheBBeBpkfN6tbYMzbsqRcVtx4znDlOZ01b3acGulZvO+atLDbzYsgWAZGrh/Rz+oKVFcnKP2o55pK6S",
+	"5owIrkqrHIyBIgv37n2ghXatGWwMTwQ4AdzMwoxiS67vCCzZHPYASm1S4Db3OiFHoJ42/a4N7E8ebyPX",
+	"wAK/MKvodCvBwhgKJ+IEFRd0kvyq+xcmuev21dVICJPX5vHOivsiuVT+ApgcDA/RbB/bYqN4LQbckR44",
+	"JW1pNnbMTfuaG+tcZUIWXmG0XcUBpxgHePTwxJH/Fs7N4di5kgakqU1ziJq6qpS2UKTWgP7V8bl+hOtm",
+	"LrWMxm5OaqtYbWDfyGNYisb3yDLRJYBb76ttfMnDxVFYDJ4D2yQqO0C0iNgFyGloFWE3DuMYAUSYFtGO",
+	"cITpUU4TOzKfGauqCvnPZrVs+o2h6dS1PrF/bdsOiYvbVq4XCnB2G2DykF/5CwpdDdfcMA8H2/ALPJtI",
+	"zXc+vSHMyIyZETKHbBflkykJW8UssIdJRy7cPkQwmq3HHD36TRLdKBHs2YWxBY8oK6jufMPlfelkCy7l",
+	"uEK24LfQdD1ke3UxGnR0dRRnc8+2Ah+9kzU2g4nr6cMyQc2MJ5p6628iB1iuyhKc6z2wSDjG/NBJEwEp",
+	"7B7cs9Z9fw8k8hIsF6VpNNYARRQkQCFT/XB2vElpyEHactuaguZBz2nMQwQFK/wsLr6ulcuyYBquuC5C",
+	"iyGFRovJhCzgOk3mvOO9KOCaiTTQy2ZmYVkeYgJlPEDaC+eiLPNSGSQAF765T9tpoi4fGFZL4TWbK9Ae",
+	"riVo3dqZQ7hgCHHcBccuVHj3yV2QgF3T0zrg3G6ZVJQrfWBCso3IteIueBWR2lsg07DhCB2FUUbO6vSc",
+	"u5D9wn0PsbQhhimm3fS4gV6zvfaTqzVtFp7BfSTGVI8GMzAwtpBVqRa8zIzlFrICSrvXTos3THhJLVGR",
+	"U/mwexfk8/N3ZXF+/p69xrZ06QR2AdtDCilm+Roti22cV8wv7joJ15DXsc7RQ+Mkkeq9mV3o+1EDlVJl",
+	"1ph9+nFpAz2kj/cLkV9AwVBeBb+6VAU86O4QTsK+QBI3TeTe1Xob7hZVBRKKhweMnUgGm8puvRG/pwr3",
+	"JpcP7K75r2nWoqZYDC4ZLfLgXKYNpi4E+SN5Kgyzm5NcTs5HTuUG2T2RvZYj7MSv6ByEIsbpVP/lKfWM",
+	"jr7hydwSlYNiyuH8PSWq8M4ui8L5FZvTzdSLjaBslajZnAnbBBAPTT/CHjCMCNFAN28Dl6DRpsyNuwT4",
+	"cP+NQAuOqfMcoDg+l1kHklxt/MRftP91Yum8Pjp6CuzoYb+PsXiP8UYGxwP9vl+zo7n7ROhiX7Pz2fls",
+	"MJKGjbqEwl3UY7p2vfYO+1+acc/lTwPBzDZ86674gReZqZdLkQuH9FKhXF+p3nVEKvoCGsEDPGYNE3bu",
+	"PVTCuGuc25eWAdPa031o2IlRmXBJGSjtQthol3YMg2ue4yq5cQ4q0ggaOhsqQVZVWTxA0s+5Y0bvpjcd",
+	"OX5HvhvKc2eZ2g3fWc821UFHRK4T3BIDZCQhmBQaxCqFuy58gkjIIiiFj1yMgfR2qnIbwB05dA7Y/1I1",
+	"yznxb1VbaC79StNNGvvSDMJEc3pNrcUQlLABZzqkL48e9Rf+6JHfc2HYEq5CVtWjR0N0PHrkmEAZ+9Ec",
+	"0CPN61cJBYrcfXiaJjJh0R93sNdXTuNOumBFQ796GSYkZjKGjhhc+D1F7YriOqmzwHVqpX7nyA77wLCK",
+	"b0fVa4pIS6TTuCi0Jl6tI0Gd/FuL6tOHSRorFmlv8p993KeXHNfylXSxVah5kiV36w1EavlvjjzEzQyY",
+	"j5Y0hejepDZESMbdZhPNof2v3N7DIeMGYhr8HcN07ObGfVXLOGvQU57ZGguboevJdf155PbzNpitBlSq",
+	"ZCkkZBslYZtMlBcSfqCPo3GsY53pgBjr2zfrdeDvgdWdZ8pmfix+abcjMfSmyWG8Dxteb9ye1zHOl6Sb",
+	"DZQV4ywvBUhnXba6zu255GS17anePbIItuhxO/6L0CTtOEjY9f1Q55KTpaux5SYd70tIeGm+AwjmfFOv",
+	"VmB6qjhbApxL30pIMrTQXHSTydyGVaAp5uTAtUTtc4lhfVaxf4FWbFHb7nFPaV1Om3YuUJyGqeW55JaV",
+	"wI1lPwh0++Nw4VYdaEY6z3WDhRGrAEgwwowkUHzvvpI89cuPY+p95yBvPvUBEGAXxSjkr156VfjVS9J3",
+	"WufnAPZP5hHDTMUkkVEsvZCUu9qjLfaFVLYhoIetG9Xv+rnEkAurMHlbFNzejRz6Im7Ai447elTT2Yie",
+	"gyOs9X3qir1SGcYPU5DjbCXsul4c5GpzGK4AhyvVXAcOCw4bJelbccgrcWgqyA8vH+9Rxz5CXrGEuLqZ",
+	"z7zUMfceFukHTi2oP2fjWgx/W8UefP/tGTv0O2Ue0G76oaPUscStzX3oGhBw8a6ChkvBxAv0S1gKKfD7",
+	"8bksuOWHC25Ebg5rgz4ZyiI5WCl2zPyQL7nl53Ig4keL3ES5D6yqF6XI0XiYYs0xY+z5+TskEDRB9gMR",
+	"hgdnm/SRMHDTBBmmaqjaZsHlMmq7au17NDL13jnrnPmxO34XP/6I0b2qTBZZYdPLr6oSlx+RoWHUySWf",
+	"GKt0EILCBGhof39UPhQDzWSOTVltwLB/bHj1Tkj7nmXe5nNSVWTiJRvrP7ysQZrcVjDdTtuC2A6WutvT",
+	"wp1CdessExr01PUKjguTxhx+ItRRG5QKrR36rnjCof6sStzcO6MpGiOJndquM+Sp5KoMkhbxQ1SMyacF",
+	"+oAENNUg8fniIJhGvgY0L5PTjezS8053teycLIFlhXH1PFwyCSWdkwkC63xUBfdnL5fbfvavAWtDyvNb",
+	"uIDtmWpz1m+T7otuFedIypBmxhikQnxEhwCaWmN28WP0N9/7FRFSXlXM+VNcnk4gi+OGLkKfcQZyJ9M9",
+	"ME+KKBo07KD3iusEIqjDGArusFAc76NIP7W8imsrclG59U/zB73p9MFB9gn1pBjHuPSutB4I06T0do0z",
+	"DEVPbgfgF9wP5KF+eFmYyVnznIOYUU04T7iLEiJPpvGczTUpO2HZcrULtDSVgJbtaRrA6GIkPrbX3iUv",
+	"LltHPJlaphxwex2hSEUhiEp0XR4C5y3hko/hf7wYw6soMiqq8dMGTHjB1meGeVN2w6XXhpIMoQ5DKL4w",
+	"m9+qkMJ85oN1U9uhJJ3uBZSw4t7Zgo17Wc4PTLRBCMdPy2UpJLAsFWTFjVG5cP73Vpb7OQCVv0eMOcMK",
+	"mzxCiowjsMlKTQOzH1XMm3J1GyAlCDJr8zA22bejv2G/lbete+jVyr3q31B2tEzU5gf7bRxaf5pE3jd9",
+	"MZbUzDutmGuygMFVJkWiTMiEPWRodTHgI4KyjmTNLmCb1iqAyPAUmkCiRl1nX4glHvIPI2eFhpUwFtr7",
+	"akhD//Q2g0tlIVsKjXF3eFVOLg8bfWdIGfwOm6bFTwdVzBVOE0Va+tC0F7DNClHW6d328/7lJU77Y5sN",
+	"Wi8uYEuHDNViWFChP7XsTY9tdkztAg13Lvi1W/Brfm/rnUZL2BQn1krZ3hy/E6rqyZNdzJQgwBRxDHdt",
+	"FKU7xEsUATOULVHsjYvToZieg1239QEz3TqKaFTyupGSa2kB3b0KF2zm4smiOnnDpJcRHuBVJYrr3t3Z",
+	"jTriLsMpbqOoO40/4QKaNYPtwUB0T07FVWsId323pdGZ6YpODEIM92OmH9gYCYR4KmFCvd4hopC0KQJs",
+	"b/EX4OVfYPs3bEvLmd3MZx935U/h2o+4B9dvmu1N4plsyO4K2LGc3RLlvMJicrzMfFLqGGlqdelJk5qH",
+	"HNZPLOrS1++zb09ev/HgU8QkcO0DBXetitpVv5tVaeBW6REGCfVAUVsNd2eniEWb31TZiI0pIbizo8uh",
+	"FPPE5dirOeBiVvTGlWXalbXXVBIHhN6JM+MBPtoyF4eX3ivLDzgsTaHtDu+RC/FcOyo0blwRUhPqZkVB",
+	"NajG4QyOXNANuABvmB0KCFlvMmSBzJQiT5sO5MIgF8l6g8NjY0aNRxRCHLEWI+ZzWYtoLGw2pa5ID8ho",
+	"jiQyTbIKSou7hfLV42spfqmBiQKkxU+6KQMRMQvyRogbHx5p6Rh1PzD1iYb/mHMehxo74QmI3Yd8bOVN",
+	"ZEiES19YaGOexh8i49wtnDTxjINjaYeDxdOHp2bn6V53rbVxsfehDELCcIVB91eaD6aDtQN0ZI5k5fhR",
+	"iX0yLq2x9y3kdCuWCdxYILt4UF4alRimlldcukLQ2M/h0Pc24O7t2OtKacrkNJD0UAuTLbX6F6Rvk0vc",
+	"qETcn0clqWzU+yCRIdcXoo1lpC3xH/AbwzFK2mPaVPSRdZ1oIxxOVB6ZrymQORiZuHRk7YpWd1y3aeaI",
+	"WphDN37LHB7mQYhKya+wUF5aqUGYTlpHScccZhULncMumCZ+39Ne5HNp2gqX/liBboNzh6n2d1RQfl8k",
+	"X0AuNslKhOfn7wrCfjf9qRAr4Sp/1wai0tJ+IPdkgqMiX57buaJa1LxaYlR5W7ze70YhLoURixKoxeO5",
+	"L6Zo6NSyncwrHxRkQdq1oeZPJjRf17LQUNi1cYg1ijVKpEuoCfbnBdgrAMmOqN3j5+wLX1PwEh4iFr0u",
+	"Mjt+/JxCMtwfR6nDzpf43yVXChIsf/eCJU3H5HpwY+Ah5Uc9SKbiundZxkXYDm5yXafwErX0Um8/L224",
+	"5CtIe1Q3e2ByfWk3yXDXw4ukRgUYq9UWczSS84PlKJ9GwrJQ/DkwfH4Gleixihm1QXpq60a7ScNwrkCo",
+	"O4cbuMJHcnNUTQHX7qX10xpp3VmeWjU5o37kG+iilWqMUpCkaGuCeIF4MFIpCvRlehI9ssHh3PR9MSRL",
+	"ZhvkneJhG/AX0V9qYnKkJae1QXb1I1d2Dz1V1cJRslHE1h3E8kgm3RnFtU6vk9c41V/fvvYHw0bpVOGa",
+	"Vhr6Q0KD1QIukxzbD1xrNJPmuAiYTyko39SiLP7Whpv20tM1l/k6af9cYMef2+LyDdod1pNpn2supSvp",
+	"PDzBiZd/DjyfkEr/VFPn2Qg5sW0/cd4tt7e4FvAumAGoMCGiV9gSJ4ix2o2/awJHMJaP0Txt5YmWEIZ5",
+	"ed0Cf8lnkO5WOXCeqPs3rdZxPOHIW2ufVnYiIFNSipUJJU19wVnamQht/v9jVoRb5C936q+7+lEhQ783",
+	"6Vh8wiWMFp+mRDNtmGsUiaZ21E5ZxkoZcat04k4lTJwjFZBCUBTZFWAy5a7KSK5FQEkAfoD7kovNDn+e",
+	"Nll78I/Wp4wUA5zAdQxTd/BjOT5r0pRD6tCFkt2SkQxh0Wit9e/GtBT373Hn6hAceFvsjyJkSmw0aDvr",
+	"cNuQJVrK7RNIahfTi3m/WwZ6ikxIQkdaHbbgKfE4FH6BQ9KIDF+7JBxubx6l7bLSNLxrp1IcMmFDGqjn",
+	"sx2oC0ULf6nBpF4NcB9cqKylF1qU9gULGciCLosHzKVsI3idpFu6pIlNXboETihWoL39vq5KxYs5w3HQ",
+	"scDcrK6PTxWmgokrl/7fOQSTj3ZMLzHTVHdPR9ZOH2d3yCGu2tisKUydSprAFmehAWVmXHJRhug1ur3E",
+	"2DlgL93F0QQZ5iaJTu9mOq+qkkqB/7GW52tsoDpSaVxjml7pMyg1JnqOyf8/bwuVkYxCuH2xT1frc84U",
+	"XpuvhHFPymFSfkcpCmAEBgh5G93l6VpKRykHt3hBoilLdlu0B+D8eS53QNZD/C1vKa6k7m0Ln55SrxRR",
+	"DqqoDt5hcgmiTSH88FRozqWSIqek7OgRuwZk/zzdFJfbhPz19HMUZuY5NMFcydqtTeSZx+JoNdf5rIO4",
+	"ob8h+oqb6qjD/WnpHTTU4lZgjZdsUMxDsWJvBhTSgC88h0QUy0mlO25MkpBJz3hbYeiWZETK98ht9zv8",
+	"Rjdd4SM+L4RTUj3aHEELZ6ij17PsGiQTlq0UmPi9o3ZN77DPAWUaF3D9/iC8tkVjOA8kLtu5vIdDnQQH",
+	"uHc4Y9sX2JaRt7H9uROp7iY9qSo/6Xgp6+R10l7LUQQnnKhZ8GJFyG3Gj0fbQW47I1foPEVCg0vye0NF",
+	"5/CAMEaq9nyLNlFHUdSCuYixZGafkAkwXgsJ7VtwiQMiTx4JtDHEryP9TK4xZm/6E0jAS3K0Jy8ahO9s",
+	"7wqCQhZx7jzUh2rf9QiV0K9i2w43vkN6Nda7Pj52LT0KoxURksMc43TUFroekVxNg9bwgHkngSuRvSJt",
+	"5gU9vulRMSxbTWqd1+IKCkruFbJOSS48OcIbC90TaMiHQ6XMdbea59DpO+EoHEuiKoThxsBmUSbCMF82",
+	"H6Py+LgjeG3Af1NFW8ZX4AND7lxkjDreWsHdXfCrxL3PMAvgbrvS9r/HbenxQLxHKer/Vmul47zTQf0d",
+	"J/matFAKQVPh4R+61TSJVV2axW9po2P7DMluo+v4gyJzks0jgahv24oH3Ekr59saC0fNR6OnufWpEZaz",
+	"XZX33LMPqRFcHA19969gJw3bY7EzLnQGPw96T1NcBmogjb0ToSEoawjQX0LUJau48I7blkWGmPXx2UMT",
+	"y5TIzXaD+4vwUc80SGolcYH9IUVTzdiopr9VTdmjg+mJxWfD5EUc978RToTM1cYVPaVZhJLGeWc1bJQd",
+	"9GybJaljUZcX2S811JCVIFdjbyxGFdLr8iK8p2XYFRfW3xuDY9yqBuIdBJ0JuW8mP4kn8JCm1Zr99s2g",
+	"anu7KaaB3yI0S753hDPgxYdBySuqoC9kDr29IBUGjOWLUpi1f3s+qtOcnroQGkYEyt/jKtmqgCEREml+",
+	"wVRtVwq37OGcKd1+SRIt+6Klt4cHUWZY+HU2n4UBk7lha7FaZ5UWSgu7vRWdYU8Wen4cwQlpLJc5jNyw",
+	"zuglCteEYROmIa4lPhh6cNrcgprHCHlOc/HVZDt/JIjO+OrFmMmsgXA6NwwZ4dcCrqKcNHqy2mox9uRA",
+	"65nBRo634jd6KkpxluYqfsrKgT2FqUbtZ2eR0WjwOo6ElbJR4PwOMhnNIhnK3kg4xlJsIHRiUbCTybok",
+	"2iOHdu17TrpmD6cSEDnljZCrEgLd9Osm3WK49L5ZvkoP4LuFiff43kNNDb5KYiEUcE+c9VGZeLZWxg5X",
+	"ib+mYXz1pj2ntQ9zNNZJn+jIHwkG4WaMXtVyCdJAMwCdMx7QpdIk8h9suKx5+QAtDsLGLexaq3rliPnk",
+	"zau0OimtKHecep2HIxZcMriuhAZDzOgZMToSoVL5eoK/hFDZrD2Akd6xQYn6UU/szgrzSf+TX9wVd3FZ",
+	"qBNDwbaQ2H20kYHMJjiP7LoBAooWMOPKaFnF4DqHtnpSBx53EDo47pinrpZDN6p/L8lNNDZyC+k+Vo7W",
+	"FC2U3qbZNzTG9m0yuxYmk6qAKfXjk7ikF8a9rG7rT09NS72jxzwFCTEhVVATy1bTElQom6JLmojOvaEG",
+	"3iE/coVoqysoAzEq8KRsQevEG0R19O/4IAT5D/R40dtP6fbusmDkcWgJdxepDf3gAeG9xUyTQx4zI97v",
+	"jizyzP6xIug2V8yB6zqqMbiHA+PLR7uUK34npotCbSeKznZKXhStE7AbUXMvHDeqyg0IZwdh3DHPeRI7",
+	"Dg0tCT6Ms+P2WLguOlYZVxCp5w1TGu7ZOhNZ4W9pnRnm/U1dHq2DeK42MFzn5A3o4HYE91MQ35oWh8gd",
+	"twjaxRSLYLquDHYnk6RDSKh8NDx0PplBsfPgrZ83uetaAFUXfrEWZZGMs8QPTqcj1vchARW3VJuiTUoz",
+	"bOPKR1stfA3poVa/s4gziR6crjGvtI+3+M++TLmX8CXw5QH7QRh6l6VR8QjiEriTTYpe/PE+MIzm8WN8",
+	"+hC2Ha/o4Pz9NfrqEnLVrir97h7w5e6DxWFEGI+ysffdRnIZafwU8fxt7PrvQkRGAn17DFl7utslGTph",
+	"2205WApM/nnx1bNO9POnLEj7s0toHMpqB+utHE99Dq7dfg/W2pk8mioKyJ4Qi+27HSTfszaQ12gQodzn",
+	"4OkUPyfrumD5XffCsb/+NBlkPoHJKow49aH9q6Z1bQJlf6/c+9cbLgvnirT0sMG31xxfDfVC9esHiz/C",
+	"0z89K46ePv7j4k9HXx7l8OzL50dH/Pkz/vj508fw5E9fPjuCx8uvni+eFE+ePVk8e/Lsqy+f50+fPV48",
+	"++r5Hx/MkAVnxzMH6Cxkn8z+J1Vtzk7evMrOENgWJ7wSf4Gtq9OKZBwqwPKcxDhKp3J2HH7670E8Y23b",
+	"dvjw68xnSszW1lbm+PDw6urqIO5yuKKntjKr6nx9GOYZviPx5lUToegEIe2oCz5DUjiYtaRwQt/efnt6",
+	"FswSjdFudnRwdPAYx1cVSF6J2fHsKf1E3LOmfT/0xDY7/nAznx2ugZd27f/YgNUiD5/MFV+tQB/4Urj4",
+	"0+WTw3A4HH7wGt/Nrm+H4WGC8RadPE5fnCvq0Ood2Kn9KxNFPLMxQKP6HNfok3vG9vAD3XdGf++C8cFe",
+	"i2IAvH8O8vBD+z7rjeOfElLBMeF5oLY5PfvDF0pTAqXN18gyIXNLmO5zvs3+4wsZsxPs9aJ5qzYqknP8",
+	"LnHIY0MWRiImQQpoabgzUyumrK4hrtvSCOFO+1YUvzvKnr//8Hj++OjmDyhq/Z9fPr2ZGGH3ohmXnTZy",
+	"dGLD9/NZcJcTaT85OvqISvknMkK/26TGG3+Qfn67rrLIx558HLg3EGuQsccU2xt+5DnMZ7dc8c7rUidC",
+	"IVHJ+htesBB+TXM//nRzv5JUSwtFHnMi/WY++/JTrv6VRJLnJaOWUb7tcOv/Ki+kupKhJZ6/9WbD9Taw",
+	"sekIBeY3+8CZ3o0z1ohLbmH2nt67M3aycDGW30G4nGKvz8LlUwkX2qT7EC7dge5ZuDy5JYP//lf8WZz+",
+	"3sTpqRN308VpUOWcKTkHbYc6Z/xxoDZGH037awGXG1WAVyp9KlZiLX/XwoJ/uaTNjHHpRq1Jpk0RYQVc",
+	"Qqkqsh/gDC68iYpQYgdn/sb09sF7o8Y/P+OXF6KKB+/NDTXN4pLLHF7C5Q+qAEo8MfsOhV5cVLO8K1zv",
+	"QTglfqlBb6NjwvubYxHiaeBxwt58vxL5rPvkCwJqXcULPkD6SNDaVGciTUM4CdNMsKfvfOT9s6D63el9",
+	"jqlMm87u/eqjtDYqu7qy5lAtl74m2ypVmu178A+StewZIg94UbQhblcYSZ87IqUcg5CvFKw6nqknSamh",
+	"UPkeLL3jhymEpzjsTw7se+dpx2cN9MyhZw+uu3zdYnSnG//WWHQYnMD7HoDPzP//CfO/9Q8927vS5+1k",
+	"weEH9+/NuB5y+tuQCqejUmGvttHHYBxTdZC+lqpW5IxdSXdV2PoVFJDPwuqzsPrNXamCaLhfSeUuOYfu",
+	"OdT24uSDig8XXJpRHSaWn02Iafykto/Z9PHOku5JoXwmr61CZ6VLCPeP0Ns1CM02wixgzS+F0kxp5mJB",
+	"y21Se/HBr+ZX0Fja2FmT8KdyOT2dzgO53xfJR544/8w193HE9zZ0lCdi4j/8gES905/0WixtM0PzkCvZ",
+	"gRuumPuyJAa5WFjDNDTPTg3I+q9ywSXSzJQD9xZh2omzt4lZHjt5+wa895/57Nfms2dHzz4dBGdBdvsq",
+	"nm43fq/sTpxjGG9IfrrjBg+RNOcWwoTcFq9dw7Uwtpfg6FPYXTFSYT2/L134hYQrpiQYRjkB/byDoQT4",
+	"5jfL//MRFmZF7eDvKPyDsjd0F/Fs+tL3OHWNXQmilf9xzDgZppklrwefxj75m5NYn3X535eY+mafkOop",
+	"Ithwuhpu4vjxTtLsnPFSyVWbDugfNfLlPK3my6XI6VdhrMhN87hGN0V7oIVHiXi/gibeLmGE5Rr83Dbz",
+	"cy/fuZE/q+S/kkqe2NlRZgiP0w1fbOsGvY0FZviISPaF0kyDhKuH/ox2wyYyPpqybMhKLhLaKQehfnWU",
+	"idRlibd+0M5Dk3+B7SSH3T/88Jko/kFV9qlGDsVq/4OXZfQbQ0uRbz1mXWsTQD7ykF8ChJr/ZJYy9WIj",
+	"3DPT+Jygw6PDQcffOVQCTL1agaEEIhj1Qi4BRnyQR0cpK+AAZp8j4CDG3bNXKivRQDLc6jEgek8IDjC2",
+	"Y/qeIzN++TEOz01Q3RW6hxfQPgaZgoxG7T5neBvoXipMLMJKBQ417X4hxlyx0VCN1hXT9HXam0CRFFBS",
+	"ZThkCpY2+P1j9bGu6LfXrxK+XqoviBB7DTheHwZ470+6pnFTcn9wgYqGbh/XIeYwLvL75maHVDPr2hbq",
+	"Su7wCVSQC176VxDItNdEJVvFwgBtFRf2ky9PVG4pFUMUwDgZDFVt27Bx7BxeBW4fBcYR2nfrV0LSBMTl",
+	"NIt77oMPbdgJL4KH7EeXBdGTeyn68TCm+f7XMP0PT/WdexVeke78fYgkjzFrGVlmM8LQMEDFAi8PfUHH",
+	"3q+u6ln0YyQ9078eNi9oJT/2I2dSX30wdWjUJlLEiQm0U01Kwrv3iHB6qsFvYhtnf3x4SJXG8EJ5OLuZ",
+	"x99M7+P7Bscfws4HXN+8v/l/AwCeP5eIecwAAA==",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
@@ -20,6 +20,9 @@ type ServerInterface interface {
 	// Get account information.
 	// (GET /v2/accounts/{address})
 	AccountInformation(ctx echo.Context, address string, params AccountInformationParams) error
+	// Get a proof of the account data against the accounts merkle trie root.
+	// (GET /v2/accounts/{address}/proof)
+	AccountProof(ctx echo.Context, address string) error
 	// Get a list of unconfirmed transactions currently in the transaction pool by address.
 	// (GET /v2/accounts/{address}/transactions/pending)
 	GetPendingTransactionsByAddress(ctx echo.Context, address string, params GetPendingTransactionsByAddressParams) error
@@ -35,6 +38,15 @@ type ServerInterface interface {
 	// Get a Merkle proof for a transaction in a block.
 	// (GET /v2/blocks/{round}/transactions/{txid}/proof)
 	GetProof(ctx echo.Context, round uint64, txid string, params GetProofParams) error
+	// Get the signature collection progress of the pending compact certificates.
+	// (GET /v2/compactcert/pending)
+	GetPendingCompactCerts(ctx echo.Context) error
+	// Get the compact certificate covering a round.
+	// (GET /v2/compactcert/{round})
+	GetCompactCert(ctx echo.Context, round uint64) error
+	// Get the compact certificates covering a range of rounds.
+	// (GET /v2/compactcerts)
+	GetCompactCerts(ctx echo.Context, params GetCompactCertsParams) error
 	// Get the current supply reported by the ledger.
 	// (GET /v2/ledger/supply)
 	GetSupply(ctx echo.Context) error
@@ -46,7 +58,7 @@ type ServerInterface interface {
 	WaitForBlock(ctx echo.Context, round uint64) error
 	// Compile TEAL source code to binary, produce its hash
 	// (POST /v2/teal/compile)
-	TealCompile(ctx echo.Context) error
+	TealCompile(ctx echo.Context, params TealCompileParams) error
 	// Provide debugging information for a transaction (or group).
 	// (POST /v2/teal/dryrun)
 	TealDryrun(ctx echo.Context) error
@@ -112,6 +124,36 @@ func (w *ServerInterfaceWrapper) AccountInformation(ctx echo.Context) error {
 	return err
 }
 
+// AccountProof converts echo context to params.
+func (w *ServerInterfaceWrapper) AccountProof(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "address" -------------
+	var address string
+
+	err = runtime.BindStyledParameter("simple", false, "address", ctx.Param("address"), &address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AccountProof(ctx, address)
+	return err
+}
+
 // GetPendingTransactionsByAddress converts echo context to params.
 func (w *ServerInterfaceWrapper) GetPendingTransactionsByAddress(ctx echo.Context) error {
 
@@ -320,6 +362,110 @@ func (w *ServerInterfaceWrapper) GetProof(ctx echo.Context) error {
 	return err
 }
 
+// GetPendingCompactCerts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPendingCompactCerts(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPendingCompactCerts(ctx)
+	return err
+}
+
+// GetCompactCert converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCompactCert(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "round" -------------
+	var round uint64
+
+	err = runtime.BindStyledParameter("simple", false, "round", ctx.Param("round"), &round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetCompactCert(ctx, round)
+	return err
+}
+
+// GetCompactCerts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCompactCerts(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":    true,
+		"min-round": true,
+		"max-round": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	ctx.Set("api_key.Scopes", []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCompactCertsParams
+	// ------------- Required query parameter "min-round" -------------
+	if paramValue := ctx.QueryParam("min-round"); paramValue != "" {
+
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument min-round is required, but not found"))
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Required query parameter "max-round" -------------
+	if paramValue := ctx.QueryParam("max-round"); paramValue != "" {
+
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument max-round is required, but not found"))
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetCompactCerts(ctx, params)
+	return err
+}
+
 // GetSupply converts echo context to params.
 func (w *ServerInterfaceWrapper) GetSupply(ctx echo.Context) error {
 
@@ -400,7 +546,8 @@ func (w *ServerInterfaceWrapper) WaitForBlock(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) TealCompile(ctx echo.Context) error {
 
 	validQueryParams := map[string]bool{
-		"pretty": true,
+		"pretty":    true,
+		"sourcemap": true,
 	}
 
 	// Check for unknown query parameters.
@@ -414,8 +561,20 @@ func (w *ServerInterfaceWrapper) TealCompile(ctx echo.Context) error {
 
 	ctx.Set("api_key.Scopes", []string{""})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TealCompileParams
+	// ------------- Optional query parameter "sourcemap" -------------
+	if paramValue := ctx.QueryParam("sourcemap"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sourcemap", ctx.QueryParams(), &params.Sourcemap)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sourcemap: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.TealCompile(ctx)
+	err = w.Handler.TealCompile(ctx, params)
 	return err
 }
 
@@ -596,11 +755,15 @@ func RegisterHandlers(router interface {
 	}
 
 	router.GET("/v2/accounts/:address", wrapper.AccountInformation, m...)
+	router.GET("/v2/accounts/:address/proof", wrapper.AccountProof, m...)
 	router.GET("/v2/accounts/:address/transactions/pending", wrapper.GetPendingTransactionsByAddress, m...)
 	router.GET("/v2/applications/:application-id", wrapper.GetApplicationByID, m...)
 	router.GET("/v2/assets/:asset-id", wrapper.GetAssetByID, m...)
 	router.GET("/v2/blocks/:round", wrapper.GetBlock, m...)
 	router.GET("/v2/blocks/:round/transactions/:txid/proof", wrapper.GetProof, m...)
+	router.GET("/v2/compactcert/pending", wrapper.GetPendingCompactCerts, m...)
+	router.GET("/v2/compactcert/:round", wrapper.GetCompactCert, m...)
+	router.GET("/v2/compactcerts", wrapper.GetCompactCerts, m...)
 	router.GET("/v2/ledger/supply", wrapper.GetSupply, m...)
 	router.GET("/v2/status", wrapper.GetStatus, m...)
 	router.GET("/v2/status/wait-for-block-after/:round", wrapper.WaitForBlock, m...)
@@ -616,177 +779,225 @@ func RegisterHandlers(router interface {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9/XPbOJLov4LTXVWSOdFyvmY3rpq654kzs36byaRiz96+i/PmILIlYU0CXAC0pMnz",
-	"//4KDYAESVCSP5JM5vRTYhEfjUZ/obvR+DhKRVEKDlyr0dHHUUklLUCDxL9omoqK64Rl5q8MVCpZqZng",
-	"oyP/jSgtGZ+PxiNmfi2pXozGI04LaNqY/uORhH9WTEI2OtKygvFIpQsoqBlYr0vTuh5plcxF4oY4tkOc",
-	"noyuN3ygWSZBqT6UP/N8TRhP8yoDoiXliqbmkyJLphdEL5girjNhnAgORMyIXrQakxmDPFMHfpH/rECu",
-	"g1W6yYeXdN2AmEiRQx/Ol6KYMg4eKqiBqjeEaEEymGGjBdXEzGBg9Q21IAqoTBdkJuQWUC0QIbzAq2J0",
-	"9H6kgGcgcbdSYFf435kE+A0STeUc9OjDOLa4mQaZaFZElnbqsC9BVblWBNviGufsCjgxvQ7IT5XSZAqE",
-	"cvLuh5fk6dOnL8xCCqo1ZI7IBlfVzB6uyXYfHY0yqsF/7tMazedCUp4ldft3P7zE+c/cAndtRZWCOLMc",
-	"my/k9GRoAb5jhIQY1zDHfWhRv+kRYYrm5ynMhIQd98Q2vtdNCef/oruSUp0uSsG4juwLwa/Efo7KsKD7",
-	"JhlWA9BqXxpMSTPo+8PkxYePj8ePD6//9f1x8l/uz+dPr3dc/st63C0YiDZMKymBp+tkLoEitywo7+Pj",
-	"naMHtRBVnpEFvcLNpwWKeteXmL5WdF7RvDJ0wlIpjvO5UIQ6MspgRqtcEz8xqXhuxJQZzVE7YYqUUlyx",
-	"DLKxkb7LBUsXJKXKDoHtyJLluaHBSkE2RGvx1W1gpusQJQauW+EDF/T7RUazri2YgBVKgyTNhYJEiy3q",
-	"yWscyjMSKpRGV6mbKStyvgCCk5sPVtki7rih6TxfE437mhGqCCVeNY0Jm5G1qMgSNydnl9jfrcZgrSAG",
-	"abg5LT1qmHcIfT1kRJA3FSIHyhF5nu/6KOMzNq8kKLJcgF44nSdBlYIrIGL6D0i12fb/ffbzGyIk+QmU",
-	"onN4S9NLAjwV2fAeu0ljGvwfSpgNL9S8pOllXF3nrGARkH+iK1ZUBeFVMQVp9svrBy2IBF1JPgSQHXEL",
-	"nRV01Z/0XFY8xc1tpm0ZaoaUmCpzuj4gpzNS0NV3h2MHjiI0z0kJPGN8TvSKDxppZu7t4CVSVDzbwYbR",
-	"ZsMCralKSNmMQUbqUTZA4qbZBg/jN4OnsawCcPwgg+DUs2wBh8MqQjOGdc0XUtI5BCRzQH5xkgu/anEJ",
-	"vBZwZLrGT6WEKyYqVXcagBGn3mxec6EhKSXMWITGzhw6jPSwbZx4LZyBkwquKeOQGcmLQAsNVhINwhRM",
-	"uPkw01fRU6rg22dDCrz5uuPuz0R31zfu+E67jY0Sy5IRvWi+OoaNm02t/jsc/sK5FZsn9ufeRrL5uVEl",
-	"M5ajmvmH2T+PhkqhEGghwisexeac6krC0QX/xvxFEnKmKc+ozMwvhf3ppyrX7IzNzU+5/em1mLP0jM0H",
-	"kFnDGj1NYbfC/mPGi4tjvYoeGl4LcVmV4YLS1ql0uianJ0ObbMe8KWEe10fZ8FRxvvInjZv20Kt6IweA",
-	"HMRdSU3DS1hLMNDSdIb/rGZIT3QmfzP/lGUew6khYKdo0SngnAXv3G/mJ8PyYM8EZhSWUoPUCarPo48B",
-	"QP8mYTY6Gv3rpPGUTOxXNXHjmhmvx6PjZpz7n6npadfXOcg0nwnjdnew6dieCe8fHjNqFBI0VDswfJ+L",
-	"9PJWMJRSlCA1s/s4NeP0OQWHJwugGUiSUU0PmkOVtbMG6B07/gX74SkJZETF/Yz/oTkxnw0XUu3NN2O6",
-	"MmWMOBE4mjJj8Vk9YmcyDdASFaSwRh4xxtmNoHzZTG4FdC1R3zu0fOiOFtmdV9auJNjDL8IsvTk1Hk+F",
-	"vB29dAiBk+YsTKgZtbZ+zcrbO4tNqzJx+InY07ZBZ6DG/dgXqyGGusPHcNXCwpmmnwALyox6H1hoD3Tf",
-	"WBBFyXK4B35dULXoL8IYOE+fkLO/HD9//OTXJ8+/NRq6lGIuaUGmaw2KPHR6hSi9zuFRf2Uo4Ktcx0f/",
-	"9pk/QbXH3YohBLgeexeOOgcjGSzGiPUXGOhO5FpW/B5QCFIKGbF5kXS0SEWeXIFUTETcF29dC+JaGDlk",
-	"7e7O7xZasqSKmLnxOFbxDORBDPPmnIUqXUOhtikKO/T5ije4cQNSKem6twN2vZHVuXl32ZM28r11r0gJ",
-	"MtErTjKYVvNQR5GZFAWhJMOOKBDfiAzONNWVugcp0AzWAGM2IgSBTkWlCSVcZIahTeO4fBjwZaITBX0/",
-	"OhQ5emH1zxSMdZzSar7QxJiVIra1TceEpnZTEtQVauDoV5/ZbSs7nfWT5RJotiZTAE7E1J2v3MkPF0nR",
-	"LaN9xMVJpwas+kzQgquUIgWlIEtceGkraL6d3WW9AU8IOAJcz0KUIDMqbwmsFprmWwDFNjFwa3PCHUr7",
-	"UO82/aYN7E4ebiOV5oxpqcDYLoa7c9AwhMIdcXIFEg9nn3T//CS33b6qHAidOA18zgrDvoRTLhSkgmcq",
-	"OlhOlU62sa1p1DITzAoCTolxKg484CB4TZW2R3TGMzQZrbjBebAPTjEM8KBGMSP/zSuT/tipkZNcVarW",
-	"LKoqSyE1ZLE1cFhtmOsNrOq5xCwYu1ZfWpBKwbaRh7AUjO+QZVdiEUS18xHVPqz+4tAdb/TAOorKFhAN",
-	"IjYBcuZbBdgN3ccDgJjzRd0TCYepDuXUPuvxSGlRlob/dFLxut8Qms5s62P9S9O2T1xUN3I9E2Bm1x4m",
-	"B/nSYtYGDhbU2HY4MinopdFNaKlZX0IfZsOMiWI8hWQT5Ru2PDOtQhbYwqQDRrILTQazdZijQ79Rohsk",
-	"gi27MLTgAYv9rfWAnzfeoXswWk5AU5ar2jCp3ezNLOiR72ZLGCtSQgpc52tDqzMmCxvUQnWm/G/W7Mnc",
-	"LDZ807Afz4iEJZWZb9E/LQWLSRjPYBWXrrTlG8lgRVgc6Fk9M9Mk9SEnHg5wEGV0G8RLc6EYnyc2OrhN",
-	"qdVBvQeKVJw5BbYE6eCagXRqV/voWKKFj6BtgmMTKpxz5jZIMF3j01rg7G6pWBAVPxhGLFgqBbWxUYPU",
-	"zgKJhIIa6DBK59T+8JybkP3SfvehWu8iD2k3Pq6n10EJU5PocoGbZURtF4kh1ZujLSgYWsg8F1OaJ8bg",
-	"hySDXG91vZmDBJxgS6OvRdrv3gb54uJ9nl1cfCCvTVs8WwC5hPUEI9YkXVA+hyaMEPKLPTXACtIqVC0d",
-	"NO50EHS+0jb07aPgeFQKkSf1kbcb9uipmy7eL1l6CRkx8gpZzGnBB+0dMpOQh4bEVR0YWi7W3oQsS+CQ",
-	"PTog5JgTKEq9dv6VjsXTmZw/0JvmX+GsWYUxasoJLvLggsddGzbCfUee8sNs5iSb8nXHqewgmyfSKz7A",
-	"TnSJARozXJQ/N3pHz7BnoPp6Gj0gKgvFLj6EHzEPirZ2mWV4HGm0m6qmBcNkqKDZ2EhOH5/un/CZPiDk",
-	"HGWHOWApuAJJc8z0UN5xzBQpmDmoqypNAbKjC560IElF4SZ+2PzXiqWL6vDwKZDDR90+Shtz1Z0lLQ90",
-	"+35HDsf2E6KLfEcuRhej3kgSCnEFmT2PhXRte20d9l/qcS/4zz3BTAq6tic5z4tEVbMZS5lFei6MXJ+L",
-	"jtXJBX4BacADo2YVYXqMqgwxita63ZeGAUdR6+k+fD6RUY2dblSpkXY+KtmmHUVgRVOzSopCZm0tgprO",
-	"+kaQFmUSDhB1QW+Y0QUBVEuO35Lv+vLcOiA2w3fecUG00BGQ68F2272HjCgEu7D/MSmF2XXm8o98kkrO",
-	"lO4B6dwRGAGqCTKidA7I/xEVSSnyb1lpqM92QuKBCQ/SZgbUsX5OZ6k1GIIcCrAeIvzyzTfdhX/zjdtz",
-	"psgMlj5pzzTsouObbywTCKXvzAEd0lydRgwodMwbbRpJtF5QtTjY6qTHcXfyzQdDn574CZGZlEIVYxYu",
-	"hZjdw2pZtoraLLCKrdTtHLrbHihS0vWgeV0aACPZWiAvc/Tli1mHIomTfwtWmiGbzJK1hlZW6v99+B9H",
-	"74+T/6LJb4fJi3+ffPj47PrRN70fn1x/993/a//09Pq7R//xbzHjRWk2jcd9/kLVwkDqJMeKn3IbuTWW",
-	"Jzrs1s4PIGafG+4OiZnN9JgPlrQL0b2NbQgzpgRuNtLcWVWW+foelIwdiEhwZwzVco8q+1XMwqRUR3lq",
-	"rTQU/QiD7frrwOnnnfdO9KhU8JxxSArBYR29h8E4/IQfo7YhiqWBzqgghvp2vTct+DtgtefZZTPvil/c",
-	"7UAMva1TZO9h87vjdoJLYTounmwgLwklac7QdS640rJK9QWn6JzrmN4dsvAux2F37UvfJO4fjrhv3VAX",
-	"nCqDw9plFw06ziDijP8BwHttVTWfg+qY4mQGcMFdK8bR0YJz4UkmsRtWgsTo8IFtaazPGc3Ru/wbSEGm",
-	"lW6re8watNa0jXSZaYiYXXCqSQ5UafIT4+crHM6fqj3NcNBLIS9rLAx4BYCDYiqJC9If7VeUp275Cydb",
-	"8QqH/ezlzedWAB72WE6bg/z0xJnCpydo7zQxrh7sny3wUTCeRInMHFELxjE1ukNb5KGx2jwBPWqiZW7X",
-	"L7hecUNIVzRnGdW3I4euiOvxouWODtW0NqLjx/Zr/RA7Ys9FUtL0EvNPRnOmF9X0IBXFxB8BJnNRHwcm",
-	"GYVCcPyWTWjJJqqEdHL1eIs5dgd5RSLi6no8clJH3Xummxs4tqDunHUEyf+tBXnw46tzMnE7pR7YBFc7",
-	"dJCZGDm1ufuVLQeCWby9oGUzfM0B+gRmjDPz/eiCZ1TTyZQqlqpJpUB+T3PKUziYC3JE3JAnVFP0O3W8",
-	"6UN3KNEn6KApq2nOUnIZquKGNYecsRcX7w2BXFx86MWb+4rTTRV3cOMEyZLphah04iISw76rxr+HI1tf",
-	"8KZZx8SNbSnSRTzc+ANO97JUSeCFjS+/LHOz/IAMFcFOmK9IlBbSC0EjGZ0fzezvG+Ei7pIu/a2RSoEi",
-	"/13Q8j3j+gNJnM/nuCzRxYs+1v92ssbQ5LqE3f20DYjNYLGzPS7cGlQ3zmHFQc9sLx+4UHHMmU+IOmxj",
-	"pELjh74tnsxQfxG52dxboykYI4qdSi8Sw1PRVSlDWsgPwV1fOjey0MedFZtzQ3zu7tkUSLqA9BIyDLqh",
-	"X3rc6u5TG5xm8SzLlL0uZlNV8U4DuiCmQKoyo073Ur7uJpcr0Npn1L+DS1ifi+ZKxE2yya/HIxdISgzN",
-	"DDFIafARKAExa7OLD0Z1Nt/FFTHYU5bExlNsFrAni6OaLnyfYQaymukemCdGFDUaNtB7SWUEEZb4B1Bw",
-	"i4Wa8e5E+tHoDZWapay0698tHvS21ccMsk2oR8W4mHWldU+YRqW3bZxMqYoLbjBfzH4YHupmEfmZrDfP",
-	"BogJlhxwhDvNIYhkKsfZVKKx45dt71APgRanEpC80aYejDZGQrW9cCF5dtUE4tHVsouC2xoINVTkc2VY",
-	"O+TBzLw5XNHB6NPgXZ/TIAEmuEJa3+Txgq3LDOP6Vpet5uBv/PhrPv5uz2h8o3s645HLyYxth+Co3TPI",
-	"YU5dsAWzPX2g34L2QAUbZOD4eTbLGQeSxHJpqFIiZTb+3shyNwcY4+8bQqxjhew8QoyMA7DRS40Dkzci",
-	"5E0+vwmQHBi6takfG/3bwd+w3cvblNVwZuVW868vOxomGjfX3uw29r0/41FUJA1Z5q1WxDaZQu8oEyNR",
-	"I5r6/pC+10VBDqiOk5ZkTS5jXjJjVQCS4ZnvFpjr5CGbGSX/KAhWSJibs3dzXjXc6h0wn9dncCU0JDMm",
-	"lU7wqBxdnmn0g0Jj8AfTNC5+Wqgi9l4+y+LSB6e9hHWSsbyK77ab968nZto39blFVdNLWKOSAZouyBTr",
-	"SBgt1JretNkwtc0n27jg13bBr+m9rXc3WjJNzcRSCN2Z4yuhqo482cRMEQKMEUd/1wZRukG8BBkwfdkS",
-	"5N7YPB3M6TnYdFrvMdONs4gGJa8dKbqWwNDduAqbbGbzyYIyDP27DQM8QMuSZavO2dmOOhAuQwP+Boa6",
-	"tfgjIaBRPdgWDATn5Fj6rAR/1rdbGuhMW1Cjl2K4HTPdxMZAIIRTMeXLQfURZUgbM8C24eocaP5XWP/N",
-	"tMXljK7Ho7sd+WO4diNuwfXbenujeEYfsj0CtjxnN0Q5LUsprmieuOtjQ6QpxZUjTWzub5t9ZlEXP36f",
-	"vzp+/daBjxmTQKVLFNy0KmxXfjWrMifiWLbgeeAZQWvVn52tIRZsfn2HN3Sm+OTOli1npJgjLstetYIL",
-	"WdE5V2bxUNZWV0mYEHorzmxllN7VMxeml94ry/c4LE6hzQ5vkQvhXBsKgBS2xo0igneTaowZh6dMJJeC",
-	"rs0uWsdsX0DwqkgMCyQqZ2ncdcCnynARrwq8WLXWQLDxgEFoRqzYgPucVywYyzRTO0SKOkAGc0SRiW6d",
-	"DbibClecsOLsnxUQlgHX5pN0SXYtZjG84fPG+yotnqPuBnZp6vXwd9HzZqghDY9AbFbyoZc3ckPCH/r8",
-	"Qmv3tPkhcM7dIEgTzthTSxsCLI4+HDXbSPei7a0Nawn2ZZAhDFt3ZnshQ+86WFhAB+aIFiYclNjHw9Ia",
-	"7x7sLqcbsYzghgLZ5oPSXInIMBVfUm7rjJl+FoeutwJ7bje9lkLihT0F0Qg1U8lMit8gfpqcmY2K5P05",
-	"VKLJhr0PIhehukK09ow0FSQ9fkM4Bkl7yJoKPpJ2EG2Aw5HKA/c1JjJ7JxPllqxtTbRW6DbOHGG6xcSO",
-	"3zCHg7mXopLT5ZTGyoMYo8bAdNwESlruMC2I7+x3QdX5+472gphL3ZbZW24lyCY5t3+j+pYGytdF8hmk",
-	"rKB53DuaIfbb158yNme2sFylIKhc5gayFTktFbnqbzYU1aDmdEYOx0FtRLcbGbtiik1zwBaPbYspVWBv",
-	"WYU3r1xSkAauFwqbP9mh+aLimYRML5RFrBKkNiLthRrvf56CXgJwcojtHr8gD9HzrtgVPDJYdLbI6Ojx",
-	"C0zJsH8cxpSdqyC5Sa5kKFj+0wmWOB1j6MGOYZSUG/UgeuPSlv0dFmEbuMl23YWXsKWTett5qaCcziEe",
-	"US22wGT74m6i466DF57ZmpVKS7EmTMfnB02NfBpIyzLiz4Lh7mcUhoG0IEoUhp6asmR2Uj+cLYDpSgV5",
-	"uPxHDHOU/p5N59D6eZ20VpfHVo3BqDe0gDZax4Tai8l4VchdaHcC8WCgpgvIq/gkcmCDvd50fclDLnhS",
-	"GN7JHjUJfwH9RUuaCE3z6LTay65u5srmoXc1tcwoySBiqxZiaSCTbo3iSsbXSSsz1S/vXjvFUAgZq0/S",
-	"SEOnJCRoyeAqyrHdxLXaMqnVhcd8zED5vmJ59rcm3bRTCkxSni6i/s+p6fhrU7uwRrvFevTa54JyDnl0",
-	"OMvLv3qej0ilf4hd5ykY37Ftt8SXXW5ncQ3gbTA9UH5Cg16mczNBiNV2/l2dOJLPRUZwnqbAQEMI/Xt5",
-	"Qbmjf1agdOwOIX6wuU54xjb2iq22Q4BnqO0PiL1zZ2Bp3ZpCLcuKKrc3cCCbg3QOmKrMBc3GxIxz/ur4",
-	"NbGzKnd/HO96YbWfub2/2VpF52wVVCO5yYXWodSo3cfZnDNiVq00liNQmhZlLOvVtDj3DTC19oqy3Kcf",
-	"oPoJsXNATqzmV16v2Emae8ukns7JGqQJ8x+tabpAldpSQMMkv3uZKk+VKijXWle+rAuK2Ku4WvhKVbZQ",
-	"1ZgIY/csmbIlp+EK2om2dda5M+l84m17ebLi3FJKXD9tuBVxG7R74Gxgz7ukopB1EH9DNaNEJVO4adWu",
-	"M+wVvdfXLQHWq9Nqb/jUdRL9UwIp5YKzFG/VBUWua5Bd+epdfKY7XEDsHpc9izsOjTBXtPBYnTrgsDhY",
-	"iswLQoe4vsMo+Go21VKH/VNjnWRzEJyDVk6yQTb2xeXcOY5xBa5ADFYyD+SkOY5344fR0EZTIuKGZITp",
-	"fwPmyg/mG5oqzKXsXDKOF6Yd2lx2kD1pYXVdbY53TJO5AOXW074mp96bPgd4VSyD1YcDX40Xx7AuZLNs",
-	"G7PoD3XsIxguYmDavjRtCbqLm59bqYZ20uOydJNGb7nVOxwrjzeI4IgXPPFuyAC59fjhaBvIbWPoEfWp",
-	"ITS4wsAFlKiHe4QxUHbhlTnUWoqyt7dtyD96NYPxCBivGYemVnREQaRRlYAbg/w60E+lkmprAu4k086B",
-	"5hgpiQk0pZ3r6K5DdTYYUYJr9HMMb2NTJHFAcNQNGsON8nVdotpQd2BMvMTa+A6R/ZKHaFU5IyrDpK5O",
-	"EcSY4DCC25cPbSuAPhv0bSLbXUtqOecmmmgoCT1jyhxHimkeSWM5qT8GhUAxX266xn9jl96HV+ACa7cu",
-	"0oIdb2xfbi6Ykpu9TxSb33JXmv73uC0dHgj3KEb9r4xYCe/t9OoXWMFTX6vBEL7wZZnxUFEnprdpFgVd",
-	"9NDWVNjdfGgdrpU7RtE4kMjzrrkxSq30tb7BoXSedDD7jGqXWqop2VS5yBa4jY1g45C2sK59pCbqGBiK",
-	"PdrQo/nc672b3dCzwnDsjQj1Qe0+QH/1WSukpMw5vhsW6WPW5bf1Mw53yXxpNri7CJc1hoPEVnLLJK+d",
-	"eK+PpQhjh6kBW8jzsoVSexukY0kKCfeM2kCF3hC1/aSHXZeH60CKqRT017nzBrRwO4D7XRDfyIU+cofZ",
-	"WU93Yed4Ur3pjvLEIsRf++hLk88mDVp1ud28sV3/25D3wJ6QBxxVHZxWLM+2bW7L7dhcZ0bH2q/Tb5+1",
-	"vHef80L1rzYg32c3d7f0Joq/uwmImMhaW5MHUwUOxR18ia5bxHOI9cfSSjK9xtwdb2myX6N5yT8Cd9XJ",
-	"3WMPdQTUBeDsO0PONT2vWzdPw/wobLn2wpi/aApqLMzzakWLMgfHF989mP4Jnv75WXb49PGfpn8+fH6Y",
-	"wrPnLw4P6Ytn9PGLp4/hyZ+fPzuEx7NvX0yfZE+ePZk+e/Ls2+cv0qfPHk+fffviTw/8uywW0ObNk79j",
-	"1YHk+O1pcm6AbXBCS/ZXWNt7xoaM/Q1mmiInQkFZPjryP/0vz2EHqSiCpyTdryPn6R8ttC7V0WSyXC4P",
-	"wi6TOZaKTLSo0sXEz9Ovg/T2tHbQ2oA/7qj1vRlSwE11pHCM3969Ojsnx29PDxqCGR2NDg8ODx5joZAS",
-	"OC3Z6Gj0FH9C7lngvk8csY2OPl6PR5MF0Fwv3B8FaMlS/0kt6XwO8sBd5TY/XT2ZeP/O5KMLcl+bUeex",
-	"rCZf3q32L/ZvOI+tw8KcWepybsFlHuXu+IzJ1ObvEFdRkGfoAbS5GUa01cg6zYKHa4MXUsatd3fff0VP",
-	"ycVqjcWuisceB66zzIcfhwrez/RvZj7/83Uk0PSh8+DPk8PDT/DIz7g1isfLLV8LenaPILZPUHcGtDtc",
-	"Tyr8RHNDN1A/ADnCBT3+ahd0yvE+hxFbxIrl6/Ho+Ve8Q6fcMA7NCbYMUkj6ovAXfsnFkvuWRiVXRUHl",
-	"GhVucJE8NK2uB0VuO3nL3cgblsMQVL0LLvG2HNvTtaezMVF1kfNSMmEMB3wuNYNUAkU1LyTGg5r6ee6q",
-	"Itiq7j8d/x29xz8d/90Wpow+JRlMb4u0toX4j6Aj9R2/XzfPoW2U6F9KTI5/t69vfj06766qZl8l9Kut",
-	"ErqD0N7v7r4G7FdbA/brNklXdeItJVzwhGNRgysggVtrb6P+rm3U54dPv9rVnIG8YimQcyhKIalk+Zr8",
-	"wuuMoLuZ4LXMqXiQo7VR/vRePGis6MB8DwosTT62HlTJtjtPWjeys1bdexp/kDaoPeOyQcfNNVPKM5vJ",
-	"4WO1auyvW6K3zt5rtvsx7l3GPIgZ6UGo5fv16ckudnlrTcENtJht3sLXzZ65/qQei1s/FvwpNUAPju9p",
-	"RnzK6CeWzbsJ02eHzz4fBOEuvBGa/IBJZp9YpH9SP0GcrAJhg0XMJh/9ZbUdBIy7CNoWLd0XpmNCxXDo",
-	"2OXsuzLN9YMvRp5YQWjv4valhplhV3nRv6sakxTN/bzfi4y40QPee7mwlwu3lgtdgmokgn1tdPIRE2xD",
-	"cdBjSXwo4A8UKAmq50lR+PItgsxApwv3PHsnlh0RKz4xeVimbLpWeGf5sn+c/y6P8+9wINkj+C4I7gm1",
-	"V+5NeMtebhFfu+Mj0JYkIW/QHEIG9znXf0S3x6fUyJ96QW8EBwIrprCqpqXFfbixNhfqZ5HqtxLCivcD",
-	"pkM76PhRr1h2PakfThoyKt669302GhWNpmY8eBI6dK/QsgQq1a2V9PZw2HlnxtOTsAykqFOdCG2eT4qA",
-	"YvByw0jiv+8SRvzjRuv2b3zt3/i63Rtfn/XI3CTkWFHl40SyIzW+6Hlaf5Hz9BvBE9S2wLW3/Fpo+XJn",
-	"a7zW0qrH7i8pB6/nm99DOaAOdlKvMBhKaAkVTOkcJmOnbN2b+JOP+B9MBr1u0i7tjfyJdbNt0rf2NbXR",
-	"vSZQ7F/A+wpewPvyLrw7maOd1Uoo6yQ0jNYj/Tfc4itg98tCtzOTXXO1qHQmlkEec/PSwCAn2Rb3yklv",
-	"RAZ23HYuf78IDLUvLisPRIeBahkRL3rmsdm0s9femXLPzae0mi+0rXoULalWd0xoagk/sceB+IRN0oRt",
-	"5R96vwJCcwk0W5MpACdiahbd7CsusvNWgpOE8SIyDVylFCkoBVkSlhXZBFqdVY7+QL0BTwg4AlzPQpQg",
-	"MypvCawVCZsB7VY/qsGtvT6O6/tQ7zb9pg3sTh5uI5XQPLunBWbV5OCegoqgcEecoKnKPvH++Uluu31V",
-	"iZUrIm9x2q/nrMBrbpxyoSAVPFPRwbCg/Ta2xTcUg7UosEXkPKd8zrcabQX+oRthZuT4I6R2DfXLG3VN",
-	"GWtpQRategarDXO9gVU9l5jFXjm1JQ63jTyEpWD8usqMrj0SVAceCTNcZHH47D11hlek7HAIRIOITYCc",
-	"+VYBdsNj/wAgTDWIrl9EaVNOUH5QaVGWhv90UvG63xCazmzrY/1L07ZPXC4RHOV6JkCFZraDfGkxawtI",
-	"LagiDg5S0Etnoc9dPnYfZsOMiWI8dW9EDL2cxAo4M61CFtjCpF0jL2T/zuOeLebo0G+U6AaJYMsuDC04",
-	"Zlb+LozAm57yuv6DT+j2bJvVgXnVmJX278mSMp3MhLQaM8HSqZEIanv2/6RMu4K97gyshXNbuuKrVqC4",
-	"cYLyaSpMZnWvWjk+Mrvfz58wU/0g5E4B28a3qgUxCyMV18xft8PXD72N+fuLfu6t5731vLee99bz3nre",
-	"W89763lvPX9q6/nLZGCSJPFy2l+viV2uIaOv0sL/iu6vfM4LJ43RX5v8eEgwJrrh442ZGRpoPnFFSzGE",
-	"LtRgindYADU10zFOypziqwwr7S8a44MM3z7ziQJ1KT9bA8nIGtPg6RNy9pfj54+f/Prk+bdG+tj3OFtt",
-	"H/oC8kqvc3jkMtjqAic+lQ04xZqBmMlG/ekn9VkO1pqfsRwIPsv9CpufwBXkxpS3sU5iDiP949E50Pyl",
-	"Q46VSqD09yJbdwjHrH+CqGiTTBMwZ5zKSBnOPqH0kKwFluJ1dWV7J6jre82ZiOcJ9Dds214NvBcQJe9N",
-	"9LI1L8BVUHdj7xIjM3vq0UlcCc8vKrIJQuTIrBFPv5tM+u4zWo5xsK2xKhz/fa1Z7x7xUcZDth0bmsyq",
-	"FPBVVEdxq8Q0mgNPnFhIpiJb+ye0XEXglpS1pVqHheyrFaSV4SWExLHBQ/XIPUCNJadDV0+0VH7w8gPg",
-	"eM2jiZ9bcNqqoxvl5u2po/2GwZ1zJrvD9aVGkHTxUEgyl6IqH9nHmvgaj8RFSfnau8GMrYiPIOB745jn",
-	"fb+Sui4A3ZOzu9fwD88reGm/+7tFC1lS5Qv4Z7aCf7yKYbfO/HaMN1WUt1W9s+uNVnwfqO/e30S/yy7R",
-	"sXb9lSATveKRusudKsv7y1X/I1TCWymumDk4RyVsPwurEQgHWzWDDEQWqoZOqQ2vG9ry9B1dhoU7dpWp",
-	"q8QZnne2ShdgH0X1VlqkLonRl1LQLKUK74+4pzE+scWqV6cRvwOCifWl+pm+RoFvf/8Ix93JnmxnersJ",
-	"sQCMsoU0v6x12WSbHrvrOi1s7F0BfxRXwPee+RShRNJllzmD52p2EFN0qVc8KqUmzWO+0Yy3gCHq1z/v",
-	"MXbXG74dwgue2bQhCMhLQkmaMwxQCK60rFJ9wSm6QMPnTfvhPe/YHTalXvomcS98xEnuhrrgFB+kqx2j",
-	"UZNqBrHHWwC8xaaq+RyU7kjiGcAFd60Ybx6/K1gqRWLzPo26NhL9wLYs6JrMaI4+/N9ACjI1p4iwZgk6",
-	"FJVmee7iiWYaImYXnGqSgxH6PzFj0JnhvM+pjpG7Z5I8FuIXK1xF2YEXG3+0X/HSglu+9xuhe8t+9tnQ",
-	"4y9T9zn6ELOD/PTE1RM7PcESMU0ksQf7ZwsvFYwnUSIzGt9F5Lu0RR661z+RgB41MUm36xfcGNNaEBT0",
-	"zdP7NyOHbhigx4uWOzpU09qITrTAr/VD7C7rXCTmyIjPTIzmTC+qKVZe9ndcJ3NR33edZBQKwfFbNqEl",
-	"m6gS0snV4y32wR3kFYmIq73m/uM48bvPQ9cbb4zY3t4P6OV7KN/6+67ZujVFaV8hdV8hdV9Dc18hdb+7",
-	"+wqp+/qh+/qh/1Prhx5stBBdzY2tFf1aN40z+6q9hNTOXAvwsFmr9l8/LMn0ASHn+GQ9NToArkDSnKRU",
-	"WcOI20y5gs0XmqgqTQGyowuetCCxb6ebiR82/7XH3Ivq8PApkMNH3T7WbxFI3n5fNFXxk33A8DtyMboY",
-	"9UaSUIgrcJXAsHlWYazY9to67L/U4/4se1tX0LV1rixoWYJRa6qazVjKLMpzYQ4Dc9HJ7+MCv4A0wNlC",
-	"E4RpW3QV8Yl5kS47h7rb5jGju6/fb/DwzXGHXPZFTT6FgX0CmrJc1bcTIucpPNl0KWtJVcO6tVTx5QxA",
-	"+d9cwNrNkrNLCHNwMftgSWXmW0Qfnm3K7PqHlfuupXb90QxW3iToAj2rZ2baVgw1B87eU4B9z5at4pnm",
-	"wpxZE/vA07bMdnwxyvR7oNBrahkN7VWEawbS5d6jNysXChItmkrNw3BsQoUruXgbJKjBIjUWOLtbKva0",
-	"IX4wIhG9whSdwojUzgKNUKEGOonXkGzu//Ccm5D90n53r23VXsGODz4yrqfXwTTjmkSXqFxQ6nWRGFL9",
-	"jLgKCQOOaPt4sE3kuPUTwp3uvdcZ8+zi4gN5bStl49Oil7Ce2Eft0gXlc1A1jkJ+sVeHbHpPkF/eQeP9",
-	"PVtstFcy8OD4aT/nvIv3S5ZeQkaMvEIWc6nwkcMEeViX/Z0xlORrf4/EqsNHB4QccwJFqdfEStiOz7sz",
-	"OX+gN82/ChV4WzNG0hdTYFcg78hTfpjNnKTAMNwdp7KDbJ5Ir/gAO9Fl5Gi9ax3IyEm6c64NiMpCcR8O",
-	"ir123GvHvXbca8e9dtxrxz+8duw5pfZum8/htvnijps/UA3sfbnr39mCwmTW1nsWd/Bm1692x6xx56du",
-	"XsUPX5lHL2P9vvz7D9cfzDd55R2QzaPpR5MJWhULofRkdD3+2HlQPfxoRCmd2xGcg6+U7Aqr1X+4/v8B",
-	"AAD//8YbKCxl8wAA",
+	"H4sIAAAAAAAC/+y9e3fcNpIo/lWwvXtO7GxTkh/JTrwnZ3+KnczoN7HjE3se90a+GTRZ3Y0RG+AAoKSe",
+	"XH33e6oAkCAJdlMP23FGf9lq4lEoFAqFev4yy9WmUhKkNbNnv8wqrvkGLGj6i+e5qqXNRIF/FWByLSor",
+	"lJw9C9+YsVrI1Ww+E/hrxe16Np9JvoHZs7j/fKbhH7XQUMyeWV3DfGbyNWw4Dmy3FbZuRrrMVirzQxy7",
+	"IU5ezK52fOBFocGYIZQ/yHLLhMzLugBmNZeG5/jJsAth18yuhWG+MxOSKQlMLZlddxqzpYCyMAdhkf+o",
+	"QW+jVfrJx5d01YKYaVXCEM7narMQEgJU0ADVbAizihWwpEZrbhnOgLCGhlYxA1zna7ZUeg+oDogYXpD1",
+	"Zvbsp5kBWYCm3cpBnNN/lxrgn5BZrldgZ+/mqcUtLejMik1iaSce+xpMXVrDqC2tcSXOQTLsdcBe1say",
+	"BTAu2Y/fPWdPnjz5Chey4dZC4YlsdFXt7PGaXPfZs1nBLYTPQ1rj5UppLousaf/jd89p/jd+gVNbcWMg",
+	"fViO8Qs7eTG2gNAxQUJCWljRPnSoH3skDkX78wKWSsPEPXGN73RT4vk/6q7k3ObrSglpE/vC6Ctzn5M8",
+	"LOq+i4c1AHTaV4gpjYP+dJR99e6XR/NHR1f//tNx9r/9n188uZq4/OfNuHswkGyY11qDzLfZSgOn07Lm",
+	"coiPHz09mLWqy4Kt+TltPt8Qq/d9GfZ1rPOclzXSici1Oi5XyjDuyaiAJa9Ly8LErJYlGEOjeWpnwrBK",
+	"q3NRQDFnQrKLtcjXLOfGDUHt2IUoS6TB2kAxRmvp1e04TFcxShCuG+GDFvTrRUa7rj2YgEviBlleKgOZ",
+	"VXuup3DjcFmw+EJp7ypzvcuKvV0Do8nxg7tsCXcSabost8zSvhaMG8ZZuJrmTCzZVtXsgjanFGfU368G",
+	"sbZhiDTanM49iod3DH0DZCSQt1CqBC4JeeHcDVEml2JVazDsYg127e88DaZS0gBTi79DbnHb//83P7xi",
+	"SrOXYAxfwWuenzGQuSrG99hPmrrB/24UbvjGrCqen6Wv61JsRALkl/xSbOoNk/VmARr3K9wPVjENttZy",
+	"DCA34h462/DL4aRvdS1z2tx22o6ghqQkTFXy7QE7WbINv/z6aO7BMYyXJatAFkKumL2Uo0Iazr0fvEyr",
+	"WhYTZBiLGxbdmqaCXCwFFKwZZQckfpp98Ah5PXhaySoCR8g94Ag5DRwJlwmawaOLX1jFVxCRzAH7k+dc",
+	"9NWqM5ANg2OLLX2qNJwLVZum0wiMNPVu8VoqC1mlYSkSNPbGowO5h2vj2evGCzi5kpYLCQUT0gGtLDhO",
+	"NApTNOHux8zwil5wA18+nV3t+zpx95eqv+s7d3zSblOjzB3JxL2IX/2BTYtNnf4THn/x3EasMvfzYCPF",
+	"6i1eJUtR0jXzd9y/gIbaEBPoICJcPEasJLe1hmen8nP8i2XsjeWy4LrAXzbup5d1acUbscKfSvfT92ol",
+	"8jdiNYLMBtbka4q6bdw/OF6aHdvL5KPhe6XO6ipeUN55lS627OTF2Ca7Ma9LmMfNUzZ+Vby9DC+N6/aw",
+	"l81GjgA5iruKY8Mz2GpAaHm+pH8ul0RPfKn/if9UVZnCKRKwv2hJKeCVBa+1Ussf/Qf8Hc89uIcBDiVy",
+	"jpg9pDv02S8RVJVWFWgrINaLpLmhv3nDFc58Y1Zwy+eMG7bmZk2cxiqvaRAkjbQMYGuh83j4Pw/+5xk+",
+	"Gnj2z6Psq/88fPfL06uHnw9+fHz19df/t/vTk6uvH/7PfwyeGPg+LFV+liEsw2W8ECswNmhCqGX4o33e",
+	"OCaTXFHUqOQLKD/86mja9P70gfM3kVJLdsEN2/ACGF9xIY09SA1NfC498lqUhQaJuAKer5lUBTDlbhTs",
+	"xpZabegvrZRtNU2CBFf8vycWOtUWNkRtzX/+Q8Ny9mz274etzu7Qkag5fKsFEHk/RyBmVw3gXGu+Tf2N",
+	"IKQ4u7K0nwE6D5FhG9BnpYP2V7rpI9flW8I3XlhqmYaUvW0XSueUCUMtG32gMH6IjZC1+7bgJZc5sFKp",
+	"swXPzyJiaS6z+cwqy0tzPU7h+vwqkXwVX+U/NYyww07C6ZsHkYAobd7IHR4lLdt2TyHHtnv6mXhP3OPT",
+	"H1W1dGpdI5QMQluKVA9wE/wwN+L8uw6dHxchx1nace5+prZnEk/tZyakIwhqOncqwbuHB0dNQoIf+jB8",
+	"g8RxB/cuEdnwLNHwbA28AE2kcjDr01ZaeKGOf6B+CGYOOsERf6D/8JLhZxTCuA2vd2QcwjBhmIrsDAU+",
+	"+B1FupmwAWLFKrZxb3xWdRnGfiift5MPTqFDy5Tz9K3nNP5Kd4toduit2MAbyzfVD8vlTYlmyOXcXFZs",
+	"wODYTNHgpA1iBZxDqaoNSMs2eFnijYl46e6765IevtUaGMiVLMieA0W4UC9QQZATCG5XAhz4ViDQTIpx",
+	"9zDsAUih+GoeaVyPF0rfBd6OZczpOY7aaI6G6KGmdZV54kroolyD3kCt6W43k+8PvxcLbyx/D1gwlkfA",
+	"3wIL3YHuGgtqU/HcPocbYmAX043GTrJelrsGHU7FZeElThRfhCUhN0flpN6QZEHnPxra3AGjRgBGhJ4E",
+	"jGbOlC5At4ohB6xdw9a323Yk4slI6ku9/T0lMKcwzjHIWa7OAemFcaa59CowhN40eBUl3AFK0y811BU9",
+	"ecze/OH4i0ePf378xZc4f6XVSvMNQ3nQsAf+ic6M3ZbwMCk4kwYlPfqXTxv5tDNuahyjap3DhlfDoZyS",
+	"28nfrhnDdkPUd3fIC5QewEk7BXhVO7QzZ79B0F7A+UtVACmuzB3dbCU3/hXMLrSwFkgnM/lWm/pcoWnc",
+	"LeqnmXBjucFHmNQLvdW1vAOiBK2VTihkaalW5arMzkEboRK2tde+BfMtwpur6v/uoCW+hXOTraCWBejk",
+	"8xyNAJNfzW7ot5eyJZSdDMOtN7E6P+8UAu0iP6ieDatAZ/ZSsgIW9SqWoJ3WgLOCOhJbeQX2Qumz1wD6",
+	"rog5V1JCjritcNQhudLPkzEbQbgXq27kEVp9pQoUSm19FwttB2vxjjQXY5svVG0Zd8obQ43TssaITwEh",
+	"k2ywNhZf7No9BBaAt0XO69XaMlTvqhQVtx0znjtMZU5a3ScFu1ZuOmevLjXwYssWAJKphbdz+IuWFsnJ",
+	"PGo76pG6SqozIrgqrXIwBoosvLv3gRbatWqwMTwR4ARwMwszii25viGwpHPYAyi1SYHbvOuEHIF62vS7",
+	"NrA/ebyNXAML54VZRbdbCRbGUDgRJyi4oJHkve5fmOSm21dXIy5MXprHNyvui+RS+QdgcjC8RLN9xxYb",
+	"xWsx4K70cFLSmmZjx8y033NjnalMyMILjLYrOOAU4wCPXp448p/DvTkcO1fSgDS1aS5RU1eV0haK1BrQ",
+	"vjo+1yu4bOZSy2js5qa2itUG9o08hqVofI8sEz0CuPW22saWPFwcucXgPbBNorIDRIuIXYC8Ca0i7MZu",
+	"HCOACNMi2hGOMD3KaXxH5jNjVVXh+bNZLZt+Y2h641of2z+1bYfExW3L1wsFOLsNMHnIL/wDhZ6Ga26Y",
+	"h4Nt+BneTSTmO5veEGY8jJkRModsF+WTKglbxUdgzyEdeXB7F8Fott7h6NFvkuhGiWDPLowteERYQXHn",
+	"Gy7vSiZbcCnHBbIFv4ak6yHbK4vRoKOrIz+bO9YVeO+drNEZTFxPH5YJYmY80dRXf+M5wHJVluBM7+GI",
+	"hGvMD51UEZDA7sF925rv74BEXoDlojSNxBqgiJwEyGWq786OLykNOUhbbltV0DzIOY16iKBghZ/F+de1",
+	"fFkWTMMF10VoMaTQaDGZkAVcpsmcd6wXBVwykQZ62cwsLMuDT6CMB0hb4ZyXZV4qgwTg3Df3STuN1+Vn",
+	"htVSeMnmArSHawlat3rm4C4YXBx3wbELFd58chMkYNf0tA44t1sm5eVKH5iQbCNyrbhzXkWk9hbINGw4",
+	"QkdulJGxOj3nLmQ/d9+DL23wYYppNz1uoNdsr/7kYk2bhXdwH4kx1aPCDAyMLWRVqgUvM2O5hayA0u7V",
+	"0+ILE15QSxTkVD7s3gX59PSnsjg9fce+x7b06AR2BttDcilm+Ro1i62fV3xe3HMSLiGvY5mjh8ZJLNVb",
+	"M7vQ970GKqXKrFH79P3SBnJIH+9nIj+DgiG/CnZ1qQr4rLtDOAl7gCRuGs+9i/U2vC2qCiQUDw8YO5YM",
+	"NpXdeiV+TxTuTS4/s7vmv6RZi5p8MbhktMiDU5lWmDoX5FueqTDM7pPkYnJuOZUbZPdE9lKOHCd+Qfcg",
+	"FDFOp9ov31DP6Oob3swtUTkoplzOv6dAFd7ZZVE4u2Jzu5l6sREUrRI1mzNhGwfioepH2AOGHiEa6OVt",
+	"4Bw06pS5cY8A7+6/EajBMXWeAxTPTmXWgSRXGz/xg/a/ji2d1kdHT4AdPez3MRbfMV7J4M5Av+/X7Gju",
+	"PhG62NfsdHY6G4ykYaPOoXAP9ZiuXa+9w/5bM+6p/GHAmNmGb90TP5xFZurlUuTCIb1UyNdXqvcckYq+",
+	"gEbwAK9Zw4SdewuVMO4Z5/alPYBp6ekuJOzEqEy4oAzkdsFttEs7hsElz3GV3DgDFUkEDZ0NhSCrqiwe",
+	"IGnn3DGjN9ObDh+/4bkb8nOnmdoN39uebqqDjohcJ5glBshIQjDJNYhVCndd+ACREEVQCu+5GAPp9VTl",
+	"NoA7cukcsP+lapZzOr9VbaF59CtNL2nsSzMIE83pJbUWQ1DCBpzqkL58/nl/4Z9/7vdcGLaEixBV9fnn",
+	"Q3R8/rk7BMrYW5+AHmleniQEKDL34W2aiIRFe9zBXls5jTvpgRUNffIiTEiHyRi6YnDhd+S1K4rLpMwC",
+	"l6mV+p0jPexnhlV8Oypek0daIpzGeaE1/modDur431pUH95N0lixSFuT/+D9Pj3nuJQn0vlWoeRJmtyt",
+	"VxCp5Uf2PMTNDJiPljSF6F6nNkRIxt1mE82h/q/c3sEl4wZiGvwbw3T05sZ9Vcs4atBTntkaC5uh6cl1",
+	"/Xnk9fNjUFsNqFTJUkjINkrCNhkoLyS8pI+jfqxjnemCGOvbV+t14O+B1Z1nymbeFr+02xEbet3EMN6F",
+	"Dq83bs/qGMdL0ssGyopxlpcCpNMuW13n9lRy0tr2RO8eWQRd9Lge/3lokjYcJPT6fqhTyUnT1ehyk4b3",
+	"JSSsNN8BBHW+qVcrMD1RnC0BTqVvJSQpWmgueslkbsMq0ORzcuBaovS5RLc+q9g/QSu2qG33uqewLidN",
+	"OxMoTsPU8lRyy0rgxrKXAs3+OFx4VQeakc5y3WBhRCsAEowwIwEUv3dfiZ/65cc+9b5z4Dcf+gIIsIti",
+	"FPKTF14UPnlB8k5r/BzA/sEsYhipmCQy8qUXkmJXe7TFHkhlGwJ62JpR/a6fSnS5sAqDt0XB7c3Ioc/i",
+	"BmfRnY4e1XQ2omfgCGt9l3pir1SG/sPk5DhbCbuuFwe52hyGJ8DhSjXPgcOCw0ZJ+lYc8kocmgryw/NH",
+	"e8SxW/ArlmBXV/OZ5zrmzt0i/cCpBfXnbEyL4W+r2Ge///YtO/Q7ZT6j3fRDR6FjiVeb+9BVIODiXQYN",
+	"F4KJD+gXsBRS4Pdnp7Lglh8uuBG5OawN2mQoiuRgpdgz5od8wS0/lQMWP5rkJop9YFW9KEWOysPU0RxT",
+	"xp6e/oQEgirIviPC8OJsgz4SCm6aIMNQDVXbLJhcRnVXrX6PRqbeO2edMz92x+7ixx9RuleVySItbHr5",
+	"VVXi8iMyNIw6ueATY5UOTFCYAA3t7yvlXTFQTeaOKasNGPa3Da9+EtK+Y5nX+RxXFal4Scf6N89rkCa3",
+	"FUzX07YgtoOl3va0cCdQXTvKhAZ943oFw4VJYw4/EeqoDXKFVg99UzzhUH9QJW7ujdEUjZHETm3XGZ6p",
+	"5KoMkhadhygZkw8L9A4JqKpB4vPJQTCMfA2oXiajG+ml553uatm5WcKRFcbl83DBJBR0TioIzPNRFdzf",
+	"vVxu+9G/BqwNIc8/whls36o2Zv064b5oVnGGpAxpZuyAVIiP6BJAVWt8XPwY/c33dkWElFcVc/YUF6cT",
+	"yOJZQxehz/gBcjfTHRyeFFE0aNhB7xXXCURQhzEU3GChON6tSD+1vIprK3JRufVPswe97vTBQfYx9SQb",
+	"R7/0LrceMNMk93aNM3RFT24H4BfcDzxDffeyMJPT5jkDMaOccJ5wFyVElkzjTzbXJOyEZcvVLtDSVAJa",
+	"trdpAKOLkfjaXnuTvDhvDfGkaplywe01hCIVBScq0TV5CJy3hHM+hv/xZAwnkWdUlOOndZjwjK1/GOZN",
+	"2g0XXhtSMoQ8DCH5wmx+rUQK85l31k1th5J0uxdQwop7Yws27kU5f2aiDUI4flguSyGBZSknK26MyoWz",
+	"v7e83M8BKPx9zphTrLDJI6TIOAKbtNQ0MHul4rMpV9cBUoIgtTYPY5N+O/ob9mt527yHXqzcK/4NeUd7",
+	"iNr4YL+NQ+1PE8j7us/GkpJ5pxVzTRYweMqkSJQJmdCHDLUuBrxHUNbhrNkZbNNSBRAZvoHGkagR19kD",
+	"scRL/mFkrNCwEsZC+14NYegfXmdwrixkS6HR7w6fysnlYaPvDAmD32HTNPvpoIq5xGmiSHMfmvYMtlkh",
+	"yjq9237eP77AaV+10aD14gy2dMlQLoYFJfpTy9702GbH1M7RcOeCv3cL/p7f2Xqn0RI2xYm1UrY3xydC",
+	"VT1+suswJQgwRRzDXRtF6Q72EnnADHlL5Hvj/HTIp+dg12t9cJiu7UU0ynndSMm1tIDuXoVzNnP+ZFGe",
+	"vGHQy8gZ4FUlisve29mNOmIuwymuI6g7iT9hApo1g+3BQPROTvlVawhvfbel0Z3pkk4MXAz3Y6bv2Bgx",
+	"hHgqYUK+3iGikLTJA2xv8hfg5R9h+2dsS8uZXc1nt3vyp3DtR9yD69fN9ibxTDpk9wTsaM6uiXJeYTI5",
+	"XmY+KHWMNLU696RJzUMM6wdmdenn99tvj79/7cEnj0ng2jsK7loVtas+mVVp4FbpkQMS8oGitBrezk4Q",
+	"iza/ybIRK1OCc2dHlkMu5onLHa/mgouPoleuLNOmrL2qktgh9EYnMx7g1pq52L30To/84ISlKbTd4T18",
+	"IZ5rR4bGjUtCakLerMipBsU4nMGRC5oBF+AVs0MGIetNhkcgM6XI06oDuTB4imS9weGxMaPGIwIhjliL",
+	"EfW5rEU0FjabklekB2Q0RxKZJpkFpcXdQvns8bUU/6iBiQKkxU+6SQMRHRY8G8FvfHilpX3U/cDUJxr+",
+	"Nvc8DjV2wxMQuy/5WMubiJAIj76w0EY9jT9EyrlrGGniGQfX0g4Di6cPT83O0r3uamvjZO9DHoSE4RKD",
+	"7s80H1QHawfoyBzJzPGjHPt4nFtj72vw6ZYtE7gxQ3b+oLw0KjFMLS+4dImgsZ/Doe9twL3bsdeF0hTJ",
+	"aSBpoRYmW2r1T0i/Jpe4UQm/P49KEtmo90EiQq7PRBvNSJviP+A3hmOUtMekqegj6xrRRk44UXmkviZH",
+	"5qBk4tKRtUta3THdpg9H1MIcuvHbw+FhHriolPwCE+WlhRqE6bg1lHTUYVax0Dnsgmn89z3tRTaXpq1w",
+	"4Y8V6NY5dxhqf0MB5dMi+QJysUlmIjw9/akg7HfDnwqxEi7zd20gSi3tB3IlExwV+fTczhTVouZkiV7l",
+	"bfJ6vxuFOBdGLEqgFo/mPpmioVvLdiKvvFOQBWnXhpo/ntB8XctCQ2HXxiHWKNYIkS6gJuifF2AvACQ7",
+	"onaPvmIPfE7Bc3iIWPSyyOzZo6/IJcP9cZS67HyK/118pSDG8hfPWNJ0TKYHNwZeUn7Ug2QorqvLMs7C",
+	"dpwm13XKWaKWnuvtP0sbLvkK0hbVzR6YXF/aTVLc9fAiqVEBxmq1xRiN5PxgOfKnEbcsZH8ODB+fQSl6",
+	"rGJGbZCe2rzRbtIwnEsQ6u7hBq7wkcwcVZPAtfto/bBKWneXp1ZNxqhXfANdtFKOUXKSFG1OEM8QD0Yy",
+	"RYE+T0+iRzY43Ju+L7pkyWyDZ6d42Dr8RfSXmpgMaclpbeBdfc+V3UNPFbVwlGwUsXUHsTziSTdGca3T",
+	"6+Q1TvWnH7/3F8NG6VTimpYb+ktCg9UCzpMntu+41kgmzXURMJ8SUL6pRVn8uXU37YWnay7zdVL/ucCO",
+	"P7fJ5Ru0O6wnwz7XXEqX0nl4g9NZ/jmc+QRX+ruaOs9GyIlt+4Hzbrm9xbWAd8EMQIUJEb3CljhBjNWu",
+	"/13jOIK+fIzmaTNPtIQwjMvrJvhLlkG6WebAeSLv37Rcx/GEI7XWPizvRECmhBQrE1Ka+oSztDMR2vz/",
+	"x7QI14hf7uRfd/mjQoR+b9Ix/4RzGE0+TYFm2jDXKGJN7aidtIyVMuJa4cSdTJg4R8ohhaAosgvAYMpd",
+	"mZFci4CSAPwA9yUXmx32PG2y9uIfzU8ZCQY4gesYpu7gx3Isa9KkQ+rQhZLdlJEMYdGorfV1Y1qK+zjm",
+	"XB2cA6+L/VGETPGNBm1nndM2PBIt5fYJJLWL6cW8280DPUUmOKEjrc6x4Cn2OGR+4YSkERm+dkk4vN48",
+	"SttlpWl4106lTsiEDWmgns92oC4kLfxHDSZVNcB9cK6yliq0KO0TFjKQBT0WD5gL2UbwOkG39EgTm7p0",
+	"AZxQrEB7/X1dlYoXc4bjoGGBuVldHx8qTAkTVy78v3MJJot2TE8x02R3T3vWTh9nt8shrtrYrElMnQqa",
+	"wBZvQwOKzDjnogzea/R6ibFzwF64h6MJPMxNEt3ezXReVCWRAv9jLc/X2EB1uNK4xDQ902cQakxUjsn/",
+	"P28TlRGPQrh9sk+X63POFD6bL4RxJeUwKL8jFAUwwgEIcRvd5elaSkcpB9eoINGkJbsu2gNw/j6XOyDr",
+	"If6arxSXUve6iU/fUK8UUQ6yqA7qMLkA0SYRfigVmnOppMgpKDsqYteA7MvTTTG5TYhfT5ejMDN/QhOH",
+	"K5m7tfE881gczeY6n3UQN7Q3RF9xUx11uD8t1UFDKW4F1njOBsU8JCv2akAhDfjEc0hEMZ9UumPGJA6Z",
+	"tIy3GYauSUYkfI+8dr/Db/TSFd7j80w4IdWjzRG0cIo6qp5l1yCZsGylwMT1jto1/YR9DijSuIDLdweh",
+	"2haN4SyQuGxn8h4OdRwM4N7gjG2fY1tG1sb2546nupv0uKr8pOOprJPPSXspRxGcMKJmwYoVIbcZPx5t",
+	"B7nt9Fyh+xQJDc7J7g0V3cMDwhjJ2vMt6kQdRVEL5jzGkpF9QibA+F5IaGvBJS6IPHkl0MbQeR3pZ3KN",
+	"PnvTSyABL8nQnnxoEL6zvSsIAll0cuchP1Rb1yNkQr+IdTvc+A7p1Vhv+rjtWnoURisiJIc5xumoTXQ9",
+	"wrmaBq3iAeNOwqnE4xVJM8+p+KZHxTBtNYl1XooryCm5l8g6xbnw5gg1Fro30PAcDoUy191qnkOn74Sr",
+	"cCyIqhCGGwObRZlww3zRfIzS4+OO4LMB/00lbRlfgXcMuXGSMep4bQF3d8KvEvc+wyiAm+1K2/8Ot6V3",
+	"BuI9SlH/t1orHcedDvLvOM7XhIWSC5oKhX/oVdMEVnVpFr+llY5tGZLdStfxgiJz4s0jjqg/thkPuONW",
+	"zrY15o6aj3pPc+tDIyxnuzLvubIPqRGcHw1991Wwk4rtMd8Z5zqDnwe9pwkuAzGQxt6J0OCUNQToj8Hr",
+	"klVceMNte0SGmPX+2UMVyxTPzXaD+4vwXs80SGolcYL9IUVTztgop79VTdqjg+mBxW+HwYs47n8TToTM",
+	"1cYlPaVZhJLGWWc1bJQd9GybJaljUZdn2T9qqCErQa7GaixGGdLr8izU0zLsggvr343BMG5VA/EOgs6E",
+	"3DeTn8QTeAjTatV++2ZQtb3eFNPAbxGaJesd4Qz48GFQ8ooy6AuZQ28vSIQBY/miFGbta89HeZrTUxdC",
+	"wwhD+UucJVsVMCRCIs0HTNV2pXDLHs6Z0u2XJNGyBy29PTyIIsPCr7P5LAyYjA1bi9U6q7RQWtjttegM",
+	"e7LQ83YEJ6SxXOYw8sJ6S5UoXBOGTZiGOJf4YOjBbXMNah4j5DnNxVeT9fwRI3rLV8/HVGYNhNNPw/Ag",
+	"vC/gKopJo5LVVouxkgOtZQYbubMV1+ipKMRZmou4lJUDe8qhGtWfvY2URoPqOBJWykaO8zvIZDSKZMh7",
+	"I+YYc7EB04lZwc5D1iXRHjm0a99z0zV7OJWAyChvhFyVEOimnzfpGsOl983yVXoA3y1MvMf2HnJq8FUS",
+	"CyGBe+Kuj9LEs7UydrhK/DUN48nr9p7W3s3RWMd9oit/xBmEmzF6VcslSAPNAHTPeECXShPL/2zDZc3L",
+	"z1DjIGzcwq61qleOmI9fn6TFSWlFuePW6xSOWHDJ4LISGgwdRn8QoysRKpWvJ9hLCJXN2gMY6R0bpKgf",
+	"tcTuzDCftD/5xV1w55eFMjEUbAuJ3UcdGchsgvHIrhsgoGgBMy6NllUMLnNosyd14HEXoYPjhnHqajk0",
+	"o/p6SW6isZFbSPcd5WhN0UKpNs2+odG3b5PZtTCZVAVMyR+fxCVVGPe8us0/PTUs9YYW8xQkdAgpg5pY",
+	"tpKWoETZ5F3SeHTudTXwBvmRJ0SbXUEZiFGBN2ULWsffIMqjf8OCEGQ/0ONJbz+k2bt7BCOLQ0u4u0ht",
+	"aAcPCO8tZhof8pgZsX53eJE/7LdlQdd5Yg5M11GOwT0nMH58tEu54Dc6dJGr7UTW2U7Ji6I1AnY9au7k",
+	"xI2KcgPC2UEYN4xznnQch4qWxDmMo+P2aLjOOloZlxCpZw1TGu5YOxNp4a+pnRnG/U1dHq2DzlxtYLjO",
+	"yRvQwe0I7qcgvlUtDpE7rhG0iykawXReGexOKkmHkJD5aHjpfDCFYqfgrZ83uetaAGUXfr4WZZH0s8QP",
+	"Tqajo+9dAipuKTdFG5Rm2Malj7Za+BzSQ6l+ZxJnYj04XaNeaYu3+M8+Tbnn8CXw5QF7KQzVZWlEPIK4",
+	"BO54k6KKP94Ght48fowP78K2o4oOzt9fo88uIVftqtJ194Avd18sDiPCeJSN1XcbiWWk8VPE8+ex579z",
+	"ERlx9O0dyNrT3S7O0HHbbtPBkmPyz4svn3a8nz9kQtqfXUDjkFc7WK9leOqf4Nrt92CtncmjqSKH7Am+",
+	"2L7bQbKetYG8RoUIxT4HS6f4OZnXBdPvugrH/vnTRJD5ACar0OPUu/avmta1CZT9e+XqX2+4LJwp0lJh",
+	"g28vOVYN9Uz1688W/wVPfve0OHry6L8Wvzv64iiHp198dXTEv3rKH3315BE8/t0XT4/g0fLLrxaPi8dP",
+	"Hy+ePn765Rdf5U+ePlo8/fKr//pshkdw9mzmAJ2F6JPZXylrc3b8+iR7i8C2OOGV+CNsXZ5WJOOQAZbn",
+	"xMaRO5WzZ+Gn/y+wZ8xt2w4ffp35SInZ2trKPDs8vLi4OIi7HK6o1FZmVZ2vD8M8wzoSr08aD0XHCGlH",
+	"nfMZksLBrCWFY/r247dv3ga1RKO0mx0dHB08wvFVBZJXYvZs9oR+otOzpn0/9MQ2e/bL1Xx2uAZe2rX/",
+	"YwNWizx8Mhd8tQJ94FPh4k/njw/D5XD4i5f4rnDUVSoqPJTHaRzshhli517U5E3ZlU5SOuNzpM3ZwsU/",
+	"M1+RSRbkAudiW81sPmuQheUkQhadk5ZRhRBul1fm2U+JzORLsXIvwojRNuZYd5iYMMyVkdfspVOtvY5C",
+	"Cg4CQf6jBr1tCcZBMYsTogTjgXdG87EJCbvB1TxVqyWVapdmxn2OKLURyltOZHUNMSQtX0VeeZR99e6X",
+	"L353lVAWvpvPAjqIkh4fHd1ZAuXG0fVq3hkl4OUGA+FQT+8QxK4F/9aA9ocbcIWXvPTPWd4m/nh69OiT",
+	"XdCJpHxYyLaYY8tX89kXn/AOnUg8OLxk1DIKwR2ywj/JM6kuZGiJV3K92XC9pQs3SsQbi1ZXoyz3sKn3",
+	"8p4Yr3M44aZjXIrKUpd8AWWrPkDVxtxnTfLVZlywkA+I7hYvT74vqL0b1oUgGHRDpqhKN2Cb47gp6B2S",
+	"IfMhaE2Vee/wZXVtnJ611jmEDKyNL3XjVkfLsZpj5fN2UHMwdsW8Ds+iXZfLp8qxk9EL04LrYjqi+FMU",
+	"dxuX4rXb9A//YiNkJxdATyoNsknzM3gcNzZqrZRt6zUJaAKv2yTCzRthmqKk+2hPqYt7fyMIqSABZTtV",
+	"PlIn7cMjfarRJaYZd/IJu4RukSja3znvyyiLJKvK2nijmxMbS6XOMB/HFEVmU1vAn0zCdVjFpLpwMQ/t",
+	"MEW1ZD69Q1veK71H71l4+fjSxjTx4OnR0w8HQdi3UN6BN+SjIVe61WJ1S9vTbRFfFu9brnmvgkhLqUNx",
+	"wF+3o3c4npSdYksnZ49PxDwuxUBU7DDK3R4PEpnp5sw0jkrBAYQM7QXkGjhpJ8i4N4/KJvoM1SDpvy+P",
+	"/0pRHy+P/+rqkQbJiLzME9O72rxdweD3YBNlPb/ZHjc3+69SVhg8M982SBopu2lVSLtDSNvwy6/HUHbp",
+	"dBipt/GGX3YexkPu/Ok81W8rb90Xh/1ki8NOeGve7+596d9PtvTvp61Ju2zyrXEmlcwk1bI4BxZZ3e5V",
+	"a79q1doXR08+2dW8AX0ucmBvYVMpzbUot+xPstE+3VJgDzynllFuhZ38p894Os5ujfjeogRF+PavTBT7",
+	"bT5ReyaKORO2lQzjT3EdoKbkkE8CNm+zi3NZuAjsEOJo5iHLNn7y6ezdfswHObgPUkJ65F70zfbkxRS5",
+	"vLOmKPFwSjbv4GuniD64tN6roaXtmbzX0nvzwdUR3/CChVQv/4KKiGgXXinLviPV06esVUiTVcRsjAHS",
+	"FPgcxRMYjM//3WUt7sfdTAVP6Ny71/rq3N6qnAteBkYIJs01cIap/GKYojzFKdq0zL8WHuFq9yXoso/e",
+	"e75wzxduxRf6BNVyBIrAMIe/kNo9ZgeDI/kNtvwN+XdERRO12oQIIsWWYLGIGK6274KXYCttHrsxnrIr",
+	"m/Qdmw4J6CF5fBNH2ZDVZzafpFygjn+gfiFx5nD0H0LyhDheICSLCknTlSy3/pKAojUDu5mwARKoVU0g",
+	"XdU1Iu2H8nk7+dAlsFQdmriONukewbdB8ICpfevt1tQjLOJTV3xEtyXL2CsSh+iAh1xJv0W1x/u8kd/3",
+	"gl4pCQwuhSEvcUeL915SjbjQRmaG0IAVvQZ8Btu06NA1Ov5iL0Ux9J8amvCm+PW0N7WQUaRvNCG+fIBr",
+	"c+NLer857G1vxpMXcfVP1XhoB9PuCCiIl2taEv9zihnxt2ut6xdgvEzWp4bL1lWo3SSviCNKpSDAbbo0",
+	"gEtuqhLhIC+d3b211kejbwC5u1mL6sM7+xgrFrujgprMnSfym+Ywk08f1W9piPQj1oHFzQyYj5Y0RZB4",
+	"ndoQIdGZBBf7MT17HKsKdiLd4xof9T1tP8p7+pWSGd22IG2Q/Dpo+Xhva8CWnTL8IeuKVJbUVkqTkBDz",
+	"AXMw6XqFUVNCPJgPyRslY3/Zkt9TXR3+0jpAXbXRIj5kOwdt9/r7/Bi5Ice5J0JKCrmK4/a9N2YUSI1T",
+	"Y/tzARdzgp78OFNR4+RsnA4aH3PmiWLXncfNnd0cHi9ZTkNP9RkdgrU3Aq070RRmNik3CO2XGzqFbHPw",
+	"69DUfao6MntHe5A4kUPl2viJTB2jXOG1LVd9UXzupU/f1icI7pamCCnSLy3b1KUVVdnEyyZLVswZL5Vc",
+	"tRmm9hYoEenzHJ+YfTp8PwmtM6pPki7F8OtTwk2sjZJ2cLlhPZ5/Pa388whR7kYBiWb9Yh7jyn0JJ4Ku",
+	"m8af2D1Ymypl/bRGXAN1bsNVKCzBX30UXUFaNq7ztUBrOf5smAbL8cYuy2iST50T7mRDvK8JiJiduSmX",
+	"M102RzN48WMjpEvZ54oHXmae+0U5hJouzl3Pjbt10Q3kuGGsqkzwQ14KqqC5X1JxPg5IMNR3o4xlj46O",
+	"OnD/d3hoN1ETmssVNHU/0ZwAlB/bSZJtgU0X7dWQalMgaBcvNVOUJW59DcoIsaOOwQG5N+efSV0JLW4i",
+	"CPzytiDcrZKhIeRk0agB5U6hxBvU0toraF5PwNx95rinWrX0R+/goxidHeYIlPsrLr7iJl1h5v4O232H",
+	"7SF4f50VcL5RBXgFd/vK7v5+qJZLV6V/1+fDX9y/0WPdlb06dD4xu5Tjb1yLO412cGMy3SZ+inOcOJgQ",
+	"Jy9FrhUmwWjcm83WWNgM06q6rj/vKqiUVLgqWQoJ2UbJVHqUH+jrS/qY6u08qEc6ky/7WN8+E+3A3wOr",
+	"O88UTntb/P5KJPvbHbzuavuprR39t8fNJzw+XHAZHbb418Nf1srYq+HHClwqSv+zhpUwFnTWyfaXYba/",
+	"Th4V39ysa1uoiyjrikuDsvNMuhZ3eiZfqQLcuN3MQ8OSvz6XmAlA9I5ioxockV38vrTt/FPehNyrvMYE",
+	"h1TjOlk5temY8dwdoaxhkrtT+FMrNx1lmOWlBl5s2QJAtlkEPIXQInkiFLmu0vnxW7gqrXIwBoosrgK4",
+	"C7TQrg08H8MTAU4AN7P4hLI3BNYxl92A9mtdN+D20z0MoZ42/a4N7E8ebyPXwAIjdSI+Zp2yMIbCiTgJ",
+	"WSfe6/6FSW66ffVY4vjn7itWcOxlgk8Oho+kbN+x7ScFMbiC6KQk63fhwCNX8vfcWF/nUBZeLLLdFxtO",
+	"MQ7waPp6HPnPTfa6wdi5kgakqU1bAtIZWKBIrQGVluNzvYLLZi61jMZuLDhWsdrAvpHHsBSN3xSFbN92",
+	"nZTjOFxicReiLMklOy3BdIBoEbELkDehVYTd2No/AogwLaId4fj3QDIDrrGqqvD82ayWTb8xNL1xrY/t",
+	"n9q2Q+LyepduQL9v7yG/CCof0rhwwzwcbMPPvGFu5cOwhzDjYcwow3u2i/LxWL7BVvER2HNI++JifPw7",
+	"56x3OHr0myS6USLYswtjC04JqJ+kUajvNvAen4pdAT0Sr1oB1f19iHVg0CnS3ZgZ6fH22nb+woU13sbr",
+	"rSfKeyt5TSANwPw4UbVjE8ewOhCC/hJ3f6goxKm+U3qSn3ZU4ERRgRtG1Q381GQDDjLmr8/eci8930vP",
+	"99LzvfR8Lz3fS8/30vO99Py+peePE3jJsizw6ZBVI5VTg927fb3ntBUfMs9EK/Q3Ij89EkINyp0BGRZ4",
+	"SQsSJV2ulTKjkd1R1XOW43RCsqrkQjILlzbkF2MLbuDLp0260VD42pX7QF6DDZ48Zm/+cPzFo8c/P/7i",
+	"yyYxZrftA5+XjRm7LeGhD1xr0rGHCDZv73QBbDy8fvIQ3OCk+aUogRlE1rfU/AWcQ6kq0M7FmeFjZPg8",
+	"wjIozz1y9ryO/oKz+4C5v+Fof5t3HmUebxteNU6BfrHcME4RFgfshSMBQ4MseWngb2MOEG68Da9SKdra",
+	"MhPvHDMFY79RxbZH77hth7SDXUpv3fuF5DpR7D/hLdCnDVfwzVPW8OF3dafOF+mohiGd7SOxdB3DdE38",
+	"XWS+N4qBAG7GnuSOAbwM6GS+Tv9HvWkYQeRPR8tVfzXuF71a/OG8U9vITeJT9VgIiE8ePDq2c6TJos6B",
+	"CeuSPFOsLjZagcw8W8gWqthmHabSvRwKKrU/fjd8ewl5jWeJIPHH4IF5yIQrioIScqyhKmBRr1Z4MQ21",
+	"LcgWgcYTSn4kfv/CrXcX37w5dbjBmzQZt43w7A835BpRiMgDpdlKq7p6SPvB5ZZe8puKy23Q3qGIu6lL",
+	"h0MXlX63nNoFuKQq9IRX5PgD9HW/urFoIlK6vzu0kG+mqkINHFmMlA20l3J6bIcb+u2lbFnwTn87t97E",
+	"6vy8U1h/2GW3Ca3GsgKd2UvpTlTnNPnM+u7oHtyngvnXuBJea3UuCnD0MOCww5ixliEc7L0ZdMSy6Gro",
+	"JQYNd0OXn/7ILyIONJmnXmZe8Ly1VBrKvQUpLZFFFe9LrXiRc0PCu/cTes8Sq708SahLCEzcuERcMl7g",
+	"+wuF07iT5MluXLqfkNLVGlet7ONKl21s7LFPLtLBxr0G47eiwfgmHD5D/rUX/cPplJV0JiewKX5hL2WS",
+	"Sx3S833cUS86EK9dyzs1OQ6G71oeW92Ct5xAWTHO8lKQXUVJY3Wd21PJSXMbLWyYWLrRR4+LUs9Dk7Tx",
+	"IKHb90OdSld5qNHnpisxQ8JS8x1AkNhMvVoBFf2JN3sJcCp9KyFZLYWluTYi1ypzjq94XSNHP3AtN3zL",
+	"luSCrlzl5EVt4zGN04Mai5YBZwbFaZhankpuWQncWPZSoECHwzVlTYNp39Fdg4V0Gghfti9LayF+775S",
+	"igW//LgOjO8cYrfnH6e4ZiaKUchPXvjs5ycvKC6qNYAOYP9gVjGMQEoSGRU8co4EfdpiD6SyDQE9bE2p",
+	"ftdPJQrTVjFi9NzejBz61ovBWXSno0c1nY3oGTnCWt+lMm+tVIZPRr7C31fCrusFlbcMGbkOV6rJznVY",
+	"cNgoSd+KQ16JQ1NBfnj+aI98cAt+xRLs6v7m/u3YHmI6wNPSbLwLl+nt/ci9fAfFZn7dFWb2elbd13O5",
+	"r+dyX/Hjvp7L/e7e13O5r3ZyX+3kX7XaycFOCdFnCN1bfyAeVVAeIs405G7mhoHHzTqVCoZmSWEPGKYF",
+	"0S7hi4Fz0GiN58YJRj5cfiPQl9vUeQ5QPDuVWQcSVzoZJ37Q/tc9c0/ro6MnwI4e9vs4vUXEeYd9SVSl",
+	"T2RqYl+z09npbDCSho06DzWXqXlRk63Y9do77L814/6gB1uHWhhSrqx5VQFea6ZeLkUuHMopORRfqZ5b",
+	"olT0BTQC59JiMuHTpxA+yZ3T7QrjPjdeSuge3u8n7RbuTyTVJZf7FKzvQ8B+AZaL0jRBFYn3FL1s+pSF",
+	"Jtzm6DZcZd5mEvO/eYO1n6UUZxC7DpP3wQXXRWgxFN46RYEwNWxatdStloIZZEUa6GUzs7CuvgkUJHG2",
+	"A6SVia7mSF4qfLNmfDNe1zuS0xAy7PeZIa2pT00E2sO1BO1DBrAljg2ZVW1dqXE4dqHCF4i4CRLMaEpd",
+	"B5zbLZNKCEUfmJBOK8xJKUxI7S0QmQpH6DT+HNX/Ts+5C9nP3XfmvjdawZ4OPjFuoNdsb5ntC7pciOv1",
+	"kRhT/ZL5FBHpCV1hrcw5chRQ2r0SAwZBwQtqidpalQ+7d0E+Pf2pLE5P37HvVR5qeGEZ3MNzXtbA8jWX",
+	"KzANjuLz4iKenHtP5BbfQ+MkLwxfDroLff/Fg7dX1vibDLI/913l+3g/E/kZFAz5VahgPvKYYA+aIkWU",
+	"UfFivQ3hL+46fHjA2LFksKnsljkO29N59yaXn9ld81/GF3j3Zky4L+YgzkHf8kyFYXafJAOyuPVUbpDd",
+	"E6GRL32c+EXiaT21akXiJd1P1NoSlYPiLhQU97fj/e14fzve3473t+P97fibvx2v5vdqm4+gtvnoipvf",
+	"UMWu++Jcv7IFxc6sneqbt9Bm+xsrT0rjXk/tXHoomyCOAHmthd2SlpFX4uczwP+/Q12aAX0eFJC1LmfP",
+	"Zmtrq2eHhyRVrJWxh7OrefzN9D4iK+UrN4JX8FVanFNtvXdX/28AlCnghqszAQA=",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
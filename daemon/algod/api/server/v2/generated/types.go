@@ -237,6 +237,41 @@ type BuildVersion struct {
 	Minor       uint64 `json:"minor"`
 }
 
+// CompactCert defines model for CompactCert.
+type CompactCert struct {
+
+	// The msgpack encoded compact cert transaction fields.
+	Cert []byte `json:"cert"`
+
+	// The round whose block header the certificate certifies.
+	CertRound uint64 `json:"cert-round"`
+
+	// The round of the block that includes the certificate.
+	ConfirmedRound uint64 `json:"confirmed-round"`
+
+	// The signers revealed by the certificate, ordered by position.
+	Reveals []CompactCertReveal `json:"reveals"`
+
+	// The total weight of the signers the certificate claims.
+	SignedWeight uint64 `json:"signed-weight"`
+
+	// The commitment to the voters of the certificate, taken from the block header one compact cert interval before cert-round.
+	VotersCommitment []byte `json:"voters-commitment"`
+
+	// The total weight of the voters of the certificate.
+	VotersTotalWeight uint64 `json:"voters-total-weight"`
+}
+
+// CompactCertReveal defines model for CompactCertReveal.
+type CompactCertReveal struct {
+
+	// The position of the signer in the voters commitment.
+	Position uint64 `json:"position"`
+
+	// The weight of the signer.
+	Weight uint64 `json:"weight"`
+}
+
 // DryrunRequest defines model for DryrunRequest.
 type DryrunRequest struct {
 	Accounts []Account     `json:"accounts"`
@@ -276,7 +311,10 @@ type DryrunState struct {
 	// Program counter
 	Pc      uint64       `json:"pc"`
 	Scratch *[]TealValue `json:"scratch,omitempty"`
-	Stack   []TealValue  `json:"stack"`
+
+	// Line number in the TEAL source, present when the program was supplied as source
+	SourceLine *uint64     `json:"source-line,omitempty"`
+	Stack      []TealValue `json:"stack"`
 }
 
 // DryrunTxnResult defines model for DryrunTxnResult.
@@ -321,6 +359,107 @@ type EvalDeltaKeyValue struct {
 	Value EvalDelta `json:"value"`
 }
 
+// NetworkPeer defines model for NetworkPeer.
+type NetworkPeer struct {
+
+	// The address of the peer; for incoming connections, the remote address of the connection.
+	Address string `json:"address"`
+
+	// The number of bulk messages waiting to be sent to the peer.
+	BulkQueueLength uint64 `json:"bulk-queue-length"`
+
+	// The number of message bytes received from the peer.
+	BytesIn uint64 `json:"bytes-in"`
+
+	// The number of message bytes sent to the peer.
+	BytesOut uint64 `json:"bytes-out"`
+
+	// The time elapsed since the connection was established, in nanoseconds.
+	ConnectionAge uint64 `json:"connection-age"`
+
+	// Whether the node connected to the peer ( outgoing ), or the peer connected to the node ( incoming ).
+	Direction string `json:"direction"`
+
+	// The number of high priority messages waiting to be sent to the peer.
+	HighPriorityQueueLength uint64 `json:"high-priority-queue-length"`
+
+	// The instance name reported by the peer.
+	InstanceName *string `json:"instance-name,omitempty"`
+
+	// The number of messages received from the peer, by tag.
+	MessagesIn []NetworkPeerTagCount `json:"messages-in"`
+
+	// The number of messages sent to the peer, by tag.
+	MessagesOut []NetworkPeerTagCount `json:"messages-out"`
+
+	// The round trip time of the last ping answered by the peer, in nanoseconds.
+	PingRoundTripTime *uint64 `json:"ping-round-trip-time,omitempty"`
+
+	// The network protocol version negotiated with the peer.
+	Version string `json:"version"`
+}
+
+// NetworkPeerTagCount defines model for NetworkPeerTagCount.
+type NetworkPeerTagCount struct {
+
+	// The number of messages.
+	Count uint64 `json:"count"`
+
+	// The message tag.
+	Tag string `json:"tag"`
+}
+
+// PeerBan defines model for PeerBan.
+type PeerBan struct {
+
+	// The IP address or the host name of the peer.
+	Host string `json:"host"`
+
+	// The offense the peer was banned for, or 'manual' if it was banned through the API.
+	Reason string `json:"reason"`
+
+	// The time at which the ban expires, in seconds since the epoch.
+	Until uint64 `json:"until"`
+}
+
+// PendingCompactCert defines model for PendingCompactCert.
+type PendingCompactCert struct {
+
+	// The weight the collected signatures have to exceed for the certificate to be formed.
+	ProvenWeight uint64 `json:"proven-weight"`
+
+	// The round of the block header being certified.
+	Round uint64 `json:"round"`
+
+	// The number of signatures collected so far.
+	Signatures uint64 `json:"signatures"`
+
+	// The number of the collected signatures made with this node's participation keys.
+	SignaturesFromThisNode uint64 `json:"signatures-from-this-node"`
+
+	// The total weight of the collected signatures, or zero if the node isn't building this certificate.
+	SignedWeight uint64 `json:"signed-weight"`
+
+	// The accounts whose signatures were collected, ordered by address.
+	Signers []PendingCompactCertSigner `json:"signers"`
+
+	// The total weight of the voters of the certificate.
+	TotalWeight uint64 `json:"total-weight"`
+}
+
+// PendingCompactCertSigner defines model for PendingCompactCertSigner.
+type PendingCompactCertSigner struct {
+
+	// The address of the signer.
+	Address string `json:"address"`
+
+	// Whether the signature was made with this node's participation keys.
+	FromThisNode bool `json:"from-this-node"`
+
+	// The weight the signature adds to the certificate, or zero if the node isn't building this certificate.
+	Weight uint64 `json:"weight"`
+}
+
 // StateDelta defines model for StateDelta.
 type StateDelta []EvalDeltaKeyValue
 
@@ -348,6 +487,19 @@ type TealValue struct {
 	Uint uint64 `json:"uint"`
 }
 
+// TrieProofChild defines model for TrieProofChild.
+type TrieProofChild struct {
+
+	// Hash of the child, or the remainder of the element for a leaf. Missing for the child leading toward the proven element.
+	Hash *[]byte `json:"hash,omitempty"`
+
+	// The byte of the element selecting the child
+	Index uint64 `json:"index"`
+
+	// Whether the child is a leaf node
+	Leaf bool `json:"leaf"`
+}
+
 // Version defines model for Version.
 type Version struct {
 	Build          BuildVersion `json:"build"`
@@ -422,6 +574,31 @@ type TxId string
 // TxType defines model for tx-type.
 type TxType string
 
+// AccountProofResponse defines model for AccountProofResponse.
+type AccountProofResponse struct {
+
+	// The msgpack encoded account data, as hashed into the trie.
+	Account []byte `json:"account"`
+
+	// Digest of the block of the catchpoint round, as hashed into the catchpoint label.
+	BlockHash []byte `json:"block-hash"`
+
+	// The catchpoint label the proof was made against.
+	Label string `json:"label"`
+
+	// The children of each node on the path from the root of the trie to the account.
+	Path [][]TrieProofChild `json:"path"`
+
+	// Root hash of the accounts merkle trie, as hashed into the catchpoint label.
+	Root []byte `json:"root"`
+
+	// The round of the catchpoint label. The account data is the one of this round minus the balance lookback.
+	Round uint64 `json:"round"`
+
+	// The msgpack encoded account totals, as hashed into the catchpoint label.
+	Totals []byte `json:"totals"`
+}
+
 // AccountResponse defines model for AccountResponse.
 type AccountResponse Account
 
@@ -441,6 +618,13 @@ type BlockResponse struct {
 	Cert *map[string]interface{} `json:"cert,omitempty"`
 }
 
+// BlockTimeStampOffsetResponse defines model for BlockTimeStampOffsetResponse.
+type BlockTimeStampOffsetResponse struct {
+
+	// The number of seconds added to the wall clock when timestamping blocks.
+	Offset uint64 `json:"offset"`
+}
+
 // CatchpointAbortResponse defines model for CatchpointAbortResponse.
 type CatchpointAbortResponse struct {
 
@@ -455,6 +639,16 @@ type CatchpointStartResponse struct {
 	CatchupMessage string `json:"catchup-message"`
 }
 
+// CompactCertResponse defines model for CompactCertResponse.
+type CompactCertResponse CompactCert
+
+// CompactCertsResponse defines model for CompactCertsResponse.
+type CompactCertsResponse struct {
+
+	// The compact certificates, ordered by the round they certify.
+	Certs []CompactCert `json:"certs"`
+}
+
 // CompileResponse defines model for CompileResponse.
 type CompileResponse struct {
 
@@ -463,6 +657,16 @@ type CompileResponse struct {
 
 	// base64 encoded program bytes
 	Result string `json:"result"`
+
+	// JSON of the source map
+	Sourcemap *map[string]interface{} `json:"sourcemap,omitempty"`
+}
+
+// DevModeRoundsResponse defines model for DevModeRoundsResponse.
+type DevModeRoundsResponse struct {
+
+	// The round of the last block written.
+	Round uint64 `json:"round"`
 }
 
 // DryrunResponse defines model for DryrunResponse.
@@ -474,6 +678,11 @@ type DryrunResponse struct {
 	Txns            []DryrunTxnResult `json:"txns"`
 }
 
+// NetworkPeersResponse defines model for NetworkPeersResponse.
+type NetworkPeersResponse struct {
+	Peers []NetworkPeer `json:"peers"`
+}
+
 // NodeStatusResponse defines model for NodeStatusResponse.
 type NodeStatusResponse struct {
 
@@ -523,6 +732,16 @@ type NodeStatusResponse struct {
 	TimeSinceLastRound uint64 `json:"time-since-last-round"`
 }
 
+// PeerBansResponse defines model for PeerBansResponse.
+type PeerBansResponse struct {
+	Bans []PeerBan `json:"bans"`
+}
+
+// PendingCompactCertsResponse defines model for PendingCompactCertsResponse.
+type PendingCompactCertsResponse struct {
+	PendingCerts []PendingCompactCert `json:"pending-certs"`
+}
+
 // PendingTransactionResponse defines model for PendingTransactionResponse.
 type PendingTransactionResponse struct {
 
@@ -667,6 +886,23 @@ type GetProofParams struct {
 	Format *string `json:"format,omitempty"`
 }
 
+// GetCompactCertsParams defines parameters for GetCompactCerts.
+type GetCompactCertsParams struct {
+
+	// The first round to cover.
+	MinRound uint64 `json:"min-round"`
+
+	// The last round to cover.
+	MaxRound uint64 `json:"max-round"`
+}
+
+// TealCompileParams defines parameters for TealCompile.
+type TealCompileParams struct {
+
+	// When set to `true`, returns the source map of the program as a JSON. Defaults to `false`.
+	Sourcemap *bool `json:"sourcemap,omitempty"`
+}
+
 // TealDryrunJSONBody defines parameters for TealDryrun.
 type TealDryrunJSONBody DryrunRequest
 
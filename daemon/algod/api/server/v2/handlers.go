@@ -19,14 +19,17 @@ package v2
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/algorand/go-algorand/compactcert"
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
@@ -36,8 +39,10 @@ import (
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/data/transactions/logic"
+	"github.com/algorand/go-algorand/ledger"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
@@ -46,6 +51,9 @@ import (
 const maxTealSourceBytes = 1e5
 const maxTealDryrunBytes = 1e5
 
+// maxCompactCertsPerRequest is the maximal number of compact certificates returned by GetCompactCerts.
+const maxCompactCertsPerRequest = 100
+
 // Handlers is an implementation to the V2 route handler interface defined by the generated code.
 type Handlers struct {
 	Node     NodeInterface
@@ -66,6 +74,14 @@ type NodeInterface interface {
 	StartCatchup(catchpoint string) error
 	AbortCatchup(catchpoint string) error
 	Config() config.Local
+	AdvanceDevModeRounds(count uint64) (basics.Round, error)
+	SetBlockTimeStampOffset(offset uint64) error
+	GetBlockTimeStampOffset() (uint64, error)
+	PendingCompactCerts() ([]compactcert.PendingCert, error)
+	NetworkPeers() ([]network.PeerStats, error)
+	PeerBans() ([]network.PeerBan, error)
+	BanPeer(host string, duration time.Duration) error
+	UnbanPeer(host string) (bool, error)
 }
 
 // RegisterParticipationKeys registers participation keys.
@@ -142,6 +158,48 @@ func (v2 *Handlers) AccountInformation(ctx echo.Context, address string, params
 	return ctx.JSON(http.StatusOK, response)
 }
 
+// AccountProof returns the account data as of the last catchpoint label, with a proof of its inclusion in the accounts
+// merkle trie committed to by the label.
+// (GET /v2/accounts/{address}/proof)
+func (v2 *Handlers) AccountProof(ctx echo.Context, address string) error {
+	addr, err := basics.UnmarshalChecksumAddress(address)
+	if err != nil {
+		return badRequest(ctx, err, errFailedToParseAddress, v2.Log)
+	}
+
+	ap, err := v2.Node.Ledger().AccountProof(addr)
+	if err == ledger.ErrNoAccountRecord || err == ledger.ErrAccountProofsUnavailable || err == ledger.ErrNoAccountProofCatchpoint {
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+	if err != nil {
+		return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+	}
+
+	response := generated.AccountProofResponse{
+		Round:     uint64(ap.Round),
+		Label:     ap.Label,
+		BlockHash: ap.BlockHash[:],
+		Root:      ap.Root[:],
+		Totals:    protocol.Encode(&ap.Totals),
+		Account:   protocol.Encode(&ap.Account),
+		Path:      make([][]generated.TrieProofChild, len(ap.Proof.Path)),
+	}
+	for i, children := range ap.Proof.Path {
+		response.Path[i] = make([]generated.TrieProofChild, len(children))
+		for j, child := range children {
+			response.Path[i][j] = generated.TrieProofChild{
+				Leaf:  child.Leaf,
+				Index: uint64(child.Index),
+			}
+			if child.Hash != nil {
+				hash := child.Hash
+				response.Path[i][j].Hash = &hash
+			}
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
 // GetBlock gets the block for the given round.
 // (GET /v2/blocks/{round})
 func (v2 *Handlers) GetBlock(ctx echo.Context, round uint64, params generated.GetBlockParams) error {
@@ -240,6 +298,224 @@ func (v2 *Handlers) GetProof(ctx echo.Context, round uint64, txid string, params
 	return notFound(ctx, err, err.Error(), v2.Log)
 }
 
+// GetCompactCert returns the compact certificate covering the given round.
+// (GET /v2/compactcert/{round})
+func (v2 *Handlers) GetCompactCert(ctx echo.Context, round uint64) error {
+	ledger := v2.Node.Ledger()
+	certRound, err := compactCertRound(ledger, basics.Round(round))
+	if err != nil {
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+	cert, found, err := compactCertResponse(ledger, certRound)
+	if err != nil {
+		return compactCertLookupError(ctx, err, certRound, v2.Log)
+	}
+	if !found {
+		err = fmt.Errorf("no compact cert for round %d yet", certRound)
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, generated.CompactCertResponse(cert))
+}
+
+// GetCompactCerts returns the compact certificates covering the given round range.
+// (GET /v2/compactcerts)
+func (v2 *Handlers) GetCompactCerts(ctx echo.Context, params generated.GetCompactCertsParams) error {
+	if params.MaxRound < params.MinRound {
+		err := fmt.Errorf("max-round %d is before min-round %d", params.MaxRound, params.MinRound)
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	ledger := v2.Node.Ledger()
+	certRound, err := compactCertRound(ledger, basics.Round(params.MinRound))
+	if err != nil {
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+	maxRound := basics.Round(params.MaxRound)
+	if latest := ledger.Latest(); maxRound > latest {
+		maxRound = latest
+	}
+	lastCertRound, err := compactCertRound(ledger, maxRound)
+	if err != nil {
+		return notFound(ctx, err, err.Error(), v2.Log)
+	}
+
+	response := generated.CompactCertsResponse{Certs: []generated.CompactCert{}}
+	for certRound <= lastCertRound && len(response.Certs) < maxCompactCertsPerRequest {
+		cert, found, err := compactCertResponse(ledger, certRound)
+		if err != nil {
+			return compactCertLookupError(ctx, err, certRound, v2.Log)
+		}
+		if !found {
+			// the certs are formed in order, so none of the later ones are formed either
+			break
+		}
+		response.Certs = append(response.Certs, cert)
+
+		hdr, err := ledger.BlockHdr(certRound)
+		if err != nil {
+			return internalError(ctx, err, errFailedLookingUpLedger, v2.Log)
+		}
+		interval := config.Consensus[hdr.CurrentProtocol].CompactCertRounds
+		if interval == 0 {
+			break
+		}
+		certRound += basics.Round(interval)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// compactCertLookupError reports a failure to look up the compact cert for
+// certRound. The blocks searched for the cert might be past the ones the node
+// retains, as only archival nodes keep all of them, which is reported as not
+// found rather than as an internal error.
+func compactCertLookupError(ctx echo.Context, err error, certRound basics.Round, log logging.Logger) error {
+	var noEntry ledgercore.ErrNoEntry
+	if errors.As(err, &noEntry) {
+		err = fmt.Errorf(errCompactCertNotAvailable, certRound)
+		return notFound(ctx, err, err.Error(), log)
+	}
+	return internalError(ctx, err, errFailedLookingUpLedger, log)
+}
+
+// compactCertRound returns the round of the compact certificate covering the
+// given round, which is the first multiple of the compact cert interval at or
+// after it.
+func compactCertRound(ledger *data.Ledger, round basics.Round) (basics.Round, error) {
+	hdr, err := ledger.BlockHdr(round)
+	if err != nil {
+		return 0, err
+	}
+	proto := config.Consensus[hdr.CurrentProtocol]
+	if proto.CompactCertRounds == 0 {
+		return 0, fmt.Errorf("compact certs are not enabled at round %d", round)
+	}
+	// the cert for round R covers the rounds after the previous cert round, up to R
+	interval := basics.Round(proto.CompactCertRounds)
+	return (round + interval - 1) / interval * interval, nil
+}
+
+// compactCertResponse looks up the compact certificate for certRound, and
+// returns it with the voters it is signed by. found is false if the
+// certificate was not formed yet.
+func compactCertResponse(ledger *data.Ledger, certRound basics.Round) (cert generated.CompactCert, found bool, err error) {
+	cc, confirmed, err := findCompactCert(ledger, certRound)
+	if err != nil || confirmed == 0 {
+		return
+	}
+
+	hdr, err := ledger.BlockHdr(certRound)
+	if err != nil {
+		return
+	}
+	proto := config.Consensus[hdr.CurrentProtocol]
+	votersHdr, err := ledger.BlockHdr(certRound.SubSaturate(basics.Round(proto.CompactCertRounds)))
+	if err != nil {
+		return
+	}
+	voters := votersHdr.CompactCert[protocol.CompactCertBasic]
+
+	positions := make([]uint64, 0, len(cc.Cert.Reveals))
+	for pos := range cc.Cert.Reveals {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	reveals := make([]generated.CompactCertReveal, 0, len(positions))
+	for _, pos := range positions {
+		reveals = append(reveals, generated.CompactCertReveal{
+			Position: pos,
+			Weight:   cc.Cert.Reveals[pos].Part.Weight,
+		})
+	}
+
+	cert = generated.CompactCert{
+		CertRound:         uint64(certRound),
+		ConfirmedRound:    uint64(confirmed),
+		Cert:              protocol.Encode(&cc),
+		SignedWeight:      cc.Cert.SignedWeight,
+		VotersCommitment:  voters.CompactCertVoters[:],
+		VotersTotalWeight: voters.CompactCertVotersTotal.Raw,
+		Reveals:           reveals,
+	}
+	return cert, true, nil
+}
+
+// GetPendingCompactCerts returns the signature collection progress of the
+// compact certificates that were not formed yet.
+// (GET /v2/compactcert/pending)
+func (v2 *Handlers) GetPendingCompactCerts(ctx echo.Context) error {
+	pending, err := v2.Node.PendingCompactCerts()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingCompactCerts, v2.Log)
+	}
+
+	response := generated.PendingCompactCertsResponse{
+		PendingCerts: make([]generated.PendingCompactCert, 0, len(pending)),
+	}
+	for _, pc := range pending {
+		signers := make([]generated.PendingCompactCertSigner, 0, len(pc.Signers))
+		for _, signer := range pc.Signers {
+			signers = append(signers, generated.PendingCompactCertSigner{
+				Address:      signer.Signer.String(),
+				FromThisNode: signer.FromThisNode,
+				Weight:       signer.Weight,
+			})
+		}
+		response.PendingCerts = append(response.PendingCerts, generated.PendingCompactCert{
+			Round:                  uint64(pc.Round),
+			Signatures:             pc.Signatures,
+			SignaturesFromThisNode: pc.SignaturesFromThisNode,
+			SignedWeight:           pc.SignedWeight,
+			ProvenWeight:           pc.ProvenWeight,
+			TotalWeight:            pc.TotalWeight,
+			Signers:                signers,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// findCompactCert looks for the compact cert transaction for certRound, and
+// returns it with the round of its block, or round 0 if there is none.
+func findCompactCert(ledger *data.Ledger, certRound basics.Round) (cc transactions.CompactCertTxnFields, confirmed basics.Round, err error) {
+	latest := ledger.Latest()
+	if certRound >= latest {
+		return
+	}
+
+	// CompactCertNextRound only moves past certRound in the block that
+	// includes the cert for certRound, so search for that block.
+	var searchErr error
+	count := int(latest - certRound)
+	i := sort.Search(count, func(i int) bool {
+		hdr, err := ledger.BlockHdr(certRound + 1 + basics.Round(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return hdr.CompactCert[protocol.CompactCertBasic].CompactCertNextRound > certRound
+	})
+	if searchErr != nil {
+		return cc, 0, searchErr
+	}
+	if i == count {
+		return
+	}
+
+	rnd := certRound + 1 + basics.Round(i)
+	block, err := ledger.Block(rnd)
+	if err != nil {
+		return
+	}
+	txns, err := block.DecodePaysetFlat()
+	if err != nil {
+		return
+	}
+	for _, txn := range txns {
+		if txn.Txn.Type == protocol.CompactCertTx && txn.Txn.CertRound == certRound {
+			return txn.Txn.CompactCertTxnFields, rnd, nil
+		}
+	}
+	return
+}
+
 // GetSupply gets the current supply reported by the ledger.
 // (GET /v2/ledger/supply)
 func (v2 *Handlers) GetSupply(ctx echo.Context) error {
@@ -740,9 +1016,147 @@ func (v2 *Handlers) AbortCatchup(ctx echo.Context, catchpoint string) error {
 	return v2.abortCatchup(ctx, catchpoint)
 }
 
+// AdvanceDevModeRounds writes blocks to the ledger of a development mode node.
+// (POST /v2/devmode/blocks)
+func (v2 *Handlers) AdvanceDevModeRounds(ctx echo.Context, params private.AdvanceDevModeRoundsParams) error {
+	count := uint64(1)
+	if params.Count != nil {
+		count = *params.Count
+	}
+	rnd, err := v2.Node.AdvanceDevModeRounds(count)
+	if err == node.ErrDevModeDisabled {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	if err != nil {
+		return internalError(ctx, err, fmt.Sprintf(errFailedToAdvanceDevModeRounds, err), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, private.DevModeRoundsResponse{Round: uint64(rnd)})
+}
+
+// GetNetworkPeers returns the connected peers.
+// (GET /v2/network/peers)
+func (v2 *Handlers) GetNetworkPeers(ctx echo.Context) error {
+	peers, err := v2.Node.NetworkPeers()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingNetworkPeers, v2.Log)
+	}
+	now := time.Now()
+	response := private.NetworkPeersResponse{Peers: make([]private.NetworkPeer, 0, len(peers))}
+	for _, peer := range peers {
+		networkPeer := private.NetworkPeer{
+			Address:                 peer.Address,
+			Direction:               "incoming",
+			Version:                 peer.Version,
+			InstanceName:            strOrNil(peer.InstanceName),
+			ConnectionAge:           uint64(now.Sub(peer.ConnectedSince)),
+			MessagesIn:              tagCounts(peer.MessagesIn),
+			MessagesOut:             tagCounts(peer.MessagesOut),
+			BytesIn:                 peer.BytesIn,
+			BytesOut:                peer.BytesOut,
+			HighPriorityQueueLength: uint64(peer.HighPriorityQueueLength),
+			BulkQueueLength:         uint64(peer.BulkQueueLength),
+		}
+		if peer.Outgoing {
+			networkPeer.Direction = "outgoing"
+		}
+		if peer.PingRoundTripTime > 0 {
+			pingRoundTripTime := uint64(peer.PingRoundTripTime)
+			networkPeer.PingRoundTripTime = &pingRoundTripTime
+		}
+		response.Peers = append(response.Peers, networkPeer)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// tagCounts converts per-tag message counts to their REST representation, sorted by tag.
+func tagCounts(counts map[protocol.Tag]uint64) []private.NetworkPeerTagCount {
+	out := make([]private.NetworkPeerTagCount, 0, len(counts))
+	for tag, count := range counts {
+		out = append(out, private.NetworkPeerTagCount{Tag: string(tag), Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tag < out[j].Tag })
+	return out
+}
+
+// GetPeerBans returns the banned peers.
+// (GET /v2/network/bans)
+func (v2 *Handlers) GetPeerBans(ctx echo.Context) error {
+	return v2.peerBansResponse(ctx)
+}
+
+// BanPeer bans a peer host.
+// (POST /v2/network/bans/{host})
+func (v2 *Handlers) BanPeer(ctx echo.Context, host string, params private.BanPeerParams) error {
+	if host == "" {
+		return badRequest(ctx, nil, errMissingPeerHost, v2.Log)
+	}
+	duration := time.Duration(v2.Node.Config().PeerBanDurationSeconds) * time.Second
+	if params.Duration != nil {
+		duration = time.Duration(*params.Duration) * time.Second
+	}
+	if duration <= 0 {
+		return badRequest(ctx, nil, errInvalidPeerBanDuration, v2.Log)
+	}
+	err := v2.Node.BanPeer(host, duration)
+	if err != nil {
+		return internalError(ctx, err, fmt.Sprintf(errFailedToBanPeer, err), v2.Log)
+	}
+	return v2.peerBansResponse(ctx)
+}
+
+// UnbanPeer lifts the ban of a peer host.
+// (DELETE /v2/network/bans/{host})
+func (v2 *Handlers) UnbanPeer(ctx echo.Context, host string) error {
+	unbanned, err := v2.Node.UnbanPeer(host)
+	if err != nil {
+		return internalError(ctx, err, fmt.Sprintf(errFailedToBanPeer, err), v2.Log)
+	}
+	if !unbanned {
+		return notFound(ctx, nil, errPeerNotBanned, v2.Log)
+	}
+	return v2.peerBansResponse(ctx)
+}
+
+// peerBansResponse responds with the bans currently in effect.
+func (v2 *Handlers) peerBansResponse(ctx echo.Context) error {
+	bans, err := v2.Node.PeerBans()
+	if err != nil {
+		return internalError(ctx, err, errFailedRetrievingPeerBans, v2.Log)
+	}
+	response := private.PeerBansResponse{Bans: make([]private.PeerBan, 0, len(bans))}
+	for _, ban := range bans {
+		response.Bans = append(response.Bans, private.PeerBan{
+			Host:   ban.Host,
+			Reason: ban.Reason,
+			Until:  uint64(ban.Until.Unix()),
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetBlockTimeStampOffset returns the block timestamp offset of a development mode node.
+// (GET /v2/devmode/blocks/offset)
+func (v2 *Handlers) GetBlockTimeStampOffset(ctx echo.Context) error {
+	offset, err := v2.Node.GetBlockTimeStampOffset()
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, private.BlockTimeStampOffsetResponse{Offset: offset})
+}
+
+// SetBlockTimeStampOffset sets the block timestamp offset of a development mode node.
+// (POST /v2/devmode/blocks/offset/{offset})
+func (v2 *Handlers) SetBlockTimeStampOffset(ctx echo.Context, offset uint64) error {
+	err := v2.Node.SetBlockTimeStampOffset(offset)
+	if err != nil {
+		return badRequest(ctx, err, err.Error(), v2.Log)
+	}
+	return ctx.JSON(http.StatusOK, private.BlockTimeStampOffsetResponse{Offset: offset})
+}
+
 // TealCompile compiles TEAL code to binary, return both binary and hash
 // (POST /v2/teal/compile)
-func (v2 *Handlers) TealCompile(ctx echo.Context) error {
+func (v2 *Handlers) TealCompile(ctx echo.Context, params generated.TealCompileParams) error {
 	// return early if teal compile is not allowed in node config
 	if !v2.Node.Config().EnableDeveloperAPI {
 		return ctx.String(http.StatusNotFound, "/teal/compile was not enabled in the configuration file by setting the EnableDeveloperAPI to true")
@@ -761,5 +1175,17 @@ func (v2 *Handlers) TealCompile(ctx echo.Context) error {
 		Hash:   addr.String(),
 		Result: base64.StdEncoding.EncodeToString(ops.Program),
 	}
+	if params.Sourcemap != nil && *params.Sourcemap {
+		// the generated model expects a free-form object, so round trip the map through JSON
+		var sourcemap map[string]interface{}
+		data, err := json.Marshal(ops.GetSourceMap(""))
+		if err == nil {
+			err = json.Unmarshal(data, &sourcemap)
+		}
+		if err != nil {
+			return internalError(ctx, err, "failed to generate source map", v2.Log)
+		}
+		response.Sourcemap = &sourcemap
+	}
 	return ctx.JSON(http.StatusOK, response)
 }
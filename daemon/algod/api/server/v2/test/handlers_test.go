@@ -18,11 +18,13 @@ package test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
@@ -30,6 +32,7 @@ import (
 	v2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2"
 	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
 	generatedV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	"github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/private"
 	"github.com/algorand/go-algorand/data/account"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
@@ -88,6 +91,23 @@ func TestAccountInformation(t *testing.T) {
 	accountInformationTest(t, "bad account", 400)
 }
 
+func accountProofTest(t *testing.T, address string, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.AccountProof(c, address)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestAccountProof(t *testing.T) {
+	t.Parallel()
+
+	// the test ledger hasn't made any catchpoint label yet to prove the accounts against
+	accountProofTest(t, poolAddr.String(), 404)
+	accountProofTest(t, "bad account", 400)
+	accountProofTest(t, basics.Address{1}.String(), 404)
+}
+
 func getBlockTest(t *testing.T, blockNum uint64, format string, expectedCode int) {
 	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
 	defer releasefunc()
@@ -105,6 +125,135 @@ func TestGetBlock(t *testing.T) {
 	getBlockTest(t, 0, "bad format", 400)
 }
 
+func getCompactCertTest(t *testing.T, round uint64, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetCompactCert(c, round)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestGetCompactCert(t *testing.T) {
+	t.Parallel()
+
+	// the protocol of the test ledger has no compact certs
+	getCompactCertTest(t, 0, 404)
+	getCompactCertTest(t, 1000, 404)
+}
+
+func getCompactCertsTest(t *testing.T, minRound, maxRound uint64, expectedCode int) {
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetCompactCerts(c, generatedV2.GetCompactCertsParams{MinRound: minRound, MaxRound: maxRound})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestGetCompactCerts(t *testing.T) {
+	t.Parallel()
+
+	getCompactCertsTest(t, 1, 0, 400)
+	// the protocol of the test ledger has no compact certs
+	getCompactCertsTest(t, 0, 1000, 404)
+}
+
+func TestGetPendingCompactCerts(t *testing.T) {
+	t.Parallel()
+
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetPendingCompactCerts(c)
+	require.NoError(t, err)
+	require.Equal(t, 200, rec.Code)
+	var response generatedV2.PendingCompactCertsResponse
+	err = protocol.DecodeJSON(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, []generatedV2.PendingCompactCert{{
+		Round:                  256,
+		Signatures:             1,
+		SignaturesFromThisNode: 1,
+		SignedWeight:           10,
+		ProvenWeight:           30,
+		TotalWeight:            100,
+		Signers: []generatedV2.PendingCompactCertSigner{{
+			Address:      basics.Address{1}.String(),
+			FromThisNode: true,
+			Weight:       10,
+		}},
+	}}, response.PendingCerts)
+}
+
+func TestGetNetworkPeers(t *testing.T) {
+	t.Parallel()
+
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetNetworkPeers(c)
+	require.NoError(t, err)
+	require.Equal(t, 200, rec.Code)
+	var response private.NetworkPeersResponse
+	err = protocol.DecodeJSON(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Peers, 1)
+	peer := response.Peers[0]
+	require.Equal(t, "10.0.0.1:4160", peer.Address)
+	require.Equal(t, "outgoing", peer.Direction)
+	require.Equal(t, "2.1", peer.Version)
+	require.Nil(t, peer.InstanceName)
+	require.GreaterOrEqual(t, peer.ConnectionAge, uint64(time.Minute))
+	require.Equal(t, uint64(time.Millisecond), *peer.PingRoundTripTime)
+	require.Equal(t, []private.NetworkPeerTagCount{{Tag: "AV", Count: 3}, {Tag: "TX", Count: 2}}, peer.MessagesIn)
+	require.Empty(t, peer.MessagesOut)
+	require.Equal(t, uint64(100), peer.BytesIn)
+	require.Equal(t, uint64(10), peer.BytesOut)
+	require.Equal(t, uint64(0), peer.HighPriorityQueueLength)
+	require.Equal(t, uint64(1), peer.BulkQueueLength)
+}
+
+func TestPeerBans(t *testing.T) {
+	t.Parallel()
+
+	expected := []private.PeerBan{{Host: "10.0.0.1", Reason: "InvalidMessage", Until: 1000}}
+	checkBans := func(rec *httptest.ResponseRecorder) {
+		require.Equal(t, 200, rec.Code)
+		var response private.PeerBansResponse
+		err := protocol.DecodeJSON(rec.Body.Bytes(), &response)
+		require.NoError(t, err)
+		require.Equal(t, expected, response.Bans)
+	}
+	newContext := func(method string) (echo.Context, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(httptest.NewRequest(method, "/", nil), rec), rec
+	}
+
+	handler, c, rec, _, _, releasefunc := setupTestForMethodGet(t)
+	defer releasefunc()
+	err := handler.GetPeerBans(c)
+	require.NoError(t, err)
+	checkBans(rec)
+
+	c, rec = newContext(http.MethodPost)
+	duration := uint64(60)
+	err = handler.BanPeer(c, "10.0.0.1", private.BanPeerParams{Duration: &duration})
+	require.NoError(t, err)
+	checkBans(rec)
+
+	c, rec = newContext(http.MethodPost)
+	err = handler.BanPeer(c, "", private.BanPeerParams{})
+	require.NoError(t, err)
+	require.Equal(t, 400, rec.Code)
+
+	c, rec = newContext(http.MethodDelete)
+	err = handler.UnbanPeer(c, "10.0.0.1")
+	require.NoError(t, err)
+	checkBans(rec)
+
+	c, rec = newContext(http.MethodDelete)
+	err = handler.UnbanPeer(c, "10.0.0.2")
+	require.NoError(t, err)
+	require.Equal(t, 404, rec.Code)
+}
+
 func TestGetSupply(t *testing.T) {
 	t.Parallel()
 
@@ -334,7 +483,79 @@ func TestAbortCatchup(t *testing.T) {
 	abortCatchupTest(t, badCatchPoint, 400)
 }
 
-func tealCompileTest(t *testing.T, bytesToUse []byte, expectedCode int, enableDeveloperAPI bool) {
+func devModeTest(t *testing.T, nodeError error, expectedCode int) {
+	numAccounts := 1
+	numTransactions := 1
+	offlineAccounts := true
+	mockLedger, _, _, _, releasefunc := testingenv(t, numAccounts, numTransactions, offlineAccounts)
+	defer releasefunc()
+	dummyShutdownChan := make(chan struct{})
+	mockNode := makeMockNode(mockLedger, t.Name(), nodeError)
+	handler := v2.Handlers{
+		Node:     mockNode,
+		Log:      logging.Base(),
+		Shutdown: dummyShutdownChan,
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	count := uint64(3)
+	err := handler.AdvanceDevModeRounds(c, private.AdvanceDevModeRoundsParams{Count: &count})
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+	if expectedCode == http.StatusOK {
+		var response private.DevModeRoundsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Equal(t, uint64(mockLedger.Latest())+count, response.Round)
+	}
+}
+
+func TestAdvanceDevModeRounds(t *testing.T) {
+	t.Parallel()
+
+	devModeTest(t, nil, 200)
+	devModeTest(t, node.ErrDevModeDisabled, 400)
+	devModeTest(t, errors.New("unable to write block"), 500)
+}
+
+func blockTimeStampOffsetTest(t *testing.T, nodeError error, expectedCode int) {
+	numAccounts := 1
+	numTransactions := 1
+	offlineAccounts := true
+	mockLedger, _, _, _, releasefunc := testingenv(t, numAccounts, numTransactions, offlineAccounts)
+	defer releasefunc()
+	dummyShutdownChan := make(chan struct{})
+	mockNode := makeMockNode(mockLedger, t.Name(), nodeError)
+	handler := v2.Handlers{
+		Node:     mockNode,
+		Log:      logging.Base(),
+		Shutdown: dummyShutdownChan,
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	err := handler.SetBlockTimeStampOffset(c, 1000)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	err = handler.GetBlockTimeStampOffset(c)
+	require.NoError(t, err)
+	require.Equal(t, expectedCode, rec.Code)
+}
+
+func TestBlockTimeStampOffset(t *testing.T) {
+	t.Parallel()
+
+	blockTimeStampOffsetTest(t, nil, 200)
+	blockTimeStampOffsetTest(t, node.ErrDevModeDisabled, 400)
+}
+
+func tealCompileTest(t *testing.T, bytesToUse []byte, expectedCode int, enableDeveloperAPI bool, params generatedV2.TealCompileParams) (response generatedV2.CompileResponse) {
 	numAccounts := 1
 	numTransactions := 1
 	offlineAccounts := true
@@ -352,22 +573,37 @@ func tealCompileTest(t *testing.T, bytesToUse []byte, expectedCode int, enableDe
 	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(bytesToUse))
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	err := handler.TealCompile(c)
+	err := handler.TealCompile(c, params)
 	require.NoError(t, err)
 	require.Equal(t, expectedCode, rec.Code)
+	if rec.Code == 200 {
+		data := rec.Body.Bytes()
+		err = protocol.DecodeJSON(data, &response)
+		require.NoError(t, err, string(data))
+	}
+	return
 }
 
 func TestTealCompile(t *testing.T) {
 	t.Parallel()
 
-	tealCompileTest(t, nil, 200, true) // nil program should work
+	var params generatedV2.TealCompileParams
+	tealCompileTest(t, nil, 200, true, params) // nil program should work
 	goodProgram := `int 1`
 	goodProgramBytes := []byte(goodProgram)
-	tealCompileTest(t, goodProgramBytes, 200, true)
-	tealCompileTest(t, goodProgramBytes, 404, false)
+	response := tealCompileTest(t, goodProgramBytes, 200, true, params)
+	require.Nil(t, response.Sourcemap)
+	tealCompileTest(t, goodProgramBytes, 404, false, params)
 	badProgram := "bad program"
 	badProgramBytes := []byte(badProgram)
-	tealCompileTest(t, badProgramBytes, 400, true)
+	tealCompileTest(t, badProgramBytes, 400, true, params)
+
+	sourcemap := true
+	params.Sourcemap = &sourcemap
+	response = tealCompileTest(t, goodProgramBytes, 200, true, params)
+	require.NotNil(t, response.Sourcemap)
+	require.EqualValues(t, 3, (*response.Sourcemap)["version"])
+	require.Equal(t, ";;;;AAAA", (*response.Sourcemap)["mappings"])
 }
 
 func tealDryrunTest(
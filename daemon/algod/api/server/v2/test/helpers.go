@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/algorand/go-algorand/agreement"
+	"github.com/algorand/go-algorand/compactcert"
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/crypto"
 	generatedV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
@@ -34,6 +35,7 @@ import (
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
 	"github.com/algorand/go-algorand/node"
 	"github.com/algorand/go-algorand/node/indexer"
 	"github.com/algorand/go-algorand/protocol"
@@ -181,6 +183,50 @@ func (m mockNode) AbortCatchup(catchpoint string) error {
 	return m.err
 }
 
+func (m mockNode) AdvanceDevModeRounds(count uint64) (basics.Round, error) {
+	return m.ledger.Latest() + basics.Round(count), m.err
+}
+
+func (m mockNode) SetBlockTimeStampOffset(offset uint64) error {
+	return m.err
+}
+
+func (m mockNode) GetBlockTimeStampOffset() (uint64, error) {
+	return 0, m.err
+}
+
+func (m mockNode) PendingCompactCerts() ([]compactcert.PendingCert, error) {
+	return []compactcert.PendingCert{{Round: 256, Signatures: 1, SignaturesFromThisNode: 1, SignedWeight: 10, ProvenWeight: 30, TotalWeight: 100,
+		Signers: []compactcert.PendingCertSigner{{Signer: basics.Address{1}, FromThisNode: true, Weight: 10}}}}, m.err
+}
+
+func (m mockNode) NetworkPeers() ([]network.PeerStats, error) {
+	return []network.PeerStats{{
+		Address:           "10.0.0.1:4160",
+		Outgoing:          true,
+		Version:           "2.1",
+		ConnectedSince:    time.Now().Add(-time.Minute),
+		PingRoundTripTime: time.Millisecond,
+		MessagesIn:        map[protocol.Tag]uint64{protocol.TxnTag: 2, protocol.AgreementVoteTag: 3},
+		MessagesOut:       map[protocol.Tag]uint64{},
+		BytesIn:           100,
+		BytesOut:          10,
+		BulkQueueLength:   1,
+	}}, m.err
+}
+
+func (m mockNode) PeerBans() ([]network.PeerBan, error) {
+	return []network.PeerBan{{Host: "10.0.0.1", Reason: "InvalidMessage", Until: time.Unix(1000, 0)}}, m.err
+}
+
+func (m mockNode) BanPeer(host string, duration time.Duration) error {
+	return m.err
+}
+
+func (m mockNode) UnbanPeer(host string) (bool, error) {
+	return host == "10.0.0.1", m.err
+}
+
 ////// mock ledger testing environment follows
 
 var sinkAddr = basics.Address{0x7, 0xda, 0xcb, 0x4b, 0x6d, 0x9e, 0xd1, 0x41, 0xb1, 0x75, 0x76, 0xbd, 0x45, 0x9a, 0xe6, 0x42, 0x1d, 0x48, 0x6d, 0xa3, 0xd4, 0xef, 0x22, 0x47, 0xc4, 0x9, 0xa3, 0x96, 0xb8, 0x2e, 0xa2, 0x21}
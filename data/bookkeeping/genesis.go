@@ -68,6 +68,11 @@ type Genesis struct {
 
 	// Arbitrary genesis comment string - will be excluded from file if empty
 	Comment string `codec:"comment"`
+
+	// DevMode defines whether this network operates in a developer mode or not. Developer mode networks
+	// are a single node network, that operates without the agreement service being active. In lieu of the
+	// agreement service, a new block is generated each time a node receives a transaction group.
+	DevMode bool `codec:"devmode"`
 }
 
 // LoadGenesisFromFile attempts to load a Genesis structure from a (presumably) genesis.json file.
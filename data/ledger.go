@@ -121,6 +121,23 @@ func makeGenesisBlock(proto protocol.ConsensusVersion, genesisBal GenesisBalance
 		blk.BlockHeader.GenesisHash = genesisHash
 	}
 
+	if params.TxnCounter {
+		// the assets and applications of the genesis accounts, as in a fork of another network,
+		// take up their indices, so new ones have to be created past them.
+		for _, data := range genesisBal.balances {
+			for aidx := range data.AssetParams {
+				if uint64(aidx) > blk.TxnCounter {
+					blk.TxnCounter = uint64(aidx)
+				}
+			}
+			for aidx := range data.AppParams {
+				if uint64(aidx) > blk.TxnCounter {
+					blk.TxnCounter = uint64(aidx)
+				}
+			}
+		}
+	}
+
 	return blk, nil
 }
 
@@ -415,3 +415,40 @@ func TestConsensusVersion(t *testing.T) {
 	require.Equal(t, protocol.ConsensusVersion(""), ver)
 	require.Equal(t, ledgercore.ErrNoEntry{Round: basics.Round(blk.BlockHeader.NextProtocolSwitchOn + 1), Latest: basics.Round(blk.BlockHeader.Round), Committed: basics.Round(blk.BlockHeader.Round)}, err)
 }
+
+func TestLedgerGenesisCreatables(t *testing.T) {
+	proto := protocol.ConsensusCurrentVersion
+	creator := basics.Address{1}
+	sink := basics.Address{2}
+	pool := basics.Address{3}
+	balances := map[basics.Address]basics.AccountData{
+		creator: {
+			Status:      basics.Offline,
+			MicroAlgos:  basics.MicroAlgos{Raw: 1000 * 1000000},
+			AssetParams: map[basics.AssetIndex]basics.AssetParams{7: {Total: 100}},
+			AppParams:   map[basics.AppIndex]basics.AppParams{9: {}},
+		},
+		sink: {Status: basics.NotParticipating, MicroAlgos: basics.MicroAlgos{Raw: 1000 * 1000000}},
+		pool: {Status: basics.NotParticipating, MicroAlgos: basics.MicroAlgos{Raw: 1000 * 1000000}},
+	}
+
+	log := logging.TestingLog(t)
+	log.SetLevel(logging.Warn)
+	l, err := LoadLedger(log, t.Name(), true, proto, MakeGenesisBalances(balances, sink, pool), "test", crypto.Digest{1}, nil, config.GetDefaultLocal())
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the creatables of the genesis accounts can be looked up, and new ones are created past them.
+	addr, ok, err := l.GetCreator(7, basics.AssetCreatable)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, creator, addr)
+	addr, ok, err = l.GetCreator(9, basics.AppCreatable)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, creator, addr)
+
+	hdr, err := l.BlockHdr(0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), hdr.TxnCounter)
+}
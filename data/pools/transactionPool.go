@@ -87,6 +87,10 @@ type TransactionPool struct {
 	rememberedTxGroups [][]transactions.SignedTxn
 	rememberedTxids    map[transactions.Txid]transactions.SignedTxn
 
+	// remembered, when set, is called with every transaction group added
+	// by Remember(), once pool.mu is released.
+	remembered func(txgroup []transactions.SignedTxn)
+
 	log logging.Logger
 }
 
@@ -429,17 +433,28 @@ func (pool *TransactionPool) Remember(txgroup []transactions.SignedTxn) error {
 	}
 
 	pool.mu.Lock()
-	defer pool.mu.Unlock()
-
 	err := pool.remember(txgroup)
 	if err != nil {
+		pool.mu.Unlock()
 		return fmt.Errorf("TransactionPool.Remember: %v", err)
 	}
-
 	pool.rememberCommit(false)
+	pool.mu.Unlock()
+
+	if pool.remembered != nil {
+		pool.remembered(txgroup)
+	}
 	return nil
 }
 
+// SetRememberedCallback sets the function called with every transaction
+// group Remember() adds to the pool, whichever way it was submitted. It's
+// called without holding the pool lock, so it may assemble a block from
+// the pool. It must be set before the pool is used.
+func (pool *TransactionPool) SetRememberedCallback(remembered func(txgroup []transactions.SignedTxn)) {
+	pool.remembered = remembered
+}
+
 // Lookup returns the error associated with a transaction that used
 // to be in the pool.  If no status information is available (e.g., because
 // it was too long ago, or the transaction committed successfully), then
@@ -882,6 +897,23 @@ func (pool *TransactionPool) AssembleBlock(round basics.Round, deadline time.Tim
 	return pool.assemblyResults.blk, nil
 }
 
+// AssembleDevModeBlock assembles a new block out of all the transactions currently in the pool, without waiting
+// for any deadline. It's used by nodes running in development mode, which write a block for every transaction group
+// they receive rather than running the agreement.
+func (pool *TransactionPool) AssembleDevModeBlock() (assembled *ledger.ValidatedBlock, err error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	// drop the current block evaluator and start over with a new one; since no one is waiting for the
+	// assembly, the recompute generates the entire block right away.
+	pool.recomputeBlockEvaluator(make(map[transactions.Txid]basics.Round))
+	if pool.pendingBlockEvaluator == nil {
+		return nil, fmt.Errorf("AssembleDevModeBlock: unable to start a block evaluator")
+	}
+	assembled, err = pool.AssembleBlock(pool.pendingBlockEvaluator.Round(), time.Now().Add(config.ProposalAssemblyTime))
+	return
+}
+
 // assembleEmptyBlock construct a new block for the given round. Internally it's using the ledger database calls, so callers
 // need to be aware that it might take a while before it would return.
 func (pool *TransactionPool) assembleEmptyBlock(round basics.Round) (assembled *ledger.ValidatedBlock, err error) {
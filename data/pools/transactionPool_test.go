@@ -556,6 +556,102 @@ func TestRememberForget(t *testing.T) {
 	require.Len(t, pending, 0)
 }
 
+func TestAssembleDevModeBlock(t *testing.T) {
+	numOfAccounts := 3
+	// Generate accounts
+	secrets := make([]*crypto.SignatureSecrets, numOfAccounts)
+	addresses := make([]basics.Address, numOfAccounts)
+
+	for i := 0; i < numOfAccounts; i++ {
+		secret := keypair()
+		addr := basics.Address(secret.SignatureVerifier)
+		secrets[i] = secret
+		addresses[i] = addr
+	}
+
+	mockLedger := makeMockLedger(t, initAccFixed(addresses, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+
+	for i, sender := range addresses {
+		tx := transactions.Transaction{
+			Type: protocol.PaymentTx,
+			Header: transactions.Header{
+				Sender:      sender,
+				Fee:         basics.MicroAlgos{Raw: proto.MinTxnFee},
+				FirstValid:  0,
+				LastValid:   basics.Round(proto.MaxTxnLife),
+				GenesisHash: mockLedger.GenesisHash(),
+			},
+			PaymentTxnFields: transactions.PaymentTxnFields{
+				Receiver: addresses[(i+1)%numOfAccounts],
+				Amount:   basics.MicroAlgos{Raw: 1},
+			},
+		}
+		require.NoError(t, transactionPool.RememberOne(tx.Sign(secrets[i])))
+	}
+
+	// the whole pool makes it into the block, without waiting for any deadline.
+	blk, err := transactionPool.AssembleDevModeBlock()
+	require.NoError(t, err)
+	require.Equal(t, basics.Round(1), blk.Block().Round())
+	require.Len(t, blk.Block().Payset, numOfAccounts)
+
+	err = mockLedger.AddValidatedBlock(*blk, agreement.Certificate{})
+	require.NoError(t, err)
+
+	// the next block is assembled on top of the ledger, even before the pool is notified of the new block.
+	blk, err = transactionPool.AssembleDevModeBlock()
+	require.NoError(t, err)
+	require.Equal(t, basics.Round(2), blk.Block().Round())
+	require.Len(t, blk.Block().Payset, 0)
+	require.Len(t, transactionPool.PendingTxGroups(), 0)
+}
+
+func TestRememberedCallback(t *testing.T) {
+	secret := keypair()
+	sender := basics.Address(secret.SignatureVerifier)
+
+	mockLedger := makeMockLedger(t, initAccFixed([]basics.Address{sender}, 1<<32))
+	cfg := config.GetDefaultLocal()
+	cfg.TxPoolSize = testPoolSize
+	cfg.EnableProcessBlockStats = false
+	transactionPool := MakeTransactionPool(mockLedger, cfg, logging.Base())
+
+	var remembered [][]transactions.SignedTxn
+	transactionPool.SetRememberedCallback(func(txgroup []transactions.SignedTxn) {
+		// the pool lock is released by then, so the pending transactions can be assembled into a block.
+		blk, err := transactionPool.AssembleDevModeBlock()
+		require.NoError(t, err)
+		require.Len(t, blk.Block().Payset, 1)
+		remembered = append(remembered, txgroup)
+	})
+
+	tx := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender:      sender,
+			Fee:         basics.MicroAlgos{Raw: proto.MinTxnFee},
+			FirstValid:  0,
+			LastValid:   basics.Round(proto.MaxTxnLife),
+			GenesisHash: mockLedger.GenesisHash(),
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: sender,
+			Amount:   basics.MicroAlgos{Raw: 1},
+		},
+	}
+	signedTx := tx.Sign(secret)
+	require.NoError(t, transactionPool.RememberOne(signedTx))
+	require.Equal(t, [][]transactions.SignedTxn{{signedTx}}, remembered)
+
+	// the transaction groups the pool rejects aren't passed on.
+	require.Error(t, transactionPool.RememberOne(signedTx))
+	require.Len(t, remembered, 1)
+}
+
 //	Test that clean up works
 func TestCleanUp(t *testing.T) {
 	numOfAccounts := 10
@@ -26,7 +26,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -60,11 +62,17 @@ type OpStream struct {
 	// and cblocks added before these bytes become a legal program.
 	pending bytes.Buffer
 
-	intc        []uint64 // observed ints in code. We'll put them into a intcblock
-	noIntcBlock bool     // prevent prepending intcblock because asm has one
+	intc        []uint64        // observed ints in code. We'll put them into a intcblock
+	intcRefs    map[uint64]int  // number of times each int was referred to by the `int` pseudo-op
+	pushInts    map[uint64]bool // ints to assemble as pushint instead of intc
+	noIntcBlock bool            // prevent prepending intcblock because asm has one
+	intcByIndex bool            // asm refers to int constants by index, so they can't be moved
 
-	bytec        [][]byte // observed bytes in code. We'll put them into a bytecblock
-	noBytecBlock bool     // prevent prepending bytecblock because asm has one
+	bytec        [][]byte        // observed bytes in code. We'll put them into a bytecblock
+	bytecRefs    map[string]int  // number of times each []byte was referred to by the `byte` and `addr` pseudo-ops
+	pushBytes    map[string]bool // []byte to assemble as pushbytes instead of bytec
+	noBytecBlock bool            // prevent prepending bytecblock because asm has one
+	bytecByIndex bool            // asm refers to []byte constants by index, so they can't be moved
 
 	// Keep a stack of the types of what we would push and pop to typecheck a program
 	typeStack []StackType
@@ -72,7 +80,10 @@ type OpStream struct {
 	// current sourceLine during assembly
 	sourceLine int
 
-	// map label string to position within pending buffer
+	// column of the opcode on the current sourceLine
+	sourceColumn int
+
+	// map label string to position within pending buffer (within Program once assembled)
 	labels map[string]int
 
 	// track references in order to patch in jump offsets
@@ -80,6 +91,26 @@ type OpStream struct {
 
 	// map opcode offsets to source line
 	OffsetToLine map[int]int
+
+	// map opcode offsets to source column
+	offsetToColumn map[int]int
+
+	// IncludeDirs are searched in order for files named by #include.
+	// #include is not allowed if it is empty.
+	IncludeDirs []string
+
+	// macros and named constants from #define and #slot
+	macros map[string]macro
+
+	// scratch slots taken by #slot
+	slotsUsed [EvalMaxScratchSize + 1]bool
+
+	// nesting of #include and macro expansion being assembled
+	expansionDepth int
+
+	// directory of the #include file being assembled, empty for the
+	// program text itself
+	includeDir string
 }
 
 // GetVersion returns the LogicSigVersion we're building to
@@ -108,6 +139,10 @@ func (ops *OpStream) RecordSourceLine() {
 		ops.OffsetToLine = make(map[int]int)
 	}
 	ops.OffsetToLine[ops.pending.Len()] = ops.sourceLine - 1
+	if ops.offsetToColumn == nil {
+		ops.offsetToColumn = make(map[int]int)
+	}
+	ops.offsetToColumn[ops.pending.Len()] = ops.sourceColumn
 }
 
 // ReferToLabel records an opcode label refence to resolve later
@@ -162,6 +197,10 @@ func (ops *OpStream) Intc(constIndex uint) {
 
 // Uint writes opcodes for loading a uint literal
 func (ops *OpStream) Uint(val uint64) {
+	if ops.pushInts[val] {
+		ops.PushInt(val)
+		return
+	}
 	found := false
 	var constIndex uint
 	for i, cv := range ops.intc {
@@ -175,9 +214,23 @@ func (ops *OpStream) Uint(val uint64) {
 		constIndex = uint(len(ops.intc))
 		ops.intc = append(ops.intc, val)
 	}
+	if ops.intcRefs == nil {
+		ops.intcRefs = make(map[uint64]int)
+	}
+	ops.intcRefs[val]++
 	ops.Intc(constIndex)
 }
 
+// PushInt writes opcodes for pushing a uint literal that follows the opcode
+func (ops *OpStream) PushInt(val uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	l := binary.PutUvarint(scratch[:], val)
+	ops.pending.WriteByte(0x81) // pushint
+	ops.pending.Write(scratch[:l])
+	ops.trace("pushint %d", val)
+	ops.tpush(StackUint64)
+}
+
 // Bytec writes opcodes for loading a []byte constant onto the stack.
 func (ops *OpStream) Bytec(constIndex uint) {
 	switch constIndex {
@@ -207,6 +260,10 @@ func (ops *OpStream) Bytec(constIndex uint) {
 // ByteLiteral writes opcodes and data for loading a []byte literal
 // Values are accumulated so that they can be put into a bytecblock
 func (ops *OpStream) ByteLiteral(val []byte) {
+	if ops.pushBytes[string(val)] {
+		ops.PushBytes(val)
+		return
+	}
 	found := false
 	var constIndex uint
 	for i, cv := range ops.bytec {
@@ -220,9 +277,24 @@ func (ops *OpStream) ByteLiteral(val []byte) {
 		constIndex = uint(len(ops.bytec))
 		ops.bytec = append(ops.bytec, val)
 	}
+	if ops.bytecRefs == nil {
+		ops.bytecRefs = make(map[string]int)
+	}
+	ops.bytecRefs[string(val)]++
 	ops.Bytec(constIndex)
 }
 
+// PushBytes writes opcodes for pushing a []byte literal that follows the opcode
+func (ops *OpStream) PushBytes(val []byte) {
+	var scratch [binary.MaxVarintLen64]byte
+	l := binary.PutUvarint(scratch[:], uint64(len(val)))
+	ops.pending.WriteByte(0x80) // pushbytes
+	ops.pending.Write(scratch[:l])
+	ops.pending.Write(val)
+	ops.trace("pushbytes %s", hex.EncodeToString(val))
+	ops.tpush(StackBytes)
+}
+
 // Arg writes opcodes for loading from Lsig.Args
 func (ops *OpStream) Arg(val uint64) error {
 	switch val {
@@ -379,6 +451,7 @@ func assembleIntC(ops *OpStream, spec *OpSpec, args []string) error {
 		ops.error(err)
 		constIndex = 0 // By continuing, Intc will maintain type stack.
 	}
+	ops.intcByIndex = true
 	ops.Intc(uint(constIndex))
 	return nil
 }
@@ -392,6 +465,7 @@ func assembleByteC(ops *OpStream, spec *OpSpec, args []string) error {
 		ops.error(err)
 		constIndex = 0 // By continuing, Bytec will maintain type stack.
 	}
+	ops.bytecByIndex = true
 	ops.Bytec(uint(constIndex))
 	return nil
 }
@@ -559,6 +633,36 @@ func assembleByte(ops *OpStream, spec *OpSpec, args []string) error {
 	return nil
 }
 
+func assemblePushInt(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) != 1 {
+		ops.error("pushint needs one argument")
+		args = []string{"0"} // By continuing, PushInt will maintain type stack.
+	}
+	val, err := strconv.ParseUint(args[0], 0, 64)
+	if err != nil {
+		ops.error(err)
+		val = 0 // By continuing, PushInt will maintain type stack.
+	}
+	ops.PushInt(val)
+	return nil
+}
+
+func assemblePushBytes(ops *OpStream, spec *OpSpec, args []string) error {
+	if len(args) == 0 {
+		ops.error("pushbytes operation needs byte literal argument")
+		args = []string{"0x00"} // By continuing, PushBytes will maintain type stack.
+	}
+	val, consumed, err := parseBinaryArgs(args)
+	if err != nil {
+		ops.error(err)
+		val = []byte{} // By continuing, PushBytes will maintain type stack.
+	} else if consumed != len(args) {
+		ops.error("pushbytes operation with extra argument")
+	}
+	ops.PushBytes(val)
+	return nil
+}
+
 func assembleIntCBlock(ops *OpStream, spec *OpSpec, args []string) error {
 	ops.pending.WriteByte(0x20) // intcblock
 	var scratch [binary.MaxVarintLen64]byte
@@ -745,6 +849,98 @@ func disSubstring(dis *disassembleState, spec *OpSpec) {
 	_, dis.err = fmt.Fprintf(dis.out, "substring %d %d\n", start, end)
 }
 
+// parseStackDepth reads the immediate depth argument of dig, cover and uncover
+func parseStackDepth(ops *OpStream, spec *OpSpec, args []string) int {
+	if len(args) != 1 {
+		ops.errorf("%s expects one argument", spec.Name)
+		return 0
+	}
+	depth, err := strconv.ParseUint(args[0], 0, 64)
+	if err != nil {
+		ops.error(err)
+		return 0
+	}
+	if depth > 255 {
+		ops.errorf("%s outside 0..255: %d", spec.Name, depth)
+		return 0
+	}
+	return int(depth)
+}
+
+// forgetTypes marks every tracked stack type as unknown. It is used when an
+// op rearranges more of the stack than the assembler is tracking.
+func (ops *OpStream) forgetTypes() {
+	for i := range ops.typeStack {
+		ops.typeStack[i] = StackAny
+	}
+}
+
+func assembleDig(ops *OpStream, spec *OpSpec, args []string) error {
+	depth := parseStackDepth(ops, spec, args)
+	dug := StackAny
+	if idx := len(ops.typeStack) - 1 - depth; idx >= 0 {
+		dug = ops.typeStack[idx]
+	}
+	ops.pending.WriteByte(spec.Opcode)
+	ops.pending.WriteByte(byte(depth))
+	ops.trace(" pushes(%s)", dug.String())
+	ops.tpush(dug)
+	return nil
+}
+
+func assembleSwap(ops *OpStream, spec *OpSpec, args []string) error {
+	top := len(ops.typeStack) - 1
+	if top < 1 {
+		return asmDefault(ops, spec, args)
+	}
+	ops.typeStack[top], ops.typeStack[top-1] = ops.typeStack[top-1], ops.typeStack[top]
+	ops.pending.WriteByte(spec.Opcode)
+	return nil
+}
+
+func assembleSelect(ops *OpStream, spec *OpSpec, args []string) error {
+	// A, B, C -> (C != 0 ? B : A), so the type is only known if A and B agree
+	selected := StackAny
+	if top := len(ops.typeStack) - 1; top >= 2 && ops.typeStack[top-2] == ops.typeStack[top-1] {
+		selected = ops.typeStack[top-1]
+	}
+	ops.checkArgs(*spec)
+	ops.trace(" pushes(%s)", selected.String())
+	ops.tpush(selected)
+	ops.pending.WriteByte(spec.Opcode)
+	return nil
+}
+
+func assembleCover(ops *OpStream, spec *OpSpec, args []string) error {
+	depth := parseStackDepth(ops, spec, args)
+	top := len(ops.typeStack) - 1
+	if idx := top - depth; idx >= 0 {
+		moved := ops.typeStack[top]
+		copy(ops.typeStack[idx+1:], ops.typeStack[idx:top])
+		ops.typeStack[idx] = moved
+	} else {
+		ops.forgetTypes()
+	}
+	ops.pending.WriteByte(spec.Opcode)
+	ops.pending.WriteByte(byte(depth))
+	return nil
+}
+
+func assembleUncover(ops *OpStream, spec *OpSpec, args []string) error {
+	depth := parseStackDepth(ops, spec, args)
+	top := len(ops.typeStack) - 1
+	if idx := top - depth; idx >= 0 {
+		moved := ops.typeStack[idx]
+		copy(ops.typeStack[idx:], ops.typeStack[idx+1:])
+		ops.typeStack[top] = moved
+	} else {
+		ops.forgetTypes()
+	}
+	ops.pending.WriteByte(spec.Opcode)
+	ops.pending.WriteByte(byte(depth))
+	return nil
+}
+
 func assembleTxn(ops *OpStream, spec *OpSpec, args []string) error {
 	if len(args) != 1 {
 		return ops.error("txn expects one argument")
@@ -1066,8 +1262,104 @@ func (ops *OpStream) checkArgs(spec OpSpec) {
 	}
 }
 
-// assemble reads text from an input and accumulates the program
+// assemble reads text from an input and accumulates the program.
+// Starting from optimizeConstantsEnabledVersion the text is assembled twice:
+// the first pass counts constant references and the second one uses those
+// counts to pick between constant blocks and pushint/pushbytes.
 func (ops *OpStream) assemble(fin io.Reader) error {
+	source, err := ioutil.ReadAll(fin)
+	if err != nil {
+		return ops.error(err)
+	}
+	version, trace := ops.Version, ops.Trace
+	var firstPassTrace bytes.Buffer
+	if trace != nil {
+		ops.Trace = &firstPassTrace
+	}
+	err = ops.assembleSource(bytes.NewReader(source))
+	ops.Trace = trace
+	if err != nil || !ops.optimizeConstants() {
+		if trace != nil {
+			trace.Write(firstPassTrace.Bytes())
+		}
+		return err
+	}
+	*ops = OpStream{
+		Version:     version,
+		Trace:       trace,
+		IncludeDirs: ops.IncludeDirs,
+		intc:        ops.intc,
+		pushInts:    ops.pushInts,
+		bytec:       ops.bytec,
+		pushBytes:   ops.pushBytes,
+	}
+	return ops.assembleSource(bytes.NewReader(source))
+}
+
+// optimizeConstants uses the constant references counted during assembly to
+// choose constants better pushed inline: a constant used once costs the same
+// bytes in a pushint/pushbytes as in a cblock, but saves the reference and
+// possibly the whole block. The remaining constants are ordered by use so the
+// most frequent ones get the single byte intc_N/bytec_N forms. It returns
+// false if assembling again would not change the program.
+func (ops *OpStream) optimizeConstants() bool {
+	if ops.Version < optimizeConstantsEnabledVersion || ops.Version == assemblerNoVersion {
+		return false
+	}
+	changed := false
+	if !ops.noIntcBlock && !ops.intcByIndex && len(ops.intc) > 0 {
+		ops.pushInts = make(map[uint64]bool)
+		kept := make([]uint64, 0, len(ops.intc))
+		for _, iv := range ops.intc {
+			if ops.intcRefs[iv] == 1 {
+				ops.pushInts[iv] = true
+				changed = true
+			} else {
+				kept = append(kept, iv)
+			}
+		}
+		sort.SliceStable(kept, func(i, j int) bool {
+			return ops.intcRefs[kept[i]] > ops.intcRefs[kept[j]]
+		})
+		for i := range kept {
+			if kept[i] != ops.intc[i] {
+				changed = true
+			}
+		}
+		ops.intc = kept
+	} else {
+		// leave the constants where the program expects them
+		ops.intc = nil
+	}
+	if !ops.noBytecBlock && !ops.bytecByIndex && len(ops.bytec) > 0 {
+		ops.pushBytes = make(map[string]bool)
+		kept := make([][]byte, 0, len(ops.bytec))
+		for _, bv := range ops.bytec {
+			if ops.bytecRefs[string(bv)] == 1 {
+				ops.pushBytes[string(bv)] = true
+				changed = true
+			} else {
+				kept = append(kept, bv)
+			}
+		}
+		sort.SliceStable(kept, func(i, j int) bool {
+			return ops.bytecRefs[string(kept[i])] > ops.bytecRefs[string(kept[j])]
+		})
+		for i := range kept {
+			if !bytes.Equal(kept[i], ops.bytec[i]) {
+				changed = true
+			}
+		}
+		ops.bytec = kept
+	} else {
+		// leave the constants where the program expects them
+		ops.bytec = nil
+	}
+	return changed
+}
+
+// assembleSource does a single assembly pass over the program text
+func (ops *OpStream) assembleSource(fin io.Reader) error {
 	if ops.Version > LogicVersion && ops.Version != assemblerNoVersion {
 		return ops.errorf("Can not assemble version %d", ops.Version)
 	}
@@ -1076,51 +1368,8 @@ func (ops *OpStream) assemble(fin io.Reader) error {
 	for scanner.Scan() {
 		ops.sourceLine++
 		line := scanner.Text()
-		if len(line) == 0 {
-			ops.trace("%d: 0 line\n", ops.sourceLine)
-			continue
-		}
-		if strings.HasPrefix(line, "//") {
-			ops.trace("%d: // line\n", ops.sourceLine)
-			continue
-		}
-		if strings.HasPrefix(line, "#pragma") {
-			ops.trace("%d: #pragma line\n", ops.sourceLine)
-			ops.pragma(line)
-			continue
-		}
-		fields := fieldsFromLine(line)
-		if len(fields) == 0 {
-			ops.trace("%d: no fields\n", ops.sourceLine)
-			continue
-		}
-		// we're going to process opcodes, so fix the Version
-		if ops.Version == assemblerNoVersion {
-			ops.Version = AssemblerDefaultVersion
-		}
-		opstring := fields[0]
-		spec, ok := opsByName[ops.Version][opstring]
-		var asmFunc assembleFunc
-		if ok {
-			asmFunc = spec.asm
-		} else {
-			kwFunc, ok := keywords[opstring]
-			if ok {
-				asmFunc = kwFunc
-			}
-		}
-		if asmFunc != nil {
-			ops.trace("%3d: %s\t", ops.sourceLine, opstring)
-			ops.RecordSourceLine()
-			asmFunc(ops, &spec, fields[1:])
-			ops.trace("\n")
-			continue
-		}
-		if opstring[len(opstring)-1] == ':' {
-			ops.createLabel(opstring[:len(opstring)-1])
-			continue
-		}
-		ops.errorf("unknown opcode: %v", opstring)
+		ops.sourceColumn = len(line) - len(strings.TrimLeft(line, " \t"))
+		ops.assembleLine(line)
 	}
 
 	// backward compatibility: do not allow jumps behind last instruction in TEAL v1
@@ -1146,6 +1395,84 @@ func (ops *OpStream) assemble(fin io.Reader) error {
 	return nil
 }
 
+// assembleLine assembles one line of source, which may come from the
+// program text itself or from an #include file.
+func (ops *OpStream) assembleLine(line string) {
+	if len(line) == 0 {
+		ops.trace("%d: 0 line\n", ops.sourceLine)
+		return
+	}
+	if strings.HasPrefix(line, "//") {
+		ops.trace("%d: // line\n", ops.sourceLine)
+		return
+	}
+	if strings.HasPrefix(line, "#pragma") {
+		ops.trace("%d: #pragma line\n", ops.sourceLine)
+		ops.pragma(line)
+		return
+	}
+	if strings.HasPrefix(line, "#define") {
+		ops.trace("%d: #define line\n", ops.sourceLine)
+		ops.define(line)
+		return
+	}
+	if strings.HasPrefix(line, "#include") {
+		ops.trace("%d: #include line\n", ops.sourceLine)
+		ops.include(line)
+		return
+	}
+	if strings.HasPrefix(line, "#slot") {
+		ops.trace("%d: #slot line\n", ops.sourceLine)
+		ops.slot(line)
+		return
+	}
+	fields := fieldsFromLine(line)
+	if len(fields) == 0 {
+		ops.trace("%d: no fields\n", ops.sourceLine)
+		return
+	}
+	ops.assembleFields(fields)
+}
+
+// assembleFields assembles one instruction or label, after expanding macros
+func (ops *OpStream) assembleFields(fields []string) {
+	if ops.expandMacro(fields) {
+		return
+	}
+	fields, ok := ops.substituteDefines(fields)
+	if !ok {
+		return
+	}
+
+	// we're going to process opcodes, so fix the Version
+	if ops.Version == assemblerNoVersion {
+		ops.Version = AssemblerDefaultVersion
+	}
+	opstring := fields[0]
+	spec, ok := opsByName[ops.Version][opstring]
+	var asmFunc assembleFunc
+	if ok {
+		asmFunc = spec.asm
+	} else {
+		kwFunc, ok := keywords[opstring]
+		if ok {
+			asmFunc = kwFunc
+		}
+	}
+	if asmFunc != nil {
+		ops.trace("%3d: %s\t", ops.sourceLine, opstring)
+		ops.RecordSourceLine()
+		asmFunc(ops, &spec, fields[1:])
+		ops.trace("\n")
+		return
+	}
+	if opstring[len(opstring)-1] == ':' {
+		ops.createLabel(opstring[:len(opstring)-1])
+		return
+	}
+	ops.errorf("unknown opcode: %v", opstring)
+}
+
 func (ops *OpStream) pragma(line string) error {
 	fields := strings.Split(line, " ")
 	if fields[0] != "#pragma" {
@@ -1278,6 +1605,14 @@ func (ops *OpStream) prependCBlocks() []byte {
 		newOffsetToLine[o+pbl] = l
 	}
 	ops.OffsetToLine = newOffsetToLine
+	newOffsetToColumn := make(map[int]int, len(ops.offsetToColumn))
+	for o, c := range ops.offsetToColumn {
+		newOffsetToColumn[o+pbl] = c
+	}
+	ops.offsetToColumn = newOffsetToColumn
+	for label, pc := range ops.labels {
+		ops.labels[label] = pc + pbl
+	}
 
 	return out
 }
@@ -1345,8 +1680,24 @@ func AssembleString(text string) (*OpStream, error) {
 // to AssemblerDefaultVersion.  OpStream is returned to allow access
 // to warnings, (multiple) errors, or the PC to source line mapping.
 func AssembleStringWithVersion(text string, version uint64) (*OpStream, error) {
+	return assembleString(text, version, nil)
+}
+
+// AssembleStringWithIncludes is AssembleString, and also resolves #include
+// directives by searching includeDirs in order.
+func AssembleStringWithIncludes(text string, includeDirs []string) (*OpStream, error) {
+	return assembleString(text, assemblerNoVersion, includeDirs)
+}
+
+// AssembleStringWithVersionAndIncludes is AssembleStringWithVersion, and
+// also resolves #include directives by searching includeDirs in order.
+func AssembleStringWithVersionAndIncludes(text string, version uint64, includeDirs []string) (*OpStream, error) {
+	return assembleString(text, version, includeDirs)
+}
+
+func assembleString(text string, version uint64, includeDirs []string) (*OpStream, error) {
 	sr := strings.NewReader(text)
-	ops := OpStream{Version: version}
+	ops := OpStream{Version: version, IncludeDirs: includeDirs}
 	err := ops.assemble(sr)
 	return &ops, err
 }
@@ -1726,6 +2077,39 @@ func disStore(dis *disassembleState, spec *OpSpec) {
 	_, dis.err = fmt.Fprintf(dis.out, "store %d\n", n)
 }
 
+func disStackDepth(dis *disassembleState, spec *OpSpec) {
+	lastIdx := dis.pc + 1
+	if len(dis.program) <= lastIdx {
+		missing := lastIdx - len(dis.program) + 1
+		dis.err = fmt.Errorf("unexpected %s opcode end: missing %d bytes", spec.Name, missing)
+		return
+	}
+	n := uint(dis.program[dis.pc+1])
+	dis.nextpc = dis.pc + 2
+	_, dis.err = fmt.Fprintf(dis.out, "%s %d\n", spec.Name, n)
+}
+
+func disPushInt(dis *disassembleState, spec *OpSpec) {
+	pos := dis.pc + 1
+	val, bytesUsed := binary.Uvarint(dis.program[pos:])
+	if bytesUsed <= 0 {
+		dis.err = fmt.Errorf("could not decode int at pc=%d", pos)
+		return
+	}
+	dis.nextpc = pos + bytesUsed
+	_, dis.err = fmt.Fprintf(dis.out, "%s %d\n", spec.Name, val)
+}
+
+func disPushBytes(dis *disassembleState, spec *OpSpec) {
+	var start, end int
+	start, end, dis.err = parsePushBytes(dis.program, dis.pc)
+	if dis.err != nil {
+		return
+	}
+	dis.nextpc = end
+	_, dis.err = fmt.Fprintf(dis.out, "%s 0x%s\n", spec.Name, hex.EncodeToString(dis.program[start:end]))
+}
+
 func disAssetHolding(dis *disassembleState, spec *OpSpec) {
 	lastIdx := dis.pc + 1
 	if len(dis.program) <= lastIdx {
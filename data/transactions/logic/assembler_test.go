@@ -212,6 +212,16 @@ txn ConfigAssetClawback
 txn FreezeAsset
 txn FreezeAssetAccount
 txn FreezeAssetFrozen
+pushint 1000
+pushbytes "john"
+int 1
+int 0
+select
+assert
+swap
+dig 1
+cover 1
+uncover 2
 `
 
 // Check that assembly output is stable across time.
@@ -238,7 +248,7 @@ func TestAssemble(t *testing.T) {
 	ops, err := AssembleStringWithVersion(bigTestAssembleNonsenseProgram, AssemblerMaxVersion)
 	require.NoError(t, err)
 	// check that compilation is stable over time and we assemble to the same bytes this month that we did last month.
-	expectedBytes, _ := hex.DecodeString("022008b7a60cf8acd19181cf959a12f8acd19181cf951af8acd19181cf15f8acd191810f01020026050212340c68656c6c6f20776f726c6421208dae2087fbba51304eb02b91f656948397a7946390e8cb70fc9ea4d95f92251d024242047465737400320032013202320328292929292a0431003101310231043105310731083109310a310b310c310d310e310f3111311231133114311533000033000133000233000433000533000733000833000933000a33000b33000c33000d33000e33000f3300113300123300133300143300152d2e0102222324252104082209240a220b230c240d250e230f23102311231223132314181b1c2b171615400003290349483403350222231d4a484848482a50512a63222352410003420000432105602105612105270463484821052b62482b642b65484821052b2106662b21056721072b682b692107210570004848210771004848361c0037001a0031183119311b311d311e311f3120210721051e312131223123312431253126312731283129312a312b312c312d312e312f")
+	expectedBytes, _ := hex.DecodeString("032008b7a60cf8acd19181cf959a12f8acd19181cf951af8acd19181cf15f8acd191810f01020026050212340c68656c6c6f20776f726c6421208dae2087fbba51304eb02b91f656948397a7946390e8cb70fc9ea4d95f92251d024242047465737400320032013202320328292929292a0431003101310231043105310731083109310a310b310c310d310e310f3111311231133114311533000033000133000233000433000533000733000833000933000a33000b33000c33000d33000e33000f3300113300123300133300143300152d2e0102222324252104082209240a220b230c240d250e230f23102311231223132314181b1c2b171615400003290349483403350222231d4a484848482a50512a63222352410003420000432105602105612105270463484821052b62482b642b65484821052b2106662b21056721072b682b692107210570004848210771004848361c0037001a0031183119311b311d311e311f3120210721051e312131223123312431253126312731283129312a312b312c312d312e312f81e80780046a6f686e210521074d444c4b014e014f02")
 	if bytes.Compare(expectedBytes, ops.Program) != 0 {
 		// this print is for convenience if the program has been changed. the hex string can be copy pasted back in as a new expected result.
 		t.Log(hex.EncodeToString(ops.Program))
@@ -420,7 +430,12 @@ func TestAssembleInt(t *testing.T) {
 			ops, err := AssembleStringWithVersion(text, v)
 			require.NoError(t, err)
 			s := hex.EncodeToString(ops.Program)
-			require.Equal(t, mutateProgVersion(v, "012001bef5fad70c22"), s)
+			expected := "012001bef5fad70c22"
+			if v >= optimizeConstantsEnabledVersion {
+				// single use constant is pushed inline
+				expected = "0181bef5fad70c"
+			}
+			require.Equal(t, mutateProgVersion(v, expected), s)
 		})
 	}
 }
@@ -460,7 +475,12 @@ func TestAssembleBytes(t *testing.T) {
 				ops, err := AssembleStringWithVersion(vi, v)
 				require.NoError(t, err)
 				s := hex.EncodeToString(ops.Program)
-				require.Equal(t, mutateProgVersion(v, "0126010661626364656628"), s)
+				expected := "0126010661626364656628"
+				if v >= optimizeConstantsEnabledVersion {
+					// single use constant is pushed inline
+					expected = "018006616263646566"
+				}
+				require.Equal(t, mutateProgVersion(v, expected), s)
 			}
 
 		})
@@ -714,7 +734,12 @@ byte b64 avGWRM+yy3BCavBDXO/FYTNZ6o2Jai5edsMCBdDEz//=
 			ops, err := AssembleStringWithVersion(text, v)
 			require.NoError(t, err)
 			s := hex.EncodeToString(ops.Program)
-			require.Equal(t, mutateProgVersion(v, "01200101260320fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfed206af19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfff20fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfef2829122210122a291211"), s)
+			expected := "01200101260320fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfed206af19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfff20fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfef2829122210122a291211"
+			if v >= optimizeConstantsEnabledVersion {
+				// only the repeated constant stays in the bytecblock
+				expected = "012601206af19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfff8020fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfed2812810110128020fff19644cfb2cb70426af0435cefc5613359ea8d896a2e5e76c30205d0c4cfef281211"
+			}
+			require.Equal(t, mutateProgVersion(v, expected), s)
 		})
 	}
 }
@@ -857,7 +882,7 @@ gtxn 12 Fee
 			t.Errorf("TestAssembleDisassemble missing field txn %v", txnField)
 		}
 	}
-	ops, err := AssembleStringWithVersion(text, AssemblerMaxVersion)
+	ops, err := AssembleStringWithVersion(text, 2)
 	require.NoError(t, err)
 	t2, err := Disassemble(ops.Program)
 	require.Equal(t, text, t2)
@@ -870,7 +895,8 @@ func TestAssembleDisassembleCycle(t *testing.T) {
 	t.Parallel()
 
 	tests := map[uint64]string{
-		2: bigTestAssembleNonsenseProgram,
+		3: bigTestAssembleNonsenseProgram,
+		2: bigTestAssembleNonsenseProgram[:strings.Index(bigTestAssembleNonsenseProgram, "pushint")],
 		1: bigTestAssembleNonsenseProgram[:strings.Index(bigTestAssembleNonsenseProgram, "dup2")],
 	}
 
@@ -880,7 +906,7 @@ func TestAssembleDisassembleCycle(t *testing.T) {
 			require.NoError(t, err)
 			t2, err := Disassemble(ops.Program)
 			require.NoError(t, err)
-			ops2, err := AssembleStringWithVersion(t2, 2)
+			ops2, err := AssembleStringWithVersion(t2, AssemblerMaxVersion)
 			if err != nil {
 				t.Log(t2)
 			}
@@ -947,7 +973,7 @@ func TestAssembleDisassembleErrors(t *testing.T) {
 	source = "int 0\nint 0\nasset_holding_get AssetFrozen"
 	ops, err = AssembleStringWithVersion(source, AssemblerMaxVersion)
 	require.NoError(t, err)
-	ops.Program[7] = 0x50 // holding field
+	ops.Program[len(ops.Program)-1] = 0x50 // holding field
 	_, err = Disassemble(ops.Program)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid asset holding arg index")
@@ -955,7 +981,7 @@ func TestAssembleDisassembleErrors(t *testing.T) {
 	source = "int 0\nasset_params_get AssetTotal"
 	ops, err = AssembleStringWithVersion(source, AssemblerMaxVersion)
 	require.NoError(t, err)
-	ops.Program[6] = 0x50 // params field
+	ops.Program[len(ops.Program)-1] = 0x50 // params field
 	_, err = Disassemble(ops.Program)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid asset params arg index")
@@ -1031,7 +1057,7 @@ func TestDisassembleSingleOp(t *testing.T) {
 	t.Parallel()
 	// test ensures no double arg_0 entries in disassembly listing
 	sample := "// version 2\narg_0\n"
-	ops, err := AssembleStringWithVersion(sample, AssemblerMaxVersion)
+	ops, err := AssembleStringWithVersion(sample, 2)
 	require.NoError(t, err)
 	require.Equal(t, 2, len(ops.Program))
 	disassembled, err := Disassemble(ops.Program)
@@ -1043,21 +1069,21 @@ func TestDisassembleTxna(t *testing.T) {
 	t.Parallel()
 	// check txn and txna are properly disassembled
 	txnSample := "// version 2\ntxn Sender\n"
-	ops, err := AssembleStringWithVersion(txnSample, AssemblerMaxVersion)
+	ops, err := AssembleStringWithVersion(txnSample, 2)
 	require.NoError(t, err)
 	disassembled, err := Disassemble(ops.Program)
 	require.NoError(t, err)
 	require.Equal(t, txnSample, disassembled)
 
 	txnaSample := "// version 2\ntxna Accounts 0\n"
-	ops, err = AssembleStringWithVersion(txnaSample, AssemblerMaxVersion)
+	ops, err = AssembleStringWithVersion(txnaSample, 2)
 	require.NoError(t, err)
 	disassembled, err = Disassemble(ops.Program)
 	require.NoError(t, err)
 	require.Equal(t, txnaSample, disassembled)
 
 	txnSample2 := "// version 2\ntxn Accounts 0\n"
-	ops, err = AssembleStringWithVersion(txnSample2, AssemblerMaxVersion)
+	ops, err = AssembleStringWithVersion(txnSample2, 2)
 	require.NoError(t, err)
 	disassembled, err = Disassemble(ops.Program)
 	require.NoError(t, err)
@@ -1069,21 +1095,21 @@ func TestDisassembleGtxna(t *testing.T) {
 	t.Parallel()
 	// check gtxn and gtxna are properly disassembled
 	gtxnSample := "// version 2\ngtxn 0 Sender\n"
-	ops, err := AssembleStringWithVersion(gtxnSample, AssemblerMaxVersion)
+	ops, err := AssembleStringWithVersion(gtxnSample, 2)
 	require.NoError(t, err)
 	disassembled, err := Disassemble(ops.Program)
 	require.NoError(t, err)
 	require.Equal(t, gtxnSample, disassembled)
 
 	gtxnaSample := "// version 2\ngtxna 0 Accounts 0\n"
-	ops, err = AssembleStringWithVersion(gtxnaSample, AssemblerMaxVersion)
+	ops, err = AssembleStringWithVersion(gtxnaSample, 2)
 	require.NoError(t, err)
 	disassembled, err = Disassemble(ops.Program)
 	require.NoError(t, err)
 	require.Equal(t, gtxnaSample, disassembled)
 
 	gtxnSample2 := "// version 2\ngtxn 0 Accounts 0\n"
-	ops, err = AssembleStringWithVersion(gtxnSample2, AssemblerMaxVersion)
+	ops, err = AssembleStringWithVersion(gtxnSample2, 2)
 	require.NoError(t, err)
 	disassembled, err = Disassemble(ops.Program)
 	require.NoError(t, err)
@@ -1192,7 +1218,8 @@ err
 // comment
 !
 `
-	ops, err = AssembleStringWithVersion(source, AssemblerMaxVersion)
+	// v2 prepends an intcblock and shifts the offsets
+	ops, err = AssembleStringWithVersion(source, 2)
 	require.NoError(t, err)
 	require.Equal(t, 6, len(ops.Program))
 	require.Equal(t, 2, len(ops.OffsetToLine))
@@ -1208,6 +1235,20 @@ err
 	line, ok = ops.OffsetToLine[5]
 	require.True(t, ok)
 	require.Equal(t, 2, line)
+
+	// v3 pushes the single use constant inline instead
+	ops, err = AssembleStringWithVersion(source, 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(ops.Program))
+	require.Equal(t, 2, len(ops.OffsetToLine))
+	// pushint 0
+	line, ok = ops.OffsetToLine[1]
+	require.True(t, ok)
+	require.Equal(t, 0, line)
+	// !
+	line, ok = ops.OffsetToLine[3]
+	require.True(t, ok)
+	require.Equal(t, 2, line)
 }
 
 func TestHasStatefulOps(t *testing.T) {
@@ -82,6 +82,8 @@ var opDocList = []stringString{
 	{"arg_1", "push Args[1] to stack"},
 	{"arg_2", "push Args[2] to stack"},
 	{"arg_3", "push Args[3] to stack"},
+	{"pushbytes", "push the following program bytes to the stack"},
+	{"pushint", "push immediate UINT to the stack as an integer"},
 	{"txn", "push field from current transaction to stack"},
 	{"gtxn", "push field to the stack from a transaction in the current transaction group"},
 	{"txna", "push value of an array field from current transaction to stack"},
@@ -93,9 +95,15 @@ var opDocList = []stringString{
 	{"bz", "branch if value X is zero"},
 	{"b", "branch unconditionally to offset"},
 	{"return", "use last value on stack as success value; end"},
+	{"assert", "immediately fail unless value X is a non-zero number"},
 	{"pop", "discard value X from stack"},
 	{"dup", "duplicate last value on stack"},
 	{"dup2", "duplicate two last values on stack: A, B -> A, B, A, B"},
+	{"dig", "push the Nth value from the top of the stack. dig 0 is equivalent to dup"},
+	{"swap", "swaps two last values on stack: A, B -> B, A"},
+	{"select", "selects one of two values based on top-of-stack: A, B, C -> (if C != 0 then B else A)"},
+	{"cover", "remove top of stack, and place it deeper in the stack such that N elements are above it"},
+	{"uncover", "remove the value at depth N in the stack and shift above items down so the Nth deep value is on top of the stack"},
 	{"concat", "pop two byte strings A and B and join them, push the result"},
 	{"substring", "pop a byte string X. For immediate values in 0..255 M and N: extract a range of bytes from it starting at M up to but not including N, push the substring result. If N < M, or either is larger than the string length, the program fails"},
 	{"substring3", "pop a byte string A and two integers B and C. Extract a range of bytes from A starting at B up to but not including C, push the substring result. If C < B, or either is larger than the string length, the program fails"},
@@ -141,6 +149,11 @@ var opcodeImmediateNoteList = []stringString{
 	{"load", "{uint8 position in scratch space to load from}"},
 	{"store", "{uint8 position in scratch space to store to}"},
 	{"substring", "{uint8 start position}{uint8 end position}"},
+	{"dig", "{uint8 depth}"},
+	{"cover", "{uint8 depth}"},
+	{"uncover", "{uint8 depth}"},
+	{"pushbytes", "{varuint length} {bytes}"},
+	{"pushint", "{varuint int}"},
 	{"asset_holding_get", "{uint8 asset holding field index}"},
 	{"asset_params_get", "{uint8 asset params field index}"},
 }
@@ -178,6 +191,10 @@ var opDocExtraList = []stringString{
 	{"app_global_del", "params: state key.\n\nDeleting a key which is already absent has no effect on the application global state. (In particular, it does _not_ cause the program to fail.)"},
 	{"asset_holding_get", "params: account index, asset id. Return: did_exist flag (1 if exist and 0 otherwise), value."},
 	{"asset_params_get", "params: txn.ForeignAssets offset. Return: did_exist flag (1 if exist and 0 otherwise), value."},
+	{"pushbytes", "pushbytes args are not added to the bytecblock during assembly processes"},
+	{"pushint", "pushint args are not added to the intcblock during assembly processes"},
+	{"cover", "Fails if the stack depth is not greater than N. cover 0 is a no-op."},
+	{"uncover", "Fails if the stack depth is not greater than N. uncover 0 is a no-op, uncover 1 is equivalent to swap."},
 }
 
 var opDocExtras map[string]string
@@ -200,8 +217,8 @@ type OpGroup struct {
 // OpGroupList is groupings of ops for documentation purposes.
 var OpGroupList = []OpGroup{
 	{"Arithmetic", []string{"sha256", "keccak256", "sha512_256", "ed25519verify", "+", "-", "/", "*", "<", ">", "<=", ">=", "&&", "||", "==", "!=", "!", "len", "itob", "btoi", "%", "|", "&", "^", "~", "mulw", "addw", "concat", "substring", "substring3"}},
-	{"Loading Values", []string{"intcblock", "intc", "intc_0", "intc_1", "intc_2", "intc_3", "bytecblock", "bytec", "bytec_0", "bytec_1", "bytec_2", "bytec_3", "arg", "arg_0", "arg_1", "arg_2", "arg_3", "txn", "gtxn", "txna", "gtxna", "global", "load", "store"}},
-	{"Flow Control", []string{"err", "bnz", "bz", "b", "return", "pop", "dup", "dup2"}},
+	{"Loading Values", []string{"intcblock", "intc", "intc_0", "intc_1", "intc_2", "intc_3", "bytecblock", "bytec", "bytec_0", "bytec_1", "bytec_2", "bytec_3", "arg", "arg_0", "arg_1", "arg_2", "arg_3", "pushbytes", "pushint", "txn", "gtxn", "txna", "gtxna", "global", "load", "store"}},
+	{"Flow Control", []string{"err", "bnz", "bz", "b", "return", "assert", "pop", "dup", "dup2", "dig", "swap", "select", "cover", "uncover"}},
 	{"State Access", []string{"balance", "app_opted_in", "app_local_get", "app_local_get_ex", "app_global_get", "app_global_get_ex", "app_local_put", "app_global_put", "app_local_del", "app_global_del", "asset_holding_get", "asset_params_get"}},
 }
 
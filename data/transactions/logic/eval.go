@@ -169,6 +169,12 @@ type EvalParams struct {
 	// MinTealVersion is nil, we will compute it ourselves
 	MinTealVersion *uint64
 
+	// PooledApplicationBudget is the opcode budget remaining for all
+	// application calls in the group. If it is non-nil, EvalStateful fails
+	// a program as soon as its cost exceeds the remaining budget, and
+	// deducts the program's cost from it when evaluation completes.
+	PooledApplicationBudget *uint64
+
 	// determines eval mode: runModeSignature or runModeApplication
 	runModeFlags runMode
 }
@@ -299,6 +305,15 @@ func EvalStateful(program []byte, params EvalParams) (pass bool, err error) {
 	// Evaluate the program
 	pass, err = eval(program, &cx)
 
+	// Charge the group budget for the opcodes that were executed
+	if cx.PooledApplicationBudget != nil {
+		if uint64(cx.cost) > *cx.PooledApplicationBudget {
+			*cx.PooledApplicationBudget = 0
+		} else {
+			*cx.PooledApplicationBudget -= uint64(cx.cost)
+		}
+	}
+
 	return pass, err
 }
 
@@ -566,6 +581,11 @@ func (cx *evalContext) step() {
 		return
 	}
 	cx.cost += oz.cost
+	if cx.PooledApplicationBudget != nil && uint64(cx.cost) > *cx.PooledApplicationBudget {
+		cx.err = fmt.Errorf("pc=%3d dynamic cost budget exceeded, executing %s: remaining budget is %d but program cost was %d",
+			cx.pc, spec.Name, *cx.PooledApplicationBudget, cx.cost)
+		return
+	}
 	spec.op(cx)
 	if cx.Trace != nil {
 		immArgsString := " "
@@ -674,6 +694,15 @@ func opReturn(cx *evalContext) {
 	cx.nextpc = len(cx.program)
 }
 
+func opAssert(cx *evalContext) {
+	last := len(cx.stack) - 1
+	if cx.stack[last].Uint != 0 {
+		cx.stack = cx.stack[:last]
+		return
+	}
+	cx.err = errors.New("assert failed")
+}
+
 func opSHA256(cx *evalContext) {
 	last := len(cx.stack) - 1
 	hash := sha256.Sum256(cx.stack[last].Bytes)
@@ -1053,6 +1082,58 @@ func opByteConst3(cx *evalContext) {
 	opByteConstN(cx, 3)
 }
 
+func opPushInt(cx *evalContext) {
+	val, bytesUsed := binary.Uvarint(cx.program[cx.pc+1:])
+	if bytesUsed <= 0 {
+		cx.err = fmt.Errorf("could not decode int at pc=%d", cx.pc+1)
+		return
+	}
+	cx.stack = append(cx.stack, stackValue{Uint: val})
+	cx.nextpc = cx.pc + 1 + bytesUsed
+}
+
+func checkPushInt(cx *evalContext) int {
+	_, bytesUsed := binary.Uvarint(cx.program[cx.pc+1:])
+	if bytesUsed <= 0 {
+		cx.err = fmt.Errorf("could not decode int at pc=%d", cx.pc+1)
+		return 1
+	}
+	cx.nextpc = cx.pc + 1 + bytesUsed
+	return 1
+}
+
+// parsePushBytes returns the bounds of the pushbytes value starting at pc
+func parsePushBytes(program []byte, pc int) (start int, end int, err error) {
+	pos := pc + 1
+	length, bytesUsed := binary.Uvarint(program[pos:])
+	if bytesUsed <= 0 {
+		err = fmt.Errorf("could not decode length at pc=%d", pos)
+		return
+	}
+	pos += bytesUsed
+	last := uint64(pos) + length
+	if last > uint64(len(program)) || last < uint64(pos) {
+		err = fmt.Errorf("pushbytes too long at pc=%d", pos)
+		return
+	}
+	return pos, int(last), nil
+}
+
+func opPushBytes(cx *evalContext) {
+	var start, end int
+	start, end, cx.err = parsePushBytes(cx.program, cx.pc)
+	if cx.err != nil {
+		return
+	}
+	cx.stack = append(cx.stack, stackValue{Bytes: cx.program[start:end]})
+	cx.nextpc = end
+}
+
+func checkPushBytes(cx *evalContext) int {
+	_, cx.nextpc, cx.err = parsePushBytes(cx.program, cx.pc)
+	return 1
+}
+
 func opArgN(cx *evalContext, n uint64) {
 	if n >= uint64(len(cx.Txn.Lsig.Args)) {
 		cx.err = fmt.Errorf("cannot load arg[%d] of %d", n, len(cx.Txn.Lsig.Args))
@@ -1160,6 +1241,64 @@ func opDup2(cx *evalContext) {
 	cx.stack = append(cx.stack, cx.stack[prev:]...)
 }
 
+func opDig(cx *evalContext) {
+	depth := int(uint(cx.program[cx.pc+1]))
+	idx := len(cx.stack) - 1 - depth
+	// step() only checks that the stack is not empty, dig knows how deep it goes
+	if idx < 0 {
+		cx.err = fmt.Errorf("dig %d with stack size = %d", depth, len(cx.stack))
+		return
+	}
+	sv := cx.stack[idx]
+	cx.stack = append(cx.stack, sv)
+	cx.nextpc = cx.pc + 2
+}
+
+func opSwap(cx *evalContext) {
+	last := len(cx.stack) - 1
+	prev := last - 1
+	cx.stack[last], cx.stack[prev] = cx.stack[prev], cx.stack[last]
+}
+
+func opSelect(cx *evalContext) {
+	last := len(cx.stack) - 1 // condition on top
+	prev := last - 1          // true is one down
+	pprev := prev - 1         // false below that
+
+	if cx.stack[last].Uint != 0 {
+		cx.stack[pprev] = cx.stack[prev]
+	}
+	cx.stack = cx.stack[:prev]
+}
+
+func opCover(cx *evalContext) {
+	depth := int(uint(cx.program[cx.pc+1]))
+	topIdx := len(cx.stack) - 1
+	idx := topIdx - depth
+	if idx < 0 {
+		cx.err = fmt.Errorf("cover %d with stack size = %d", depth, len(cx.stack))
+		return
+	}
+	sv := cx.stack[topIdx]
+	copy(cx.stack[idx+1:], cx.stack[idx:topIdx])
+	cx.stack[idx] = sv
+	cx.nextpc = cx.pc + 2
+}
+
+func opUncover(cx *evalContext) {
+	depth := int(uint(cx.program[cx.pc+1]))
+	topIdx := len(cx.stack) - 1
+	idx := topIdx - depth
+	if idx < 0 {
+		cx.err = fmt.Errorf("uncover %d with stack size = %d", depth, len(cx.stack))
+		return
+	}
+	sv := cx.stack[idx]
+	copy(cx.stack[idx:], cx.stack[idx+1:])
+	cx.stack[topIdx] = sv
+	cx.nextpc = cx.pc + 2
+}
+
 func (cx *evalContext) assetHoldingEnumToValue(holding *basics.AssetHolding, field uint64) (sv stackValue, err error) {
 	switch AssetHoldingField(field) {
 	case AssetBalance:
@@ -1278,7 +1278,8 @@ err
 ok:
 int 1
 `
-	ops, err = AssembleStringWithVersion(source, AssemblerMaxVersion)
+	// v2 keeps ints in the intcblock, the offsets below rely on that
+	ops, err = AssembleStringWithVersion(source, 2)
 	require.NoError(t, err)
 	ledger.setHolding(txn.Txn.Sender, 55, basics.AssetHolding{Amount: 123, Frozen: false})
 	cost, err = CheckStateful(ops.Program, ep)
@@ -1308,7 +1309,7 @@ err
 ok:
 int 1
 `
-	ops, err = AssembleStringWithVersion(source, AssemblerMaxVersion)
+	ops, err = AssembleStringWithVersion(source, 2)
 	require.NoError(t, err)
 	params.DefaultFrozen = true
 	ledger.newAsset(55, params)
@@ -1442,7 +1443,8 @@ int 100
 			source := test.source
 			firstCmdOffset := test.accNumOffset
 
-			ops, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+			// offsets are for v2 programs where every int lives in the intcblock
+			ops, err := AssembleStringWithVersion(source, 2)
 			require.NoError(t, err)
 
 			txn := makeSampleTxn()
@@ -2761,6 +2763,11 @@ func TestReturnTypes(t *testing.T) {
 		"ed25519verify":     "pop\npop\npop\nint 1", // ignore
 		"asset_params_get":  "asset_params_get AssetTotal",
 		"asset_holding_get": "asset_holding_get AssetBalance",
+		"dig":               "dig 0",
+		"cover":             "cover 0",
+		"uncover":           "uncover 0",
+		"pushint":           "pushint 1",
+		"pushbytes":         "pushbytes 0x33",
 	}
 
 	byName := opsByName[LogicVersion]
@@ -2896,3 +2903,42 @@ int 42
 	require.NoError(t, err)
 	require.True(t, pass)
 }
+
+func TestPooledAppCallsBudget(t *testing.T) {
+	source := `byte 0x01
+sha256
+byte 0x02
+sha256
+!=
+`
+	ledger := makeTestLedger(
+		map[basics.Address]uint64{},
+	)
+	ops, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+	require.NoError(t, err)
+	cost, err := CheckStateful(ops.Program, defaultEvalParams(nil, nil))
+	require.NoError(t, err)
+
+	// budget for two runs minus one opcode
+	budget := uint64(2*cost - 1)
+	ep := defaultEvalParams(nil, nil)
+	ep.Ledger = ledger
+	ep.PooledApplicationBudget = &budget
+
+	pass, err := EvalStateful(ops.Program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+	require.Equal(t, uint64(cost-1), budget)
+
+	pass, err = EvalStateful(ops.Program, ep)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dynamic cost budget exceeded")
+	require.False(t, pass)
+	require.Equal(t, uint64(0), budget)
+
+	// without a pooled budget the cost is not limited during evaluation
+	ep.PooledApplicationBudget = nil
+	pass, err = EvalStateful(ops.Program, ep)
+	require.NoError(t, err)
+	require.True(t, pass)
+}
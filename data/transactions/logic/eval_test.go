@@ -1271,7 +1271,7 @@ int 1
 
 const globalV2TestProgram = `global LogicSigVersion
 int 2
-==
+>=
 &&
 global Round
 int 0
@@ -1306,6 +1306,14 @@ func TestGlobal(t *testing.T) {
 			},
 			func(program []byte, ep EvalParams) (int, error) { return CheckStateful(program, ep) },
 		},
+		3: {
+			CurrentApplicationID, globalV1TestProgram + globalV2TestProgram,
+			func(p []byte, ep EvalParams) (bool, error) {
+				pass, err := EvalStateful(p, ep)
+				return pass, err
+			},
+			func(program []byte, ep EvalParams) (int, error) { return CheckStateful(program, ep) },
+		},
 	}
 	ledger := makeTestLedger(nil)
 	ledger.appID = 42
@@ -3030,7 +3038,8 @@ int 1
 	for _, line := range branches {
 		t.Run(fmt.Sprintf("branch=%s", line), func(t *testing.T) {
 			source := fmt.Sprintf(template, line)
-			ops, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+			// v2 keeps both ints in the intcblock so the branch offset is at a known place
+			ops, err := AssembleStringWithVersion(source, 2)
 			require.NoError(t, err)
 			ops.Program[7] = 0xff // clobber the branch offset
 			ops.Program[8] = 0xff // clobber the branch offset
@@ -3581,7 +3590,7 @@ func TestEvalVersions(t *testing.T) {
 txna ApplicationArgs 0
 pop
 `
-	ops, err := AssembleStringWithVersion(text, AssemblerMaxVersion)
+	ops, err := AssembleStringWithVersion(text, 2)
 	require.NoError(t, err)
 
 	ep := defaultEvalParams(nil, nil)
@@ -3912,10 +3921,10 @@ func TestAllowedOpcodesV2(t *testing.T) {
 
 	cnt := 0
 	for _, spec := range OpSpecs {
-		if spec.Version > 1 && !excluded[spec.Name] {
+		if spec.Version == 2 && !excluded[spec.Name] {
 			source, ok := tests[spec.Name]
 			require.True(t, ok, fmt.Sprintf("Missed opcode in the test: %s", spec.Name))
-			ops, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+			ops, err := AssembleStringWithVersion(source, 2)
 			require.NoError(t, err, source)
 			// all opcodes allowed in stateful mode so use CheckStateful/EvalStateful
 			_, err = CheckStateful(ops.Program, ep)
@@ -3948,6 +3957,59 @@ func TestAllowedOpcodesV2(t *testing.T) {
 	require.Equal(t, len(tests), cnt)
 }
 
+// check all v3 opcodes: allowed in v3 and not allowed before
+func TestAllowedOpcodesV3(t *testing.T) {
+	t.Parallel()
+
+	// all tests are expected to fail in evaluation
+	tests := map[string]string{
+		"assert":    "int 0\nassert",
+		"dig":       "int 1\ndig 0",
+		"swap":      "int 1\nbyte 0x01\nswap",
+		"select":    "int 1\nbyte 0x01\nint 1\nselect",
+		"cover":     "int 1\ndup\ncover 1",
+		"uncover":   "int 1\ndup\nuncover 1",
+		"pushint":   "pushint 7\npushint 4",
+		"pushbytes": `pushbytes "stringsfail?"`,
+	}
+
+	ep := defaultEvalParams(nil, nil)
+
+	cnt := 0
+	for _, spec := range OpSpecs {
+		if spec.Version == 3 {
+			source, ok := tests[spec.Name]
+			require.True(t, ok, fmt.Sprintf("Missed opcode in the test: %s", spec.Name))
+			ops, err := AssembleStringWithVersion(source, 3)
+			require.NoError(t, err, source)
+			// all opcodes allowed in stateful mode so use CheckStateful/EvalStateful
+			_, err = CheckStateful(ops.Program, ep)
+			require.NoError(t, err, source)
+			_, err = EvalStateful(ops.Program, ep)
+			require.Error(t, err, source)
+			require.NotContains(t, err.Error(), "illegal opcode")
+
+			for v := byte(0); v <= 2; v++ {
+				ops.Program[0] = v
+				_, err = Check(ops.Program, ep)
+				require.Error(t, err, source)
+				require.Contains(t, err.Error(), "illegal opcode")
+				_, err = CheckStateful(ops.Program, ep)
+				require.Error(t, err, source)
+				require.Contains(t, err.Error(), "illegal opcode")
+				_, err = Eval(ops.Program, ep)
+				require.Error(t, err, source)
+				require.Contains(t, err.Error(), "illegal opcode")
+				_, err = EvalStateful(ops.Program, ep)
+				require.Error(t, err, source)
+				require.Contains(t, err.Error(), "illegal opcode")
+			}
+			cnt++
+		}
+	}
+	require.Equal(t, len(tests), cnt)
+}
+
 func TestRekeyFailsOnOldVersion(t *testing.T) {
 	t.Parallel()
 	for v := uint64(0); v < rekeyingEnabledVersion; v++ {
@@ -0,0 +1,424 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package logic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// LintFinding is a potential problem found in a program by Lint
+type LintFinding struct {
+	// PC of the instruction the finding refers to
+	PC int
+
+	// Line is the zero based source line of the instruction, or -1 if unknown
+	Line int
+
+	Message string
+}
+
+func (lf LintFinding) String() string {
+	if lf.Line < 0 {
+		return fmt.Sprintf("pc=%d: %s", lf.PC, lf.Message)
+	}
+	return fmt.Sprintf("%d: %s", lf.Line+1, lf.Message)
+}
+
+// lintDangerousTxnFields are the transaction fields a program should
+// check before approving, by run mode.
+var lintDangerousTxnFields = map[runMode][]TxnField{
+	runModeSignature:   {RekeyTo, CloseRemainderTo, AssetCloseTo, Fee},
+	runModeApplication: {OnCompletion, RekeyTo},
+}
+
+// lintDangerousGlobalFields are the global fields a program should check
+// before approving, by run mode.
+var lintDangerousGlobalFields = map[runMode][]GlobalField{
+	runModeSignature: {GroupSize},
+}
+
+// lintComparisonOps are the ops whose result tells something about the
+// values of their arguments, so that deciding on it checks the fields the
+// arguments were read from.
+var lintComparisonOps = map[string]bool{
+	"==": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true,
+	"&&": true, "||": true, "!": true,
+}
+
+// lintDecisionOps are the ops that decide on the value they consume, so
+// that the fields it was read from or compared with are checked.
+var lintDecisionOps = map[string]bool{
+	"bz": true, "bnz": true, "assert": true, "return": true,
+}
+
+// lintValue is what is known about a value on the stack
+type lintValue struct {
+	t StackType
+
+	// fields is the set of dangerous fields, as bits indexed into
+	// linter.dangerous, the value was read from or compared with
+	fields uint32
+}
+
+// lintInsn is a decoded instruction of the program being analyzed
+type lintInsn struct {
+	pc   int
+	spec *OpSpec
+}
+
+// lintState is what is known about the program state when reaching an
+// instruction, merged over all paths reaching it.
+type lintState struct {
+	stack []lintValue
+
+	// dangerous fields checked on every path so far. A field is checked
+	// when a branch, assert or return decides on its value, or on the
+	// result of comparing it.
+	checked map[string]bool
+}
+
+func (ls *lintState) clone() *lintState {
+	c := &lintState{
+		stack:   make([]lintValue, len(ls.stack)),
+		checked: make(map[string]bool, len(ls.checked)),
+	}
+	copy(c.stack, ls.stack)
+	for f := range ls.checked {
+		c.checked[f] = true
+	}
+	return c
+}
+
+type linter struct {
+	ops      *OpStream
+	mode     runMode
+	version  uint64
+	insns    []lintInsn
+	findings map[string]LintFinding
+
+	// names of the dangerous fields that exist in this program version
+	dangerous []string
+}
+
+// Lint analyzes an assembled program and reports code that is unreachable,
+// stack misuse along any control flow path, and approving paths that do not
+// check fields that are commonly exploited if left unchecked. stateful
+// selects analysis for an application program rather than a LogicSig.
+func Lint(ops *OpStream, stateful bool) []LintFinding {
+	l := linter{
+		ops:      ops,
+		mode:     runModeSignature,
+		findings: make(map[string]LintFinding),
+	}
+	if stateful {
+		l.mode = runModeApplication
+	}
+
+	program := ops.Program
+	version, vlen := binary.Uvarint(program)
+	if vlen <= 0 {
+		l.report(0, "invalid version")
+		return l.result()
+	}
+	if version > EvalMaxVersion {
+		l.report(0, fmt.Sprintf("program version %d greater than max supported version %d", version, EvalMaxVersion))
+		return l.result()
+	}
+	l.version = version
+	for _, f := range lintDangerousTxnFields[l.mode] {
+		if txnFieldSpecByField[f].version <= version {
+			l.dangerous = append(l.dangerous, f.String())
+		}
+	}
+	for _, f := range lintDangerousGlobalFields[l.mode] {
+		if globalFieldSpecByField[f].version <= version {
+			l.dangerous = append(l.dangerous, f.String())
+		}
+	}
+
+	if l.decode(vlen) {
+		l.analyze()
+	}
+	return l.result()
+}
+
+func (l *linter) report(pc int, msg string) {
+	key := fmt.Sprintf("%d %s", pc, msg)
+	if _, ok := l.findings[key]; ok {
+		return
+	}
+	line, ok := l.ops.OffsetToLine[pc]
+	if !ok {
+		line = -1
+	}
+	l.findings[key] = LintFinding{PC: pc, Line: line, Message: msg}
+}
+
+func (l *linter) result() []LintFinding {
+	res := make([]LintFinding, 0, len(l.findings))
+	for _, f := range l.findings {
+		res = append(res, f)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].PC != res[j].PC {
+			return res[i].PC < res[j].PC
+		}
+		return res[i].Message < res[j].Message
+	})
+	return res
+}
+
+// decode splits the program into instructions the same way Check does
+func (l *linter) decode(start int) bool {
+	cx := evalContext{program: l.ops.Program, version: l.version}
+	cx.runModeFlags = l.mode
+	cx.pc = start
+	for cx.pc < len(cx.program) {
+		pc := cx.pc
+		spec := &opsByOpcode[l.version][cx.program[pc]]
+		cx.checkStep()
+		if cx.err != nil {
+			l.report(pc, cx.err.Error())
+			return false
+		}
+		l.insns = append(l.insns, lintInsn{pc: pc, spec: spec})
+	}
+	if len(cx.branchTargets) > 0 && cx.branchTargets[0] < len(cx.program) {
+		l.report(cx.branchTargets[0], "branch target is not an aligned instruction")
+		return false
+	}
+	return true
+}
+
+// analyze walks the control flow graph. Branches only go forward, so
+// visiting the instructions in order sees every predecessor of an
+// instruction before the instruction itself.
+func (l *linter) analyze() {
+	index := make(map[int]int, len(l.insns)+1)
+	for i, insn := range l.insns {
+		index[insn.pc] = i
+	}
+	end := len(l.insns)
+	index[len(l.ops.Program)] = end
+
+	states := make([]*lintState, end+1)
+	states[0] = &lintState{checked: make(map[string]bool)}
+	reachable := true
+	for i, insn := range l.insns {
+		in := states[i]
+		if in == nil {
+			if reachable {
+				l.report(insn.pc, "unreachable code")
+			}
+			reachable = false
+			continue
+		}
+		reachable = true
+
+		out := l.step(insn, in.clone())
+		if out == nil {
+			// the path ends here
+			continue
+		}
+		switch insn.spec.Name {
+		case "err":
+		case "return":
+			l.approve(insn.pc, out)
+		case "b":
+			l.merge(states, index[l.branchTarget(insn)], out, insn.pc)
+		case "bnz", "bz":
+			l.merge(states, index[l.branchTarget(insn)], out, insn.pc)
+			l.merge(states, i+1, out, insn.pc)
+		default:
+			l.merge(states, i+1, out, insn.pc)
+		}
+	}
+
+	final := states[end]
+	if final == nil {
+		return
+	}
+	pc := len(l.ops.Program)
+	if len(l.insns) > 0 {
+		pc = l.insns[end-1].pc
+	}
+	if len(final.stack) != 1 {
+		l.report(pc, fmt.Sprintf("program ends with %d values on the stack instead of 1", len(final.stack)))
+	} else if final.stack[0].t == StackBytes {
+		l.report(pc, "program ends with []byte on the stack instead of uint64")
+	}
+	if len(final.stack) > 0 {
+		// the program approves based on the value it ends with
+		l.decide(final, final.stack[len(final.stack)-1].fields)
+	}
+	l.approve(pc, final)
+}
+
+func (l *linter) branchTarget(insn lintInsn) int {
+	offset := (int(l.ops.Program[insn.pc+1]) << 8) | int(l.ops.Program[insn.pc+2])
+	return insn.pc + 3 + offset
+}
+
+// decide marks the given dangerous fields as checked
+func (l *linter) decide(st *lintState, fields uint32) {
+	for i, f := range l.dangerous {
+		if fields&(1<<uint(i)) != 0 {
+			st.checked[f] = true
+		}
+	}
+}
+
+// approve reports the dangerous fields not checked on every path reaching an
+// instruction that may approve the transaction
+func (l *linter) approve(pc int, st *lintState) {
+	for _, f := range l.dangerous {
+		if !st.checked[f] {
+			l.report(pc, fmt.Sprintf("may approve without checking %s", f))
+		}
+	}
+}
+
+// merge combines the state from a new path into the state at instruction i
+func (l *linter) merge(states []*lintState, i int, st *lintState, from int) {
+	cur := states[i]
+	if cur == nil {
+		states[i] = st.clone()
+		return
+	}
+	if len(cur.stack) != len(st.stack) {
+		l.report(from, fmt.Sprintf("stack depth %d differs from %d on another path to the same instruction", len(st.stack), len(cur.stack)))
+		return
+	}
+	for k := range cur.stack {
+		if cur.stack[k].t != st.stack[k].t {
+			cur.stack[k].t = StackAny
+		}
+		cur.stack[k].fields &= st.stack[k].fields
+	}
+	for f := range cur.checked {
+		if !st.checked[f] {
+			delete(cur.checked, f)
+		}
+	}
+}
+
+// step applies an instruction to the state. It returns nil if the path
+// can not continue.
+func (l *linter) step(insn lintInsn, st *lintState) *lintState {
+	spec := insn.spec
+	program := l.ops.Program
+	depth := len(st.stack)
+
+	if depth < len(spec.Args) {
+		l.report(insn.pc, fmt.Sprintf("stack underflow in %s", spec.Name))
+		return nil
+	}
+	first := depth - len(spec.Args)
+	var argFields uint32
+	for i, argType := range spec.Args {
+		if !typecheck(argType, st.stack[first+i].t) {
+			l.report(insn.pc, fmt.Sprintf("%s arg %d wanted %s but got %s", spec.Name, i, argType, st.stack[first+i].t))
+		}
+		argFields |= st.stack[first+i].fields
+	}
+
+	// ops whose result depends on the stack or the immediates
+	switch spec.Name {
+	case "dup":
+		st.stack = append(st.stack, st.stack[depth-1])
+		return st
+	case "dup2":
+		st.stack = append(st.stack, st.stack[depth-2], st.stack[depth-1])
+		return st
+	case "swap":
+		st.stack[depth-1], st.stack[depth-2] = st.stack[depth-2], st.stack[depth-1]
+		return st
+	case "select":
+		a, b := st.stack[depth-3], st.stack[depth-2]
+		if a.t != b.t {
+			a.t = StackAny
+		}
+		a.fields &= b.fields
+		st.stack = append(st.stack[:depth-3], a)
+		return st
+	case "dig", "cover", "uncover":
+		n := int(program[insn.pc+1])
+		if depth <= n {
+			l.report(insn.pc, fmt.Sprintf("%s %d with stack size = %d", spec.Name, n, depth))
+			return nil
+		}
+		switch spec.Name {
+		case "dig":
+			st.stack = append(st.stack, st.stack[depth-1-n])
+		case "cover":
+			top := st.stack[depth-1]
+			copy(st.stack[depth-n:], st.stack[depth-1-n:depth-1])
+			st.stack[depth-1-n] = top
+		case "uncover":
+			sv := st.stack[depth-1-n]
+			copy(st.stack[depth-1-n:], st.stack[depth-n:])
+			st.stack[depth-1] = sv
+		}
+		return st
+	}
+
+	st.stack = st.stack[:first]
+	if lintDecisionOps[spec.Name] {
+		l.decide(st, argFields)
+	}
+	returns := spec.Returns
+	var fields uint32
+	switch spec.Name {
+	case "txn", "txna":
+		returns, fields = l.readTxnField(TxnField(program[insn.pc+1]), returns)
+	case "gtxn", "gtxna":
+		returns, fields = l.readTxnField(TxnField(program[insn.pc+2]), returns)
+	case "global":
+		field := GlobalField(program[insn.pc+1])
+		if fs, ok := globalFieldSpecByField[field]; ok {
+			returns, fields = StackTypes{fs.ftype}, l.dangerousField(field.String())
+		}
+	default:
+		if lintComparisonOps[spec.Name] {
+			fields = argFields
+		}
+	}
+	for _, t := range returns {
+		st.stack = append(st.stack, lintValue{t: t, fields: fields})
+	}
+	return st
+}
+
+func (l *linter) readTxnField(field TxnField, returns StackTypes) (StackTypes, uint32) {
+	fs, ok := txnFieldSpecByField[field]
+	if !ok {
+		return returns, 0
+	}
+	return StackTypes{fs.ftype}, l.dangerousField(field.String())
+}
+
+// dangerousField returns the bit of the named field if it is dangerous
+func (l *linter) dangerousField(name string) uint32 {
+	for i, f := range l.dangerous {
+		if f == name {
+			return 1 << uint(i)
+		}
+	}
+	return 0
+}
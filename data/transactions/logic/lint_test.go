@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package logic
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func lintSource(t *testing.T, source string, stateful bool) []string {
+	ops, err := AssembleStringWithVersion(source, AssemblerMaxVersion)
+	require.NoError(t, err)
+	findings := Lint(ops, stateful)
+	res := make([]string, len(findings))
+	for i, f := range findings {
+		res[i] = f.String()
+	}
+	return res
+}
+
+const lintCheckedSig = `txn RekeyTo
+global ZeroAddress
+==
+txn CloseRemainderTo
+global ZeroAddress
+==
+&&
+txn AssetCloseTo
+global ZeroAddress
+==
+&&
+txn Fee
+int 1000
+<=
+&&
+global GroupSize
+int 1
+==
+&&
+`
+
+func TestLintClean(t *testing.T) {
+	t.Parallel()
+	require.Empty(t, lintSource(t, lintCheckedSig, false))
+	require.Empty(t, lintSource(t, "txn OnCompletion\nint NoOp\n==\ntxn RekeyTo\nglobal ZeroAddress\n==\n&&", true))
+}
+
+func TestLintDangerousFields(t *testing.T) {
+	t.Parallel()
+	findings := lintSource(t, "int 1", false)
+	require.Equal(t, []string{
+		"1: may approve without checking AssetCloseTo",
+		"1: may approve without checking CloseRemainderTo",
+		"1: may approve without checking Fee",
+		"1: may approve without checking GroupSize",
+		"1: may approve without checking RekeyTo",
+	}, findings)
+
+	findings = lintSource(t, "int 1", true)
+	require.Equal(t, []string{
+		"1: may approve without checking OnCompletion",
+		"1: may approve without checking RekeyTo",
+	}, findings)
+
+	// RekeyTo checked on only one of the paths
+	source := `txn OnCompletion
+bnz other
+txn RekeyTo
+global ZeroAddress
+==
+return
+other:
+int 1
+return
+`
+	findings = lintSource(t, source, true)
+	require.Equal(t, []string{"9: may approve without checking RekeyTo"}, findings)
+	findings = lintSource(t, "txn Fee\npop\n"+source, false)
+	require.Contains(t, findings, "11: may approve without checking RekeyTo")
+	require.NotContains(t, findings, "8: may approve without checking RekeyTo")
+}
+
+func TestLintUnreachable(t *testing.T) {
+	t.Parallel()
+	source := lintCheckedSig + `return
+int 2
+pop
+done:
+int 1
+return
+`
+	findings := lintSource(t, source, false)
+	require.Equal(t, []string{"21: unreachable code"}, findings)
+
+	source = lintCheckedSig + `bnz done
+err
+int 2
+done:
+int 1
+`
+	findings = lintSource(t, source, false)
+	require.Equal(t, []string{"22: unreachable code"}, findings)
+}
+
+func TestLintStack(t *testing.T) {
+	t.Parallel()
+	source := lintCheckedSig + `int 1
+bnz skip
+int 2
+skip:
+`
+	findings := lintSource(t, source, false)
+	require.Contains(t, findings, "22: stack depth 2 differs from 1 on another path to the same instruction")
+
+	// the assembler would refuse this, so lint the bytes directly
+	program, err := hex.DecodeString("03800101810108") // pushbytes 0x01; pushint 1; +
+	require.NoError(t, err)
+	lf := Lint(&OpStream{Program: program}, false)
+	require.NotEmpty(t, lf)
+	require.Equal(t, "pc=6: + arg 0 wanted uint64 but got []byte", lf[0].String())
+
+	source = lintCheckedSig + `assert
+byte 0x01
+`
+	findings = lintSource(t, source, false)
+	require.Equal(t, []string{"21: program ends with []byte on the stack instead of uint64"}, findings)
+
+	source = lintCheckedSig + `assert
+int 1
+int 2
+`
+	findings = lintSource(t, source, false)
+	require.Equal(t, []string{"22: program ends with 2 values on the stack instead of 1"}, findings)
+
+	// stack manipulation keeps track of types
+	source = lintCheckedSig + `assert
+int 2
+byte 0x01
+swap
+pop
+len
+dig 0
+cover 1
+uncover 1
+==
+`
+	require.Empty(t, lintSource(t, source, false))
+}
+
+func TestLintReadIsNotCheck(t *testing.T) {
+	t.Parallel()
+	// reading a field, or comparing it without deciding on the result, does not check it
+	source := `txn RekeyTo
+pop
+txn OnCompletion
+int NoOp
+==
+pop
+int 1
+`
+	require.Equal(t, []string{
+		"7: may approve without checking OnCompletion",
+		"7: may approve without checking RekeyTo",
+	}, lintSource(t, source, true))
+
+	// arithmetic on a field is not a comparison
+	source = `txn OnCompletion
+int 1
++
+txn RekeyTo
+global ZeroAddress
+==
+&&
+`
+	require.Equal(t, []string{"7: may approve without checking OnCompletion"}, lintSource(t, source, true))
+
+	// asserting on a comparison, or on the field value itself, checks it
+	source = `txn RekeyTo
+global ZeroAddress
+==
+assert
+txn OnCompletion
+!
+assert
+int 1
+`
+	require.Empty(t, lintSource(t, source, true))
+
+	// the check is carried through stack manipulation
+	source = `txn RekeyTo
+global ZeroAddress
+==
+txn OnCompletion
+int NoOp
+==
+swap
+dup2
+pop
+pop
+&&
+`
+	require.Empty(t, lintSource(t, source, true))
+}
@@ -21,7 +21,7 @@ import (
 )
 
 // LogicVersion defines default assembler and max eval versions
-const LogicVersion = 2
+const LogicVersion = 3
 
 // rekeyingEnabledVersion is the version of TEAL where RekeyTo functionality
 // was enabled. This is important to remember so that old TEAL accounts cannot
@@ -33,6 +33,12 @@ const rekeyingEnabledVersion = 2
 // from being used with applications. Do not edit!
 const appsEnabledVersion = 2
 
+// optimizeConstantsEnabledVersion is the first version of TEAL where the
+// assembler replaces single use constants with pushint/pushbytes and orders
+// constant blocks by frequency of use. pushint/pushbytes are not available
+// before this version, so older programs are assembled as before.
+const optimizeConstantsEnabledVersion = 3
+
 // opSize records the length in bytes for an op that is constant-length but not length 1
 type opSize struct {
 	cost      int
@@ -143,9 +149,16 @@ var OpSpecs = []OpSpec{
 	{0x41, "bz", opBz, assembleBranch, disBranch, oneInt, nil, 2, modeAny, opSize{1, 3, checkBranch}},
 	{0x42, "b", opB, assembleBranch, disBranch, nil, nil, 2, modeAny, opSize{1, 3, checkBranch}},
 	{0x43, "return", opReturn, asmDefault, disDefault, oneInt, nil, 2, modeAny, opSizeDefault},
+	{0x44, "assert", opAssert, asmDefault, disDefault, oneInt, nil, 3, modeAny, opSizeDefault},
 	{0x48, "pop", opPop, asmDefault, disDefault, oneAny, nil, 1, modeAny, opSizeDefault},
 	{0x49, "dup", opDup, asmDefault, disDefault, oneAny, twoAny, 1, modeAny, opSizeDefault},
 	{0x4a, "dup2", opDup2, asmDefault, disDefault, twoAny, twoAny.plus(twoAny), 2, modeAny, opSizeDefault},
+	// dig, cover and uncover check the stack depth themselves since it depends on the immediate
+	{0x4b, "dig", opDig, assembleDig, disStackDepth, oneAny, twoAny, 3, modeAny, opSize{1, 2, nil}},
+	{0x4c, "swap", opSwap, assembleSwap, disDefault, twoAny, twoAny, 3, modeAny, opSizeDefault},
+	{0x4d, "select", opSelect, assembleSelect, disDefault, twoAny.plus(oneInt), oneAny, 3, modeAny, opSizeDefault},
+	{0x4e, "cover", opCover, assembleCover, disStackDepth, oneAny, oneAny, 3, modeAny, opSize{1, 2, nil}},
+	{0x4f, "uncover", opUncover, assembleUncover, disStackDepth, oneAny, oneAny, 3, modeAny, opSize{1, 2, nil}},
 
 	{0x50, "concat", opConcat, asmDefault, disDefault, twoBytes, oneBytes, 2, modeAny, opSizeDefault},
 	{0x51, "substring", opSubstring, assembleSubstring, disSubstring, oneBytes, oneBytes, 2, modeAny, opSize{1, 3, nil}},
@@ -164,6 +177,9 @@ var OpSpecs = []OpSpec{
 
 	{0x70, "asset_holding_get", opAssetHoldingGet, assembleAssetHolding, disAssetHolding, twoInts, oneAny.plus(oneInt), 2, runModeApplication, opSize{1, 2, nil}},
 	{0x71, "asset_params_get", opAssetParamsGet, assembleAssetParams, disAssetParams, oneInt, oneAny.plus(oneInt), 2, runModeApplication, opSize{1, 2, nil}},
+
+	{0x80, "pushbytes", opPushBytes, assemblePushBytes, disPushBytes, nil, oneBytes, 3, modeAny, opSize{1, 0, checkPushBytes}},
+	{0x81, "pushint", opPushInt, assemblePushInt, disPushInt, nil, oneInt, 3, modeAny, opSize{1, 0, checkPushInt}},
 }
 
 type sortByOpcode []OpSpec
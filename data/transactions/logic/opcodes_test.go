@@ -84,7 +84,7 @@ func TestOpcodesByVersion(t *testing.T) {
 		OpSpecs2[idx] = cp
 	}
 
-	opSpecs := make([][]OpSpec, 2)
+	opSpecs := make([][]OpSpec, LogicVersion)
 	for v := uint64(1); v <= LogicVersion; v++ {
 		t.Run(fmt.Sprintf("v=%d", v), func(t *testing.T) {
 			opSpecs[v-1] = OpcodesByVersion(v)
@@ -117,8 +117,8 @@ func TestOpcodesByVersion(t *testing.T) {
 func TestOpcodesVersioningV2(t *testing.T) {
 	t.Parallel()
 
-	require.Equal(t, 3, len(opsByOpcode))
-	require.Equal(t, 3, len(opsByName))
+	require.Equal(t, LogicVersion+1, len(opsByOpcode))
+	require.Equal(t, LogicVersion+1, len(opsByName))
 
 	// ensure v0 has only v0 opcodes
 	cntv0 := 0
@@ -202,4 +202,23 @@ func TestOpcodesVersioningV2(t *testing.T) {
 	require.Equal(t, newOpcodes+overwritten, cntAdded)
 
 	require.Equal(t, cntv2, cntv1+newOpcodes)
+
+	// ensure v3 has v1, v2 and v3 opcodes
+	cntv3 := 0
+	cntAdded = 0
+	for _, spec := range opsByOpcode[3] {
+		if spec.op != nil {
+			require.True(t, spec.Version >= 1 && spec.Version <= 3)
+			if spec.Version == 3 {
+				cntAdded++
+			}
+			cntv3++
+		}
+	}
+	require.Equal(t, cntv3, len(opsByName[3]))
+
+	// assert, dig, swap, select, cover, uncover, pushbytes, pushint
+	newOpcodes = 8
+	require.Equal(t, newOpcodes, cntAdded)
+	require.Equal(t, cntv3, cntv2+newOpcodes)
 }
@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxExpansionDepth limits nesting of #include files and macro expansions
+const maxExpansionDepth = 16
+
+// macro is a #define. A macro without params that expands to a single
+// field is a named constant and may also be used as an operand.
+type macro struct {
+	// params is nil for a macro that takes no arguments
+	params []string
+
+	// body holds the fields of each instruction of the expansion
+	body [][]string
+}
+
+// splitInstructions splits fields into instructions separated by ";"
+func splitInstructions(fields []string) [][]string {
+	var insns [][]string
+	var cur []string
+	for _, field := range fields {
+		if strings.HasSuffix(field, ";") && !strings.HasPrefix(field, "\"") {
+			if field != ";" {
+				cur = append(cur, field[:len(field)-1])
+			}
+			if len(cur) > 0 {
+				insns = append(insns, cur)
+			}
+			cur = nil
+			continue
+		}
+		cur = append(cur, field)
+	}
+	if len(cur) > 0 {
+		insns = append(insns, cur)
+	}
+	return insns
+}
+
+// parseCall splits fields starting with "name(a, b)" into the name, the
+// arguments and the fields after the closing parenthesis
+func parseCall(fields []string) (name string, args []string, rest []string, ok bool) {
+	open := strings.IndexByte(fields[0], '(')
+	if open <= 0 {
+		return
+	}
+	name = fields[0][:open]
+	var sb strings.Builder
+	sb.WriteString(fields[0][open+1:])
+	i := 0
+	for !strings.HasSuffix(fields[i], ")") {
+		i++
+		if i == len(fields) {
+			return
+		}
+		sb.WriteByte(' ')
+		sb.WriteString(fields[i])
+	}
+	inner := sb.String()
+	inner = strings.TrimSpace(inner[:len(inner)-1])
+	if inner != "" {
+		for _, arg := range strings.Split(inner, ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+	return name, args, fields[i+1:], true
+}
+
+// define handles #define NAME body or #define NAME(param, ...) body, where
+// body is one or more instructions separated by ";"
+func (ops *OpStream) define(line string) error {
+	fields := fieldsFromLine(line)
+	if fields[0] != "#define" {
+		return ops.errorf("invalid syntax: %s", fields[0])
+	}
+	if len(fields) < 3 {
+		return ops.error("#define needs a name and a value")
+	}
+	var m macro
+	name := fields[1]
+	body := fields[2:]
+	if strings.IndexByte(name, '(') >= 0 {
+		var params []string
+		var ok bool
+		name, params, body, ok = parseCall(fields[1:])
+		if !ok {
+			return ops.errorf("#define %s has unterminated parameter list", name)
+		}
+		if len(body) == 0 {
+			return ops.errorf("#define %s needs a value", name)
+		}
+		// non-nil even without params, so NAME() is still a call
+		m.params = append([]string{}, params...)
+	}
+	_, isOp := opsByName[AssemblerMaxVersion][name]
+	_, isKeyword := keywords[name]
+	if isOp || isKeyword {
+		return ops.errorf("#define %s would hide the opcode of the same name", name)
+	}
+	if _, ok := ops.macros[name]; ok {
+		return ops.errorf("%s is already defined", name)
+	}
+	m.body = splitInstructions(body)
+	if len(m.body) == 0 {
+		return ops.errorf("#define %s needs a value", name)
+	}
+	if ops.macros == nil {
+		ops.macros = make(map[string]macro)
+	}
+	ops.macros[name] = m
+	return nil
+}
+
+// slot handles #slot NAME [N], naming scratch slot N, or the lowest slot not
+// yet named if N is omitted
+func (ops *OpStream) slot(line string) error {
+	fields := fieldsFromLine(line)
+	if fields[0] != "#slot" {
+		return ops.errorf("invalid syntax: %s", fields[0])
+	}
+	if len(fields) < 2 || len(fields) > 3 {
+		return ops.error("#slot needs a name and an optional slot number")
+	}
+	name := fields[1]
+	if _, ok := ops.macros[name]; ok {
+		return ops.errorf("%s is already defined", name)
+	}
+	var n uint64
+	if len(fields) == 3 {
+		var err error
+		n, err = strconv.ParseUint(fields[2], 0, 64)
+		if err != nil || n > EvalMaxScratchSize {
+			return ops.errorf("#slot %s outside 0..%d: %s", name, EvalMaxScratchSize, fields[2])
+		}
+		if ops.slotsUsed[n] {
+			return ops.errorf("#slot %s reuses slot %d", name, n)
+		}
+	} else {
+		for n <= EvalMaxScratchSize && ops.slotsUsed[n] {
+			n++
+		}
+		if n > EvalMaxScratchSize {
+			return ops.errorf("#slot %s: no scratch slots left", name)
+		}
+	}
+	ops.slotsUsed[n] = true
+	if ops.macros == nil {
+		ops.macros = make(map[string]macro)
+	}
+	ops.macros[name] = macro{body: [][]string{{strconv.FormatUint(n, 10)}}}
+	return nil
+}
+
+// expandMacro assembles the expansion if fields invoke a macro. Fields after
+// the invocation are appended to the last instruction of the expansion.
+func (ops *OpStream) expandMacro(fields []string) bool {
+	var m macro
+	name, args, rest, ok := parseCall(fields)
+	if ok {
+		m, ok = ops.macros[name]
+		if ok && m.params == nil {
+			ops.errorf("%s does not take arguments", name)
+			return true
+		}
+	} else {
+		name = fields[0]
+		rest = fields[1:]
+		m, ok = ops.macros[name]
+		if ok && m.params != nil {
+			ops.errorf("%s needs %d arguments", name, len(m.params))
+			return true
+		}
+	}
+	if !ok {
+		return false
+	}
+	if len(args) != len(m.params) {
+		ops.errorf("%s needs %d arguments but was given %d", name, len(m.params), len(args))
+		return true
+	}
+	if ops.expansionDepth >= maxExpansionDepth {
+		ops.errorf("%s expands too deeply", name)
+		return true
+	}
+
+	ops.expansionDepth++
+	for i, insn := range m.body {
+		expanded := make([]string, 0, len(insn)+len(rest))
+		for _, field := range insn {
+			replaced := false
+			for j, param := range m.params {
+				if field == param {
+					expanded = append(expanded, fieldsFromLine(args[j])...)
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				expanded = append(expanded, field)
+			}
+		}
+		if i == len(m.body)-1 {
+			expanded = append(expanded, rest...)
+		}
+		if len(expanded) > 0 {
+			ops.assembleFields(expanded)
+		}
+	}
+	ops.expansionDepth--
+	return true
+}
+
+// substituteDefines replaces operands that are named constants with their
+// values. It returns false after reporting an operand that is not a constant.
+func (ops *OpStream) substituteDefines(fields []string) ([]string, bool) {
+	if len(ops.macros) == 0 {
+		return fields, true
+	}
+	var out []string
+	for i, field := range fields {
+		m, ok := ops.macros[field]
+		if i == 0 || !ok {
+			if out != nil {
+				out = append(out, field)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]string, 0, len(fields)), fields[:i]...)
+		}
+		if m.params != nil || len(m.body) != 1 {
+			ops.errorf("%s can not be used as an operand", field)
+			return nil, false
+		}
+		out = append(out, m.body[0]...)
+	}
+	if out == nil {
+		return fields, true
+	}
+	return out, true
+}
+
+// include handles #include "file", assembling the lines of the file as if
+// they appeared in place of the directive
+func (ops *OpStream) include(line string) error {
+	fields := fieldsFromLine(line)
+	if fields[0] != "#include" {
+		return ops.errorf("invalid syntax: %s", fields[0])
+	}
+	if len(fields) != 2 {
+		return ops.error("#include needs one file name")
+	}
+	name := fields[1]
+	if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+		name = name[1 : len(name)-1]
+	}
+	if len(ops.IncludeDirs) == 0 {
+		return ops.errorf("#include %s: includes are not available here", name)
+	}
+	if ops.expansionDepth >= maxExpansionDepth {
+		return ops.errorf("#include %s nests too deeply", name)
+	}
+
+	// a nested #include is relative to the file including it first
+	dirs := ops.IncludeDirs
+	if ops.includeDir != "" {
+		dirs = append([]string{ops.includeDir}, ops.IncludeDirs...)
+	}
+	var text []byte
+	var err error
+	path := name
+	if filepath.IsAbs(name) {
+		text, err = ioutil.ReadFile(name)
+	} else {
+		for _, dir := range dirs {
+			path = filepath.Join(dir, name)
+			text, err = ioutil.ReadFile(path)
+			if !os.IsNotExist(err) {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return ops.errorf("#include %s: %v", name, err)
+	}
+
+	ops.expansionDepth++
+	includer := ops.includeDir
+	ops.includeDir = filepath.Dir(path)
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	for scanner.Scan() {
+		ops.assembleLine(scanner.Text())
+	}
+	ops.includeDir = includer
+	ops.expansionDepth--
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package logic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testSameProgram checks that source assembles to the same program as expected
+func testSameProgram(t *testing.T, source string, expected string, includeDirs []string) *OpStream {
+	ops, err := assembleString(source, AssemblerMaxVersion, includeDirs)
+	require.NoError(t, err)
+	exp, err := AssembleStringWithVersion(expected, AssemblerMaxVersion)
+	require.NoError(t, err)
+	require.Equal(t, exp.Program, ops.Program)
+	return ops
+}
+
+func TestDefine(t *testing.T) {
+	t.Parallel()
+	source := `#define FEE 1000
+#define ZERO global ZeroAddress
+#define CHECKZERO(f) txn f; ZERO; ==
+txn Fee
+int FEE
+<=
+CHECKZERO(RekeyTo)
+&&
+CHECKZERO(CloseRemainderTo)
+&&
+`
+	expected := `txn Fee
+int 1000
+<=
+txn RekeyTo
+global ZeroAddress
+==
+&&
+txn CloseRemainderTo
+global ZeroAddress
+==
+&&
+`
+	ops := testSameProgram(t, source, expected, nil)
+	// instructions from a macro map to the line of the invocation
+	lines := make(map[int]bool)
+	for _, line := range ops.OffsetToLine {
+		lines[line] = true
+	}
+	require.Equal(t, map[int]bool{3: true, 4: true, 5: true, 6: true, 7: true, 8: true, 9: true}, lines)
+
+	// trailing fields go to the last instruction of the expansion
+	testSameProgram(t, "#define ld load\nld 3", "load 3", nil)
+
+	// a macro may take several arguments, which may be several fields
+	testSameProgram(t, "#define ADD(a, b) a; b; +\nADD(int 1, int 2)", "int 1\nint 2\n+", nil)
+}
+
+func TestDefineErrors(t *testing.T) {
+	t.Parallel()
+	testProg(t, "#define int 1", AssemblerMaxVersion, expect{1, "#define int would hide the opcode of the same name"})
+	testProg(t, "#define sha256 1", AssemblerMaxVersion, expect{1, "#define sha256 would hide the opcode of the same name"})
+	testProg(t, "#define X 1\n#define X 2", AssemblerMaxVersion, expect{2, "X is already defined"})
+	testProg(t, "#define X", AssemblerMaxVersion, expect{1, "#define needs a name and a value"})
+	testProg(t, "#define F(a int 1", AssemblerMaxVersion, expect{1, "#define F has unterminated parameter list"})
+	testProg(t, "#define F(a) a\nF(int 1, int 2)", AssemblerMaxVersion, expect{2, "F needs 1 arguments but was given 2"})
+	testProg(t, "#define F(a) a\nF", AssemblerMaxVersion, expect{2, "F needs 1 arguments"})
+	testProg(t, "#define X int 1\nX(1)", AssemblerMaxVersion, expect{2, "X does not take arguments"})
+	testProg(t, "#define X int 1; int 2\nint X", AssemblerMaxVersion, expect{2, "X can not be used as an operand"})
+	testProg(t, "#define X Y\n#define Y X\nX", AssemblerMaxVersion, expect{3, "X expands too deeply"})
+}
+
+func TestSlot(t *testing.T) {
+	t.Parallel()
+	source := `#slot counter
+#slot total 10
+#slot other
+int 1
+store counter
+load counter
+store total
+load total
+store other
+load other
+`
+	expected := `int 1
+store 0
+load 0
+store 10
+load 10
+store 1
+load 1
+`
+	testSameProgram(t, source, expected, nil)
+
+	testProg(t, "#slot a 1\n#slot b 1", AssemblerMaxVersion, expect{2, "#slot b reuses slot 1"})
+	testProg(t, "#slot a 256", AssemblerMaxVersion, expect{1, "#slot a outside 0..255: 256"})
+	testProg(t, "#slot a\n#slot a", AssemblerMaxVersion, expect{2, "a is already defined"})
+}
+
+func TestInclude(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "TestInclude")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sub := filepath.Join(dir, "sub")
+	require.NoError(t, os.Mkdir(sub, 0700))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "checks.teal"), []byte(`#define CHECKZERO(f) txn f; global ZeroAddress; ==
+#include "inner.teal"
+`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(sub, "inner.teal"), []byte("#define FEE 1000\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "loop.teal"), []byte("#include \"loop.teal\"\n"), 0600))
+
+	source := `#include "checks.teal"
+CHECKZERO(RekeyTo)
+txn Fee
+int FEE
+<=
+&&
+`
+	expected := `txn RekeyTo
+global ZeroAddress
+==
+txn Fee
+int 1000
+<=
+&&
+`
+	testSameProgram(t, source, expected, []string{dir, sub})
+
+	ops, err := AssembleStringWithIncludes(source, nil)
+	require.Error(t, err)
+	require.Contains(t, ops.Errors[0].Error(), "#include checks.teal: includes are not available here")
+
+	ops, err = AssembleStringWithIncludes("#include missing.teal", []string{dir})
+	require.Error(t, err)
+	require.Contains(t, ops.Errors[0].Error(), "#include missing.teal")
+
+	ops, err = AssembleStringWithIncludes("#include loop.teal", []string{dir})
+	require.Error(t, err)
+	require.Contains(t, ops.Errors[0].Error(), "#include loop.teal nests too deeply")
+}
+
+func TestNestedInclude(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "TestNestedInclude")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lib := filepath.Join(dir, "lib")
+	require.NoError(t, os.Mkdir(lib, 0700))
+
+	// lib/outer.teal includes fee.teal, which is found next to it before
+	// the one in the include directory
+	require.NoError(t, ioutil.WriteFile(filepath.Join(lib, "outer.teal"), []byte("#include \"fee.teal\"\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(lib, "fee.teal"), []byte("#define FEE 1000\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fee.teal"), []byte("#define FEE 2000\n"), 0600))
+
+	source := `#include "lib/outer.teal"
+txn Fee
+int FEE
+<=
+`
+	expected := `txn Fee
+int 1000
+<=
+`
+	testSameProgram(t, source, expected, []string{dir})
+
+	// the program itself still resolves against the include directories
+	testSameProgram(t, "#include \"fee.teal\"\ntxn Fee\nint FEE\n<=\n", "txn Fee\nint 2000\n<=\n", []string{dir})
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package logic
+
+import (
+	"strings"
+)
+
+// sourceMapVersion is the revision of the source map format we emit
+const sourceMapVersion = 3
+
+// SourceMap maps an assembled program back to its TEAL source. It uses the
+// Source Map Revision 3 layout, with every byte of the program treated as a
+// line of generated code, so the segment for a pc is found on "line" pc of
+// Mappings. Bytes that do not start an opcode have an empty segment.
+// Labels and constants are carried as x_ extension fields.
+type SourceMap struct {
+	Version    int      `json:"version"`
+	File       string   `json:"file,omitempty"`
+	SourceRoot string   `json:"sourceRoot,omitempty"`
+	Sources    []string `json:"sources"`
+	Names      []string `json:"names"`
+	Mappings   string   `json:"mappings"`
+
+	// Labels maps each label to the pc it marks
+	Labels map[string]int `json:"x_labels,omitempty"`
+
+	// IntConstants and ByteConstants are the intcblock and bytecblock contents
+	IntConstants  []uint64 `json:"x_intc,omitempty"`
+	ByteConstants [][]byte `json:"x_bytec,omitempty"`
+}
+
+// GetSourceMap returns the source map of an assembled program. sourceName
+// is recorded as the only source the program was assembled from.
+func (ops *OpStream) GetSourceMap(sourceName string) SourceMap {
+	sm := SourceMap{
+		Version:       sourceMapVersion,
+		Sources:       []string{sourceName},
+		Names:         []string{},
+		IntConstants:  ops.intc,
+		ByteConstants: ops.bytec,
+	}
+	if len(ops.labels) > 0 {
+		sm.Labels = make(map[string]int, len(ops.labels))
+		for label, pc := range ops.labels {
+			sm.Labels[label] = pc
+		}
+	}
+
+	// Segments are [generated column, source index, source line, source column].
+	// All but the first are relative to the previous segment.
+	segments := make([]string, len(ops.Program))
+	prevLine := 0
+	prevColumn := 0
+	for pc := range ops.Program {
+		line, ok := ops.OffsetToLine[pc]
+		if !ok {
+			continue
+		}
+		column := ops.offsetToColumn[pc]
+		segments[pc] = encodeVLQ(0) + encodeVLQ(0) + encodeVLQ(line-prevLine) + encodeVLQ(column-prevColumn)
+		prevLine = line
+		prevColumn = column
+	}
+	sm.Mappings = strings.Join(segments, ";")
+	return sm
+}
+
+const vlqBase64 = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// encodeVLQ encodes a value as a base64 variable length quantity, with the
+// sign in the lowest bit and 5 bits of value per digit
+func encodeVLQ(value int) string {
+	var v uint
+	if value < 0 {
+		v = uint(-value)<<1 | 1
+	} else {
+		v = uint(value) << 1
+	}
+	var sb strings.Builder
+	for {
+		digit := v & 0x1f
+		v >>= 5
+		if v > 0 {
+			digit |= 0x20
+		}
+		sb.WriteByte(vlqBase64[digit])
+		if v == 0 {
+			break
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package logic
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeVLQ(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "A", encodeVLQ(0))
+	require.Equal(t, "C", encodeVLQ(1))
+	require.Equal(t, "D", encodeVLQ(-1))
+	require.Equal(t, "gB", encodeVLQ(16))
+	require.Equal(t, "hB", encodeVLQ(-16))
+	require.Equal(t, "2H", encodeVLQ(123))
+}
+
+func TestGetSourceMap(t *testing.T) {
+	t.Parallel()
+	source := `int 1
+  bnz done
+int 2
+done:
+int 1`
+	ops, err := AssembleStringWithVersion(source, 3)
+	require.NoError(t, err)
+	require.Equal(t, "0320010122400002810222", hex.EncodeToString(ops.Program))
+
+	sm := ops.GetSourceMap("test.teal")
+	require.Equal(t, 3, sm.Version)
+	require.Equal(t, []string{"test.teal"}, sm.Sources)
+	require.Equal(t, ";;;;AAAA;AACE;;;AACF;;AAEA", sm.Mappings)
+	require.Equal(t, map[string]int{"done": 10}, sm.Labels)
+	require.Equal(t, []uint64{1}, sm.IntConstants)
+	require.Empty(t, sm.ByteConstants)
+
+	data, err := json.Marshal(&sm)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"mappings":";;;;AAAA;AACE;;;AACF;;AAEA"`)
+	require.Contains(t, string(data), `"x_labels":{"done":10}`)
+}
@@ -17,6 +17,7 @@
 package gen
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -29,6 +30,7 @@ import (
 	"github.com/algorand/go-deadlock"
 
 	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/account"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
@@ -55,20 +57,77 @@ type genesisAllocation struct {
 	Online basics.Status
 }
 
+// ForkedNetwork is the state of an existing network that a new network is forked from.
+type ForkedNetwork struct {
+	// Accounts are the accounts of the existing network, carried over to the genesis of the new one
+	Accounts map[basics.Address]basics.AccountData
+	// RewardsLevel is the rewards level of the existing network, as of the round of the accounts
+	RewardsLevel uint64
+}
+
 // GenerateGenesisFiles generates the genesis.json file and wallet files for a give genesis configuration.
 func GenerateGenesisFiles(genesisData GenesisData, consensus config.ConsensusProtocols, outDir string, verbose bool) error {
+	return generateGenesis(genesisData, consensus, nil, outDir, verbose)
+}
+
+// GenerateForkedGenesisFiles generates the genesis.json file and wallet files for a network forked off an
+// existing one. The accounts of the existing network, along with their assets and applications, are all
+// carried over to the genesis, but offline; the wallets of the genesis configuration are the only online
+// accounts, and they are funded out of the stake of the accounts which were online, so that the genesis
+// has as much money as the existing network.
+func GenerateForkedGenesisFiles(genesisData GenesisData, consensus config.ConsensusProtocols, forked ForkedNetwork, outDir string, verbose bool) error {
+	return generateGenesis(genesisData, consensus, &forked, outDir, verbose)
+}
+
+func generateGenesis(genesisData GenesisData, consensus config.ConsensusProtocols, forked *ForkedNetwork, outDir string, verbose bool) error {
 	err := os.Mkdir(outDir, os.ModeDir|os.FileMode(0777))
 	if err != nil && os.IsNotExist(err) {
 		return fmt.Errorf("couldn't make output directory '%s': %v", outDir, err.Error())
 	}
 
+	// Backwards compatibility with older genesis files: if the consensus
+	// protocol version is not specified, default to V0.
+	proto := genesisData.ConsensusProtocol
+	if proto == protocol.ConsensusVersion("") {
+		proto = protocol.ConsensusCurrentVersion
+	}
+
+	consensusParams, ok := consensus[proto]
+	if !ok {
+		return fmt.Errorf("protocol %s not supported", proto)
+	}
+
+	// Backwards compatibility with older genesis files: if the fee sink
+	// or the rewards pool is not specified, set their defaults.
+	if (genesisData.FeeSink == basics.Address{}) {
+		genesisData.FeeSink = defaultSinkAddr
+	}
+	if (genesisData.RewardsPool == basics.Address{}) {
+		genesisData.RewardsPool = defaultPoolAddr
+	}
+
+	totalStake := TotalMoney
+	sinkBalance := consensusParams.MinBalance
+	poolBalance := defaultIncentivePoolBalanceAtInception
+	var forkedAccounts map[basics.Address]basics.AccountData
+	if forked != nil {
+		forkedAccounts, totalStake = takeOffline(forked, consensusParams)
+		// the fee sink and the rewards pool keep their balances from the forked network, or else
+		// they're funded out of the online stake too, so that the total money stays the same.
+		sinkBalance, totalStake = forkedBalance(forkedAccounts, genesisData.FeeSink, consensusParams.MinBalance, totalStake)
+		poolBalance, totalStake = forkedBalance(forkedAccounts, genesisData.RewardsPool, consensusParams.MinBalance, totalStake)
+		if totalStake == 0 {
+			return fmt.Errorf("the forked network has no online stake")
+		}
+	}
+
 	var sum uint64
 	allocation := make([]genesisAllocation, len(genesisData.Wallets))
 
 	for i, wallet := range genesisData.Wallets {
 		acct := genesisAllocation{
 			Name:   wallet.Name,
-			Stake:  uint64(float64(TotalMoney/100)*wallet.Stake + .5),
+			Stake:  uint64(float64(totalStake/100)*wallet.Stake + .5),
 			Online: basics.Online,
 		}
 		if !wallet.Online {
@@ -78,36 +137,61 @@ func GenerateGenesisFiles(genesisData GenesisData, consensus config.ConsensusPro
 		sum += acct.Stake
 	}
 
-	if sum != TotalMoney {
-		panic(fmt.Sprintf("Amounts don't add up to TotalMoney - off by %v", int64(TotalMoney)-int64(sum)))
+	if forked != nil && len(allocation) > 0 {
+		// the online stake of the forked network isn't a round number, so the rounding
+		// of the wallet percentages is absorbed by the last wallet.
+		allocation[len(allocation)-1].Stake += totalStake - sum
+		sum = totalStake
 	}
 
-	// Backwards compatibility with older genesis files: if the consensus
-	// protocol version is not specified, default to V0.
-	proto := genesisData.ConsensusProtocol
-	if proto == protocol.ConsensusVersion("") {
-		proto = protocol.ConsensusCurrentVersion
+	if sum != totalStake {
+		panic(fmt.Sprintf("Amounts don't add up to TotalMoney - off by %v", int64(totalStake)-int64(sum)))
 	}
 
-	// Backwards compatibility with older genesis files: if the fee sink
-	// or the rewards pool is not specified, set their defaults.
-	if (genesisData.FeeSink == basics.Address{}) {
-		genesisData.FeeSink = defaultSinkAddr
-	}
-	if (genesisData.RewardsPool == basics.Address{}) {
-		genesisData.RewardsPool = defaultPoolAddr
-	}
+	return generateGenesisFiles(outDir, proto, consensusParams, genesisData.NetworkName, genesisData.VersionModifier, allocation, forkedAccounts, sinkBalance, poolBalance, genesisData.FirstPartKeyRound, genesisData.LastPartKeyRound, genesisData.PartKeyDilution, genesisData.FeeSink, genesisData.RewardsPool, genesisData.Comment, genesisData.DevMode, verbose)
+}
 
-	consensusParams, ok := consensus[proto]
-	if !ok {
-		return fmt.Errorf("protocol %s not supported", proto)
+// takeOffline returns the accounts of the forked network with their pending rewards paid out, as the
+// rewards of the new network start over, and with all of them offline. The accounts which were online
+// are left with their minimum balance only, and the rest of their balance, the online stake the forked
+// network had, is returned so that the wallets of the new network are funded out of it.
+func takeOffline(forked *ForkedNetwork, proto config.ConsensusParams) (accounts map[basics.Address]basics.AccountData, onlineStake uint64) {
+	accounts = make(map[basics.Address]basics.AccountData, len(forked.Accounts))
+	for addr, data := range forked.Accounts {
+		data = data.WithUpdatedRewards(proto, forked.RewardsLevel)
+		data.RewardsBase = 0
+		if data.Status == basics.Online {
+			minBalance := data.MinBalance(&proto).Raw
+			if data.MicroAlgos.Raw > minBalance {
+				onlineStake += data.MicroAlgos.Raw - minBalance
+				data.MicroAlgos.Raw = minBalance
+			}
+			data.Status = basics.Offline
+			data.VoteID = crypto.OneTimeSignatureVerifier{}
+			data.SelectionID = crypto.VRFVerifier{}
+			data.VoteFirstValid = 0
+			data.VoteLastValid = 0
+			data.VoteKeyDilution = 0
+		}
+		accounts[addr] = data
 	}
+	return
+}
 
-	return generateGenesisFiles(outDir, proto, consensusParams, genesisData.NetworkName, genesisData.VersionModifier, allocation, genesisData.FirstPartKeyRound, genesisData.LastPartKeyRound, genesisData.PartKeyDilution, genesisData.FeeSink, genesisData.RewardsPool, genesisData.Comment, verbose)
+// forkedBalance returns the balance the forked network had at the given address, or the given minimum
+// balance taken out of the stake when the forked network has no such account. It also returns the stake left.
+func forkedBalance(accounts map[basics.Address]basics.AccountData, addr basics.Address, minBalance uint64, stake uint64) (balance uint64, stakeLeft uint64) {
+	if data, ok := accounts[addr]; ok {
+		return data.MicroAlgos.Raw, stake
+	}
+	if stake < minBalance {
+		return stake, 0
+	}
+	return minBalance, stake - minBalance
 }
 
 func generateGenesisFiles(outDir string, protoVersion protocol.ConsensusVersion, protoParams config.ConsensusParams, netName string, schemaVersionModifier string,
-	allocation []genesisAllocation, firstWalletValid uint64, lastWalletValid uint64, partKeyDilution uint64, feeSink, rewardsPool basics.Address, comment string, verbose bool) (err error) {
+	allocation []genesisAllocation, forkedAccounts map[basics.Address]basics.AccountData, sinkBalance, poolBalance uint64, firstWalletValid uint64, lastWalletValid uint64, partKeyDilution uint64, feeSink, rewardsPool basics.Address, comment string, devMode bool, verbose bool) (err error) {
 
 	genesisAddrs := make(map[string]basics.Address)
 	records := make(map[string]basics.AccountData)
@@ -274,21 +358,21 @@ func generateGenesisFiles(outDir string, protoVersion protocol.ConsensusVersion,
 
 	records["FeeSink"] = basics.AccountData{
 		Status:     basics.NotParticipating,
-		MicroAlgos: basics.MicroAlgos{Raw: protoParams.MinBalance},
+		MicroAlgos: basics.MicroAlgos{Raw: sinkBalance},
 	}
 	records["RewardsPool"] = basics.AccountData{
 		Status:     basics.NotParticipating,
-		MicroAlgos: basics.MicroAlgos{Raw: defaultIncentivePoolBalanceAtInception},
+		MicroAlgos: basics.MicroAlgos{Raw: poolBalance},
 	}
 
 	sinkAcct := genesisAllocation{
 		Name:   "FeeSink",
-		Stake:  protoParams.MinBalance,
+		Stake:  sinkBalance,
 		Online: basics.NotParticipating,
 	}
 	poolAcct := genesisAllocation{
 		Name:   "RewardsPool",
-		Stake:  defaultIncentivePoolBalanceAtInception,
+		Stake:  poolBalance,
 		Online: basics.NotParticipating,
 	}
 
@@ -305,6 +389,7 @@ func generateGenesisFiles(outDir string, protoVersion protocol.ConsensusVersion,
 		FeeSink:     feeSink.String(),
 		RewardsPool: rewardsPool.String(),
 		Comment:     comment,
+		DevMode:     devMode,
 	}
 
 	for _, wallet := range allocation {
@@ -317,6 +402,24 @@ func generateGenesisFiles(outDir string, protoVersion protocol.ConsensusVersion,
 		})
 	}
 
+	// the fee sink and the rewards pool of the genesis take the place of the forked
+	// accounts with the same addresses.
+	forkedAddrs := make([]basics.Address, 0, len(forkedAccounts))
+	for addr := range forkedAccounts {
+		if addr != feeSink && addr != rewardsPool {
+			forkedAddrs = append(forkedAddrs, addr)
+		}
+	}
+	sort.Slice(forkedAddrs, func(i, j int) bool {
+		return bytes.Compare(forkedAddrs[i][:], forkedAddrs[j][:]) < 0
+	})
+	for _, addr := range forkedAddrs {
+		g.Allocation = append(g.Allocation, bookkeeping.GenesisAllocation{
+			Address: addr.String(),
+			State:   forkedAccounts[addr],
+		})
+	}
+
 	jsonData := protocol.EncodeJSON(g)
 	err = ioutil.WriteFile(filepath.Join(outDir, config.GenesisJSONFile), append(jsonData, '\n'), 0666)
 
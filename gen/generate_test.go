@@ -24,7 +24,12 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/account"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util/db"
 
 	"github.com/stretchr/testify/require"
@@ -100,3 +105,98 @@ func TestLoadSingleRootKeyConcurrent(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestGenerateForkedGenesis(t *testing.T) {
+	a := require.New(t)
+	outDir, err := ioutil.TempDir("", "fork-test-")
+	a.NoError(err)
+	defer os.RemoveAll(outDir)
+
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	online := basics.AccountData{
+		Status:         basics.Online,
+		MicroAlgos:     basics.MicroAlgos{Raw: 3000 * 1000000},
+		RewardsBase:    10,
+		VoteID:         crypto.OneTimeSignatureVerifier{1},
+		VoteLastValid:  1000,
+		VoteFirstValid: 1,
+	}
+	creator := basics.AccountData{
+		Status:      basics.Offline,
+		MicroAlgos:  basics.MicroAlgos{Raw: 1000 * 1000000},
+		RewardsBase: 10,
+		AssetParams: map[basics.AssetIndex]basics.AssetParams{7: {Total: 100, UnitName: "forked"}},
+		Assets:      map[basics.AssetIndex]basics.AssetHolding{7: {Amount: 100}},
+	}
+	onlineAddr := basics.Address{1}
+	creatorAddr := basics.Address{2}
+	forked := ForkedNetwork{
+		Accounts: map[basics.Address]basics.AccountData{
+			onlineAddr:      online,
+			creatorAddr:     creator,
+			defaultPoolAddr: {Status: basics.NotParticipating, MicroAlgos: basics.MicroAlgos{Raw: 1}},
+		},
+		RewardsLevel: 15,
+	}
+	genesisData := GenesisData{
+		NetworkName:       "forked",
+		ConsensusProtocol: protocol.ConsensusCurrentVersion,
+		FirstPartKeyRound: 0,
+		LastPartKeyRound:  100,
+		Wallets: []WalletData{
+			{Name: "Wallet1", Stake: 33.3, Online: true},
+			{Name: "Wallet2", Stake: 66.7, Online: true},
+		},
+	}
+	err = GenerateForkedGenesisFiles(genesisData, config.Consensus, forked, outDir, false)
+	a.NoError(err)
+
+	genesis, err := bookkeeping.LoadGenesisFromFile(filepath.Join(outDir, config.GenesisJSONFile))
+	a.NoError(err)
+	allocation := make(map[string]bookkeeping.GenesisAllocation)
+	for _, alloc := range genesis.Allocation {
+		a.NotContains(allocation, alloc.Address)
+		allocation[alloc.Address] = alloc
+	}
+	a.Len(allocation, 6)
+
+	// the genesis has as much money as the forked network.
+	var forkedTotal, genesisTotal uint64
+	for _, data := range forked.Accounts {
+		forkedTotal += data.WithUpdatedRewards(proto, 15).MicroAlgos.Raw
+	}
+	for _, alloc := range genesis.Allocation {
+		genesisTotal += alloc.State.MicroAlgos.Raw
+	}
+	a.Equal(forkedTotal, genesisTotal)
+
+	// the wallets share the online stake of the forked network, which the online account gave up
+	// along with the fee sink, which the forked network didn't have.
+	var walletsTotal uint64
+	for _, alloc := range genesis.Allocation {
+		if alloc.Comment == "Wallet1" || alloc.Comment == "Wallet2" {
+			a.Equal(basics.Online, alloc.State.Status)
+			walletsTotal += alloc.State.MicroAlgos.Raw
+		}
+	}
+	a.Equal(online.WithUpdatedRewards(proto, 15).MicroAlgos.Raw-2*proto.MinBalance, walletsTotal)
+
+	// the forked accounts are offline, with their rewards paid out.
+	forkedOnline := allocation[onlineAddr.String()].State
+	a.Equal(basics.Offline, forkedOnline.Status)
+	a.True(forkedOnline.VoteID.MsgIsZero())
+	a.Zero(forkedOnline.RewardsBase)
+	a.Equal(proto.MinBalance, forkedOnline.MicroAlgos.Raw)
+	forkedCreator := allocation[creatorAddr.String()].State
+	a.Equal(creator.AssetParams, forkedCreator.AssetParams)
+	a.Equal(creator.Assets, forkedCreator.Assets)
+	a.Equal(creator.WithUpdatedRewards(proto, 15).MicroAlgos, forkedCreator.MicroAlgos)
+
+	// the rewards pool of the genesis keeps the balance of the forked one.
+	a.Equal(uint64(1), allocation[defaultPoolAddr.String()].State.MicroAlgos.Raw)
+	a.Equal(proto.MinBalance, allocation[defaultSinkAddr.String()].State.MicroAlgos.Raw)
+
+	forked.Accounts = map[basics.Address]basics.AccountData{creatorAddr: creator}
+	err = GenerateForkedGenesisFiles(genesisData, config.Consensus, forked, outDir, false)
+	a.Error(err)
+}
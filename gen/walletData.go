@@ -50,6 +50,7 @@ type GenesisData struct {
 	FeeSink           basics.Address
 	RewardsPool       basics.Address
 	Comment           string
+	DevMode           bool
 }
 
 // LoadGenesisData loads a GenesisData structure from a json file
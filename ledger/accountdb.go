@@ -114,7 +114,7 @@ var accountsResetExprs = []string{
 // accountDBVersion is the database version that this binary would know how to support and how to upgrade to.
 // details about the content of each of the versions can be found in the upgrade functions upgradeDatabaseSchemaXXXX
 // and their descriptions.
-var accountDBVersion = int32(4)
+var accountDBVersion = int32(5)
 
 // persistedAccountData is used for representing a single account stored on the disk. In addition to the
 // basics.AccountData, it also stores complete referencing information used to maintain the base accounts
@@ -516,6 +516,25 @@ func getCatchpoint(tx *sql.Tx, round basics.Round) (fileName string, catchpoint
 	return
 }
 
+// getCatchpointLabels returns the labels of up to maxCount of the latest stored catchpoint files, latest first.
+func getCatchpointLabels(tx *sql.Tx, maxCount int) (labels []string, err error) {
+	rows, err := tx.Query("SELECT catchpoint FROM storedcatchpoints WHERE filename != '' AND catchpoint != '' ORDER BY round DESC LIMIT ?", maxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var label string
+		err = rows.Scan(&label)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	err = rows.Err()
+	return
+}
+
 // accountsInit fills the database using tx with initAccounts if the
 // database has not been initialized yet.
 //
@@ -556,6 +575,20 @@ func accountsInit(tx *sql.Tx, initAccounts map[basics.Address]basics.AccountData
 				return err
 			}
 
+			// genesis accounts might hold the assets and applications of a forked network.
+			for aidx := range data.AssetParams {
+				_, err = tx.Exec("INSERT INTO assetcreators (asset, creator, ctype) VALUES (?, ?, ?)", aidx, addr[:], basics.AssetCreatable)
+				if err != nil {
+					return err
+				}
+			}
+			for aidx := range data.AppParams {
+				_, err = tx.Exec("INSERT INTO assetcreators (asset, creator, ctype) VALUES (?, ?, ?)", aidx, addr[:], basics.AppCreatable)
+				if err != nil {
+					return err
+				}
+			}
+
 			totals.AddAccount(proto, data, &ot)
 		}
 
@@ -0,0 +1,240 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// ErrAccountProofsUnavailable is returned when the ledger doesn't maintain the accounts merkle trie,
+// which only happens when catchpoint tracking is enabled.
+var ErrAccountProofsUnavailable = errors.New("account proofs are only available when catchpoint tracking is enabled")
+
+// ErrNoAccountProofCatchpoint is returned when asking for an account proof before the ledger made any
+// catchpoint label to prove the account data against.
+var ErrNoAccountProofCatchpoint = errors.New("no catchpoint label was made yet to prove accounts against")
+
+// ErrNoAccountRecord is returned when asking for the proof of an account that has no balance record.
+var ErrNoAccountRecord = errors.New("account has no balance record")
+
+// ErrAccountProofLabelMismatch is returned when the catchpoint label components of an account proof
+// don't hash into the trusted catchpoint label.
+var ErrAccountProofLabelMismatch = errors.New("account proof doesn't match the catchpoint label")
+
+// accountProofsFileName is the name of the copy of the accounts database the account proofs are made off, within
+// the catchpoints directory. The copy is taken on the rounds of the catchpoint labels, which are published and agreed
+// upon by the nodes, unlike the ever changing trie root, and so the proofs are made against the trie the last label
+// commits to.
+const accountProofsFileName = "accountproofs.sqlite"
+
+// accountProofCatchpointSchema holds the components of the catchpoint label made for the accounts database copy. The
+// table is only created in the copy, and not in the accounts database itself.
+const accountProofCatchpointSchema = `CREATE TABLE accountproofcatchpoint (
+	id integer primary key,
+	rnd integer,
+	label text,
+	blockhash blob,
+	root blob,
+	totals blob)`
+
+// AccountProof is the data of an account, along with a proof that it is part of the accounts
+// merkle trie whose root hash is committed to by the catchpoint label of round Round. The account
+// data is the one of round Round - MaxBalLookback, which the label was made for.
+type AccountProof struct {
+	// Round is the round of the catchpoint label
+	Round basics.Round
+	// Label is the catchpoint label the proof was made against
+	Label string
+	// BlockHash is the digest of the block of round Round, as hashed into the label
+	BlockHash crypto.Digest
+	// Root is the root hash of the accounts merkle trie, as hashed into the label
+	Root crypto.Digest
+	// Totals are the account totals, as hashed into the label
+	Totals ledgercore.AccountTotals
+	// Account is the data of the account
+	Account basics.AccountData
+	// Proof shows that the account data is included in the trie
+	Proof merkletrie.Proof
+}
+
+// VerifyAccountProof checks that the catchpoint label components in the proof hash into the given
+// trusted catchpoint label, and that the account data is included in the accounts merkle trie the
+// label commits to. The label must come from a trusted source, such as the ones published along
+// with the releases, and not from the node which made the proof.
+func VerifyAccountProof(addr basics.Address, ap AccountProof, label string) error {
+	round, labelHash, err := ledgercore.ParseCatchpointLabel(label)
+	if err != nil {
+		return err
+	}
+	if round != ap.Round || ledgercore.MakeCatchpointLabel(ap.Round, ap.BlockHash, ap.Root, ap.Totals).Hash() != labelHash {
+		return ErrAccountProofLabelMismatch
+	}
+	hash := accountHashBuilder(addr, ap.Account, protocol.Encode(&ap.Account))
+	included, err := merkletrie.VerifyProof(ap.Root, hash, ap.Proof)
+	if err != nil {
+		return err
+	}
+	if !included {
+		return merkletrie.ErrInvalidProof
+	}
+	return nil
+}
+
+// snapshotAccountsForProofs takes a snapshot of the accounts database as of the round of the given catchpoint label, and
+// copies it in the background into the accounts database copy the account proofs are made off. It's called once the
+// accounts of a catchpoint round are committed, so that the commit isn't held up by the copying. The copy of a round is
+// skipped while the copy of a previous round is still being made.
+func (au *accountUpdates) snapshotAccountsForProofs(round basics.Round, label string, blockHash crypto.Digest, root crypto.Digest, totals ledgercore.AccountTotals) {
+	if atomic.LoadInt32(&au.accountProofsCopying) != 0 {
+		au.log.Warnf("accountUpdates: snapshotAccountsForProofs: skipping the accounts copy for round %d, as the accounts copy of a previous round is still being made", round)
+		return
+	}
+	snapshot, err := au.dbs.Rdb.Snapshot(au.ctx)
+	if err != nil {
+		au.log.Warnf("accountUpdates: snapshotAccountsForProofs: unable to snapshot the accounts database for round %d : %v", round, err)
+		return
+	}
+	atomic.StoreInt32(&au.accountProofsCopying, 1)
+	au.accountProofsCopy.Add(1)
+	go func() {
+		defer func() {
+			atomic.StoreInt32(&au.accountProofsCopying, 0)
+			au.accountProofsCopy.Done()
+		}()
+		err := au.copyAccountsForProofs(snapshot, round, label, blockHash, root, totals)
+		if err != nil {
+			au.log.Warnf("accountUpdates: snapshotAccountsForProofs: unable to copy the accounts database for round %d : %v", round, err)
+		}
+	}()
+}
+
+// copyAccountsForProofs copies the given accounts database snapshot, along with the components of the catchpoint label
+// made for it, into a temporary file which then replaces the accounts database copy the account proofs are made off.
+// The snapshot is closed once copied.
+func (au *accountUpdates) copyAccountsForProofs(source *db.Snapshot, round basics.Round, label string, blockHash crypto.Digest, root crypto.Digest, totals ledgercore.AccountTotals) error {
+	fileName := filepath.Join(au.dbDirectory, catchpointDirName, accountProofsFileName)
+	err := os.MkdirAll(filepath.Dir(fileName), 0700)
+	if err != nil {
+		source.Close()
+		return err
+	}
+	tempFileName := fileName + ".tmp"
+	removeDatabaseFiles(tempFileName)
+	err = source.Backup(au.ctx, tempFileName)
+	source.Close()
+	if err != nil {
+		removeDatabaseFiles(tempFileName)
+		return err
+	}
+
+	dbs, err := db.MakeAccessor(tempFileName, false, false)
+	if err != nil {
+		removeDatabaseFiles(tempFileName)
+		return err
+	}
+	err = dbs.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		_, err = tx.Exec(accountProofCatchpointSchema)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("INSERT INTO accountproofcatchpoint(id, rnd, label, blockhash, root, totals) VALUES(0, ?, ?, ?, ?, ?)",
+			round, label, blockHash[:], root[:], protocol.Encode(&totals))
+		return err
+	})
+	dbs.Close()
+	if err != nil {
+		removeDatabaseFiles(tempFileName)
+		return err
+	}
+
+	au.accountProofsMu.Lock()
+	defer au.accountProofsMu.Unlock()
+	return os.Rename(tempFileName, fileName)
+}
+
+// AccountProof returns the data of the given account as of the last catchpoint label, with a proof of
+// its inclusion in the accounts merkle trie committed to by that label. Accounts without any balance
+// record are not in the trie, so ErrNoAccountRecord is returned for them.
+func (au *accountUpdates) AccountProof(addr basics.Address) (ap AccountProof, err error) {
+	if au.catchpointInterval == 0 {
+		return AccountProof{}, ErrAccountProofsUnavailable
+	}
+	au.accountProofsMu.RLock()
+	defer au.accountProofsMu.RUnlock()
+	fileName := filepath.Join(au.dbDirectory, catchpointDirName, accountProofsFileName)
+	if _, err = os.Stat(fileName); os.IsNotExist(err) {
+		return AccountProof{}, ErrNoAccountProofCatchpoint
+	}
+	dbs, err := db.MakeAccessor(fileName, true, false)
+	if err != nil {
+		return AccountProof{}, err
+	}
+	defer dbs.Close()
+	err = dbs.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		var blockHash, root, totals []byte
+		err = tx.QueryRow("SELECT rnd, label, blockhash, root, totals FROM accountproofcatchpoint WHERE id=0").Scan(&ap.Round, &ap.Label, &blockHash, &root, &totals)
+		if err == sql.ErrNoRows {
+			return ErrNoAccountProofCatchpoint
+		}
+		if err != nil {
+			return err
+		}
+		copy(ap.BlockHash[:], blockHash)
+		copy(ap.Root[:], root)
+		err = protocol.Decode(totals, &ap.Totals)
+		if err != nil {
+			return err
+		}
+
+		var buf []byte
+		err = tx.QueryRow("SELECT data FROM accountbase WHERE address=?", addr[:]).Scan(&buf)
+		if err == sql.ErrNoRows {
+			return ErrNoAccountRecord
+		}
+		if err != nil {
+			return err
+		}
+		err = protocol.Decode(buf, &ap.Account)
+		if err != nil {
+			return err
+		}
+
+		mc, err := makeMerkleCommitter(tx, false)
+		if err != nil {
+			return err
+		}
+		trie, err := merkletrie.MakeTrie(mc, trieMemoryConfig)
+		if err != nil {
+			return err
+		}
+		ap.Proof, err = trie.Prove(accountHashBuilder(addr, ap.Account, protocol.Encode(&ap.Account)))
+		return err
+	})
+	return
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestAccountProof(t *testing.T) {
+	testProtocolVersion := protocol.ConsensusVersion("test-protocol-TestAccountProof")
+	protoParams := config.Consensus[protocol.ConsensusCurrentVersion]
+	protoParams.MaxBalLookback = 8
+	config.Consensus[testProtocolVersion] = protoParams
+	defer func() {
+		delete(config.Consensus, testProtocolVersion)
+	}()
+
+	ml := makeMockLedgerForTracker(t, true, 1, testProtocolVersion)
+	defer ml.Close()
+
+	accts := []map[basics.Address]basics.AccountData{randomAccounts(20, true)}
+
+	pooldata := basics.AccountData{}
+	pooldata.MicroAlgos.Raw = 100 * 1000 * 1000 * 1000 * 1000
+	pooldata.Status = basics.NotParticipating
+	accts[0][testPoolAddr] = pooldata
+
+	sinkdata := basics.AccountData{}
+	sinkdata.MicroAlgos.Raw = 1000 * 1000 * 1000 * 1000
+	sinkdata.Status = basics.NotParticipating
+	accts[0][testSinkAddr] = sinkdata
+
+	au := &accountUpdates{}
+	conf := config.GetDefaultLocal()
+	conf.CatchpointInterval = 20
+	conf.CatchpointTracking = 1
+	defer removeDatabaseFiles(filepath.Join(catchpointDirName, accountProofsFileName))
+	au.initialize(conf, ".", protoParams, accts[0])
+	defer au.close()
+
+	err := au.loadFromDisk(ml)
+	require.NoError(t, err)
+
+	// no catchpoint label was made yet.
+	var someAddr basics.Address
+	for addr := range accts[0] {
+		someAddr = addr
+		break
+	}
+	_, err = au.AccountProof(someAddr)
+	require.Equal(t, ErrNoAccountProofCatchpoint, err)
+
+	lastCreatableID := uint64(1)
+	knownCreatables := make(map[basics.CreatableIndex]bool)
+	var label string
+	for i := basics.Round(1); i <= basics.Round(conf.CatchpointInterval+5); i++ {
+		var updates ledgercore.AccountDeltas
+		var totals map[basics.Address]basics.AccountData
+		base := accts[i-1]
+		updates, totals, lastCreatableID = randomDeltasBalancedFull(1, base, 0, lastCreatableID)
+
+		blk := bookkeeping.Block{
+			BlockHeader: bookkeeping.BlockHeader{
+				Round: basics.Round(i),
+			},
+		}
+		blk.CurrentProtocol = testProtocolVersion
+		delta := ledgercore.MakeStateDelta(&blk.BlockHeader, 0, updates.Len())
+		delta.Accts.MergeAccounts(updates)
+		delta.Creatables = creatablesFromUpdates(base, updates, knownCreatables)
+		au.newBlock(blk, delta)
+		au.committedUpTo(i)
+		ml.addMockBlock(blockEntry{block: blk}, delta)
+		accts = append(accts, totals)
+
+		au.waitAccountsWriting()
+		au.accountProofsCopy.Wait()
+		if uint64(i) == conf.CatchpointInterval {
+			label = au.GetLastCatchpointLabel()
+			require.NotEmpty(t, label)
+		}
+	}
+
+	// the proofs are made against the accounts the catchpoint label was made for, although the
+	// accounts were written to disk for later rounds since.
+	labelRound := basics.Round(conf.CatchpointInterval)
+	labelAccts := accts[labelRound-basics.Round(protoParams.MaxBalLookback)]
+	for addr, data := range labelAccts {
+		if data.IsZero() {
+			continue
+		}
+		ap, err := au.AccountProof(addr)
+		require.NoError(t, err)
+		require.Equal(t, labelRound, ap.Round)
+		require.Equal(t, label, ap.Label)
+		require.Equal(t, data, ap.Account)
+		require.NoError(t, VerifyAccountProof(addr, ap, label))
+
+		// the proof doesn't hold against another label.
+		otherRoot := ap
+		otherRoot.Root[0]++
+		require.Equal(t, ErrAccountProofLabelMismatch, VerifyAccountProof(addr, otherRoot, label))
+		otherRound := ap
+		otherRound.Round++
+		require.Equal(t, ErrAccountProofLabelMismatch, VerifyAccountProof(addr, otherRound, label))
+
+		// the proof doesn't hold for other data of the account.
+		ap.Account.MicroAlgos.Raw++
+		require.Equal(t, merkletrie.ErrInvalidProof, VerifyAccountProof(addr, ap, label))
+	}
+
+	_, err = au.AccountProof(basics.Address{})
+	require.Equal(t, ErrNoAccountRecord, err)
+
+	// the proofs are made off a copy of the accounts database, which is kept out of the database itself.
+	err = au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		var count int
+		err := tx.QueryRow("SELECT count(*) FROM sqlite_master WHERE name LIKE 'accountproof%'").Scan(&count)
+		require.Zero(t, count)
+		return err
+	})
+	require.NoError(t, err)
+
+	au.catchpointInterval = 0
+	_, err = au.AccountProof(basics.Address{})
+	require.Equal(t, ErrAccountProofsUnavailable, err)
+}
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -60,6 +61,12 @@ const (
 	// trieAccumulatedChangesFlush defines the number of pending changes that would be applied to the merkle trie before
 	// we attempt to commit them to disk while writing a batch of rounds balances to disk.
 	trieAccumulatedChangesFlush = 256
+	// catchpointDirName is the name of the directory, within the ledger directory, the catchpoint files are stored in.
+	catchpointDirName = "catchpoints"
+	// catchpointSnapshotFilePrefix is the file name prefix of the accounts database snapshots catchpoint files are generated off.
+	catchpointSnapshotFilePrefix = "snapshot."
+	// catchpointGenerationProgressReportInterval defines how frequently the progress of the catchpoint file generation is logged.
+	catchpointGenerationProgressReportInterval = 30 * time.Second
 )
 
 // trieCachedNodesCount defines how many balances trie nodes we would like to keep around in memory.
@@ -126,6 +133,13 @@ type accountUpdates struct {
 	// vacuumOnStartup controls whether the accounts database would get vacuumed on startup.
 	vacuumOnStartup bool
 
+	// catchpointSnapshotGeneration controls whether the catchpoint files are generated in the background, off a snapshot
+	// of the accounts database, rather than inline off the accounts database itself.
+	catchpointSnapshotGeneration bool
+
+	// catchpointGenerationMaxBytesPerSecond limits the rate at which the catchpoint files generated off a snapshot are written.
+	catchpointGenerationMaxBytesPerSecond uint64
+
 	// dynamic variables
 
 	// Connection to the database.
@@ -222,6 +236,22 @@ type accountUpdates struct {
 
 	// baseAccounts stores the most recently used accounts, at exactly dbRound
 	baseAccounts lruAccounts
+
+	// catchpointGeneration tracks the catchpoint file being generated in the background off an accounts database snapshot.
+	catchpointGeneration sync.WaitGroup
+
+	// catchpointGenerating is non-zero while a catchpoint file is being generated in the background.
+	catchpointGenerating int32
+
+	// accountProofsCopy tracks the accounts database copy being made in the background for the account proofs.
+	accountProofsCopy sync.WaitGroup
+
+	// accountProofsCopying is non-zero while the accounts database copy for the account proofs is being made.
+	accountProofsCopying int32
+
+	// accountProofsMu synchronizes the replacement of the accounts database copy for the account proofs with the
+	// proofs being made off it.
+	accountProofsMu deadlock.RWMutex
 }
 
 type deferredCommit struct {
@@ -291,6 +321,8 @@ func (au *accountUpdates) initialize(cfg config.Local, dbPathPrefix string, gene
 		au.catchpointFileHistoryLength = -1
 	}
 	au.vacuumOnStartup = cfg.OptimizeAccountsDatabaseOnStartup
+	au.catchpointSnapshotGeneration = cfg.CatchpointGenerationSnapshot
+	au.catchpointGenerationMaxBytesPerSecond = cfg.CatchpointGenerationMaxBytesPerSecond
 	// initialize the commitSyncerClosed with a closed channel ( since the commitSyncer go-routine is not active )
 	au.commitSyncerClosed = make(chan struct{})
 	close(au.commitSyncerClosed)
@@ -321,6 +353,8 @@ func (au *accountUpdates) loadFromDisk(l ledgerForTracker) error {
 		return err
 	}
 
+	au.removeStaleCatchpointSnapshots(basics.Round(writingCatchpointRound))
+
 	if writingCatchpointRound != 0 && au.catchpointInterval != 0 {
 		au.generateCatchpoint(basics.Round(writingCatchpointRound), au.lastCatchpointLabel, writingCatchpointDigest, time.Duration(0))
 	}
@@ -350,6 +384,9 @@ func (au *accountUpdates) close() {
 	au.waitAccountsWriting()
 	// this would block until the commitSyncerClosed channel get closed.
 	<-au.commitSyncerClosed
+	// wait for the background catchpoint generation, which was signaled to abort by the context cancelation above.
+	au.catchpointGeneration.Wait()
+	au.accountProofsCopy.Wait()
 	au.baseAccounts.prune(0)
 }
 
@@ -755,6 +792,15 @@ func (r *readCloseSizer) Size() (int64, error) {
 	return r.size, nil
 }
 
+// Seek sets the offset of the next read, when the underlying stream supports seeking.
+func (r *readCloseSizer) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := r.ReadCloser.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("stream does not support seeking")
+	}
+	return seeker.Seek(offset, whence)
+}
+
 // GetCatchpointStream returns a ReadCloseSizer to the catchpoint file associated with the provided round
 func (au *accountUpdates) GetCatchpointStream(round basics.Round) (ReadCloseSizer, error) {
 	dbFileName := ""
@@ -793,7 +839,7 @@ func (au *accountUpdates) GetCatchpointStream(round basics.Round) (ReadCloseSize
 	}
 
 	// if the database doesn't know about that round, see if we have that file anyway:
-	fileName := filepath.Join("catchpoints", catchpointRoundToPath(round))
+	fileName := filepath.Join(catchpointDirName, catchpointRoundToPath(round))
 	catchpointPath := filepath.Join(au.dbDirectory, fileName)
 	file, err := os.OpenFile(catchpointPath, os.O_RDONLY, 0666)
 	if err == nil && file != nil {
@@ -813,6 +859,18 @@ func (au *accountUpdates) GetCatchpointStream(round basics.Round) (ReadCloseSize
 	return nil, ledgercore.ErrNoEntry{}
 }
 
+// GetCatchpointLabels returns the labels of up to maxCount of the latest catchpoint files stored on disk, latest first.
+func (au *accountUpdates) GetCatchpointLabels(maxCount int) (labels []string, err error) {
+	err = au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		labels, err = getCatchpointLabels(tx, maxCount)
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("accountUpdates: GetCatchpointLabels: unable to lookup catchpoints: %v", err)
+	}
+	return labels, nil
+}
+
 // functions below this line are all internal functions
 
 // accountUpdatesLedgerEvaluator is a "ledger emulator" which is used *only* by initializeCaches, as a way to shortcut
@@ -1069,6 +1127,12 @@ func (au *accountUpdates) accountsInitialize(ctx context.Context, tx *sql.Tx) (b
 					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 3 : %v", err)
 					return 0, err
 				}
+			case 4:
+				dbVersion, err = au.upgradeDatabaseSchema4(ctx, tx)
+				if err != nil {
+					au.log.Warnf("accountsInitialize failed to upgrade accounts database (ledger.tracker.sqlite) from schema 4 : %v", err)
+					return 0, err
+				}
 			default:
 				return 0, fmt.Errorf("accountsInitialize unable to upgrade database from schema version %d", dbVersion)
 			}
@@ -1324,6 +1388,26 @@ func (au *accountUpdates) upgradeDatabaseSchema3(ctx context.Context, tx *sql.Tx
 	return 4, nil
 }
 
+// upgradeDatabaseSchema4 upgrades the database schema from version 4 to version 5,
+// dropping the copies of the account data and of the accounts merkle trie that were
+// kept alongside them for the account proofs. The account proofs are made off a copy
+// of the whole accounts database since, which is kept in a file of its own.
+func (au *accountUpdates) upgradeDatabaseSchema4(ctx context.Context, tx *sql.Tx) (updatedDBVersion int32, err error) {
+	for _, table := range []string{"accountproofcatchpoint", "accountproofbase", "accountproofhashes"} {
+		_, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+table)
+		if err != nil {
+			return 0, fmt.Errorf("accountsInitialize unable to drop the %s table: %v", table, err)
+		}
+	}
+
+	// update version
+	_, err = db.SetUserVersion(ctx, tx, 5)
+	if err != nil {
+		return 0, fmt.Errorf("accountsInitialize unable to update database schema version from 4 to 5: %v", err)
+	}
+	return 5, nil
+}
+
 // deleteStoredCatchpoints iterates over the storedcatchpoints table and deletes all the files stored on disk.
 // once all the files have been deleted, it would go ahead and remove the entries from the table.
 func (au *accountUpdates) deleteStoredCatchpoints(ctx context.Context, dbQueries *accountsDbQueries) (err error) {
@@ -2003,9 +2087,14 @@ func (au *accountUpdates) commitRound(offset uint64, dbRound basics.Round, lookb
 	au.accountsMu.Unlock()
 	au.accountsReadCond.Broadcast()
 
+	if isCatchpointRound && catchpointLabel != "" {
+		// keep a copy of the accounts and of the trie the label commits to, so that the account proofs would be made against them.
+		au.snapshotAccountsForProofs(basics.Round(offset)+dbRound+lookback, catchpointLabel, committedRoundDigest, trieBalancesHash, roundTotals[offset])
+	}
+
 	if isCatchpointRound && au.archivalLedger && catchpointLabel != "" {
-		// generate the catchpoint file. This need to be done inline so that it will block any new accounts that from being written.
-		// the generateCatchpoint expects that the accounts data would not be modified in the background during it's execution.
+		// generate the catchpoint file. This need to be done inline so that it will block any new accounts that from being written,
+		// unless the catchpoint file is generated off a snapshot of the accounts database, which is taken here and copied in the background.
 		au.generateCatchpoint(basics.Round(offset)+dbRound+lookback, catchpointLabel, committedRoundDigest, updatingBalancesDuration)
 	}
 
@@ -2047,17 +2136,141 @@ func (au *accountUpdates) latest() basics.Round {
 	return au.dbRound + basics.Round(len(au.deltas))
 }
 
-// generateCatchpoint generates a single catchpoint file
+// generateCatchpoint generates a single catchpoint file. When snapshot generation is enabled, a snapshot of the accounts
+// database is taken, and both the copying of the snapshot and the writing of the catchpoint file off it are done in the background.
 func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label string, committedRoundDigest crypto.Digest, updatingBalancesDuration time.Duration) {
 	beforeGeneratingCatchpointTime := time.Now()
 	catchpointGenerationStats := telemetryspec.CatchpointGenerationEventDetails{
 		BalancesWriteTime: uint64(updatingBalancesDuration.Nanoseconds()),
 	}
 
+	// the catchpoint state tracks a single catchpoint file being written. Rather than holding up the commit until the
+	// catchpoint file of a previous round is generated in the background, the catchpoint file of this round is skipped.
+	if atomic.LoadInt32(&au.catchpointGenerating) != 0 {
+		au.log.Warnf("accountUpdates: generateCatchpoint: skipping the catchpoint file for round %d, as the catchpoint file of a previous round is still being generated", committedRound)
+		return
+	}
+	au.log.Debugf("accountUpdates: generateCatchpoint: generating catchpoint for round %d", committedRound)
+
+	// an existing snapshot means that we're resuming a catchpoint file generation that was interrupted. The accounts database
+	// might have been updated since, and so the catchpoint file has to be generated off the snapshot.
+	snapshotFileName := filepath.Join(au.dbDirectory, catchpointSnapshotPath(committedRound))
+	if _, err := os.Stat(snapshotFileName); err == nil {
+		au.startCatchpointGeneration()
+		go au.generateCatchpointFromSnapshot(nil, snapshotFileName, au.catchpointSlowWriting, committedRound, label, committedRoundDigest, catchpointGenerationStats, beforeGeneratingCatchpointTime)
+		return
+	}
+	if au.catchpointSnapshotGeneration {
+		// the snapshot only pins the content the accounts database has now; it's copied in the background.
+		snapshot, err := au.dbs.Rdb.Snapshot(au.ctx)
+		if err == nil {
+			au.startCatchpointGeneration()
+			go au.generateCatchpointFromSnapshot(snapshot, snapshotFileName, au.catchpointSlowWriting, committedRound, label, committedRoundDigest, catchpointGenerationStats, beforeGeneratingCatchpointTime)
+			return
+		}
+		au.log.Warnf("accountUpdates: generateCatchpoint: unable to snapshot the accounts database for round %d, generating the catchpoint file off the accounts database : %v", committedRound, err)
+	}
+
+	_, err := au.accountsq.writeCatchpointStateUint64(context.Background(), catchpointStateWritingCatchpoint, uint64(committedRound))
+	if err != nil {
+		au.log.Warnf("accountUpdates: generateCatchpoint unable to write catchpoint state '%s' for round %d: %v", catchpointStateWritingCatchpoint, committedRound, err)
+		return
+	}
+	au.writeCatchpointFile(au.dbs.Rdb, 0, au.catchpointSlowWriting, committedRound, label, committedRoundDigest, catchpointGenerationStats, beforeGeneratingCatchpointTime)
+}
+
+func (au *accountUpdates) startCatchpointGeneration() {
+	atomic.StoreInt32(&au.catchpointGenerating, 1)
+	au.catchpointGeneration.Add(1)
+}
+
+// generateCatchpointFromSnapshot copies the given accounts database snapshot into the snapshot file, unless source is nil
+// and the snapshot file is already there, and writes the catchpoint file off it. The snapshot file is removed once done,
+// and retained if the generation was aborted, so that it could be resumed on startup.
+func (au *accountUpdates) generateCatchpointFromSnapshot(source *db.Snapshot, snapshotFileName string, slowWriting chan struct{}, committedRound basics.Round, label string, committedRoundDigest crypto.Digest, catchpointGenerationStats telemetryspec.CatchpointGenerationEventDetails, beforeGeneratingCatchpointTime time.Time) {
+	defer func() {
+		atomic.StoreInt32(&au.catchpointGenerating, 0)
+		au.catchpointGeneration.Done()
+	}()
+	if source != nil {
+		snapshotStart := time.Now()
+		err := au.copyAccountsSnapshot(source, snapshotFileName)
+		source.Close()
+		if err != nil {
+			au.log.Warnf("accountUpdates: generateCatchpointFromSnapshot: unable to copy the accounts database snapshot for round %d, skipping its catchpoint file : %v", committedRound, err)
+			return
+		}
+		catchpointGenerationStats.SnapshotTime = uint64(time.Now().Sub(snapshotStart).Nanoseconds())
+		ledgerCatchpointSnapshotMicros.AddMicrosecondsSince(snapshotStart, nil)
+
+		// only a complete snapshot could be resumed on startup, as the accounts database moves on in the meantime.
+		_, err = au.accountsq.writeCatchpointStateUint64(context.Background(), catchpointStateWritingCatchpoint, uint64(committedRound))
+		if err != nil {
+			au.log.Warnf("accountUpdates: generateCatchpointFromSnapshot unable to write catchpoint state '%s' for round %d: %v", catchpointStateWritingCatchpoint, committedRound, err)
+			removeDatabaseFiles(snapshotFileName)
+			return
+		}
+	}
+
+	snapshot, err := db.MakeAccessor(snapshotFileName, true, false)
+	if err != nil {
+		au.log.Warnf("accountUpdates: generateCatchpointFromSnapshot: unable to open accounts database snapshot %s : %v", snapshotFileName, err)
+		_, err = au.accountsq.writeCatchpointStateUint64(context.Background(), catchpointStateWritingCatchpoint, uint64(0))
+		if err != nil {
+			au.log.Warnf("accountUpdates: generateCatchpointFromSnapshot unable to clear catchpoint state '%s' for round %d: %v", catchpointStateWritingCatchpoint, committedRound, err)
+		}
+		removeDatabaseFiles(snapshotFileName)
+		return
+	}
+	snapshot.SetLogger(au.log)
+	retryCatchpointCreation := au.writeCatchpointFile(snapshot, au.catchpointGenerationMaxBytesPerSecond, slowWriting, committedRound, label, committedRoundDigest, catchpointGenerationStats, beforeGeneratingCatchpointTime)
+	snapshot.Close()
+	if !retryCatchpointCreation {
+		removeDatabaseFiles(snapshotFileName)
+	}
+}
+
+// copyAccountsSnapshot copies the given accounts database snapshot into the given snapshot file. The snapshot is first written
+// into a temporary file, so that an interrupted copy would never be mistaken for a complete snapshot.
+func (au *accountUpdates) copyAccountsSnapshot(source *db.Snapshot, snapshotFileName string) error {
+	err := os.MkdirAll(filepath.Dir(snapshotFileName), 0700)
+	if err != nil {
+		return err
+	}
+	tempFileName := snapshotFileName + ".tmp"
+	removeDatabaseFiles(tempFileName)
+	err = source.Backup(au.ctx, tempFileName)
+	if err != nil {
+		removeDatabaseFiles(tempFileName)
+		return err
+	}
+	return os.Rename(tempFileName, snapshotFileName)
+}
+
+// removeStaleCatchpointSnapshots removes the accounts database snapshots left behind by catchpoint file generations
+// which were either completed or abandoned, retaining the snapshot of the catchpoint file still being written, if any.
+func (au *accountUpdates) removeStaleCatchpointSnapshots(writingCatchpointRound basics.Round) {
+	snapshots, err := filepath.Glob(filepath.Join(au.dbDirectory, catchpointDirName, catchpointSnapshotFilePrefix+"*"))
+	if err != nil {
+		return
+	}
+	retainedSnapshot := ""
+	if writingCatchpointRound != 0 {
+		retainedSnapshot = filepath.Join(au.dbDirectory, catchpointSnapshotPath(writingCatchpointRound))
+	}
+	for _, snapshot := range snapshots {
+		if retainedSnapshot != "" && strings.HasPrefix(snapshot, retainedSnapshot) {
+			continue
+		}
+		os.Remove(snapshot)
+	}
+}
+
+// writeCatchpointFile writes the catchpoint file off the given accounts database, and stores it as the catchpoint file of
+// the committed round. The returned retryCatchpointCreation is true when the writing was aborted, and should be repeated on startup.
+func (au *accountUpdates) writeCatchpointFile(dbs db.Accessor, maxBytesPerSecond uint64, slowWriting chan struct{}, committedRound basics.Round, label string, committedRoundDigest crypto.Digest, catchpointGenerationStats telemetryspec.CatchpointGenerationEventDetails, beforeGeneratingCatchpointTime time.Time) (retryCatchpointCreation bool) {
 	// the retryCatchpointCreation is used to repeat the catchpoint file generation in case the node crashed / aborted during startup
 	// before the catchpoint file generation could be completed.
-	retryCatchpointCreation := false
-	au.log.Debugf("accountUpdates: generateCatchpoint: generating catchpoint for round %d", committedRound)
 	defer func() {
 		if !retryCatchpointCreation {
 			// clear the writingCatchpoint flag
@@ -2068,13 +2281,7 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 		}
 	}()
 
-	_, err := au.accountsq.writeCatchpointStateUint64(context.Background(), catchpointStateWritingCatchpoint, uint64(committedRound))
-	if err != nil {
-		au.log.Warnf("accountUpdates: generateCatchpoint unable to write catchpoint state '%s' for round %d: %v", catchpointStateWritingCatchpoint, committedRound, err)
-		return
-	}
-
-	relCatchpointFileName := filepath.Join("catchpoints", catchpointRoundToPath(committedRound))
+	relCatchpointFileName := filepath.Join(catchpointDirName, catchpointRoundToPath(committedRound))
 	absCatchpointFileName := filepath.Join(au.dbDirectory, relCatchpointFileName)
 
 	more := true
@@ -2082,7 +2289,7 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 	const longChunkExecutionDuration = 1 * time.Second
 	var chunkExecutionDuration time.Duration
 	select {
-	case <-au.catchpointSlowWriting:
+	case <-slowWriting:
 		chunkExecutionDuration = longChunkExecutionDuration
 	default:
 		chunkExecutionDuration = shortChunkExecutionDuration
@@ -2091,8 +2298,11 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 	var catchpointWriter *catchpointWriter
 	start := time.Now()
 	ledgerGeneratecatchpointCount.Inc(nil)
-	err = au.dbs.Rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+	ledgerCatchpointGenerationProgress.Set(0, nil)
+	err := dbs.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
 		catchpointWriter = makeCatchpointWriter(au.ctx, absCatchpointFileName, tx, committedRound, committedRoundDigest, label)
+		catchpointWriter.maxBytesPerSecond = maxBytesPerSecond
+		lastProgressReport := time.Now()
 		for more {
 			stepCtx, stepCancelFunction := context.WithTimeout(au.ctx, chunkExecutionDuration)
 			writeStepStartTime := time.Now()
@@ -2100,6 +2310,13 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 			// accumulate the actual time we've spent writing in this step.
 			catchpointGenerationStats.CPUTime += uint64(time.Now().Sub(writeStepStartTime).Nanoseconds())
 			stepCancelFunction()
+			if writtenChunks, totalChunks := catchpointWriter.GetProgress(); totalChunks > 0 {
+				ledgerCatchpointGenerationProgress.Set(float64(writtenChunks)*100/float64(totalChunks), nil)
+				if time.Now().Sub(lastProgressReport) > catchpointGenerationProgressReportInterval {
+					au.log.Infof("accountUpdates: generateCatchpoint: written %d out of %d balances chunks of the catchpoint file for round %d", writtenChunks, totalChunks, committedRound)
+					lastProgressReport = time.Now()
+				}
+			}
 			if more && err == nil {
 				// we just wrote some data, but there is more to be written.
 				// go to sleep for while.
@@ -2120,11 +2337,15 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 						return fmt.Errorf("error removing catchpoint file : %v", err2)
 					}
 					return nil
-				case <-au.catchpointSlowWriting:
+				case <-slowWriting:
 					chunkExecutionDuration = longChunkExecutionDuration
 				}
 			}
 			if err != nil {
+				if au.ctx.Err() != nil {
+					// we were interrupted in the middle of a step; the catchpoint file would be generated again on startup.
+					retryCatchpointCreation = true
+				}
 				err = fmt.Errorf("unable to create catchpoint : %v", err)
 				err2 := catchpointWriter.Abort()
 				if err2 != nil {
@@ -2145,6 +2366,9 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 		au.log.Warnf("accountUpdates: generateCatchpoint: nil catchpointWriter")
 		return
 	}
+	if retryCatchpointCreation {
+		return
+	}
 
 	err = au.saveCatchpointFile(committedRound, relCatchpointFileName, catchpointWriter.GetSize(), catchpointWriter.GetCatchpoint())
 	if err != nil {
@@ -2159,10 +2383,25 @@ func (au *accountUpdates) generateCatchpoint(committedRound basics.Round, label
 	au.log.With("writingDuration", catchpointGenerationStats.WritingDuration).
 		With("CPUTime", catchpointGenerationStats.CPUTime).
 		With("balancesWriteTime", catchpointGenerationStats.BalancesWriteTime).
+		With("snapshotTime", catchpointGenerationStats.SnapshotTime).
 		With("accountsCount", catchpointGenerationStats.AccountsCount).
 		With("fileSize", catchpointGenerationStats.FileSize).
 		With("catchpointLabel", catchpointGenerationStats.CatchpointLabel).
 		Infof("Catchpoint file was generated")
+	return
+}
+
+// catchpointSnapshotPath returns the path of the accounts database snapshot the catchpoint file of the given round is
+// generated off, relative to the ledger directory.
+func catchpointSnapshotPath(rnd basics.Round) string {
+	return filepath.Join(catchpointDirName, catchpointSnapshotFilePrefix+strconv.FormatUint(uint64(rnd), 10)+".sqlite")
+}
+
+// removeDatabaseFiles removes the given sqlite database file, along with its journal files.
+func removeDatabaseFiles(dbFileName string) {
+	for _, suffix := range []string{"", "-journal", "-wal", "-shm"} {
+		os.Remove(dbFileName + suffix)
+	}
 }
 
 // catchpointRoundToPath calculate the catchpoint file path for a given round
@@ -2286,5 +2525,7 @@ var ledgerCommitroundCount = metrics.NewCounter("ledger_commitround_count", "cal
 var ledgerCommitroundMicros = metrics.NewCounter("ledger_commitround_micros", "µs spent")
 var ledgerGeneratecatchpointCount = metrics.NewCounter("ledger_generatecatchpoint_count", "calls")
 var ledgerGeneratecatchpointMicros = metrics.NewCounter("ledger_generatecatchpoint_micros", "µs spent")
+var ledgerCatchpointSnapshotMicros = metrics.NewCounter("ledger_catchpointsnapshot_micros", "µs spent")
+var ledgerCatchpointGenerationProgress = metrics.MakeGauge(metrics.MetricName{Name: "ledger_catchpoint_generation_progress", Description: "percentage of the balances written into the catchpoint file being generated"})
 var ledgerVacuumCount = metrics.NewCounter("ledger_vacuum_count", "calls")
 var ledgerVacuumMicros = metrics.NewCounter("ledger_vacuum_micros", "µs spent")
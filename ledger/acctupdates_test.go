@@ -28,6 +28,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -907,6 +908,41 @@ func TestAcctUpdatesDeleteStoredCatchpoints(t *testing.T) {
 	require.Equal(t, 0, len(fileNames))
 }
 
+func TestGetCatchpointLabels(t *testing.T) {
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+
+	ml := makeMockLedgerForTracker(t, true, 10, protocol.ConsensusCurrentVersion)
+	defer ml.Close()
+
+	accts := []map[basics.Address]basics.AccountData{randomAccounts(20, true)}
+	au := &accountUpdates{}
+	conf := config.GetDefaultLocal()
+	conf.CatchpointInterval = 1
+	au.initialize(conf, ".", proto, accts[0])
+	defer au.close()
+
+	err := au.loadFromDisk(ml)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		err := au.accountsq.storeCatchpoint(context.Background(), basics.Round(i), fmt.Sprintf("./dummy_catchpoint_file-%d", i), fmt.Sprintf("%d#label", i), 0)
+		require.NoError(t, err)
+	}
+	// a catchpoint file which was found on disk has no label, and a catchpoint label without a file cannot be served.
+	err = au.accountsq.storeCatchpoint(context.Background(), basics.Round(6), "./dummy_catchpoint_file-6", "", 0)
+	require.NoError(t, err)
+	err = au.accountsq.storeCatchpoint(context.Background(), basics.Round(7), "", "7#label", 0)
+	require.NoError(t, err)
+
+	labels, err := au.GetCatchpointLabels(3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"5#label", "4#label", "3#label"}, labels)
+
+	labels, err = au.GetCatchpointLabels(10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"5#label", "4#label", "3#label", "2#label", "1#label"}, labels)
+}
+
 // listAndCompareComb lists the assets/applications and then compares against the expected
 // It repeats with different combinations of the limit parameters
 func listAndCompareComb(t *testing.T, au *accountUpdates, expected map[basics.CreatableIndex]ledgercore.ModifiedCreatable) {
@@ -1460,6 +1496,8 @@ func TestReproducibleCatchpointLabels(t *testing.T) {
 	cfg := config.GetDefaultLocal()
 	cfg.CatchpointInterval = 50
 	cfg.CatchpointTracking = 1
+	// the accounts are copied for the account proofs on the catchpoint rounds.
+	defer removeDatabaseFiles(filepath.Join(catchpointDirName, accountProofsFileName))
 	au.initialize(cfg, ".", protoParams, accts[0])
 	defer au.close()
 
@@ -1545,3 +1583,112 @@ func TestReproducibleCatchpointLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestCatchpointSnapshotGeneration(t *testing.T) {
+	// create new protocol version, which has lower lookback
+	testProtocolVersion := protocol.ConsensusVersion("test-protocol-TestCatchpointSnapshotGeneration")
+	protoParams := config.Consensus[protocol.ConsensusCurrentVersion]
+	protoParams.MaxBalLookback = 32
+	protoParams.SeedLookback = 2
+	protoParams.SeedRefreshInterval = 8
+	config.Consensus[testProtocolVersion] = protoParams
+	defer func() {
+		delete(config.Consensus, testProtocolVersion)
+		os.RemoveAll("./catchpoints")
+	}()
+
+	ml := makeMockLedgerForTracker(t, true, 10, testProtocolVersion)
+	defer ml.Close()
+
+	accts := []map[basics.Address]basics.AccountData{randomAccounts(100, true)}
+
+	pooldata := basics.AccountData{}
+	pooldata.MicroAlgos.Raw = 1000 * 1000 * 1000 * 1000
+	pooldata.Status = basics.NotParticipating
+	accts[0][testPoolAddr] = pooldata
+
+	sinkdata := basics.AccountData{}
+	sinkdata.MicroAlgos.Raw = 1000 * 1000 * 1000 * 1000
+	sinkdata.Status = basics.NotParticipating
+	accts[0][testSinkAddr] = sinkdata
+
+	au := &accountUpdates{}
+	conf := config.GetDefaultLocal()
+	conf.CatchpointInterval = 50
+	conf.Archival = true
+	conf.CatchpointGenerationSnapshot = true
+	conf.CatchpointGenerationMaxBytesPerSecond = 1024 * 1024
+	au.initialize(conf, ".", protoParams, accts[0])
+	defer au.close()
+	err := au.loadFromDisk(ml)
+	require.NoError(t, err)
+
+	rewardLevel := uint64(0)
+	for i := 1; i < 10; i++ {
+		accts = append(accts, accts[0])
+	}
+
+	for i := basics.Round(10); i <= basics.Round(2*conf.CatchpointInterval+protoParams.MaxBalLookback); i++ {
+		rewardLevelDelta := crypto.RandUint64() % 5
+		rewardLevel += rewardLevelDelta
+		updates, totals := randomDeltasBalanced(1, accts[i-1], rewardLevel)
+
+		prevTotals, err := au.Totals(basics.Round(i - 1))
+		require.NoError(t, err)
+
+		newPool := totals[testPoolAddr]
+		newPool.MicroAlgos.Raw -= prevTotals.RewardUnits() * rewardLevelDelta
+		updates.Upsert(testPoolAddr, newPool)
+		totals[testPoolAddr] = newPool
+
+		blk := bookkeeping.Block{
+			BlockHeader: bookkeeping.BlockHeader{
+				Round: basics.Round(i),
+			},
+		}
+		blk.RewardsLevel = rewardLevel
+		blk.CurrentProtocol = testProtocolVersion
+
+		delta := ledgercore.MakeStateDelta(&blk.BlockHeader, 0, updates.Len())
+		delta.Accts.MergeAccounts(updates)
+		au.newBlock(blk, delta)
+		accts = append(accts, totals)
+
+		au.committedUpTo(i)
+		au.waitAccountsWriting()
+		// the catchpoint file is being written in the background, and doesn't hold back the commits.
+		require.False(t, au.IsWritingCatchpointFile())
+		// a catchpoint file still being generated makes the next one skipped, so let it complete.
+		au.catchpointGeneration.Wait()
+	}
+
+	labels, err := au.GetCatchpointLabels(10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(labels))
+	require.Equal(t, au.GetLastCatchpointLabel(), labels[0])
+
+	for _, round := range []basics.Round{basics.Round(conf.CatchpointInterval), basics.Round(2 * conf.CatchpointInterval)} {
+		reader, err := au.GetCatchpointStream(round)
+		require.NoError(t, err)
+		reader.Close()
+	}
+
+	// the snapshots are removed once the catchpoint files are written.
+	snapshots, err := filepath.Glob(filepath.Join(".", catchpointDirName, catchpointSnapshotFilePrefix+"*"))
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+	writingCatchpointRound, _, err := au.accountsq.readCatchpointStateUint64(context.Background(), catchpointStateWritingCatchpoint)
+	require.NoError(t, err)
+	require.Zero(t, writingCatchpointRound)
+
+	// the commits don't wait on the catchpoint file of a previous round; the catchpoint file is skipped instead.
+	atomic.StoreInt32(&au.catchpointGenerating, 1)
+	au.generateCatchpoint(basics.Round(3*conf.CatchpointInterval), au.GetLastCatchpointLabel(), crypto.Digest{}, 0)
+	atomic.StoreInt32(&au.catchpointGenerating, 0)
+	au.catchpointGeneration.Wait()
+	_, err = os.Stat(filepath.Join(".", catchpointSnapshotPath(basics.Round(3*conf.CatchpointInterval))))
+	require.True(t, os.IsNotExist(err))
+	writingCatchpointRound, _, err = au.accountsq.readCatchpointStateUint64(context.Background(), catchpointStateWritingCatchpoint)
+	require.NoError(t, err)
+	require.Zero(t, writingCatchpointRound)
+}
@@ -18,6 +18,7 @@ package apply
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/transactions"
@@ -344,6 +345,10 @@ func ApplicationCall(ac transactions.ApplicationCallTxnFields, header transactio
 	// update), check that the programs are valid and not too expensive
 	if ac.ApplicationID == 0 || ac.OnCompletion == transactions.UpdateApplicationOC {
 		maxCost := balances.ConsensusParams().MaxAppProgramCost
+		if balances.ConsensusParams().EnableAppCostPooling {
+			// cost is enforced against the group budget during evaluation
+			maxCost = math.MaxInt32
+		}
 		err = checkPrograms(&ac, evalParams, maxCost)
 		if err != nil {
 			return err
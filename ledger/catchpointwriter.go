@@ -26,6 +26,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/algorand/msgp/msgp"
 
@@ -67,6 +68,8 @@ type catchpointWriter struct {
 	blockHeaderDigest crypto.Digest
 	label             string
 	accountsIterator  encodedAccountsBatchIter
+	// maxBytesPerSecond limits the rate at which the catchpoint file is written to disk; zero means no limit.
+	maxBytesPerSecond uint64
 }
 
 type encodedBalanceRecord struct {
@@ -132,7 +135,11 @@ func (cw *catchpointWriter) WriteStep(stepCtx context.Context) (more bool, err e
 		if err != nil {
 			return
 		}
-		cw.gzip = gzip.NewWriter(cw.file)
+		var output io.Writer = cw.file
+		if cw.maxBytesPerSecond > 0 {
+			output = makeThrottledWriter(cw.ctx, cw.file, cw.maxBytesPerSecond)
+		}
+		cw.gzip = gzip.NewWriter(output)
 		cw.tar = tar.NewWriter(cw.gzip)
 	}
 
@@ -320,6 +327,14 @@ func (cw *catchpointWriter) GetSize() int64 {
 	return cw.writtenBytes
 }
 
+// GetProgress returns the number of balances chunks written so far, and the total number of chunks in the catchpoint file.
+func (cw *catchpointWriter) GetProgress() (writtenChunks, totalChunks uint64) {
+	if cw.fileHeader != nil {
+		totalChunks = cw.fileHeader.TotalChunks
+	}
+	return cw.balancesChunkNum, totalChunks
+}
+
 // GetBalancesRound returns the round number of the balances to which this catchpoint is generated for.
 func (cw *catchpointWriter) GetBalancesRound() basics.Round {
 	if cw.fileHeader != nil {
@@ -362,3 +377,39 @@ func hasContextDeadlineExceeded(ctx context.Context) (contextExceeded bool, cont
 	}
 	return
 }
+
+// throttledWriter limits the rate of the writes into the underlying writer, by sleeping whenever the data written so far
+// is ahead of the configured rate.
+type throttledWriter struct {
+	ctx               context.Context
+	writer            io.Writer
+	maxBytesPerSecond uint64
+	start             time.Time
+	written           uint64
+}
+
+func makeThrottledWriter(ctx context.Context, writer io.Writer, maxBytesPerSecond uint64) *throttledWriter {
+	return &throttledWriter{
+		ctx:               ctx,
+		writer:            writer,
+		maxBytesPerSecond: maxBytesPerSecond,
+		start:             time.Now(),
+	}
+}
+
+func (tw *throttledWriter) Write(p []byte) (n int, err error) {
+	n, err = tw.writer.Write(p)
+	tw.written += uint64(n)
+	if err != nil {
+		return
+	}
+	due := tw.start.Add(time.Duration(float64(tw.written) / float64(tw.maxBytesPerSecond) * float64(time.Second)))
+	if delay := time.Until(due); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-tw.ctx.Done():
+			err = tw.ctx.Err()
+		}
+	}
+	return
+}
@@ -28,12 +28,15 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/algorand/go-algorand/config"
 	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/merkletrie"
 	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/protocol"
 )
 
@@ -354,6 +357,33 @@ func TestFullCatchpointWriter(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	// the merkle trie rebuilt out of the file matches the one of the originating ledger.
+	var expectedBalancesHash crypto.Digest
+	var expectedTotals ledgercore.AccountTotals
+	err = readDb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		mc, err := makeMerkleCommitter(tx, false)
+		if err != nil {
+			return
+		}
+		trie, err := merkletrie.MakeTrie(mc, trieMemoryConfig)
+		if err != nil {
+			return
+		}
+		expectedBalancesHash, err = trie.RootHash()
+		if err != nil {
+			return
+		}
+		expectedTotals, err = accountsTotals(tx, false)
+		return
+	})
+	require.NoError(t, err)
+	err = accessor.BuildMerkleTrie(context.Background(), nil)
+	require.NoError(t, err)
+	balancesHash, totals, err := accessor.GetVerifyData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expectedBalancesHash, balancesHash)
+	require.Equal(t, expectedTotals, totals)
+
 	err = l.trackerDBs.Wdb.Atomic(func(ctx context.Context, tx *sql.Tx) error {
 		err := applyCatchpointStagingBalances(ctx, tx, 0)
 		return err
@@ -368,3 +398,23 @@ func TestFullCatchpointWriter(t *testing.T) {
 		require.Equal(t, basics.Round(0), validThrough)
 	}
 }
+
+func TestThrottledWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := makeThrottledWriter(context.Background(), &buffer, 100*1024)
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		n, err := writer.Write(make([]byte, 2*1024))
+		require.NoError(t, err)
+		require.Equal(t, 2*1024, n)
+	}
+	require.Equal(t, 20*1024, buffer.Len())
+	require.True(t, time.Now().Sub(start) >= 200*time.Millisecond)
+
+	// a canceled context interrupts the throttling.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	writer = makeThrottledWriter(ctx, &buffer, 1)
+	_, err := writer.Write(make([]byte, 1024))
+	require.Equal(t, context.Canceled, err)
+}
@@ -57,6 +57,9 @@ type CatchpointCatchupAccessor interface {
 	// ProgressStagingBalances deserialize the given bytes as a temporary staging balances
 	ProgressStagingBalances(ctx context.Context, sectionName string, bytes []byte, progress *CatchpointCatchupAccessorProgress) (err error)
 
+	// StagingBalances calls the given function with each of the staging balances, ordered by address
+	StagingBalances(ctx context.Context, fn func(addr basics.Address, data basics.AccountData) error) (err error)
+
 	// BuildMerkleTrie inserts the account hashes into the merkle trie
 	BuildMerkleTrie(ctx context.Context, progressUpdates func(uint64)) (err error)
 
@@ -66,6 +69,9 @@ type CatchpointCatchupAccessor interface {
 	// VerifyCatchpoint verifies that the catchpoint is valid by reconstructing the label.
 	VerifyCatchpoint(ctx context.Context, blk *bookkeeping.Block) (err error)
 
+	// GetVerifyData returns the balances hash and the account totals used to verify the catchpoint
+	GetVerifyData(ctx context.Context) (balancesHash crypto.Digest, totals ledgercore.AccountTotals, err error)
+
 	// StoreBalancesRound calculates the balances round based on the first block and the associated consensus parameters, and
 	// store that to the database
 	StoreBalancesRound(ctx context.Context, blk *bookkeeping.Block) (err error)
@@ -404,6 +410,41 @@ func (c *CatchpointCatchupAccessorImpl) processStagingBalances(ctx context.Conte
 	return err
 }
 
+// StagingBalances calls the given function with each of the staging balances, ordered by address
+func (c *CatchpointCatchupAccessorImpl) StagingBalances(ctx context.Context, fn func(addr basics.Address, data basics.AccountData) error) (err error) {
+	rdb := c.ledger.trackerDB().Rdb
+	return rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
+		rows, err := tx.QueryContext(ctx, "SELECT address, data FROM catchpointbalances ORDER BY address")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var addrbuf []byte
+			var buf []byte
+			err = rows.Scan(&addrbuf, &buf)
+			if err != nil {
+				return err
+			}
+			var addr basics.Address
+			if len(addrbuf) != len(addr) {
+				return fmt.Errorf("CatchpointCatchupAccessorImpl::StagingBalances: address length mismatch: %d != %d", len(addrbuf), len(addr))
+			}
+			copy(addr[:], addrbuf)
+			var data basics.AccountData
+			err = protocol.Decode(buf, &data)
+			if err != nil {
+				return err
+			}
+			err = fn(addr, data)
+			if err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+}
+
 // BuildMerkleTrie would process the catchpointpendinghashes and insert all the items in it into the merkle trie
 func (c *CatchpointCatchupAccessorImpl) BuildMerkleTrie(ctx context.Context, progressUpdates func(uint64)) (err error) {
 	wdb := c.ledger.trackerDB().Wdb
@@ -596,7 +637,6 @@ func (c *CatchpointCatchupAccessorImpl) GetCatchupBlockRound(ctx context.Context
 
 // VerifyCatchpoint verifies that the catchpoint is valid by reconstructing the label.
 func (c *CatchpointCatchupAccessorImpl) VerifyCatchpoint(ctx context.Context, blk *bookkeeping.Block) (err error) {
-	rdb := c.ledger.trackerDB().Rdb
 	var balancesHash crypto.Digest
 	var blockRound basics.Round
 	var totals ledgercore.AccountTotals
@@ -616,6 +656,27 @@ func (c *CatchpointCatchupAccessorImpl) VerifyCatchpoint(ctx context.Context, bl
 
 	start := time.Now()
 	ledgerVerifycatchpointCount.Inc(nil)
+	balancesHash, totals, err = c.GetVerifyData(ctx)
+	ledgerVerifycatchpointMicros.AddMicrosecondsSince(start, nil)
+	if err != nil {
+		return err
+	}
+	if blockRound != blk.Round() {
+		return fmt.Errorf("block round in block header doesn't match block round in catchpoint")
+	}
+
+	catchpointLabelMaker := ledgercore.MakeCatchpointLabel(blockRound, blk.Digest(), balancesHash, totals)
+
+	if catchpointLabel != catchpointLabelMaker.String() {
+		return fmt.Errorf("catchpoint hash mismatch; expected %s, calculated %s", catchpointLabel, catchpointLabelMaker.String())
+	}
+	return nil
+}
+
+// GetVerifyData returns the balances hash and the account totals used to verify the catchpoint; the balances
+// hash is the root of the staging merkle trie, and would be valid only once BuildMerkleTrie was called.
+func (c *CatchpointCatchupAccessorImpl) GetVerifyData(ctx context.Context) (balancesHash crypto.Digest, totals ledgercore.AccountTotals, err error) {
+	rdb := c.ledger.trackerDB().Rdb
 	err = rdb.Atomic(func(ctx context.Context, tx *sql.Tx) (err error) {
 		// create the merkle trie for the balances
 		mc, err0 := makeMerkleCommitter(tx, true)
@@ -639,20 +700,7 @@ func (c *CatchpointCatchupAccessorImpl) VerifyCatchpoint(ctx context.Context, bl
 		}
 		return
 	})
-	ledgerVerifycatchpointMicros.AddMicrosecondsSince(start, nil)
-	if err != nil {
-		return err
-	}
-	if blockRound != blk.Round() {
-		return fmt.Errorf("block round in block header doesn't match block round in catchpoint")
-	}
-
-	catchpointLabelMaker := ledgercore.MakeCatchpointLabel(blockRound, blk.Digest(), balancesHash, totals)
-
-	if catchpointLabel != catchpointLabelMaker.String() {
-		return fmt.Errorf("catchpoint hash mismatch; expected %s, calculated %s", catchpointLabel, catchpointLabelMaker.String())
-	}
-	return nil
+	return
 }
 
 // StoreBalancesRound calculates the balances round based on the first block and the associated consensus parameters, and
@@ -23,6 +23,7 @@ import (
 	"github.com/algorand/go-algorand/crypto/compactcert"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/protocol"
 )
@@ -99,45 +100,6 @@ func AcceptableCompactCertWeight(votersHdr bookkeeping.BlockHeader, firstValid b
 	return w
 }
 
-// CompactCertParams computes the parameters for building or verifying
-// a compact cert for block hdr, using voters from block votersHdr.
-func CompactCertParams(votersHdr bookkeeping.BlockHeader, hdr bookkeeping.BlockHeader) (res compactcert.Params, err error) {
-	proto := config.Consensus[votersHdr.CurrentProtocol]
-
-	if proto.CompactCertRounds == 0 {
-		err = fmt.Errorf("compact certs not enabled")
-		return
-	}
-
-	if votersHdr.Round%basics.Round(proto.CompactCertRounds) != 0 {
-		err = fmt.Errorf("votersHdr %d not a multiple of %d",
-			votersHdr.Round, proto.CompactCertRounds)
-		return
-	}
-
-	if hdr.Round != votersHdr.Round+basics.Round(proto.CompactCertRounds) {
-		err = fmt.Errorf("certifying block %d not %d ahead of voters %d",
-			hdr.Round, proto.CompactCertRounds, votersHdr.Round)
-		return
-	}
-
-	totalWeight := votersHdr.CompactCert[protocol.CompactCertBasic].CompactCertVotersTotal.ToUint64()
-	provenWeight, overflowed := basics.Muldiv(totalWeight, uint64(proto.CompactCertWeightThreshold), 1<<32)
-	if overflowed {
-		err = fmt.Errorf("overflow computing provenWeight[%d]: %d * %d / (1<<32)",
-			hdr.Round, totalWeight, proto.CompactCertWeightThreshold)
-		return
-	}
-
-	res = compactcert.Params{
-		Msg:          hdr,
-		ProvenWeight: provenWeight,
-		SigRound:     hdr.Round + 1,
-		SecKQ:        proto.CompactCertSecKQ,
-	}
-	return
-}
-
 // validateCompactCert checks that a compact cert is valid.
 func validateCompactCert(certHdr bookkeeping.BlockHeader, cert compactcert.Cert, votersHdr bookkeeping.BlockHeader, nextCertRnd basics.Round, atRound basics.Round) error {
 	proto := config.Consensus[certHdr.CurrentProtocol]
@@ -167,7 +129,7 @@ func validateCompactCert(certHdr bookkeeping.BlockHeader, cert compactcert.Cert,
 			atRound, cert.SignedWeight, acceptableWeight)
 	}
 
-	ccParams, err := CompactCertParams(votersHdr, certHdr)
+	ccParams, err := ledgercore.CompactCertParams(votersHdr, certHdr)
 	if err != nil {
 		return err
 	}
@@ -671,6 +671,7 @@ func (eval *BlockEvaluator) TransactionGroup(txads []transactions.SignedTxnWithA
 func (eval *BlockEvaluator) prepareEvalParams(txgroup []transactions.SignedTxnWithAD) (res []*logic.EvalParams) {
 	var groupNoAD []transactions.SignedTxn
 	var minTealVersion uint64
+	var pooledApplicationBudget *uint64
 	res = make([]*logic.EvalParams, len(txgroup))
 	for i, txn := range txgroup {
 		// Ignore any non-ApplicationCall transactions
@@ -685,14 +686,23 @@ func (eval *BlockEvaluator) prepareEvalParams(txgroup []transactions.SignedTxnWi
 				groupNoAD[j] = txgroup[j].SignedTxn
 			}
 			minTealVersion = logic.ComputeMinTealVersion(groupNoAD)
+			if eval.proto.EnableAppCostPooling {
+				pooledApplicationBudget = new(uint64)
+			}
+		}
+
+		// Every app call contributes its budget to the group-wide pool
+		if pooledApplicationBudget != nil {
+			*pooledApplicationBudget += uint64(eval.proto.MaxAppProgramCost)
 		}
 
 		res[i] = &logic.EvalParams{
-			Txn:            &groupNoAD[i],
-			Proto:          &eval.proto,
-			TxnGroup:       groupNoAD,
-			GroupIndex:     i,
-			MinTealVersion: &minTealVersion,
+			Txn:                     &groupNoAD[i],
+			Proto:                   &eval.proto,
+			TxnGroup:                groupNoAD,
+			GroupIndex:              i,
+			MinTealVersion:          &minTealVersion,
+			PooledApplicationBudget: pooledApplicationBudget,
 		}
 	}
 	return
@@ -1285,3 +1295,16 @@ func (vb ValidatedBlock) WithSeed(s committee.Seed) ValidatedBlock {
 		delta: vb.delta,
 	}
 }
+
+// WithTimestamp returns a copy of the ValidatedBlock with a modified timestamp.
+func (vb ValidatedBlock) WithTimestamp(ts int64) ValidatedBlock {
+	newblock := vb.blk
+	newblock.BlockHeader.TimeStamp = ts
+
+	newdelta := vb.delta
+	newdelta.Hdr = &newblock.BlockHeader
+	return ValidatedBlock{
+		blk:   newblock,
+		delta: newdelta,
+	}
+}
@@ -315,12 +315,22 @@ func TestPrepareEvalParams(t *testing.T) {
 					require.Equal(t, res[j].TxnGroup, expGroupNoAD)
 					require.Equal(t, *res[j].Proto, eval.proto)
 					require.Equal(t, *res[j].Txn, testCase.group[j].SignedTxn)
+					require.Nil(t, res[j].PooledApplicationBudget)
 				} else {
 					require.Nil(t, res[j])
 				}
 			}
 		})
 	}
+
+	// With cost pooling all app calls in the group share a single budget
+	eval.proto.EnableAppCostPooling = true
+	eval.proto.MaxAppProgramCost = 700
+	res := eval.prepareEvalParams([]transactions.SignedTxnWithAD{appcall1, payment, appcall2})
+	require.Nil(t, res[1])
+	require.NotNil(t, res[0].PooledApplicationBudget)
+	require.True(t, res[0].PooledApplicationBudget == res[2].PooledApplicationBudget)
+	require.Equal(t, uint64(1400), *res[0].PooledApplicationBudget)
 }
 
 func testLedgerCleanup(l *Ledger, dbName string, inMem bool) {
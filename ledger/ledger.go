@@ -21,6 +21,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/algorand/go-deadlock"
@@ -475,6 +476,15 @@ func (l *Ledger) LookupWithoutRewards(rnd basics.Round, addr basics.Address) (ba
 	return data, validThrough, nil
 }
 
+// AccountProof returns the data of the given account as of the last catchpoint label, with a proof
+// of its inclusion in the accounts merkle trie the label commits to. The proof is made off the copy
+// of the accounts database taken for the round of that label.
+func (l *Ledger) AccountProof(addr basics.Address) (AccountProof, error) {
+	l.trackerMu.RLock()
+	defer l.trackerMu.RUnlock()
+	return l.accts.AccountProof(addr)
+}
+
 // Totals returns the totals of all accounts at the end of round rnd.
 func (l *Ledger) Totals(rnd basics.Round) (ledgercore.AccountTotals, error) {
 	l.trackerMu.RLock()
@@ -628,6 +638,20 @@ func (l *Ledger) GetCatchpointStream(round basics.Round) (ReadCloseSizer, error)
 	return l.accts.GetCatchpointStream(round)
 }
 
+// GetCatchpointLabels returns the labels of up to maxCount of the latest catchpoint files
+// the ledger can serve, latest first.
+func (l *Ledger) GetCatchpointLabels(maxCount int) ([]string, error) {
+	l.trackerMu.RLock()
+	defer l.trackerMu.RUnlock()
+	return l.accts.GetCatchpointLabels(maxCount)
+}
+
+// CatchpointDownloadDirectory returns the directory in which the catchpoint catchup stages the
+// parts of the catchpoint file it downloads, so that an interrupted download can be resumed.
+func (l *Ledger) CatchpointDownloadDirectory() string {
+	return filepath.Join(l.accts.dbDirectory, "catchpointdownload")
+}
+
 // ledgerForTracker methods
 func (l *Ledger) trackerDB() db.Pair {
 	return l.trackerDBs
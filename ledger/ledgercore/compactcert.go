@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package ledgercore
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto/compactcert"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// CompactCertParams computes the parameters for building or verifying
+// a compact cert for block hdr, using voters from block votersHdr.
+func CompactCertParams(votersHdr bookkeeping.BlockHeader, hdr bookkeeping.BlockHeader) (res compactcert.Params, err error) {
+	proto := config.Consensus[votersHdr.CurrentProtocol]
+
+	if proto.CompactCertRounds == 0 {
+		err = fmt.Errorf("compact certs not enabled")
+		return
+	}
+
+	if votersHdr.Round%basics.Round(proto.CompactCertRounds) != 0 {
+		err = fmt.Errorf("votersHdr %d not a multiple of %d",
+			votersHdr.Round, proto.CompactCertRounds)
+		return
+	}
+
+	if hdr.Round != votersHdr.Round+basics.Round(proto.CompactCertRounds) {
+		err = fmt.Errorf("certifying block %d not %d ahead of voters %d",
+			hdr.Round, proto.CompactCertRounds, votersHdr.Round)
+		return
+	}
+
+	totalWeight := votersHdr.CompactCert[protocol.CompactCertBasic].CompactCertVotersTotal.ToUint64()
+	provenWeight, overflowed := basics.Muldiv(totalWeight, uint64(proto.CompactCertWeightThreshold), 1<<32)
+	if overflowed {
+		err = fmt.Errorf("overflow computing provenWeight[%d]: %d * %d / (1<<32)",
+			hdr.Round, totalWeight, proto.CompactCertWeightThreshold)
+		return
+	}
+
+	res = compactcert.Params{
+		Msg:          hdr,
+		ProvenWeight: provenWeight,
+		SigRound:     hdr.Round + 1,
+		SecKQ:        proto.CompactCertSecKQ,
+	}
+	return
+}
@@ -25,6 +25,7 @@ import (
 	algodclient "github.com/algorand/go-algorand/daemon/algod/api/client"
 	v2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2"
 	generatedV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated"
+	privateV2 "github.com/algorand/go-algorand/daemon/algod/api/server/v2/generated/private"
 	kmdclient "github.com/algorand/go-algorand/daemon/kmd/client"
 	"github.com/algorand/go-algorand/rpcs"
 
@@ -757,6 +758,42 @@ func (c *Client) BookkeepingBlock(round uint64) (block bookkeeping.Block, err er
 	return
 }
 
+// CompactCert takes a round and returns the compact certificate covering it
+func (c *Client) CompactCert(round uint64) (resp generatedV2.CompactCertResponse, err error) {
+	algod, err := c.ensureAlgodClient()
+	if err == nil {
+		resp, err = algod.CompactCert(round)
+	}
+	return
+}
+
+// CompactCerts returns the compact certificates covering the rounds from minRound to maxRound
+func (c *Client) CompactCerts(minRound, maxRound uint64) (resp generatedV2.CompactCertsResponse, err error) {
+	algod, err := c.ensureAlgodClient()
+	if err == nil {
+		resp, err = algod.CompactCerts(minRound, maxRound)
+	}
+	return
+}
+
+// PendingCompactCerts returns the signature collection progress of the compact certificates that were not formed yet
+func (c *Client) PendingCompactCerts() (resp generatedV2.PendingCompactCertsResponse, err error) {
+	algod, err := c.ensureAlgodClient()
+	if err == nil {
+		resp, err = algod.PendingCompactCerts()
+	}
+	return
+}
+
+// NetworkPeers returns the peers the node is connected to
+func (c *Client) NetworkPeers() (resp privateV2.NetworkPeersResponse, err error) {
+	algod, err := c.ensureAlgodClient()
+	if err == nil {
+		resp, err = algod.NetworkPeers()
+	}
+	return
+}
+
 // HealthCheck returns an error if something is wrong
 func (c *Client) HealthCheck() error {
 	algod, err := c.ensureAlgodClient()
@@ -922,6 +959,42 @@ func (c *Client) Catchup(catchpointLabel string) error {
 	return nil
 }
 
+// AdvanceDevModeRounds writes count blocks to the ledger of a development mode node, and returns the last round written.
+func (c *Client) AdvanceDevModeRounds(count uint64) (uint64, error) {
+	algod, err := c.ensureAlgodClient()
+	if err != nil {
+		return 0, err
+	}
+	resp, err := algod.AdvanceDevModeRounds(count)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Round, nil
+}
+
+// SetBlockTimeStampOffset sets the number of seconds a development mode node adds to the wall clock when timestamping blocks.
+func (c *Client) SetBlockTimeStampOffset(offset uint64) error {
+	algod, err := c.ensureAlgodClient()
+	if err != nil {
+		return err
+	}
+	_, err = algod.SetBlockTimeStampOffset(offset)
+	return err
+}
+
+// GetBlockTimeStampOffset gets the number of seconds a development mode node adds to the wall clock when timestamping blocks.
+func (c *Client) GetBlockTimeStampOffset() (uint64, error) {
+	algod, err := c.ensureAlgodClient()
+	if err != nil {
+		return 0, err
+	}
+	resp, err := algod.GetBlockTimeStampOffset()
+	if err != nil {
+		return 0, err
+	}
+	return resp.Offset, nil
+}
+
 const defaultAppIdx = 1380011588
 
 // MakeDryrunStateBytes function creates DryrunRequest data structure in serialized form according to the format
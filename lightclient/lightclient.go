@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package lightclient verifies block headers and transactions using compact
+// certificates, without following the chain one block at a time.
+//
+// A Client starts from a trusted block header that commits to the voters of
+// the first compact certificate, such as the one of the round compact certs
+// were enabled at. Each certificate added to the Client proves the header of
+// the round it certifies, and that header commits to the voters of the next
+// certificate. Headers of other rounds are proven by linking them to a
+// certified header through the Branch of the headers that follow them.
+//
+// The package only depends on the block data types, and not on the ledger
+// and its database, so that it could be embedded in light clients.
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/compactcert"
+	"github.com/algorand/go-algorand/crypto/merklearray"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// Client tracks a chain of compact certificates. It is not safe for
+// concurrent use.
+type Client struct {
+	// votersHdr is the latest trusted header; its voters sign the next certificate
+	votersHdr bookkeeping.BlockHeader
+
+	// certified are the trusted headers, by round
+	certified map[basics.Round]bookkeeping.BlockHeader
+}
+
+// TxnProof is a proof that a transaction is part of a block, as returned by
+// /v2/blocks/{round}/transactions/{txid}/proof.
+type TxnProof struct {
+	// Txid is the ID of the transaction
+	Txid transactions.Txid
+
+	// Stibhash is the hash of the SignedTxnInBlock of the transaction
+	Stibhash crypto.Digest
+
+	// Idx is the index of the transaction in the payset of the block
+	Idx uint64
+
+	// Proof is the merkle proof of the transaction against the TxnRoot of the block
+	Proof []crypto.Digest
+}
+
+// MakeClient creates a Client that trusts the given header, which must be
+// on a compact cert round and commit to the voters of the next certificate.
+func MakeClient(trusted bookkeeping.BlockHeader) (*Client, error) {
+	proto, ok := config.Consensus[trusted.CurrentProtocol]
+	if !ok {
+		return nil, fmt.Errorf("header %d has unknown protocol %s", trusted.Round, trusted.CurrentProtocol)
+	}
+	if proto.CompactCertRounds == 0 {
+		return nil, fmt.Errorf("compact certs are not enabled in protocol %s", trusted.CurrentProtocol)
+	}
+	if trusted.Round%basics.Round(proto.CompactCertRounds) != 0 {
+		return nil, fmt.Errorf("header %d is not on a multiple of %d", trusted.Round, proto.CompactCertRounds)
+	}
+	if trusted.CompactCert[protocol.CompactCertBasic].CompactCertVoters.IsZero() {
+		return nil, fmt.Errorf("header %d has no compact cert voters", trusted.Round)
+	}
+	return &Client{
+		votersHdr: trusted,
+		certified: map[basics.Round]bookkeeping.BlockHeader{trusted.Round: trusted},
+	}, nil
+}
+
+// Latest returns the latest header proven by the certificates.
+func (c *Client) Latest() bookkeeping.BlockHeader {
+	return c.votersHdr
+}
+
+// NextCertRound returns the round of the certificate the client expects next.
+func (c *Client) NextCertRound() basics.Round {
+	proto := config.Consensus[c.votersHdr.CurrentProtocol]
+	return c.votersHdr.Round + basics.Round(proto.CompactCertRounds)
+}
+
+// AddCert verifies the certificate for the header of NextCertRound, signed by
+// the voters of the latest trusted header, and then trusts the header.
+func (c *Client) AddCert(hdr bookkeeping.BlockHeader, cert compactcert.Cert) error {
+	params, err := ledgercore.CompactCertParams(c.votersHdr, hdr)
+	if err != nil {
+		return err
+	}
+	verif := compactcert.MkVerifier(params, c.votersHdr.CompactCert[protocol.CompactCertBasic].CompactCertVoters)
+	err = verif.Verify(&cert)
+	if err != nil {
+		return fmt.Errorf("compact cert for round %d does not verify: %v", hdr.Round, err)
+	}
+	if hdr.CompactCert[protocol.CompactCertBasic].CompactCertVoters.IsZero() {
+		return fmt.Errorf("header %d has no compact cert voters to verify the next cert", hdr.Round)
+	}
+	c.certified[hdr.Round] = hdr
+	c.votersHdr = hdr
+	return nil
+}
+
+// VerifyHeader checks that hdr is part of the chain proven by the
+// certificates. If hdr is not a certified header, following are the headers
+// of the rounds after it, up to a certified round.
+func (c *Client) VerifyHeader(hdr bookkeeping.BlockHeader, following []bookkeeping.BlockHeader) error {
+	prev := hdr
+	for _, next := range following {
+		if next.Round != prev.Round+1 {
+			return fmt.Errorf("header %d does not follow header %d", next.Round, prev.Round)
+		}
+		if next.Branch != prev.Hash() {
+			return fmt.Errorf("header %d does not link to header %d", next.Round, prev.Round)
+		}
+		prev = next
+	}
+	certified, ok := c.certified[prev.Round]
+	if !ok {
+		return fmt.Errorf("round %d is not certified", prev.Round)
+	}
+	if certified.Hash() != prev.Hash() {
+		return fmt.Errorf("header %d differs from the certified one", prev.Round)
+	}
+	return nil
+}
+
+// VerifyTxn checks that a transaction is part of the block of hdr, after
+// checking hdr the same way VerifyHeader does.
+func (c *Client) VerifyTxn(hdr bookkeeping.BlockHeader, following []bookkeeping.BlockHeader, tp TxnProof) error {
+	err := c.VerifyHeader(hdr, following)
+	if err != nil {
+		return err
+	}
+	return VerifyTxn(hdr, tp)
+}
+
+// VerifyTxn checks that a transaction is part of the block of a header
+// already known to be valid.
+func VerifyTxn(hdr bookkeeping.BlockHeader, tp TxnProof) error {
+	proto := config.Consensus[hdr.CurrentProtocol]
+	if proto.PaysetCommit != config.PaysetCommitMerkle {
+		return fmt.Errorf("protocol %s does not commit to transactions with a merkle tree", hdr.CurrentProtocol)
+	}
+
+	// the leaf is the same as the txnMerkleElem hashed into the block's TxnRoot
+	leaf := []byte(protocol.TxnMerkleLeaf)
+	leaf = append(leaf, tp.Txid[:]...)
+	leaf = append(leaf, tp.Stibhash[:]...)
+	elems := map[uint64]crypto.Digest{tp.Idx: crypto.Hash(leaf)}
+	return merklearray.Verify(hdr.TxnRoot, elems, tp.Proof)
+}
+
+// ParseProof splits the concatenated digests of a proof returned by
+// /v2/blocks/{round}/transactions/{txid}/proof.
+func ParseProof(proof []byte) ([]crypto.Digest, error) {
+	if len(proof)%crypto.DigestSize != 0 {
+		return nil, fmt.Errorf("proof length %d is not a multiple of %d", len(proof), crypto.DigestSize)
+	}
+	digests := make([]crypto.Digest, len(proof)/crypto.DigestSize)
+	for i := range digests {
+		copy(digests[i][:], proof[i*crypto.DigestSize:])
+	}
+	return digests, nil
+}
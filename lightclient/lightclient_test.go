@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package lightclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/crypto/compactcert"
+	"github.com/algorand/go-algorand/crypto/merklearray"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+type participants []compactcert.Participant
+
+func (p participants) Length() uint64 {
+	return uint64(len(p))
+}
+
+func (p participants) GetHash(pos uint64) (crypto.Digest, error) {
+	if pos >= uint64(len(p)) {
+		return crypto.Digest{}, fmt.Errorf("pos %d >= len %d", pos, len(p))
+	}
+	return crypto.HashObj(p[pos]), nil
+}
+
+// testChain builds headers for consecutive rounds, with voters on every
+// compact cert round, and the certificates signed by those voters.
+type testChain struct {
+	t     *testing.T
+	proto config.ConsensusParams
+	key   *crypto.OneTimeSignatureSecrets
+	parts participants
+	tree  *merklearray.Tree
+	hdrs  []bookkeeping.BlockHeader
+}
+
+func makeTestChain(t *testing.T, rounds int) *testChain {
+	tc := &testChain{
+		t:     t,
+		proto: config.Consensus[protocol.ConsensusFuture],
+		key:   crypto.GenerateOneTimeSignatureSecrets(0, 1),
+	}
+	for i := 0; i < 10; i++ {
+		tc.parts = append(tc.parts, compactcert.Participant{
+			PK:          tc.key.OneTimeSignatureVerifier,
+			Weight:      1000000,
+			KeyDilution: 10000,
+		})
+	}
+	var err error
+	tc.tree, err = merklearray.Build(tc.parts)
+	require.NoError(t, err)
+
+	var prev bookkeeping.BlockHeader
+	for r := 0; r <= rounds; r++ {
+		var hdr bookkeeping.BlockHeader
+		hdr.Round = basics.Round(r)
+		hdr.CurrentProtocol = protocol.ConsensusFuture
+		if r > 0 {
+			hdr.Branch = prev.Hash()
+		}
+		hdr.TxnRoot = crypto.Hash([]byte{byte(r)})
+		if uint64(r)%tc.proto.CompactCertRounds == 0 {
+			hdr.CompactCert = map[protocol.CompactCertType]bookkeeping.CompactCertState{
+				protocol.CompactCertBasic: {
+					CompactCertVoters:      tc.tree.Root(),
+					CompactCertVotersTotal: basics.MicroAlgos{Raw: 10 * 1000000},
+				},
+			}
+		}
+		tc.hdrs = append(tc.hdrs, hdr)
+		prev = hdr
+	}
+	return tc
+}
+
+func (tc *testChain) cert(round basics.Round) compactcert.Cert {
+	votersHdr := tc.hdrs[round-basics.Round(tc.proto.CompactCertRounds)]
+	params, err := ledgercore.CompactCertParams(votersHdr, tc.hdrs[round])
+	require.NoError(tc.t, err)
+
+	b, err := compactcert.MkBuilder(params, tc.parts, tc.tree)
+	require.NoError(tc.t, err)
+	sig := tc.key.Sign(basics.OneTimeIDForRound(params.SigRound, tc.parts[0].KeyDilution), params.Msg)
+	for i := range tc.parts {
+		require.NoError(tc.t, b.Add(uint64(i), sig, true))
+	}
+	cert, err := b.Build()
+	require.NoError(tc.t, err)
+	return *cert
+}
+
+func TestClient(t *testing.T) {
+	tc := makeTestChain(t, 300)
+	ccRounds := basics.Round(tc.proto.CompactCertRounds)
+
+	_, err := MakeClient(tc.hdrs[1])
+	require.Error(t, err)
+
+	c, err := MakeClient(tc.hdrs[0])
+	require.NoError(t, err)
+	require.Equal(t, ccRounds, c.NextCertRound())
+
+	// a certificate for one header doesn't prove another
+	cert := tc.cert(ccRounds)
+	forged := tc.hdrs[ccRounds]
+	forged.TxnRoot = crypto.Digest{}
+	require.Error(t, c.AddCert(forged, cert))
+	require.Error(t, c.AddCert(tc.hdrs[2*ccRounds], cert))
+
+	require.NoError(t, c.AddCert(tc.hdrs[ccRounds], cert))
+	require.NoError(t, c.AddCert(tc.hdrs[2*ccRounds], tc.cert(2*ccRounds)))
+	require.Equal(t, tc.hdrs[2*ccRounds], c.Latest())
+
+	// headers are proven by linking them to a certified header
+	require.NoError(t, c.VerifyHeader(tc.hdrs[2*ccRounds], nil))
+	require.NoError(t, c.VerifyHeader(tc.hdrs[2*ccRounds-3], tc.hdrs[2*ccRounds-2:2*ccRounds+1]))
+	require.Error(t, c.VerifyHeader(tc.hdrs[2*ccRounds-3], tc.hdrs[2*ccRounds-2:2*ccRounds]))
+	require.Error(t, c.VerifyHeader(forged, nil))
+	require.Error(t, c.VerifyHeader(forged, tc.hdrs[ccRounds+1:2*ccRounds+1]))
+	require.Error(t, c.VerifyHeader(tc.hdrs[2*ccRounds+1], nil))
+}
+
+func TestVerifyTxn(t *testing.T) {
+	var stxns []transactions.SignedTxn
+	var blk bookkeeping.Block
+	blk.CurrentProtocol = protocol.ConsensusFuture
+	blk.BlockHeader.GenesisHash = crypto.Hash([]byte("genesis"))
+	for i := 0; i < 10; i++ {
+		var stxn transactions.SignedTxn
+		stxn.Txn.Type = protocol.PaymentTx
+		stxn.Txn.FirstValid = basics.Round(i)
+		stxn.Txn.GenesisHash = blk.BlockHeader.GenesisHash
+		stib, err := blk.EncodeSignedTxn(stxn, transactions.ApplyData{})
+		require.NoError(t, err)
+		blk.Payset = append(blk.Payset, stib)
+		stxns = append(stxns, stxn)
+	}
+	var err error
+	blk.TxnRoot, err = blk.PaysetCommit()
+	require.NoError(t, err)
+
+	tree, err := blk.TxnMerkleTree()
+	require.NoError(t, err)
+	proof, err := tree.Prove([]uint64{3})
+	require.NoError(t, err)
+
+	var proofconcat []byte
+	for _, d := range proof {
+		proofconcat = append(proofconcat, d[:]...)
+	}
+	parsed, err := ParseProof(proofconcat)
+	require.NoError(t, err)
+	require.Equal(t, proof, parsed)
+
+	tp := TxnProof{
+		Txid:     stxns[3].ID(),
+		Stibhash: blk.Payset[3].Hash(),
+		Idx:      3,
+		Proof:    parsed,
+	}
+	require.NoError(t, VerifyTxn(blk.BlockHeader, tp))
+
+	tp.Txid = stxns[4].ID()
+	require.Error(t, VerifyTxn(blk.BlockHeader, tp))
+
+	_, err = ParseProof(proofconcat[1:])
+	require.Error(t, err)
+}
@@ -304,6 +304,9 @@ type CatchpointGenerationEventDetails struct {
 	FileSize uint64
 	// CatchpointLabel is the catchpoint label for which the catchpoint file was generated.
 	CatchpointLabel string
+	// SnapshotTime is the time it took to create the accounts database snapshot the catchpoint file was generated from,
+	// or zero if the catchpoint file was generated off the accounts database itself.
+	SnapshotTime uint64
 }
 
 // BalancesAccountVacuumEvent event
@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package netdeploy
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/gen"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/netdeploy/remote"
+	"github.com/algorand/go-algorand/nodecontrol"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// forkTemplateFileName is the name of the template written to the root directory of a forked network
+// created without a template.
+const forkTemplateFileName = "template.json"
+
+// defaultForkTemplate is the template of a forked network created without a template; a single relay
+// which holds the only online account.
+var defaultForkTemplate = NetworkTemplate{
+	Genesis: gen.GenesisData{
+		FirstPartKeyRound: gen.DefaultGenesis.FirstPartKeyRound,
+		LastPartKeyRound:  gen.DefaultGenesis.LastPartKeyRound,
+		Wallets: []gen.WalletData{
+			{Name: "Wallet1", Stake: 100, Online: true},
+		},
+	},
+	Nodes: []remote.NodeConfigGoal{
+		{
+			Name:    "Primary",
+			IsRelay: true,
+			Wallets: []remote.NodeWalletData{{Name: "Wallet1"}},
+		},
+	},
+}
+
+// CreateForkedNetworkFromTemplate deploys a new private network the same way CreateNetworkFromTemplate does,
+// with a genesis holding all the accounts, assets and applications of the given catchpoint file of an existing
+// network. The online accounts of the existing network are taken offline, and the wallets of the template get
+// their online stake, with participation keys generated locally. Without a template, a template with a single
+// relay holding the only online wallet is written to the root directory and used.
+func CreateForkedNetworkFromTemplate(name, rootDir, templateFile, catchpointFile, binDir string, importKeys bool, nodeExitCallback nodecontrol.AlgodExitErrorCallback, consensus config.ConsensusProtocols) (Network, error) {
+	err := os.MkdirAll(rootDir, os.ModePerm)
+	if err != nil {
+		return Network{}, err
+	}
+	if templateFile == "" {
+		templateFile = filepath.Join(rootDir, forkTemplateFileName)
+		var templateJSON []byte
+		templateJSON, err = json.MarshalIndent(defaultForkTemplate, "", "    ")
+		if err == nil {
+			err = ioutil.WriteFile(templateFile, templateJSON, 0644)
+		}
+		if err != nil {
+			return Network{}, err
+		}
+	}
+
+	forked, err := loadForkedNetwork(catchpointFile)
+	if err != nil {
+		return Network{}, fmt.Errorf("unable to load catchpoint file '%s' : %v", catchpointFile, err)
+	}
+	return createNetworkFromTemplate(name, rootDir, templateFile, binDir, importKeys, nodeExitCallback, consensus, &forked)
+}
+
+// loadForkedNetwork reads the accounts out of a catchpoint file, either compressed or not, using the
+// catchpoint catchup accessor of a temporary ledger.
+func loadForkedNetwork(catchpointFile string) (forked gen.ForkedNetwork, err error) {
+	file, err := os.Open(catchpointFile)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	bufReader := bufio.NewReader(file)
+	magic, err := bufReader.Peek(2)
+	if err != nil {
+		return
+	}
+	var reader io.Reader = bufReader
+	if magic[0] == 0x1f && magic[1] == 0x8b {
+		// catchpoint files are stored compressed.
+		var gzipReader *gzip.Reader
+		gzipReader, err = gzip.NewReader(reader)
+		if err != nil {
+			return
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	tempDir, err := ioutil.TempDir("", "fork")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	// the accessor normalizes the online balances using the reward unit of the genesis protocol.
+	var initState ledger.InitState
+	initState.Block.CurrentProtocol = protocol.ConsensusCurrentVersion
+	l, err := ledger.OpenLedger(logging.Base(), filepath.Join(tempDir, "ledger"), false, initState, config.GetDefaultLocal())
+	if err != nil {
+		return
+	}
+	defer l.Close()
+
+	ctx := context.Background()
+	accessor := ledger.MakeCatchpointCatchupAccessor(l, logging.Base())
+	err = accessor.ResetStagingBalances(ctx, true)
+	if err != nil {
+		return
+	}
+
+	var fileHeader ledger.CatchpointFileHeader
+	var progress ledger.CatchpointCatchupAccessorProgress
+	tarReader := tar.NewReader(reader)
+	for {
+		var header *tar.Header
+		header, err = tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return
+		}
+		sectionBytes := make([]byte, header.Size)
+		_, err = io.ReadFull(tarReader, sectionBytes)
+		if err != nil {
+			return
+		}
+		err = accessor.ProgressStagingBalances(ctx, header.Name, sectionBytes, &progress)
+		if err != nil {
+			return
+		}
+		if header.Name == "content.msgpack" {
+			// it was already decoded successfully by the accessor.
+			protocol.Decode(sectionBytes, &fileHeader)
+		}
+	}
+	if !progress.SeenHeader {
+		err = fmt.Errorf("catchpoint file has no content header")
+		return
+	}
+
+	forked.RewardsLevel = fileHeader.Totals.RewardsLevel
+	forked.Accounts = make(map[basics.Address]basics.AccountData, fileHeader.TotalAccounts)
+	err = accessor.StagingBalances(ctx, func(addr basics.Address, data basics.AccountData) error {
+		forked.Accounts[addr] = data
+		return nil
+	})
+	return
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package netdeploy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// testBalanceRecord and testBalancesChunk are encoded the same way as the balances of a catchpoint file.
+type testBalanceRecord struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Address     []byte             `codec:"pk"`
+	AccountData basics.AccountData `codec:"ad"`
+}
+
+type testBalancesChunk struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Balances []testBalanceRecord `codec:"bl"`
+}
+
+func TestLoadForkedNetwork(t *testing.T) {
+	a := require.New(t)
+	tempDir, err := ioutil.TempDir("", "fork-test-")
+	a.NoError(err)
+	defer os.RemoveAll(tempDir)
+
+	accounts := map[basics.Address]basics.AccountData{
+		{1}: {Status: basics.Online, MicroAlgos: basics.MicroAlgos{Raw: 1000000}},
+		{2}: {
+			Status:      basics.Offline,
+			MicroAlgos:  basics.MicroAlgos{Raw: 2000000},
+			AssetParams: map[basics.AssetIndex]basics.AssetParams{7: {Total: 100}},
+		},
+	}
+	var chunk testBalancesChunk
+	for addr, data := range accounts {
+		addr := addr
+		chunk.Balances = append(chunk.Balances, testBalanceRecord{Address: addr[:], AccountData: data})
+	}
+	header := ledger.CatchpointFileHeader{
+		Version:       0200,
+		TotalAccounts: uint64(len(accounts)),
+		TotalChunks:   1,
+	}
+	header.Totals.RewardsLevel = 12
+
+	catchpointFile := filepath.Join(tempDir, "catchpoint.tar.gz")
+	file, err := os.Create(catchpointFile)
+	a.NoError(err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, section := range []struct {
+		name  string
+		bytes []byte
+	}{
+		{"content.msgpack", protocol.Encode(&header)},
+		{"balances.1.1.msgpack", protocol.EncodeReflect(&chunk)},
+	} {
+		a.NoError(tarWriter.WriteHeader(&tar.Header{Name: section.name, Mode: 0600, Size: int64(len(section.bytes))}))
+		_, err = tarWriter.Write(section.bytes)
+		a.NoError(err)
+	}
+	a.NoError(tarWriter.Close())
+	a.NoError(gzipWriter.Close())
+	a.NoError(file.Close())
+
+	forked, err := loadForkedNetwork(catchpointFile)
+	a.NoError(err)
+	a.Equal(uint64(12), forked.RewardsLevel)
+	a.Equal(accounts, forked.Accounts)
+
+	_, err = loadForkedNetwork(filepath.Join(tempDir, "missing"))
+	a.Error(err)
+}
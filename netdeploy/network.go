@@ -59,6 +59,10 @@ type Network struct {
 // CreateNetworkFromTemplate uses the specified template to deploy a new private network
 // under the specified root directory.
 func CreateNetworkFromTemplate(name, rootDir, templateFile, binDir string, importKeys bool, nodeExitCallback nodecontrol.AlgodExitErrorCallback, consensus config.ConsensusProtocols) (Network, error) {
+	return createNetworkFromTemplate(name, rootDir, templateFile, binDir, importKeys, nodeExitCallback, consensus, nil)
+}
+
+func createNetworkFromTemplate(name, rootDir, templateFile, binDir string, importKeys bool, nodeExitCallback nodecontrol.AlgodExitErrorCallback, consensus config.ConsensusProtocols, forked *gen.ForkedNetwork) (Network, error) {
 	n := Network{
 		rootDir:          rootDir,
 		nodeExitCallback: nodeExitCallback,
@@ -80,7 +84,7 @@ func CreateNetworkFromTemplate(name, rootDir, templateFile, binDir string, impor
 		return n, err
 	}
 	template.Consensus = consensus
-	err = template.generateGenesisAndWallets(rootDir, name, binDir)
+	err = template.generateGenesisAndWallets(rootDir, name, binDir, forked)
 	if err != nil {
 		return n, err
 	}
@@ -46,10 +46,13 @@ var defaultNetworkTemplate = NetworkTemplate{
 	Genesis: gen.DefaultGenesis,
 }
 
-func (t NetworkTemplate) generateGenesisAndWallets(targetFolder, networkName, binDir string) error {
+func (t NetworkTemplate) generateGenesisAndWallets(targetFolder, networkName, binDir string, forked *gen.ForkedNetwork) error {
 	genesisData := t.Genesis
 	genesisData.NetworkName = networkName
 	mergedConsensus := config.Consensus.Merge(t.Consensus)
+	if forked != nil {
+		return gen.GenerateForkedGenesisFiles(genesisData, mergedConsensus, *forked, targetFolder, true)
+	}
 	return gen.GenerateGenesisFiles(genesisData, mergedConsensus, targetFolder, true)
 }
 
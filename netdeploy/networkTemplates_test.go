@@ -58,7 +58,7 @@ func TestGenerateGenesis(t *testing.T) {
 	networkName := "testGenGen"
 	binDir := os.ExpandEnv("${GOPATH}/bin")
 
-	err = template.generateGenesisAndWallets(targetFolder, networkName, binDir)
+	err = template.generateGenesisAndWallets(targetFolder, networkName, binDir, nil)
 	a.NoError(err)
 	_, err = os.Stat(filepath.Join(targetFolder, config.GenesisJSONFile))
 	fileExists := err == nil
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// bandwidthBurstDuration is the duration of traffic at the configured rate which a token bucket can accumulate while
+// the connection is idle, and then let through at once.
+const bandwidthBurstDuration = time.Second
+
+// the directions of the bandwidth limiters, as reported by the metrics.
+const (
+	bandwidthIncoming = "incoming"
+	bandwidthOutgoing = "outgoing"
+)
+
+var networkRateLimitedMessagesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_rate_limited_messages_total", Description: "number of gossip messages dropped by the peer bandwidth limits, by direction and tag"})
+var networkRateLimitedBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_rate_limited_bytes_total", Description: "bytes of gossip messages dropped by the peer bandwidth limits, by direction and tag"})
+
+// rateLimitExemptTag returns true for the tags of the messages which aren't gossip, such as the network control
+// messages and the request-response ones. These are neither charged nor dropped by the bandwidth limits, since
+// dropping them would break the connection or leave the requests hanging. The transaction groups sent in response
+// to a TxnRequestTag share their tag with the gossiped ones, so they're exempted by the peer instead.
+func rateLimitExemptTag(tag protocol.Tag) bool {
+	switch tag {
+	case protocol.MsgOfInterestTag, protocol.MsgDigestSkipTag, protocol.NetPrioResponseTag, protocol.IdentityVerifyTag,
+		protocol.PingTag, protocol.PingReplyTag, protocol.TopicMsgRespTag, protocol.UniEnsBlockReqTag, protocol.UniCatchupReqTag,
+		protocol.TxnRequestTag:
+		return true
+	}
+	return false
+}
+
+// agreementTag returns true for the tags of the agreement messages, which the bandwidth limits never drop, so that the
+// agreement wouldn't be held up behind the rest of the gossip.
+func agreementTag(tag protocol.Tag) bool {
+	return highPriorityTag(tag) || tag == protocol.VoteBundleTag
+}
+
+// tokenBucket limits the traffic to a rate of bytes per second, allowing bursts of up to bandwidthBurstDuration.
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func makeTokenBucket(bytesPerSecond uint64, now time.Time) *tokenBucket {
+	rate := float64(bytesPerSecond)
+	capacity := rate * bandwidthBurstDuration.Seconds()
+	return &tokenBucket{rate: rate, capacity: capacity, tokens: capacity, last: now}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(tb.last).Seconds(); elapsed > 0 {
+		tb.tokens = math.Min(tb.capacity, tb.tokens+elapsed*tb.rate)
+		tb.last = now
+	}
+}
+
+// available returns true if the bucket has any tokens left. A nil bucket is unlimited.
+func (tb *tokenBucket) available(now time.Time) bool {
+	if tb == nil {
+		return true
+	}
+	tb.refill(now)
+	return tb.tokens > 0
+}
+
+// take charges the bucket with the given number of bytes. The bucket may go into debt, so that messages larger than
+// its capacity can still get through once in a while, and the average rate is kept nonetheless.
+func (tb *tokenBucket) take(bytes int, now time.Time) {
+	if tb == nil {
+		return
+	}
+	tb.refill(now)
+	tb.tokens -= float64(bytes)
+}
+
+// bandwidthLimiter rate limits the gossip messages going in one direction of a peer connection, with one token bucket
+// for all of them and one for each tag which has a limit of its own. It's used only by either the readLoop or the
+// writeLoop of the peer, and therefore isn't synchronized.
+type bandwidthLimiter struct {
+	direction string
+	all       *tokenBucket
+	tags      map[protocol.Tag]*tokenBucket
+}
+
+// makeBandwidthLimiter creates a bandwidthLimiter, or returns nil if no limit is configured.
+func makeBandwidthLimiter(direction string, bytesPerSecond uint64, tagBytesPerSecond map[protocol.Tag]uint64, now time.Time) *bandwidthLimiter {
+	if bytesPerSecond == 0 && len(tagBytesPerSecond) == 0 {
+		return nil
+	}
+	bl := &bandwidthLimiter{direction: direction, tags: make(map[protocol.Tag]*tokenBucket, len(tagBytesPerSecond))}
+	if bytesPerSecond > 0 {
+		bl.all = makeTokenBucket(bytesPerSecond, now)
+	}
+	for tag, tagRate := range tagBytesPerSecond {
+		bl.tags[tag] = makeTokenBucket(tagRate, now)
+	}
+	return bl
+}
+
+// allow charges the buckets with a message, and returns false if the message should be dropped instead. The agreement
+// messages are never dropped, but they're charged nonetheless, so that the rest of the gossip would make room for them.
+func (bl *bandwidthLimiter) allow(tag protocol.Tag, length int, now time.Time) bool {
+	if rateLimitExemptTag(tag) {
+		return true
+	}
+	tagBucket := bl.tags[tag]
+	if !agreementTag(tag) && (!bl.all.available(now) || !tagBucket.available(now)) {
+		labels := map[string]string{"direction": bl.direction, "tag": string(tag)}
+		networkRateLimitedMessagesTotal.Inc(labels)
+		networkRateLimitedBytesTotal.AddUint64(uint64(length), labels)
+		return false
+	}
+	bl.all.take(length, now)
+	tagBucket.take(length, now)
+	return true
+}
+
+// parseTagBytesPerSecond parses the semicolon-separated list of tag:rate pairs of the PeerTagBytesPerSecond config,
+// skipping the invalid entries.
+func parseTagBytesPerSecond(log logging.Logger, encoded string) map[protocol.Tag]uint64 {
+	limits := make(map[protocol.Tag]uint64)
+	for _, entry := range strings.Split(encoded, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || len(parts[0]) != 2 {
+			log.Warnf("ignoring invalid PeerTagBytesPerSecond entry '%s'", entry)
+			continue
+		}
+		rate, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || rate == 0 {
+			log.Warnf("ignoring invalid PeerTagBytesPerSecond entry '%s'", entry)
+			continue
+		}
+		tag := protocol.Tag(parts[0])
+		if rateLimitExemptTag(tag) {
+			log.Warnf("ignoring PeerTagBytesPerSecond entry '%s' since %s messages aren't rate limited", entry, tag)
+			continue
+		}
+		limits[tag] = rate
+	}
+	return limits
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	tb := makeTokenBucket(1000, now)
+	require.True(t, tb.available(now))
+	tb.take(600, now)
+	require.True(t, tb.available(now))
+	// a message larger than what's left still gets through, and puts the bucket in debt.
+	tb.take(1400, now)
+	require.False(t, tb.available(now))
+	require.False(t, tb.available(now.Add(time.Second)))
+	require.True(t, tb.available(now.Add(time.Second+time.Millisecond)))
+
+	// the tokens accumulated while idle are capped by the burst duration.
+	later := now.Add(time.Hour)
+	require.True(t, tb.available(later))
+	tb.take(1000, later)
+	require.False(t, tb.available(later))
+
+	var unlimited *tokenBucket
+	unlimited.take(1000000, now)
+	require.True(t, unlimited.available(now))
+}
+
+func TestBandwidthLimiter(t *testing.T) {
+	now := time.Now()
+	require.Nil(t, makeBandwidthLimiter(bandwidthIncoming, 0, nil, now))
+
+	bl := makeBandwidthLimiter(bandwidthIncoming, 1000, map[protocol.Tag]uint64{protocol.TxnTag: 100}, now)
+	require.True(t, bl.allow(protocol.TxnTag, 200, now))
+	// the transactions are out of their own tokens, while the rest of the gossip isn't.
+	require.False(t, bl.allow(protocol.TxnTag, 200, now))
+	require.True(t, bl.allow(protocol.CompactCertSigTag, 900, now))
+	require.False(t, bl.allow(protocol.CompactCertSigTag, 100, now))
+
+	// the agreement messages, and the ones which aren't gossip, aren't dropped.
+	require.True(t, bl.allow(protocol.AgreementVoteTag, 500, now))
+	require.True(t, bl.allow(protocol.ProposalPayloadTag, 500, now))
+	require.True(t, bl.allow(protocol.VoteBundleTag, 500, now))
+	require.True(t, bl.allow(protocol.MsgOfInterestTag, 500, now))
+	require.True(t, bl.allow(protocol.TopicMsgRespTag, 500, now))
+	require.True(t, bl.allow(protocol.TxnRequestTag, 500, now))
+
+	// the agreement messages are charged nonetheless, so it takes longer for the rest of the gossip to resume.
+	require.False(t, bl.allow(protocol.CompactCertSigTag, 100, now.Add(time.Second)))
+	require.True(t, bl.allow(protocol.CompactCertSigTag, 100, now.Add(2*time.Second+time.Millisecond)))
+}
+
+func TestParseTagBytesPerSecond(t *testing.T) {
+	log := logging.TestingLog(t)
+	require.Empty(t, parseTagBytesPerSecond(log, ""))
+	limits := parseTagBytesPerSecond(log, "TX:100000; TA : 2000;invalid;TXN:5;CS:;TQ:0;MI:100;AV:3000")
+	require.Equal(t, map[protocol.Tag]uint64{
+		protocol.TxnTag:           100000,
+		protocol.AgreementVoteTag: 3000,
+	}, limits)
+}
+
+// Set up two nodes, A limiting the transactions it sends and B limiting all the gossip it receives, and test that
+// the transactions beyond the limits are dropped while the agreement votes are all delivered.
+func TestWebsocketNetworkBandwidthLimits(t *testing.T) {
+	confA := defaultConfig
+	confA.PeerTagBytesPerSecond = "TX:1000"
+	netA := makeTestWebsocketNodeWithConfig(t, confA)
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	confB := defaultConfig
+	confB.NetAddress = ""
+	confB.PeerIncomingBytesPerSecond = 2000
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+	var txns, votes uint32
+	netB.RegisterHandlers([]TaggedMessageHandler{
+		{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+			atomic.AddUint32(&txns, 1)
+			return OutgoingMessage{}
+		})},
+		{Tag: protocol.AgreementVoteTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+			atomic.AddUint32(&votes, 1)
+			return OutgoingMessage{}
+		})},
+	})
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	const messages = 20
+	for i := 0; i < messages; i++ {
+		data := make([]byte, 500)
+		data[0] = byte(i)
+		require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, data, true, nil))
+		require.NoError(t, netA.Broadcast(context.Background(), protocol.AgreementVoteTag, data, true, nil))
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&votes) == messages
+	}, 5*time.Second, 10*time.Millisecond)
+	// only the first couple of transactions fit within A's burst, and B's limit is exhausted by the votes anyway.
+	require.Less(t, atomic.LoadUint32(&txns), uint32(4))
+	require.Greater(t, atomic.LoadUint32(&txns), uint32(0))
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// CompressionHeader HTTP header for the message compression codecs. The requesting peer lists the codecs it supports
+// ( in order of preference ), and the responding peer returns the one chosen for the connection, if any.
+const CompressionHeader = "X-Algorand-Compression"
+
+// deflateDictCodec is DEFLATE using a preset dictionary of the msgpack field names common in the gossiped messages.
+const deflateDictCodec = "deflate-msgp1"
+
+// supportedCompressionCodecs contains the list of message compression codecs supported by this node ( in order of preference ).
+var supportedCompressionCodecs = []string{deflateDictCodec}
+
+// compressibleTags are the message tags which carry a compression flag byte ahead of their payload on connections
+// that negotiated a compression codec.
+var compressibleTags = map[protocol.Tag]bool{
+	protocol.ProposalPayloadTag: true,
+	protocol.TxnTag:             true,
+}
+
+// compressionMinMessageSize is the size below which messages are sent uncompressed, as compressing them won't save much.
+const compressionMinMessageSize = 512
+
+// compression flags, sent as the first byte of the payload of the compressible tags.
+const (
+	compressionFlagNone    byte = 0
+	compressionFlagDeflate byte = 1
+)
+
+var errDecompressedMessageTooLong = errors.New("decompressed message exceeds the maximal message length")
+var errMissingCompressionFlag = errors.New("message is missing the compression flag")
+
+var networkCompressionInputBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_compression_input_bytes_total", Description: "bytes of outgoing messages given to the compressor, by tag"})
+var networkCompressionOutputBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_compression_output_bytes_total", Description: "bytes of outgoing messages produced by the compressor, by tag"})
+var networkDecompressionInputBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_decompression_input_bytes_total", Description: "bytes of incoming compressed messages, by tag"})
+var networkDecompressionOutputBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_decompression_output_bytes_total", Description: "bytes of incoming messages after decompression, by tag"})
+
+// compressionDictionary is the preset dictionary of the deflateDictCodec. It must never change, as both peers need to
+// use the very same dictionary; a different dictionary requires a new codec name.
+var compressionDictionary = makeCompressionDictionary()
+
+func makeCompressionDictionary() []byte {
+	// the msgpack encoded field names of the transactions and the proposals, the most common ones last since these
+	// are the cheapest to refer to.
+	fields := []string{
+		"apaa", "apan", "apap", "apar", "apas", "apat", "apfa", "apgs", "apid", "apls", "apsu", "nbs", "nui", "gs", "ls",
+		"xaid", "aamt", "aclose", "arcv", "asnd", "caid", "afrz", "fadd", "faid", "am", "an", "au", "c", "dc", "df", "f", "m", "r", "t", "un",
+		"votekey", "selkey", "votefst", "votelst", "votekd", "nonpart",
+		"msig", "subsig", "thr", "v", "lsig", "arg", "l", "pk",
+		"earn", "fees", "frac", "proto", "nextbefore", "nextproto", "nextswitch", "nextyes", "upgradeprop", "upgradedelay", "upgradeyes",
+		"oper", "oprop", "prev", "rate", "rnd", "rwcalr", "rwd", "seed", "tc", "ts", "txn", "txns", "sdpf",
+		"axfer", "acfg", "afrz", "appl", "keyreg", "pay",
+		"close", "note", "grp", "lx", "rekey", "gen", "gh", "hgi", "hgh",
+		"sig", "type", "snd", "rcv", "amt", "fee", "fv", "lv",
+	}
+	var dict bytes.Buffer
+	for _, field := range fields {
+		// msgpack fixstr encoding
+		dict.WriteByte(0xa0 | byte(len(field)))
+		dict.WriteString(field)
+	}
+	return dict.Bytes()
+}
+
+var deflateWriterPool = sync.Pool{
+	New: func() interface{} {
+		w, err := flate.NewWriterDict(nil, flate.BestSpeed, compressionDictionary)
+		if err != nil {
+			// only happens on an invalid compression level.
+			panic(err)
+		}
+		return w
+	},
+}
+
+var deflateReaderPool = sync.Pool{
+	New: func() interface{} {
+		return flate.NewReaderDict(nil, compressionDictionary)
+	},
+}
+
+// negotiateCompression returns the first of the compression codecs listed in the given headers which this node
+// supports, or an empty string if there is none or if compression is disabled.
+func (wn *WebsocketNetwork) negotiateCompression(otherHeaders http.Header) string {
+	if !wn.config.EnableGossipCompression {
+		return ""
+	}
+	for _, otherCodec := range otherHeaders[http.CanonicalHeaderKey(CompressionHeader)] {
+		for _, codec := range supportedCompressionCodecs {
+			if codec == otherCodec {
+				return codec
+			}
+		}
+	}
+	return ""
+}
+
+// compressMessage encodes the message of a compressible tag for a connection that negotiated compression,
+// returning the tag followed by the compression flag and the ( possibly compressed ) payload.
+func compressMessage(tag protocol.Tag, data []byte) []byte {
+	out := make([]byte, 0, len(tag)+1+len(data))
+	out = append(out, []byte(tag)...)
+	if len(data) < compressionMinMessageSize {
+		out = append(out, compressionFlagNone)
+		return append(out, data...)
+	}
+
+	buf := bytes.NewBuffer(append(out, compressionFlagDeflate))
+	w := deflateWriterPool.Get().(*flate.Writer)
+	defer deflateWriterPool.Put(w)
+	w.Reset(buf)
+	_, err := w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil || buf.Len() >= len(out)+1+len(data) {
+		// the compression didn't help; send the message uncompressed.
+		out = append(out, compressionFlagNone)
+		return append(out, data...)
+	}
+
+	labels := map[string]string{"tag": string(tag)}
+	networkCompressionInputBytesTotal.AddUint64(uint64(len(data)), labels)
+	networkCompressionOutputBytesTotal.AddUint64(uint64(buf.Len()-len(out)-1), labels)
+	return buf.Bytes()
+}
+
+// decompressMessage decodes the payload of a compressible tag received over a connection that negotiated compression.
+// The decompressed message is limited to maxMessageLength, same as the uncompressed ones.
+func decompressMessage(tag protocol.Tag, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errMissingCompressionFlag
+	}
+	switch data[0] {
+	case compressionFlagNone:
+		return data[1:], nil
+	case compressionFlagDeflate:
+	default:
+		return nil, fmt.Errorf("unknown compression flag %d", data[0])
+	}
+
+	r := deflateReaderPool.Get().(io.ReadCloser)
+	defer deflateReaderPool.Put(r)
+	err := r.(flate.Resetter).Reset(bytes.NewReader(data[1:]), compressionDictionary)
+	if err != nil {
+		return nil, err
+	}
+	slurper := MakeLimitedReaderSlurper(averageMessageLength, maxMessageLength)
+	err = slurper.Read(r)
+	if err != nil {
+		if err == ErrIncomingMsgTooLarge {
+			return nil, errDecompressedMessageTooLong
+		}
+		return nil, err
+	}
+	out := slurper.Bytes()
+
+	labels := map[string]string{"tag": string(tag)}
+	networkDecompressionInputBytesTotal.AddUint64(uint64(len(data)-1), labels)
+	networkDecompressionOutputBytesTotal.AddUint64(uint64(len(out)), labels)
+	return out, nil
+}
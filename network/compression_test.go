@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestCompressMessage(t *testing.T) {
+	tag := protocol.ProposalPayloadTag
+	for _, data := range [][]byte{
+		{},
+		[]byte("short message"),
+		bytes.Repeat([]byte("\xa3snd\xa3rcv\xa3amt\xa3fee"), 1000),
+	} {
+		encoded := compressMessage(tag, data)
+		require.Equal(t, []byte(tag), encoded[:len(tag)])
+		decoded, err := decompressMessage(tag, encoded[len(tag):])
+		require.NoError(t, err)
+		require.Equal(t, len(data), len(decoded))
+		if len(data) > 0 {
+			require.Equal(t, data, decoded)
+		}
+	}
+
+	// repetitive data gets compressed, and random data doesn't.
+	repetitive := bytes.Repeat([]byte{1, 2, 3, 4}, 1000)
+	encoded := compressMessage(tag, repetitive)
+	require.Equal(t, compressionFlagDeflate, encoded[len(tag)])
+	require.Less(t, len(encoded), len(repetitive)/10)
+
+	random := make([]byte, 4096)
+	crypto.RandBytes(random)
+	encoded = compressMessage(tag, random)
+	require.Equal(t, compressionFlagNone, encoded[len(tag)])
+	require.Equal(t, len(tag)+1+len(random), len(encoded))
+
+	_, err := decompressMessage(tag, nil)
+	require.Error(t, err)
+	_, err = decompressMessage(tag, []byte{7, 1, 2, 3})
+	require.Error(t, err)
+	_, err = decompressMessage(tag, []byte{compressionFlagDeflate, 1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestDecompressMessageSizeLimit(t *testing.T) {
+	tag := protocol.TxnTag
+	data := make([]byte, maxMessageLength+1)
+	encoded := compressMessage(tag, data)
+	require.Equal(t, compressionFlagDeflate, encoded[len(tag)])
+	_, err := decompressMessage(tag, encoded[len(tag):])
+	require.Equal(t, errDecompressedMessageTooLong, err)
+
+	decoded, err := decompressMessage(tag, compressMessage(tag, data[:maxMessageLength])[len(tag):])
+	require.NoError(t, err)
+	require.Equal(t, data[:maxMessageLength], decoded)
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	wn := makeTestWebsocketNode(t)
+	header := make(http.Header)
+	header.Add(CompressionHeader, "unknown")
+	header.Add(CompressionHeader, deflateDictCodec)
+	require.Equal(t, "", wn.negotiateCompression(header))
+
+	wn.config.EnableGossipCompression = true
+	require.Equal(t, deflateDictCodec, wn.negotiateCompression(header))
+	require.Equal(t, "", wn.negotiateCompression(make(http.Header)))
+
+	header = make(http.Header)
+	wn.setHeaders(header)
+	require.Equal(t, supportedCompressionCodecs, header[CompressionHeader])
+}
+
+// Test that large proposal payloads make it across a connection which negotiated compression,
+// and across a connection to a node which doesn't support it.
+func TestWebsocketNetworkCompression(t *testing.T) {
+	for _, compressB := range []bool{true, false} {
+		confA := defaultConfig
+		confA.EnableGossipCompression = true
+		netA := makeTestWebsocketNodeWithConfig(t, confA)
+		netA.config.GossipFanout = 1
+		netA.Start()
+		addrA, postListen := netA.Address()
+		require.True(t, postListen)
+
+		confB := defaultConfig
+		confB.GossipFanout = 1
+		confB.NetAddress = ""
+		confB.EnableGossipCompression = compressB
+		netB := makeTestWebsocketNodeWithConfig(t, confB)
+		netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+		netB.Start()
+
+		received := make(chan []byte, 2)
+		netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.ProposalPayloadTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+			received <- msg.Data
+			return OutgoingMessage{}
+		})}})
+
+		readyTimeout := time.NewTimer(2 * time.Second)
+		waitReady(t, netA, readyTimeout.C)
+		waitReady(t, netB, readyTimeout.C)
+		for _, peer := range netA.GetPeers(PeersConnectedIn) {
+			require.Equal(t, compressB, peer.(*wsPeer).compression)
+		}
+
+		payloads := [][]byte{bytes.Repeat([]byte("\xa3txn\xa3sig"), 10000), []byte("tiny")}
+		for _, payload := range payloads {
+			netA.Broadcast(context.Background(), protocol.ProposalPayloadTag, payload, true, nil)
+		}
+		var receivedPayloads [][]byte
+		for range payloads {
+			select {
+			case data := <-received:
+				receivedPayloads = append(receivedPayloads, data)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timeout, received %d messages, wanted %d", len(receivedPayloads), len(payloads))
+			}
+		}
+		require.ElementsMatch(t, payloads, receivedPayloads)
+		netB.Stop()
+		netA.Stop()
+	}
+}
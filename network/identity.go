@@ -0,0 +1,333 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/algorand/websocket"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// IdentityHeader is the HTTP header carrying the base64-encoded node identity of the sender.
+const IdentityHeader = "X-Algorand-Identity"
+
+// IdentityChallengeHeader is the HTTP header carrying the base64-encoded challenge which the other side of the
+// connection needs to sign with its node identity key.
+const IdentityChallengeHeader = "X-Algorand-Identity-Challenge"
+
+// IdentitySignatureHeader is the HTTP header carrying the server's base64-encoded signature of the identity transcript.
+const IdentitySignatureHeader = "X-Algorand-Identity-Signature"
+
+// NodeIdentityKeyFilename is the name of the file, in the genesis directory, holding the seed of the node identity key.
+const NodeIdentityKeyFilename = "identity.key"
+
+// identityVerificationTimeout is the time a client has to sign the server challenge once the websocket connection
+// is established.
+const identityVerificationTimeout = 10 * time.Second
+
+// identityChannelBindingLabel is the label of the TLS keying material the identity transcript is bound to.
+const identityChannelBindingLabel = "EXPORTER-algorand-node-identity"
+
+// identityChallenge is a random challenge which the other side of a connection signs to prove its node identity.
+type identityChallenge [32]byte
+
+// identityChannelBinding is the keying material exported from the TLS connection the identity handshake is made over.
+type identityChannelBinding [32]byte
+
+// identityTranscript is the identity handshake of a connection, which each side signs to prove its node identity.
+// Binding both challenges and both identities to the signature keeps a node in the middle from passing off the
+// signature made for its own connection with one side as a proof of identity on its connection with the other side.
+//
+// The transcript is bound to the connection itself only when it's made over TLS, since a node in the middle has a TLS
+// connection of its own with each side. Over plain connections, a node in the middle which relays the handshake headers
+// and the identity verification message unchanged in both directions isn't detected: both sides authenticate each
+// other, and the node in the middle can then read and inject the messages on the connection.
+type identityTranscript struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	ClientChallenge identityChallenge        `codec:"cc"`
+	ServerChallenge identityChallenge        `codec:"sc"`
+	ClientIdentity  crypto.SignatureVerifier `codec:"ci"`
+	ServerIdentity  crypto.SignatureVerifier `codec:"si"`
+
+	// ChannelBinding is the keying material of the TLS connection, or zero if the connection isn't over TLS.
+	ChannelBinding identityChannelBinding `codec:"cb"`
+
+	// Server is set when the transcript is signed by the server side of the connection, so that a signature made on
+	// one side of a connection can't be replayed on the other side of another one.
+	Server bool `codec:"s"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface.
+func (t identityTranscript) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.IdentityChallenge, protocol.EncodeReflect(&t)
+}
+
+// verify checks the signature of the transcript made by the side of the connection the Server flag refers to.
+func (t identityTranscript) verify(sig crypto.Signature) bool {
+	if t.Server {
+		return t.ServerIdentity.Verify(t, sig)
+	}
+	return t.ClientIdentity.Verify(t, sig)
+}
+
+// nodeIdentity holds the node identity key, and the identities of the peers which are allowed to connect.
+type nodeIdentity struct {
+	secrets *crypto.SignatureSecrets
+	allowed map[crypto.SignatureVerifier]bool
+}
+
+func makeNodeIdentity(allowed map[crypto.SignatureVerifier]bool) *nodeIdentity {
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	return &nodeIdentity{
+		secrets: crypto.GenerateSignatureSecrets(seed),
+		allowed: allowed,
+	}
+}
+
+func newIdentityChallenge() (challenge identityChallenge) {
+	crypto.RandBytes(challenge[:])
+	return
+}
+
+// tlsChannelBinding returns the keying material exported from the TLS connection of the given state, or zero if the
+// connection isn't over TLS.
+func tlsChannelBinding(state *tls.ConnectionState) (binding identityChannelBinding) {
+	if state == nil {
+		return
+	}
+	material, err := state.ExportKeyingMaterial(identityChannelBindingLabel, nil, len(binding))
+	if err != nil {
+		// the zero binding won't match the one of the other side.
+		return
+	}
+	copy(binding[:], material)
+	return
+}
+
+// connChannelBinding returns the keying material exported from the TLS connection underlying the given one, or zero
+// if it isn't over TLS.
+func connChannelBinding(conn transportConn) identityChannelBinding {
+	if underlying, ok := conn.(interface{ UnderlyingConn() net.Conn }); ok {
+		if tlsConn, ok := underlying.UnderlyingConn().(*tls.Conn); ok {
+			state := tlsConn.ConnectionState()
+			return tlsChannelBinding(&state)
+		}
+	}
+	return identityChannelBinding{}
+}
+
+func (id *nodeIdentity) sign(transcript identityTranscript) crypto.Signature {
+	return id.secrets.Sign(transcript)
+}
+
+// required returns true if only the peers with an allowed identity could connect.
+func (id *nodeIdentity) required() bool {
+	return len(id.allowed) > 0
+}
+
+// allows returns true if the peer with the given identity is allowed to connect.
+func (id *nodeIdentity) allows(identity crypto.SignatureVerifier) bool {
+	return len(id.allowed) == 0 || id.allowed[identity]
+}
+
+// loadOrCreateSeed loads a key seed from the given file, creating the file with a random seed if it doesn't exist.
+func loadOrCreateSeed(filename string) (seed crypto.Seed, err error) {
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		crypto.RandBytes(seed[:])
+		err = ioutil.WriteFile(filename, seed[:], 0600)
+		return
+	}
+	if err != nil {
+		return
+	}
+	if len(data) != len(seed) {
+		err = fmt.Errorf("key file %s has %d bytes instead of %d", filename, len(data), len(seed))
+		return
+	}
+	copy(seed[:], data)
+	return
+}
+
+// LoadNodeIdentityKey loads the seed of the node identity key, creating the file if it doesn't exist yet, so that the
+// node keeps its identity across restarts. It does nothing unless the node identity is enabled, and it must be called
+// before Start.
+func (wn *WebsocketNetwork) LoadNodeIdentityKey(filename string) error {
+	if wn.identity == nil {
+		return nil
+	}
+	seed, err := loadOrCreateSeed(filename)
+	if err != nil {
+		return err
+	}
+	wn.identity.secrets = crypto.GenerateSignatureSecrets(seed)
+	return nil
+}
+
+// NodeIdentity returns the base64-encoded node identity, for the operators of other nodes to list in their
+// AllowedPeerIdentities or PriorityPeers. It returns an empty string unless the node identity is enabled.
+func (wn *WebsocketNetwork) NodeIdentity() string {
+	if wn.identity == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(wn.identity.secrets.SignatureVerifier[:])
+}
+
+// checkIncomingIdentity checks the identity the client claims in its request headers, and adds the server identity,
+// the server signature of the identity transcript and a challenge for the client to the response headers. The client
+// proves its identity by sending its own signature of the transcript as its first message, which verifyIncomingIdentity
+// checks once the connection is established. It returns the transcript, and whether the client needs to prove its identity.
+func (wn *WebsocketNetwork) checkIncomingIdentity(response http.ResponseWriter, request *http.Request, responseHeader http.Header) (transcript identityTranscript, verify bool, status int) {
+	if wn.identity == nil {
+		return transcript, false, http.StatusOK
+	}
+	if request.Header.Get(IdentityHeader) == "" {
+		if !wn.identity.required() {
+			return transcript, false, http.StatusOK
+		}
+		wn.log.Infof("new peer %s did not include an identity", request.RemoteAddr)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "missing identity"})
+		response.WriteHeader(http.StatusForbidden)
+		return transcript, false, http.StatusForbidden
+	}
+	if !decodeHeader(request.Header, IdentityHeader, transcript.ClientIdentity[:]) || !decodeHeader(request.Header, IdentityChallengeHeader, transcript.ClientChallenge[:]) {
+		wn.log.Warn(filterASCII(fmt.Sprintf("new peer %s included an invalid identity, headers %#v", request.RemoteAddr, request.Header)))
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "invalid identity"})
+		response.WriteHeader(http.StatusPreconditionFailed)
+		message := fmt.Sprintf("Request included invalid %s or %s headers", IdentityHeader, IdentityChallengeHeader)
+		n, err := response.Write([]byte(message))
+		if err != nil {
+			wn.log.Warnf("ws failed to write response '%s' : n = %d err = %v", message, n, err)
+		}
+		return transcript, false, http.StatusPreconditionFailed
+	}
+	if !wn.identity.allows(transcript.ClientIdentity) {
+		wn.log.Infof("new peer %s identity %s is not allowed", request.RemoteAddr, base64.StdEncoding.EncodeToString(transcript.ClientIdentity[:]))
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "identity not allowed"})
+		response.WriteHeader(http.StatusForbidden)
+		return transcript, false, http.StatusForbidden
+	}
+	transcript.ServerChallenge = newIdentityChallenge()
+	transcript.ServerIdentity = wn.identity.secrets.SignatureVerifier
+	transcript.ChannelBinding = tlsChannelBinding(request.TLS)
+	transcript.Server = true
+	sig := wn.identity.sign(transcript)
+	responseHeader.Set(IdentityHeader, wn.NodeIdentity())
+	responseHeader.Set(IdentitySignatureHeader, base64.StdEncoding.EncodeToString(sig[:]))
+	responseHeader.Set(IdentityChallengeHeader, base64.StdEncoding.EncodeToString(transcript.ServerChallenge[:]))
+	return transcript, true, http.StatusOK
+}
+
+// verifyIncomingIdentity reads the first message of a new incoming connection, and checks that it is the client
+// signature of the identity transcript, made with the identity the client claimed.
+func (wn *WebsocketNetwork) verifyIncomingIdentity(conn transportConn, transcript identityTranscript) bool {
+	var sig crypto.Signature
+	tag := []byte(protocol.IdentityVerifyTag)
+	// the read loop of the peer raises the limit to maxMessageLength once the client is verified.
+	conn.SetReadLimit(int64(len(tag) + len(sig)))
+	conn.SetReadDeadline(time.Now().Add(identityVerificationTimeout))
+	_, data, err := conn.ReadMessage()
+	conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		wn.log.Infof("new peer %s did not sign the identity transcript: %v", conn.RemoteAddr().String(), err)
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "identity verification"})
+		return false
+	}
+	if len(data) != len(tag)+len(sig) || string(data[:len(tag)]) != string(tag) {
+		wn.log.Warnf("new peer %s sent an invalid identity verification message", conn.RemoteAddr().String())
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "identity verification"})
+		return false
+	}
+	copy(sig[:], data[len(tag):])
+	transcript.Server = false
+	if !transcript.verify(sig) {
+		wn.log.Warnf("new peer %s sent an invalid signature of the identity transcript", conn.RemoteAddr().String())
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "identity verification"})
+		return false
+	}
+	return true
+}
+
+// setIdentityHeaders adds the node identity and a challenge for the server to the request headers of an outgoing
+// connection.
+func (wn *WebsocketNetwork) setIdentityHeaders(requestHeader http.Header) (challenge identityChallenge) {
+	if wn.identity == nil {
+		return
+	}
+	challenge = newIdentityChallenge()
+	requestHeader.Set(IdentityHeader, wn.NodeIdentity())
+	requestHeader.Set(IdentityChallengeHeader, base64.StdEncoding.EncodeToString(challenge[:]))
+	return
+}
+
+// checkServerIdentity checks the identity of the server we've connected to, and proves our own identity by signing
+// the identity transcript. It returns the server identity, or a zero identity if the server didn't authenticate, and
+// false if the connection needs to be closed.
+func (wn *WebsocketNetwork) checkServerIdentity(conn transportConn, responseHeader http.Header, challenge identityChallenge, addr string) (identity crypto.SignatureVerifier, ok bool) {
+	if wn.identity == nil {
+		return identity, true
+	}
+	if responseHeader.Get(IdentityHeader) == "" {
+		if wn.identity.required() {
+			wn.log.Infof("ws connect(%s) fail - the server did not authenticate", addr)
+			return identity, false
+		}
+		return identity, true
+	}
+	var sig crypto.Signature
+	transcript := identityTranscript{
+		ClientChallenge: challenge,
+		ClientIdentity:  wn.identity.secrets.SignatureVerifier,
+		ChannelBinding:  connChannelBinding(conn),
+		Server:          true,
+	}
+	if !decodeHeader(responseHeader, IdentityHeader, transcript.ServerIdentity[:]) ||
+		!decodeHeader(responseHeader, IdentitySignatureHeader, sig[:]) ||
+		!decodeHeader(responseHeader, IdentityChallengeHeader, transcript.ServerChallenge[:]) {
+		wn.log.Warnf("ws connect(%s) fail - invalid identity headers : %#v", addr, responseHeader)
+		return identity, false
+	}
+	identity = transcript.ServerIdentity
+	if !transcript.verify(sig) {
+		wn.log.Warnf("ws connect(%s) fail - the server signature of the identity transcript is invalid", addr)
+		return identity, false
+	}
+	if !wn.identity.allows(identity) {
+		wn.log.Infof("ws connect(%s) fail - the server identity %s is not allowed", addr, base64.StdEncoding.EncodeToString(identity[:]))
+		return identity, false
+	}
+	transcript.Server = false
+	sig = wn.identity.sign(transcript)
+	err := conn.WriteMessage(websocket.BinaryMessage, append([]byte(protocol.IdentityVerifyTag), sig[:]...))
+	if err != nil {
+		wn.log.Infof("ws connect(%s) fail - unable to sign the identity transcript : %v", addr, err)
+		return identity, false
+	}
+	return identity, true
+}
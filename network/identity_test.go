@@ -0,0 +1,289 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/logging"
+)
+
+func TestIdentityTranscriptSignature(t *testing.T) {
+	client := makeNodeIdentity(nil)
+	server := makeNodeIdentity(nil)
+	other := makeNodeIdentity(nil)
+	transcript := identityTranscript{
+		ClientChallenge: newIdentityChallenge(),
+		ServerChallenge: newIdentityChallenge(),
+		ClientIdentity:  client.secrets.SignatureVerifier,
+		ServerIdentity:  server.secrets.SignatureVerifier,
+		Server:          true,
+	}
+
+	sig := server.sign(transcript)
+	require.True(t, transcript.verify(sig))
+	// a signature made on one side of a connection isn't valid on the other side.
+	clientTranscript := transcript
+	clientTranscript.Server = false
+	require.False(t, clientTranscript.verify(sig))
+	require.True(t, clientTranscript.verify(client.sign(clientTranscript)))
+	require.False(t, clientTranscript.verify(other.sign(clientTranscript)))
+
+	// the signature covers both challenges, both identities and the connection.
+	for i := 0; i < 5; i++ {
+		modified := transcript
+		switch i {
+		case 0:
+			modified.ClientChallenge = newIdentityChallenge()
+		case 1:
+			modified.ServerChallenge = newIdentityChallenge()
+		case 2:
+			modified.ClientIdentity = other.secrets.SignatureVerifier
+		case 3:
+			modified.ServerIdentity = other.secrets.SignatureVerifier
+		case 4:
+			modified.ChannelBinding[0] ^= 1
+		}
+		require.False(t, modified.verify(sig), "modified field %d", i)
+	}
+
+	require.False(t, client.required())
+	require.True(t, client.allows(other.secrets.SignatureVerifier))
+	restricted := makeNodeIdentity(parsePublicKeys(logging.TestingLog(t), "AllowedPeerIdentities", base64.StdEncoding.EncodeToString(other.secrets.SignatureVerifier[:])))
+	require.True(t, restricted.required())
+	require.True(t, restricted.allows(other.secrets.SignatureVerifier))
+	require.False(t, restricted.allows(client.secrets.SignatureVerifier))
+}
+
+// Test that a node M in the middle of a connection from client C can't use its own connection with server S to pass
+// as S to C, nor use its connection with C to pass as C to S.
+func TestIdentityRelayAttack(t *testing.T) {
+	conf := defaultConfig
+	conf.EnableNodeIdentity = true
+	netC := makeTestWebsocketNodeWithConfig(t, conf)
+	netS := makeTestWebsocketNodeWithConfig(t, conf)
+	mallory := makeNodeIdentity(nil)
+
+	// C connects to M, and M forwards C's challenge to S under its own identity, to get S to sign it.
+	requestHeader := http.Header{}
+	challengeC := netC.setIdentityHeaders(requestHeader)
+	requestHeader.Set(IdentityHeader, base64.StdEncoding.EncodeToString(mallory.secrets.SignatureVerifier[:]))
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header = requestHeader
+	responseHeader := http.Header{}
+	transcriptS, verify, status := netS.checkIncomingIdentity(httptest.NewRecorder(), request, responseHeader)
+	require.Equal(t, http.StatusOK, status)
+	require.True(t, verify)
+	require.Equal(t, challengeC, transcriptS.ClientChallenge)
+
+	// M relays S's response to C, which doesn't accept S's signature since it was made for a connection with M.
+	_, ok := netC.checkServerIdentity(nil, responseHeader, challengeC, "test")
+	require.False(t, ok)
+
+	// the other way around, M connected to S claiming C's identity can't pass S's challenge to C in its own handshake
+	// with C, since C's signature would be made for a connection with M and for M's challenge.
+	transcriptM := identityTranscript{
+		ClientChallenge: challengeC,
+		ServerChallenge: transcriptS.ServerChallenge,
+		ClientIdentity:  netC.identity.secrets.SignatureVerifier,
+		ServerIdentity:  mallory.secrets.SignatureVerifier,
+	}
+	sig := netC.identity.sign(transcriptM)
+	require.True(t, transcriptM.verify(sig))
+	transcriptS.ClientIdentity = netC.identity.secrets.SignatureVerifier
+	transcriptS.ClientChallenge = newIdentityChallenge()
+	transcriptS.Server = false
+	require.False(t, transcriptS.verify(sig))
+}
+
+// makeTestTLSConnections makes a TLS connection over a pipe, and returns both of its ends once the handshake is done.
+func makeTestTLSConnections(t *testing.T, cert tls.Certificate) (client *tls.Conn, server *tls.Conn) {
+	clientConn, serverConn := net.Pipe()
+	server = tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{cert}})
+	client = tls.Client(clientConn, &tls.Config{InsecureSkipVerify: true})
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Handshake()
+	}()
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-errs)
+	return
+}
+
+// Test that the identity transcript made over one TLS connection doesn't verify for another, so that a node in the
+// middle terminating TLS on both sides can't relay the identity handshake.
+func TestIdentityChannelBinding(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+
+	client, server := makeTestTLSConnections(t, cert)
+	defer client.Close()
+	defer server.Close()
+	clientState := client.ConnectionState()
+	serverState := server.ConnectionState()
+	binding := tlsChannelBinding(&clientState)
+	require.NotEqual(t, identityChannelBinding{}, binding)
+	require.Equal(t, binding, tlsChannelBinding(&serverState))
+
+	otherClient, otherServer := makeTestTLSConnections(t, cert)
+	defer otherClient.Close()
+	defer otherServer.Close()
+	otherState := otherClient.ConnectionState()
+	require.NotEqual(t, binding, tlsChannelBinding(&otherState))
+
+	require.Equal(t, identityChannelBinding{}, tlsChannelBinding(nil))
+	require.Equal(t, identityChannelBinding{}, connChannelBinding(nil))
+
+	serverID := makeNodeIdentity(nil)
+	transcript := identityTranscript{
+		ClientChallenge: newIdentityChallenge(),
+		ServerChallenge: newIdentityChallenge(),
+		ServerIdentity:  serverID.secrets.SignatureVerifier,
+		Server:          true,
+		ChannelBinding:  binding,
+	}
+	sig := serverID.sign(transcript)
+	require.True(t, transcript.verify(sig))
+	transcript.ChannelBinding = tlsChannelBinding(&otherState)
+	require.False(t, transcript.verify(sig))
+}
+
+func TestLoadNodeIdentityKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, NodeIdentityKeyFilename)
+
+	wn := makeTestWebsocketNode(t)
+	require.NoError(t, wn.LoadNodeIdentityKey(filename))
+	require.Equal(t, "", wn.NodeIdentity())
+	_, err = os.Stat(filename)
+	require.True(t, os.IsNotExist(err))
+
+	conf := defaultConfig
+	conf.EnableNodeIdentity = true
+	wn = makeTestWebsocketNodeWithConfig(t, conf)
+	require.NoError(t, wn.LoadNodeIdentityKey(filename))
+	identity := wn.NodeIdentity()
+
+	// the identity is kept across restarts.
+	wn = makeTestWebsocketNodeWithConfig(t, conf)
+	require.NotEqual(t, identity, wn.NodeIdentity())
+	require.NoError(t, wn.LoadNodeIdentityKey(filename))
+	require.Equal(t, identity, wn.NodeIdentity())
+}
+
+func TestCheckPrioPeersIdentity(t *testing.T) {
+	wn := makeTestWebsocketNode(t)
+	id := makeNodeIdentity(nil)
+	wn.config.PriorityPeers = map[string]bool{base64.StdEncoding.EncodeToString(id.secrets.SignatureVerifier[:]): true}
+
+	require.False(t, checkPrioPeers(wn, &wsPeer{wsPeerCore: makePeerCore(wn, "http://1.2.3.4:4160", nil, "1.2.3.4")}))
+	require.True(t, checkPrioPeers(wn, &wsPeer{wsPeerCore: makePeerCore(wn, "http://1.2.3.4:4160", nil, "1.2.3.4"), identity: id.secrets.SignatureVerifier}))
+}
+
+// Set up a relay A allowing only B's identity, and test that B is authenticated on both sides of the connection, while
+// nodes with other identities or without one are refused, and that nodes refuse to connect to relays they don't allow.
+func TestWebsocketNetworkIdentity(t *testing.T) {
+	conf := defaultConfig
+	conf.EnableNodeIdentity = true
+	conf.GossipFanout = 1
+
+	confB := conf
+	confB.NetAddress = ""
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+
+	confA := conf
+	confA.AllowedPeerIdentities = netB.NodeIdentity()
+	netA := makeTestWebsocketNodeWithConfig(t, confA)
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	peersA := netA.GetPeers(PeersConnectedIn)
+	require.Len(t, peersA, 1)
+	require.Equal(t, netB.NodeIdentity(), base64.StdEncoding.EncodeToString(peersA[0].(*wsPeer).identity[:]))
+	peersB := netB.GetPeers(PeersConnectedOut)
+	require.Len(t, peersB, 1)
+	require.Equal(t, netA.NodeIdentity(), base64.StdEncoding.EncodeToString(peersB[0].(*wsPeer).identity[:]))
+
+	// C has an identity A doesn't allow, and D has no identity at all.
+	confD := confB
+	confD.EnableNodeIdentity = false
+	for i, confOther := range []config.Local{confB, confD} {
+		netOther := makeTestWebsocketNodeWithConfig(t, confOther)
+		netOther.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+		netOther.Start()
+		require.Never(t, func() bool {
+			netOther.RequestConnectOutgoing(false, nil)
+			return len(netA.GetPeers(PeersConnectedIn)) > 1 || len(netOther.GetPeers(PeersConnectedOut)) > 0
+		}, 500*time.Millisecond, 50*time.Millisecond, "node %d got connected", i)
+		netOther.Stop()
+	}
+
+	// E allows only B, so it refuses to stay connected to A, even though A doesn't restrict its peers.
+	confA.AllowedPeerIdentities = ""
+	netA2 := makeTestWebsocketNodeWithConfig(t, confA)
+	netA2.Start()
+	defer func() { t.Log("stopping A2"); netA2.Stop(); t.Log("A2 done") }()
+	addrA2, postListen := netA2.Address()
+	require.True(t, postListen)
+	confE := confB
+	confE.AllowedPeerIdentities = netB.NodeIdentity()
+	netE := makeTestWebsocketNodeWithConfig(t, confE)
+	netE.phonebook.ReplacePeerList([]string{addrA2}, "default", PhoneBookEntryRelayRole)
+	netE.Start()
+	defer func() { t.Log("stopping E"); netE.Stop(); t.Log("E done") }()
+	require.Never(t, func() bool {
+		netE.RequestConnectOutgoing(false, nil)
+		return len(netA2.GetPeers(PeersConnectedIn)) > 0 || len(netE.GetPeers(PeersConnectedOut)) > 0
+	}, 500*time.Millisecond, 50*time.Millisecond)
+}
@@ -0,0 +1,330 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/network/messagetracer"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// MessageTraceFilename is the name of the file, in the genesis directory, of the store of the message traces which
+// reached the node.
+const MessageTraceFilename = "messagetrace.sqlite"
+
+// messageTraceExpiry is how long a sampled message is tracked after it first reached the node. The trace records
+// arriving later than that are dropped.
+const messageTraceExpiry = time.Minute
+
+// messageTraceFlushInterval is the interval at which the traces are written to the store, and the expired messages
+// are dropped.
+const messageTraceFlushInterval = time.Second
+
+// messageTraceQueueLength is the maximal number of traces waiting to be written to the store.
+const messageTraceQueueLength = 1000
+
+// messageTraceMaxRecordsPerPeer is the maximal number of trace records of a message accepted from each peer. A peer
+// sends its own record of a message once, after the message itself.
+const messageTraceMaxRecordsPerPeer = 1
+
+var networkMessageTracesStoredTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_message_traces_stored_total", Description: "number of message trace records written to the trace store"})
+var networkMessageTracesDroppedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_message_traces_dropped_total", Description: "number of message trace records dropped since the trace store fell behind"})
+var networkMessageTracesInvalidTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_message_traces_invalid_total", Description: "number of message trace records received from peers which failed validation"})
+var networkMessageTracesIgnoredTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_message_traces_ignored_total", Description: "number of message trace records received from peers for messages which aren't tracked, or beyond the records allowed per peer"})
+
+var errMessageTraceSender = errors.New("trace record wasn't extended by the peer which sent it")
+
+// tracedMessage is the state of a sampled message, from the time it first reached the node or was broadcast by it.
+type tracedMessage struct {
+	tag     protocol.Tag
+	created time.Time
+
+	// from is the peer the message first arrived from, and received the time at which it did. from is nil if the
+	// message was broadcast before it arrived from any peer, in which case it originated from this node.
+	from     *wsPeer
+	received time.Time
+
+	// arrivals are the times at which the message arrived from each one of the peers, in nanoseconds since the epoch.
+	arrivals map[*wsPeer]int64
+
+	// records are the numbers of trace records of the message received from each one of the peers.
+	records map[*wsPeer]int
+
+	// sent is the time at which the node relayed the message, and except the peer it wasn't relayed to.
+	sent   time.Time
+	except *wsPeer
+
+	// upstream is the trace record received from the peer the message first arrived from.
+	upstream *messagetracer.Record
+
+	// forwarded is set once the trace record of the node was sent.
+	forwarded bool
+}
+
+// messageTracer traces the sampled proposals, votes and transactions across the relays. The node the message
+// originated from sends a trace record right after it, and each relay along the way sends the record it got from the
+// peer the message first arrived from, extended with its own signed hop, to the peers it relayed the message to.
+// Each node stores all the trace records which reach it, one for every path the message took, and the propagation
+// trees are reconstructed from the stores of the nodes.
+type messageTracer struct {
+	sampleRate uint64
+	identity   *nodeIdentity
+
+	mu       deadlock.Mutex
+	messages map[crypto.Digest]*tracedMessage
+
+	// store is the trace store, or nil if the traces aren't stored. The traces are queued to be written by the
+	// messageTraceThread.
+	store  *messagetracer.Store
+	traces chan messagetracer.Trace
+}
+
+func makeMessageTracer(sampleRate uint64, identity *nodeIdentity) *messageTracer {
+	return &messageTracer{
+		sampleRate: sampleRate,
+		identity:   identity,
+		messages:   make(map[crypto.Digest]*tracedMessage),
+		traces:     make(chan messagetracer.Trace, messageTraceQueueLength),
+	}
+}
+
+// LoadMessageTraceStore opens the store in the given file, creating it if it doesn't exist, and keeps storing the
+// message traces which reach the node into it. It does nothing unless the message tracing is enabled, and it must be
+// called before Start.
+func (wn *WebsocketNetwork) LoadMessageTraceStore(filename string) error {
+	if wn.messageTracer == nil {
+		return nil
+	}
+	store, err := messagetracer.OpenStore(filename, false)
+	if err != nil {
+		return err
+	}
+	wn.messageTracer.store = store
+	return nil
+}
+
+// traceArrival notes the arrival of a message from the given peer, if it's sampled for tracing.
+func (wn *WebsocketNetwork) traceArrival(peer *wsPeer, tag protocol.Tag, data []byte, received int64) {
+	t := wn.messageTracer
+	if t == nil || !messagetracer.TracedTags[tag] {
+		return
+	}
+	digest := generateMessageDigest(tag, data)
+	if !messagetracer.Sampled(digest, t.sampleRate) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	msg := t.messages[digest]
+	if msg == nil {
+		msg = &tracedMessage{tag: tag, created: time.Now(), from: peer, received: time.Unix(0, received), arrivals: make(map[*wsPeer]int64)}
+		t.messages[digest] = msg
+	}
+	if _, has := msg.arrivals[peer]; !has {
+		msg.arrivals[peer] = received
+	}
+}
+
+// traceBroadcast notes the broadcast of a message, if it's sampled for tracing, and sends the trace record of the
+// node once it has the record of the upstream peer, or right away if the message originated from this node. It's called
+// once the message is queued, so that the peers get the trace record after the message it traces.
+func (wn *WebsocketNetwork) traceBroadcast(tag protocol.Tag, data []byte, except *wsPeer) {
+	t := wn.messageTracer
+	if t == nil || !messagetracer.TracedTags[tag] {
+		return
+	}
+	digest := generateMessageDigest(tag, data)
+	if !messagetracer.Sampled(digest, t.sampleRate) {
+		return
+	}
+	now := time.Now()
+	t.mu.Lock()
+	msg := t.messages[digest]
+	if msg == nil {
+		msg = &tracedMessage{tag: tag, created: now, arrivals: make(map[*wsPeer]int64)}
+		t.messages[digest] = msg
+	}
+	if !msg.sent.IsZero() {
+		// the message is broadcast again, as the transactions are while they're pending, and it was traced already.
+		t.mu.Unlock()
+		return
+	}
+	msg.sent = now
+	msg.except = except
+	record := t.extend(digest, msg)
+	t.mu.Unlock()
+	if record != nil {
+		wn.sendMessageTrace(record, except)
+	}
+}
+
+// extend returns the trace record of the node for the message, if it wasn't sent yet and both the upstream record and
+// the relay time are known. It's called while holding the lock.
+func (t *messageTracer) extend(digest crypto.Digest, msg *tracedMessage) *messagetracer.Record {
+	if msg.forwarded || msg.sent.IsZero() {
+		return nil
+	}
+	upstream := messagetracer.Record{Digest: digest, Tag: msg.tag}
+	if msg.from != nil {
+		if msg.upstream == nil || len(msg.upstream.Hops) >= messagetracer.MaxHops {
+			return nil
+		}
+		upstream = *msg.upstream
+	}
+	msg.forwarded = true
+	record := upstream.Extend(t.identity.secrets, msg.received, msg.sent)
+	return &record
+}
+
+func (wn *WebsocketNetwork) sendMessageTrace(record *messagetracer.Record, except *wsPeer) {
+	var exceptPeer Peer
+	if except != nil {
+		exceptPeer = except
+	}
+	wn.Broadcast(context.Background(), protocol.MsgTraceTag, protocol.EncodeReflect(record), false, exceptPeer)
+}
+
+// admit counts a trace record of the given message received from the peer, and returns true if the message is
+// tracked and the peer didn't send more records of it than it's allowed to, so that the record is worth verifying.
+func (t *messageTracer) admit(digest crypto.Digest, tag protocol.Tag, peer *wsPeer) bool {
+	if !messagetracer.Sampled(digest, t.sampleRate) {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tracked := t.messages[digest]
+	if tracked == nil || tracked.tag != tag {
+		return false
+	}
+	if tracked.records == nil {
+		tracked.records = make(map[*wsPeer]int)
+	}
+	if tracked.records[peer] >= messageTraceMaxRecordsPerPeer {
+		return false
+	}
+	tracked.records[peer]++
+	return true
+}
+
+// handleMessageTrace validates and stores a trace record sent by the peer, and relays the extended record if the
+// peer is the one the message first arrived from. The records of the messages which aren't sampled by the node, or
+// which it doesn't track, are dropped before their signatures are verified.
+func (wp *wsPeer) handleMessageTrace(msg IncomingMessage) {
+	t := wp.net.messageTracer
+	if t == nil {
+		return
+	}
+	var record messagetracer.Record
+	err := protocol.DecodeReflect(msg.Data, &record)
+	if err == nil && !t.admit(record.Digest, record.Tag, wp) {
+		networkMessageTracesIgnoredTotal.Inc(nil)
+		return
+	}
+	if err == nil && (wp.identity != crypto.SignatureVerifier{}) && len(record.Hops) > 0 && record.Hops[len(record.Hops)-1].Node != wp.identity {
+		err = errMessageTraceSender
+	}
+	if err == nil {
+		err = record.Verify()
+	}
+	if err != nil {
+		wp.net.log.Warnf("wsPeer handleMessageTrace: invalid trace record from %s : %v", wp.conn.RemoteAddr().String(), err)
+		networkMessageTracesInvalidTotal.Inc(nil)
+		wp.net.ReportPeer(wp, PeerOffenseInvalidMessage)
+		return
+	}
+
+	trace := messagetracer.Trace{Observer: t.identity.secrets.SignatureVerifier, Received: msg.Received, Record: record}
+	var forward *messagetracer.Record
+	var except *wsPeer
+	t.mu.Lock()
+	if tracked := t.messages[record.Digest]; tracked != nil && tracked.tag == record.Tag {
+		if received, has := tracked.arrivals[wp]; has {
+			trace.Received = received
+		}
+		if tracked.from == wp && tracked.upstream == nil {
+			tracked.upstream = &record
+			forward = t.extend(record.Digest, tracked)
+			except = tracked.except
+		}
+	}
+	t.mu.Unlock()
+
+	if t.store != nil {
+		select {
+		case t.traces <- trace:
+		default:
+			networkMessageTracesDroppedTotal.Inc(nil)
+		}
+	}
+	if forward != nil {
+		wp.net.sendMessageTrace(forward, except)
+	}
+}
+
+// expire drops the messages which first reached the node before the given time.
+func (t *messageTracer) expire(before time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for digest, msg := range t.messages {
+		if msg.created.Before(before) {
+			delete(t.messages, digest)
+		}
+	}
+}
+
+// messageTraceThread drops the expired messages, and writes the queued traces to the store if there is one, until the
+// network stops. It runs whenever the message tracing is enabled, since the traced messages need to expire even if
+// the traces aren't stored.
+func (wn *WebsocketNetwork) messageTraceThread() {
+	defer wn.wg.Done()
+	t := wn.messageTracer
+	ticker := time.NewTicker(messageTraceFlushInterval)
+	defer ticker.Stop()
+	var pending []messagetracer.Trace
+	flush := func() {
+		if len(pending) == 0 || t.store == nil {
+			return
+		}
+		err := t.store.Add(pending)
+		if err != nil {
+			wn.log.Warnf("unable to store %d message traces: %v", len(pending), err)
+			networkMessageTracesDroppedTotal.AddUint64(uint64(len(pending)), nil)
+		} else {
+			networkMessageTracesStoredTotal.AddUint64(uint64(len(pending)), nil)
+		}
+		pending = nil
+	}
+	for {
+		select {
+		case trace := <-t.traces:
+			pending = append(pending, trace)
+		case now := <-ticker.C:
+			t.expire(now.Add(-messageTraceExpiry))
+			flush()
+		case <-wn.ctx.Done():
+			flush()
+			return
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/network/messagetracer"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestMessageTracerDisabled(t *testing.T) {
+	conf := defaultConfig
+	conf.MessageTraceSampleRate = 1
+	wn := makeTestWebsocketNodeWithConfig(t, conf)
+	require.Nil(t, wn.messageTracer)
+	require.NoError(t, wn.LoadMessageTraceStore(filepath.Join(os.TempDir(), "nonexistent", MessageTraceFilename)))
+
+	conf.EnableNodeIdentity = true
+	wn = makeTestWebsocketNodeWithConfig(t, conf)
+	require.NotNil(t, wn.messageTracer)
+	require.True(t, wn.messagesOfInterest[protocol.MsgTraceTag])
+}
+
+// Test that the traced messages expire even if the node doesn't store the traces.
+func TestMessageTracerExpiryWithoutStore(t *testing.T) {
+	conf := defaultConfig
+	conf.MessageTraceSampleRate = 1
+	conf.EnableNodeIdentity = true
+	wn := makeTestWebsocketNodeWithConfig(t, conf)
+	require.NotNil(t, wn.messageTracer)
+	require.Nil(t, wn.messageTracer.store)
+	wn.Start()
+	defer wn.Stop()
+
+	var digest crypto.Digest
+	crypto.RandBytes(digest[:])
+	wn.messageTracer.mu.Lock()
+	wn.messageTracer.messages[digest] = &tracedMessage{tag: protocol.TxnTag, created: time.Now().Add(-messageTraceExpiry)}
+	wn.messageTracer.mu.Unlock()
+	require.Eventually(t, func() bool {
+		wn.messageTracer.mu.Lock()
+		defer wn.messageTracer.mu.Unlock()
+		return len(wn.messageTracer.messages) == 0
+	}, 3*messageTraceFlushInterval, 10*time.Millisecond)
+}
+
+// Test that the trace records are admitted for verification only for the sampled messages the node tracks, and only
+// up to the number of records allowed per peer.
+func TestMessageTracerAdmit(t *testing.T) {
+	tracer := makeMessageTracer(2, makeNodeIdentity(nil))
+	var sampled, unsampled crypto.Digest
+	for !messagetracer.Sampled(sampled, tracer.sampleRate) || messagetracer.Sampled(unsampled, tracer.sampleRate) {
+		crypto.RandBytes(sampled[:])
+		crypto.RandBytes(unsampled[:])
+	}
+	peerA := &wsPeer{}
+	peerB := &wsPeer{}
+
+	// the records of the messages the node doesn't track are dropped.
+	require.False(t, tracer.admit(sampled, protocol.TxnTag, peerA))
+	tracer.messages[sampled] = &tracedMessage{tag: protocol.TxnTag, created: time.Now()}
+	tracer.messages[unsampled] = &tracedMessage{tag: protocol.TxnTag, created: time.Now()}
+	require.False(t, tracer.admit(unsampled, protocol.TxnTag, peerA))
+	require.False(t, tracer.admit(sampled, protocol.AgreementVoteTag, peerA))
+
+	for i := 0; i < messageTraceMaxRecordsPerPeer; i++ {
+		require.True(t, tracer.admit(sampled, protocol.TxnTag, peerA))
+	}
+	require.False(t, tracer.admit(sampled, protocol.TxnTag, peerA))
+	require.True(t, tracer.admit(sampled, protocol.TxnTag, peerB))
+}
+
+// Set up a chain of nodes where A sends a transaction group to B, which relays it to C, and test that B stores the
+// record of A while C stores the record extended by B, with the timestamps of both hops.
+func TestWebsocketNetworkMessageTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "messagetrace")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := defaultConfig
+	conf.EnableNodeIdentity = true
+	conf.MessageTraceSampleRate = 1
+	conf.GossipFanout = 1
+
+	netA := makeTestWebsocketNodeWithConfig(t, conf)
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	netB := makeTestWebsocketNodeWithConfig(t, conf)
+	require.NoError(t, netB.LoadMessageTraceStore(filepath.Join(dir, "B.sqlite")))
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		return OutgoingMessage{Action: Broadcast}
+	})}})
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+	addrB, postListen := netB.Address()
+	require.True(t, postListen)
+
+	confC := conf
+	confC.NetAddress = ""
+	netC := makeTestWebsocketNodeWithConfig(t, confC)
+	require.NoError(t, netC.LoadMessageTraceStore(filepath.Join(dir, "C.sqlite")))
+	netC.phonebook.ReplacePeerList([]string{addrB}, "default", PhoneBookEntryRelayRole)
+	netC.Start()
+	defer func() { t.Log("stopping C"); netC.Stop(); t.Log("C done") }()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netB, readyTimeout.C)
+	waitReady(t, netC, readyTimeout.C)
+	require.Eventually(t, func() bool {
+		return len(netA.GetPeers(PeersConnectedIn)) == 1 && len(netB.GetPeers(PeersConnectedIn)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	data := []byte("traced transaction group")
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, data, true, nil))
+
+	storedTraces := func(wn *WebsocketNetwork) []messagetracer.Trace {
+		traces, err := wn.messageTracer.store.Traces(nil)
+		require.NoError(t, err)
+		return traces
+	}
+	var tracesB, tracesC []messagetracer.Trace
+	require.Eventually(t, func() bool {
+		tracesB, tracesC = storedTraces(netB), storedTraces(netC)
+		return len(tracesB) > 0 && len(tracesC) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	identityA := netA.identity.secrets.SignatureVerifier
+	identityB := netB.identity.secrets.SignatureVerifier
+	require.Len(t, tracesB, 1)
+	require.Equal(t, identityB, tracesB[0].Observer)
+	require.Equal(t, generateMessageDigest(protocol.TxnTag, data), tracesB[0].Record.Digest)
+	require.Len(t, tracesB[0].Record.Hops, 1)
+	require.Equal(t, identityA, tracesB[0].Record.Hops[0].Node)
+
+	require.Len(t, tracesC, 1)
+	record := tracesC[0].Record
+	require.NoError(t, record.Verify())
+	require.Len(t, record.Hops, 2)
+	require.Equal(t, identityA, record.Hops[0].Node)
+	require.Equal(t, identityB, record.Hops[1].Node)
+	require.Equal(t, tracesB[0].Received, record.Hops[1].Received)
+	require.True(t, record.Hops[1].Sent >= record.Hops[1].Received)
+	require.True(t, tracesC[0].Received >= record.Hops[1].Sent)
+
+	trees := messagetracer.BuildTrees(append(tracesB, tracesC...))
+	require.Len(t, trees, 1)
+	require.Equal(t, 2, trees[0].Root.Hops())
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagetracer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// TreeNode is a node in the propagation tree of a message.
+type TreeNode struct {
+	Node crypto.SignatureVerifier
+
+	// Received is the time at which the message first reached the node, in nanoseconds since the epoch. It's zero
+	// for the node the message originated from.
+	Received int64
+
+	// Sent is the time at which the node relayed the message, or zero if the node isn't known to have relayed it.
+	Sent int64
+
+	// Children are the nodes the message first reached from this node, ordered by the time they received it.
+	Children []*TreeNode
+}
+
+// Hops returns the number of hops from the root of the tree to the deepest node under the given one.
+func (n *TreeNode) Hops() int {
+	hops := 0
+	for _, child := range n.Children {
+		if childHops := child.Hops() + 1; childHops > hops {
+			hops = childHops
+		}
+	}
+	return hops
+}
+
+// Tree is the propagation tree of a message, rooted at the node the message originated from.
+type Tree struct {
+	Digest crypto.Digest
+	Tag    protocol.Tag
+	Root   *TreeNode
+}
+
+type treeEntry struct {
+	node   *TreeNode
+	parent crypto.SignatureVerifier
+	origin bool
+}
+
+// BuildTrees reconstructs the propagation trees of the messages of the given traces, which are typically gathered from
+// the stores of several nodes. A node relays a message once, when it first receives it, so its hop is the same in all
+// the records which went through it and determines its parent. The nodes which only observed the message are placed
+// under the node they first received it from. The trees are ordered by the time the messages were sent.
+func BuildTrees(traces []Trace) []Tree {
+	type message struct {
+		tag     protocol.Tag
+		entries map[crypto.SignatureVerifier]*treeEntry
+	}
+	messages := make(map[crypto.Digest]*message)
+	var digests []crypto.Digest
+	for _, trace := range traces {
+		if len(trace.Record.Hops) == 0 {
+			continue
+		}
+		msg := messages[trace.Record.Digest]
+		if msg == nil {
+			msg = &message{tag: trace.Record.Tag, entries: make(map[crypto.SignatureVerifier]*treeEntry)}
+			messages[trace.Record.Digest] = msg
+			digests = append(digests, trace.Record.Digest)
+		}
+		for i, hop := range trace.Record.Hops {
+			if _, has := msg.entries[hop.Node]; has {
+				continue
+			}
+			entry := &treeEntry{node: &TreeNode{Node: hop.Node, Received: hop.Received, Sent: hop.Sent}, origin: i == 0}
+			if i > 0 {
+				entry.parent = trace.Record.Hops[i-1].Node
+			}
+			msg.entries[hop.Node] = entry
+		}
+	}
+	// the observers are added once all the hops are known, since their own hops take precedence.
+	for _, trace := range traces {
+		msg := messages[trace.Record.Digest]
+		if msg == nil {
+			continue
+		}
+		entry, has := msg.entries[trace.Observer]
+		if has && (entry.origin || entry.node.Sent != 0) {
+			continue
+		}
+		if has && entry.node.Received <= trace.Received {
+			continue
+		}
+		msg.entries[trace.Observer] = &treeEntry{
+			node:   &TreeNode{Node: trace.Observer, Received: trace.Received},
+			parent: trace.Record.Hops[len(trace.Record.Hops)-1].Node,
+		}
+	}
+
+	trees := make([]Tree, 0, len(digests))
+	for _, digest := range digests {
+		msg := messages[digest]
+		for _, entry := range msg.entries {
+			if entry.origin {
+				trees = append(trees, Tree{Digest: digest, Tag: msg.tag, Root: entry.node})
+				continue
+			}
+			if parent, has := msg.entries[entry.parent]; has {
+				parent.node.Children = append(parent.node.Children, entry.node)
+			}
+		}
+		for _, entry := range msg.entries {
+			children := entry.node.Children
+			sort.Slice(children, func(i, j int) bool { return children[i].Received < children[j].Received })
+		}
+	}
+	sort.SliceStable(trees, func(i, j int) bool { return trees[i].Root.Sent < trees[j].Root.Sent })
+	return trees
+}
+
+// Percentiles are the nearest-rank percentiles of a set of durations.
+type Percentiles struct {
+	Samples int
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+}
+
+func makePercentiles(samples []time.Duration) Percentiles {
+	p := Percentiles{Samples: len(samples)}
+	if len(samples) == 0 {
+		return p
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	// the nearest-rank percentile is the smallest sample which at least the given percentage of the samples don't
+	// exceed, which is the one ranked ceil(percentile*n/100).
+	at := func(percentile int) time.Duration {
+		return samples[(percentile*len(samples)+99)/100-1]
+	}
+	p.P50, p.P90, p.P99 = at(50), at(90), at(99)
+	return p
+}
+
+// NodeLatency is the latency of the messages through a node, across the propagation trees.
+type NodeLatency struct {
+	Node crypto.SignatureVerifier
+
+	// Hops is the number of hops from the origin at which the node received the messages, on average.
+	Hops float64
+
+	// Arrival is the time it took the messages to reach the node from the time their origin sent them. It's
+	// measured across the clocks of different nodes, so it includes their skew.
+	Arrival Percentiles
+
+	// Forwarding is the time it took the node to relay the messages it received, by its own clock.
+	Forwarding Percentiles
+}
+
+// Latencies returns the latencies of all the nodes the messages of the given trees reached other than their origin,
+// ordered by their median arrival time.
+func Latencies(trees []Tree) []NodeLatency {
+	type samples struct {
+		hops       int
+		arrival    []time.Duration
+		forwarding []time.Duration
+	}
+	nodes := make(map[crypto.SignatureVerifier]*samples)
+	var visit func(origin *TreeNode, n *TreeNode, hops int)
+	visit = func(origin *TreeNode, n *TreeNode, hops int) {
+		if n != origin {
+			s := nodes[n.Node]
+			if s == nil {
+				s = &samples{}
+				nodes[n.Node] = s
+			}
+			s.hops += hops
+			s.arrival = append(s.arrival, time.Duration(n.Received-origin.Sent))
+			if n.Sent != 0 {
+				s.forwarding = append(s.forwarding, time.Duration(n.Sent-n.Received))
+			}
+		}
+		for _, child := range n.Children {
+			visit(origin, child, hops+1)
+		}
+	}
+	for _, tree := range trees {
+		visit(tree.Root, tree.Root, 0)
+	}
+
+	latencies := make([]NodeLatency, 0, len(nodes))
+	for node, s := range nodes {
+		latencies = append(latencies, NodeLatency{
+			Node:       node,
+			Hops:       float64(s.hops) / float64(len(s.arrival)),
+			Arrival:    makePercentiles(s.arrival),
+			Forwarding: makePercentiles(s.forwarding),
+		})
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i].Arrival.P50 < latencies[j].Arrival.P50 })
+	return latencies
+}
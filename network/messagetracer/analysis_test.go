@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagetracer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// makeTestTraces traces a message sent by an origin through relay1 and then relay2, as stored by the relays and by
+// an observer which received the message from both relays.
+func makeTestTraces(start time.Time) (traces []Trace, origin, relay1, relay2, observer *crypto.SignatureSecrets) {
+	origin, relay1, relay2, observer = makeTestSecrets(0), makeTestSecrets(1), makeTestSecrets(2), makeTestSecrets(3)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	record0 := Record{Digest: crypto.Hash([]byte("proposal")), Tag: protocol.ProposalPayloadTag}.Extend(origin, time.Time{}, at(0))
+	record1 := record0.Extend(relay1, at(10), at(12))
+	record2 := record1.Extend(relay2, at(20), at(25))
+	traces = []Trace{
+		{Observer: relay1.SignatureVerifier, Received: at(10).UnixNano(), Record: record0},
+		{Observer: relay2.SignatureVerifier, Received: at(20).UnixNano(), Record: record1},
+		{Observer: observer.SignatureVerifier, Received: at(40).UnixNano(), Record: record1},
+		{Observer: observer.SignatureVerifier, Received: at(30).UnixNano(), Record: record2},
+	}
+	return
+}
+
+func TestBuildTrees(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	traces, origin, relay1, relay2, observer := makeTestTraces(start)
+
+	trees := BuildTrees(traces)
+	require.Len(t, trees, 1)
+	require.Equal(t, protocol.ProposalPayloadTag, trees[0].Tag)
+	require.Equal(t, traces[0].Record.Digest, trees[0].Digest)
+	require.Equal(t, 3, trees[0].Root.Hops())
+
+	// the observer is placed under relay2, which it received the message from first.
+	root := trees[0].Root
+	require.Equal(t, origin.SignatureVerifier, root.Node)
+	require.Len(t, root.Children, 1)
+	require.Equal(t, relay1.SignatureVerifier, root.Children[0].Node)
+	require.Len(t, root.Children[0].Children, 1)
+	node2 := root.Children[0].Children[0]
+	require.Equal(t, relay2.SignatureVerifier, node2.Node)
+	require.Len(t, node2.Children, 1)
+	require.Equal(t, observer.SignatureVerifier, node2.Children[0].Node)
+	require.Equal(t, start.Add(30*time.Millisecond).UnixNano(), node2.Children[0].Received)
+	require.Empty(t, node2.Children[0].Children)
+}
+
+func TestLatencies(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	traces, _, relay1, relay2, observer := makeTestTraces(start)
+
+	latencies := Latencies(BuildTrees(traces))
+	require.Len(t, latencies, 3)
+	require.Equal(t, relay1.SignatureVerifier, latencies[0].Node)
+	require.Equal(t, 1.0, latencies[0].Hops)
+	require.Equal(t, 10*time.Millisecond, latencies[0].Arrival.P50)
+	require.Equal(t, 2*time.Millisecond, latencies[0].Forwarding.P99)
+	require.Equal(t, relay2.SignatureVerifier, latencies[1].Node)
+	require.Equal(t, 20*time.Millisecond, latencies[1].Arrival.P90)
+	require.Equal(t, 5*time.Millisecond, latencies[1].Forwarding.P50)
+	require.Equal(t, observer.SignatureVerifier, latencies[2].Node)
+	require.Equal(t, 3.0, latencies[2].Hops)
+	require.Equal(t, 30*time.Millisecond, latencies[2].Arrival.P50)
+	require.Equal(t, 0, latencies[2].Forwarding.Samples)
+
+	p := makePercentiles([]time.Duration{5, 1, 4, 2, 3, 6, 7, 8, 9, 10})
+	require.Equal(t, Percentiles{Samples: 10, P50: 5, P90: 9, P99: 10}, p)
+	p = makePercentiles([]time.Duration{2, 1})
+	require.Equal(t, Percentiles{Samples: 2, P50: 1, P90: 2, P99: 2}, p)
+	p = makePercentiles([]time.Duration{1})
+	require.Equal(t, Percentiles{Samples: 1, P50: 1, P90: 1, P99: 1}, p)
+}
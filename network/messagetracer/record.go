@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagetracer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// MaxHops is the maximal number of hops of a Record. The messages are relayed along much shorter paths, so the
+// records which reach it aren't extended nor relayed any further.
+const MaxHops = 32
+
+// TracedTags are the tags of the messages which are sampled for tracing.
+var TracedTags = map[protocol.Tag]bool{
+	protocol.AgreementVoteTag:   true,
+	protocol.ProposalPayloadTag: true,
+	protocol.TxnTag:             true,
+}
+
+var errRecordNoHops = errors.New("trace record has no hops")
+var errRecordTooManyHops = fmt.Errorf("trace record has more than %d hops", MaxHops)
+var errRecordTag = errors.New("trace record is for a message tag which isn't traced")
+var errRecordLoop = errors.New("trace record goes through a node more than once")
+
+// Hop is the part a node took in the propagation of a traced message. The timestamps are in nanoseconds since the
+// epoch, by the clock of the node.
+type Hop struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Node is the node identity of the node.
+	Node crypto.SignatureVerifier `codec:"n"`
+
+	// Received is the time at which the message first reached the node. It's zero on the first hop, which is the
+	// node the message originated from.
+	Received int64 `codec:"r"`
+
+	// Sent is the time at which the node relayed the message.
+	Sent int64 `codec:"s"`
+
+	// Sig is the signature of the hop, along with the message digest and the signature of the previous hop, made
+	// with the node identity key.
+	Sig crypto.Signature `codec:"sig"`
+}
+
+// hopSignable is what the node of a hop signs. Chaining the signature of the previous hop keeps the hops from being
+// reordered, or spliced from the records of different paths.
+type hopSignable struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Digest   crypto.Digest            `codec:"d"`
+	Tag      protocol.Tag             `codec:"t"`
+	Previous crypto.Signature         `codec:"p"`
+	Node     crypto.SignatureVerifier `codec:"n"`
+	Received int64                    `codec:"r"`
+	Sent     int64                    `codec:"s"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface.
+func (h hopSignable) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.MessageTraceHop, protocol.EncodeReflect(&h)
+}
+
+// Record is the trace of the path a sampled message took from the node it originated from. Every node along the
+// path appends its own hop before passing the record on, so that the number of hops is the hop count of the message.
+type Record struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Digest crypto.Digest `codec:"d"`
+	Tag    protocol.Tag  `codec:"t"`
+	Hops   []Hop         `codec:"h"`
+}
+
+// Sampled returns true if the message of the given digest is traced with the given sample rate, which is one in every
+// sampleRate messages. The sampling depends on the digest only, so that all the nodes trace the same messages.
+func Sampled(digest crypto.Digest, sampleRate uint64) bool {
+	return sampleRate > 0 && binary.LittleEndian.Uint64(digest[:8])%sampleRate == 0
+}
+
+func (r Record) signable(i int) hopSignable {
+	h := hopSignable{
+		Digest:   r.Digest,
+		Tag:      r.Tag,
+		Node:     r.Hops[i].Node,
+		Received: r.Hops[i].Received,
+		Sent:     r.Hops[i].Sent,
+	}
+	if i > 0 {
+		h.Previous = r.Hops[i-1].Sig
+	}
+	return h
+}
+
+// Extend returns a copy of the record with the hop of the node of the given identity key appended to it.
+func (r Record) Extend(secrets *crypto.SignatureSecrets, received time.Time, sent time.Time) Record {
+	extended := Record{Digest: r.Digest, Tag: r.Tag, Hops: make([]Hop, len(r.Hops), len(r.Hops)+1)}
+	copy(extended.Hops, r.Hops)
+	hop := Hop{Node: secrets.SignatureVerifier, Sent: sent.UnixNano()}
+	if !received.IsZero() {
+		hop.Received = received.UnixNano()
+	}
+	extended.Hops = append(extended.Hops, hop)
+	last := len(extended.Hops) - 1
+	extended.Hops[last].Sig = secrets.Sign(extended.signable(last))
+	return extended
+}
+
+// Verify checks that the record is for a traced message tag, that it doesn't go through any node twice, and then the
+// signatures of all of its hops. It doesn't check that the message is sampled nor tracked, which the callers check
+// before verifying the signatures.
+func (r Record) Verify() error {
+	if !TracedTags[r.Tag] {
+		return errRecordTag
+	}
+	if len(r.Hops) == 0 {
+		return errRecordNoHops
+	}
+	if len(r.Hops) > MaxHops {
+		return errRecordTooManyHops
+	}
+	nodes := make(map[crypto.SignatureVerifier]bool, len(r.Hops))
+	for _, hop := range r.Hops {
+		if nodes[hop.Node] {
+			return errRecordLoop
+		}
+		nodes[hop.Node] = true
+	}
+	for i, hop := range r.Hops {
+		if !hop.Node.Verify(r.signable(i), hop.Sig) {
+			return fmt.Errorf("trace record hop %d has an invalid signature", i)
+		}
+	}
+	return nil
+}
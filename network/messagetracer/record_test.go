@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagetracer
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func makeTestSecrets(i int) *crypto.SignatureSecrets {
+	var seed crypto.Seed
+	seed[0] = byte(i + 1)
+	return crypto.GenerateSignatureSecrets(seed)
+}
+
+func TestRecordVerify(t *testing.T) {
+	origin, relay, other := makeTestSecrets(0), makeTestSecrets(1), makeTestSecrets(2)
+	start := time.Unix(1600000000, 0)
+
+	record := Record{Digest: crypto.Hash([]byte("message")), Tag: protocol.AgreementVoteTag}
+	record = record.Extend(origin, time.Time{}, start)
+	require.NoError(t, record.Verify())
+	require.Equal(t, int64(0), record.Hops[0].Received)
+
+	extended := record.Extend(relay, start.Add(10*time.Millisecond), start.Add(15*time.Millisecond))
+	require.NoError(t, extended.Verify())
+	require.Len(t, record.Hops, 1)
+	require.Len(t, extended.Hops, 2)
+	require.Equal(t, start.Add(10*time.Millisecond).UnixNano(), extended.Hops[1].Received)
+
+	// the record survives the encoding it's sent with.
+	var decoded Record
+	require.NoError(t, protocol.DecodeReflect(protocol.EncodeReflect(&extended), &decoded))
+	require.Equal(t, extended, decoded)
+
+	tampered := extended.Extend(other, start.Add(20*time.Millisecond), start.Add(25*time.Millisecond))
+	tampered.Hops[1].Sent++
+	require.Error(t, tampered.Verify())
+
+	// hops can't be spliced from the records of another message.
+	otherRecord := Record{Digest: crypto.Hash([]byte("other message")), Tag: protocol.AgreementVoteTag}.Extend(other, time.Time{}, start)
+	spliced := Record{Digest: record.Digest, Tag: record.Tag, Hops: append(append([]Hop{}, record.Hops...), otherRecord.Hops...)}
+	require.Error(t, spliced.Verify())
+
+	looped := extended.Extend(origin, start.Add(20*time.Millisecond), start.Add(25*time.Millisecond))
+	require.Equal(t, errRecordLoop, looped.Verify())
+
+	require.Equal(t, errRecordNoHops, Record{Digest: record.Digest, Tag: record.Tag}.Verify())
+	untraced := Record{Digest: record.Digest, Tag: protocol.PingTag}.Extend(origin, time.Time{}, start)
+	require.Equal(t, errRecordTag, untraced.Verify())
+	long := Record{Digest: record.Digest, Tag: record.Tag}
+	for i := 0; i <= MaxHops; i++ {
+		long = long.Extend(makeTestSecrets(i), start, start)
+	}
+	require.Equal(t, errRecordTooManyHops, long.Verify())
+}
+
+func TestSampled(t *testing.T) {
+	var digest crypto.Digest
+	require.False(t, Sampled(digest, 0))
+	require.True(t, Sampled(digest, 1))
+
+	sampled := 0
+	for i := uint64(0); i < 1000; i++ {
+		binary.LittleEndian.PutUint64(digest[:8], i)
+		if Sampled(digest, 10) {
+			sampled++
+		}
+	}
+	require.Equal(t, 100, sampled)
+}
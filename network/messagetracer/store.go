@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagetracer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// StoreRetention is how long the traces are kept in a Store.
+const StoreRetention = 24 * time.Hour
+
+var storeSchema = []string{
+	// traces has the trace records which reached the node, along with the time at which they did. A message reaches
+	// the node once along every path from its origin, so there are as many records of a digest as there are paths.
+	`CREATE TABLE IF NOT EXISTS traces (
+		digest blob,
+		tag text,
+		observer blob,
+		received integer,
+		record blob)`,
+
+	`CREATE INDEX IF NOT EXISTS traces_digest ON traces (digest)`,
+	`CREATE INDEX IF NOT EXISTS traces_received ON traces (received)`,
+}
+
+// Trace is a Record as it reached a node.
+type Trace struct {
+	// Observer is the node identity of the node the record reached.
+	Observer crypto.SignatureVerifier
+
+	// Received is the time at which the record reached the node, in nanoseconds since the epoch.
+	Received int64
+
+	Record Record
+}
+
+// Store is the local store of the traces which reached a node.
+type Store struct {
+	accessor db.Accessor
+}
+
+// OpenStore opens the trace store in the given file, creating it if it doesn't exist.
+func OpenStore(filename string, readOnly bool) (*Store, error) {
+	accessor, err := db.MakeAccessor(filename, readOnly, false)
+	if err != nil {
+		return nil, err
+	}
+	if !readOnly {
+		err = accessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+			for i, tableCreate := range storeSchema {
+				_, err := tx.Exec(tableCreate)
+				if err != nil {
+					return fmt.Errorf("could not create message trace table %d: %v", i, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			accessor.Close()
+			return nil, err
+		}
+	}
+	return &Store{accessor: accessor}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() {
+	s.accessor.Close()
+}
+
+// Add adds the given traces to the store, and prunes the traces older than StoreRetention.
+func (s *Store) Add(traces []Trace) error {
+	return s.accessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		for _, trace := range traces {
+			_, err := tx.Exec("INSERT INTO traces (digest, tag, observer, received, record) VALUES (?, ?, ?, ?, ?)",
+				trace.Record.Digest[:],
+				string(trace.Record.Tag),
+				trace.Observer[:],
+				trace.Received,
+				protocol.EncodeReflect(&trace.Record))
+			if err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec("DELETE FROM traces WHERE received<?", time.Now().Add(-StoreRetention).UnixNano())
+		return err
+	})
+}
+
+// Traces returns the traces in the store, oldest first. When a digest is given, only the traces of its message are
+// returned.
+func (s *Store) Traces(digest *crypto.Digest) (traces []Trace, err error) {
+	err = s.accessor.Atomic(func(ctx context.Context, tx *sql.Tx) error {
+		var rows *sql.Rows
+		var err error
+		if digest != nil {
+			rows, err = tx.Query("SELECT observer, received, record FROM traces WHERE digest=? ORDER BY received", digest[:])
+		} else {
+			rows, err = tx.Query("SELECT observer, received, record FROM traces ORDER BY received")
+		}
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		traces = nil
+		for rows.Next() {
+			var trace Trace
+			var observer, record []byte
+			err = rows.Scan(&observer, &trace.Received, &record)
+			if err != nil {
+				return err
+			}
+			copy(trace.Observer[:], observer)
+			err = protocol.DecodeReflect(record, &trace.Record)
+			if err != nil {
+				return err
+			}
+			traces = append(traces, trace)
+		}
+		return rows.Err()
+	})
+	return
+}
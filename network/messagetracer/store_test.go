@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messagetracer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "messagetrace")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "messagetrace.sqlite")
+	store, err := OpenStore(filename, false)
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	traces, _, _, _, _ := makeTestTraces(now)
+	other := traces[0]
+	other.Record = Record{Digest: crypto.Hash([]byte("vote")), Tag: protocol.AgreementVoteTag}.Extend(makeTestSecrets(4), time.Time{}, now)
+	other.Received = now.Add(time.Millisecond).UnixNano()
+	expired := other
+	expired.Received = now.Add(-StoreRetention - time.Minute).UnixNano()
+	require.NoError(t, store.Add([]Trace{expired}))
+	require.NoError(t, store.Add(append(traces, other)))
+
+	// the traces are returned oldest first, without the expired one.
+	stored, err := store.Traces(nil)
+	require.NoError(t, err)
+	require.Equal(t, []Trace{other, traces[0], traces[1], traces[3], traces[2]}, stored)
+
+	stored, err = store.Traces(&other.Record.Digest)
+	require.NoError(t, err)
+	require.Equal(t, []Trace{other}, stored)
+
+	readOnly, err := OpenStore(filename, true)
+	require.NoError(t, err)
+	defer readOnly.Close()
+	stored, err = readOnly.Traces(&traces[0].Record.Digest)
+	require.NoError(t, err)
+	require.Len(t, stored, 4)
+}
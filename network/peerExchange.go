@@ -0,0 +1,560 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// PeerExchangeKeyFilename is the name of the file, in the genesis directory, holding the seed of the key which the
+// node signs its relay announcements with.
+const PeerExchangeKeyFilename = "peerexchange.key"
+
+// peerExchangeNetworkName is the phonebook network name of the relays learned through the peer exchange.
+const peerExchangeNetworkName = "peerexchange"
+
+// peerExchangeInterval is the interval at which relays share the relays they know of with all their peers. Peers also
+// get the known relays as soon as they list the PeerExchangeTag in their message-of-interest.
+const peerExchangeInterval = time.Hour
+
+// relayAnnouncementLifetime is the time after which a relay announcement expires, unless the relay announces itself
+// again. It spans a few peerExchangeIntervals, so that a relay would not be forgotten if it missed one.
+const relayAnnouncementLifetime = 3 * time.Hour
+
+// relayAnnouncementMaxClockSkew is how far in the future a relay announcement timestamp could be.
+const relayAnnouncementMaxClockSkew = 10 * time.Minute
+
+// maxPeerExchangeRelays is the maximal number of relay announcements in a single peer exchange message.
+const maxPeerExchangeRelays = 200
+
+// maxExchangedRelays is the maximal number of relay announcements a node keeps track of.
+const maxExchangedRelays = 1000
+
+// maxExchangedRelaysPerSource is the maximal number of the tracked relay announcements which were learned from a single
+// peer, so that a single peer can't take over the announcements a node keeps track of.
+const maxExchangedRelaysPerSource = maxPeerExchangeRelays / 2
+
+// PeerExchangeChallengeHeader is the HTTP header carrying a random challenge, which the dialed relay signs with its relay
+// announcement key to prove that it holds the key it announces itself with.
+const PeerExchangeChallengeHeader = "X-Algorand-PeerExchange-Challenge"
+
+// PeerExchangeKeyHeader is the HTTP header carrying the relay announcement key of the dialed relay.
+const PeerExchangeKeyHeader = "X-Algorand-PeerExchange-Key"
+
+// PeerExchangeProofHeader is the HTTP header carrying the signature of the challenge made by the dialed relay.
+const PeerExchangeProofHeader = "X-Algorand-PeerExchange-Proof"
+
+var networkPeerExchangeAcceptedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peer_exchange_accepted_total", Description: "number of relay announcements accepted from peer exchange messages"})
+var networkPeerExchangeRejectedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peer_exchange_rejected_total", Description: "number of relay announcements rejected from peer exchange messages"})
+var networkPeerExchangeEvictedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peer_exchange_evicted_total", Description: "number of tracked relay announcements evicted to make room for newer ones"})
+
+var errRelayAnnouncementGenesis = errors.New("relay announcement is for a different genesis")
+var errRelayAnnouncementExpired = errors.New("relay announcement timestamp is out of range")
+var errRelayAnnouncementSignature = errors.New("relay announcement signature is invalid")
+
+// relayAnnouncement is a relay's announcement of its public address.
+type relayAnnouncement struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Address   string `codec:"a"`
+	GenesisID string `codec:"g"`
+	Timestamp int64  `codec:"t"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface.
+func (a relayAnnouncement) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.RelayAnnouncement, protocol.EncodeReflect(&a)
+}
+
+// signedRelayAnnouncement is a relay announcement, signed with a key the announcing node picked. The signature ties the
+// announcement to the key, but nothing ties the key to the announced address until the relay at that address is dialed,
+// and proves it holds the key in the handshake.
+type signedRelayAnnouncement struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Announcement relayAnnouncement        `codec:"n"`
+	Key          crypto.SignatureVerifier `codec:"k"`
+	Sig          crypto.Signature         `codec:"s"`
+}
+
+// relayKeyChallenge is a random challenge which a dialed relay signs to prove it holds its relay announcement key.
+type relayKeyChallenge [32]byte
+
+// relayKeyProof is what a dialed relay signs with its relay announcement key, binding the key to the address it's
+// reachable at.
+type relayKeyProof struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Challenge relayKeyChallenge `codec:"c"`
+	Address   string            `codec:"a"`
+	GenesisID string            `codec:"g"`
+}
+
+// ToBeHashed implements the crypto.Hashable interface.
+func (p relayKeyProof) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.RelayKeyProof, protocol.EncodeReflect(&p)
+}
+
+// provenRelayKey is the relay announcement key a dialed relay proved it holds, which is zero if it didn't prove any.
+type provenRelayKey struct {
+	key  crypto.SignatureVerifier
+	time time.Time
+}
+
+// peerExchangeMessage is the body of the PeerExchangeTag messages.
+type peerExchangeMessage struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Relays []signedRelayAnnouncement `codec:"r"`
+}
+
+// exchangedRelay is a relay announcement a node keeps track of.
+type exchangedRelay struct {
+	signedRelayAnnouncement
+
+	// timestamp is the announcement timestamp, clamped to the time the announcement was received, so that an
+	// announcement from the future won't outlive the other ones.
+	timestamp time.Time
+
+	// source is the host of the peer the announcement was learned from.
+	source string
+}
+
+// peerExchange keeps track of the relays learned through the peer exchange, along with the key used for signing the
+// announcements of this node.
+type peerExchange struct {
+	mu          deadlock.Mutex
+	secrets     *crypto.SignatureSecrets
+	trustedKeys map[crypto.SignatureVerifier]bool
+	relays      map[string]exchangedRelay
+	sources     map[string]int
+	lastRefresh time.Time
+
+	// proven are the relay announcement keys which the relays proved they hold when they were dialed, by address.
+	proven map[string]provenRelayKey
+}
+
+func makePeerExchange(log logging.Logger, trustedKeys string) *peerExchange {
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	return &peerExchange{
+		secrets:     crypto.GenerateSignatureSecrets(seed),
+		trustedKeys: parsePublicKeys(log, "PeerExchangeTrustedKeys", trustedKeys),
+		relays:      make(map[string]exchangedRelay),
+		sources:     make(map[string]int),
+		lastRefresh: time.Now(),
+		proven:      make(map[string]provenRelayKey),
+	}
+}
+
+// parsePublicKeys parses a semicolon-separated list of base64-encoded public keys from the named config, skipping
+// the invalid keys.
+func parsePublicKeys(log logging.Logger, configName string, encodedKeys string) map[crypto.SignatureVerifier]bool {
+	keys := make(map[crypto.SignatureVerifier]bool)
+	for _, encoded := range strings.Split(encodedKeys, ";") {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(decoded) != len(crypto.SignatureVerifier{}) {
+			log.Warnf("ignoring invalid %s key '%s'", configName, encoded)
+			continue
+		}
+		var key crypto.SignatureVerifier
+		copy(key[:], decoded)
+		keys[key] = true
+	}
+	return keys
+}
+
+// decodeHeader decodes a base64-encoded header value into the given fixed size buffer.
+func decodeHeader(header http.Header, name string, buf []byte) bool {
+	decoded, err := base64.StdEncoding.DecodeString(header.Get(name))
+	if err != nil || len(decoded) != len(buf) {
+		return false
+	}
+	copy(buf, decoded)
+	return true
+}
+
+// verify checks that the announcement is for the given genesis, that it didn't expire, and that it's properly signed.
+func (sa *signedRelayAnnouncement) verify(genesisID string, now time.Time) error {
+	if sa.Announcement.GenesisID != genesisID {
+		return errRelayAnnouncementGenesis
+	}
+	timestamp := time.Unix(sa.Announcement.Timestamp, 0)
+	if timestamp.Add(relayAnnouncementLifetime).Before(now) || timestamp.After(now.Add(relayAnnouncementMaxClockSkew)) {
+		return errRelayAnnouncementExpired
+	}
+	if _, err := ParseHostOrURL(sa.Announcement.Address); err != nil {
+		return fmt.Errorf("relay announcement address '%s' is invalid: %v", sa.Announcement.Address, err)
+	}
+	if !sa.Key.Verify(sa.Announcement, sa.Sig) {
+		return errRelayAnnouncementSignature
+	}
+	return nil
+}
+
+// add keeps track of the valid announcements among the given ones, which were learned from the given source peer,
+// replacing the older announcements of the same relays. Once the node keeps track of maxExchangedRelays announcements,
+// the oldest announcement, preferably an untrusted one, is evicted to make room for a newer one. It returns the number of
+// announcements added, and whether any of the announcements was forged.
+func (px *peerExchange) add(announcements []signedRelayAnnouncement, source, genesisID, publicAddress string, now time.Time) (added int, forged bool) {
+	px.mu.Lock()
+	defer px.mu.Unlock()
+	for i := range announcements {
+		sa := announcements[i]
+		if sa.Announcement.Address == publicAddress {
+			continue
+		}
+		if err := sa.verify(genesisID, now); err != nil {
+			networkPeerExchangeRejectedTotal.Inc(nil)
+			forged = forged || err == errRelayAnnouncementSignature
+			continue
+		}
+		if proven, has := px.proven[sa.Announcement.Address]; has && proven.key != sa.Key {
+			// the relay at the address proved it holds another key, or none, when it was dialed.
+			networkPeerExchangeRejectedTotal.Inc(nil)
+			continue
+		}
+		relay := exchangedRelay{signedRelayAnnouncement: sa, timestamp: time.Unix(sa.Announcement.Timestamp, 0), source: source}
+		if relay.timestamp.After(now) {
+			relay.timestamp = now
+		}
+		existing, has := px.relays[sa.Announcement.Address]
+		if has && !existing.timestamp.Before(relay.timestamp) {
+			continue
+		}
+		if (!has || existing.source != source) && px.sources[source] >= maxExchangedRelaysPerSource {
+			networkPeerExchangeRejectedTotal.Inc(nil)
+			continue
+		}
+		if !has && len(px.relays) >= maxExchangedRelays && !px.evictLocked(relay) {
+			continue
+		}
+		if has {
+			px.removeLocked(sa.Announcement.Address)
+		}
+		px.relays[sa.Announcement.Address] = relay
+		px.sources[source]++
+		networkPeerExchangeAcceptedTotal.Inc(nil)
+		added++
+	}
+	return
+}
+
+// evictLocked evicts the oldest untrusted announcement, or the oldest trusted one if there are none, to make room for
+// the given one. It returns false if the given announcement is older than the one which would be evicted.
+func (px *peerExchange) evictLocked(relay exchangedRelay) bool {
+	var victim string
+	var victimRelay exchangedRelay
+	for addr, r := range px.relays {
+		if victim != "" {
+			trusted, victimTrusted := px.trustedKeys[r.Key], px.trustedKeys[victimRelay.Key]
+			if trusted && !victimTrusted || trusted == victimTrusted && !r.timestamp.Before(victimRelay.timestamp) {
+				continue
+			}
+		}
+		victim, victimRelay = addr, r
+	}
+	if px.trustedKeys[victimRelay.Key] && !px.trustedKeys[relay.Key] {
+		return false
+	}
+	if px.trustedKeys[victimRelay.Key] == px.trustedKeys[relay.Key] && !victimRelay.timestamp.Before(relay.timestamp) {
+		return false
+	}
+	px.removeLocked(victim)
+	networkPeerExchangeEvictedTotal.Inc(nil)
+	return true
+}
+
+// removeLocked forgets the announcement of the given relay.
+func (px *peerExchange) removeLocked(addr string) {
+	relay := px.relays[addr]
+	delete(px.relays, addr)
+	px.sources[relay.source]--
+	if px.sources[relay.source] <= 0 {
+		delete(px.sources, relay.source)
+	}
+}
+
+// pruneLocked forgets the expired announcements, and the expired proofs of the relay keys.
+func (px *peerExchange) pruneLocked(now time.Time) {
+	for addr, relay := range px.relays {
+		if relay.timestamp.Add(relayAnnouncementLifetime).Before(now) {
+			px.removeLocked(addr)
+		}
+	}
+	for addr, proven := range px.proven {
+		if proven.time.Add(relayAnnouncementLifetime).Before(now) {
+			delete(px.proven, addr)
+		}
+	}
+}
+
+// provenLocked returns true if the announced relay proved it holds the announcement key when it was dialed.
+func (px *peerExchange) provenLocked(relay exchangedRelay) bool {
+	proven, has := px.proven[relay.Announcement.Address]
+	return has && proven.key == relay.Key
+}
+
+// prove notes the relay announcement key which the relay at the given address proved it holds when it was dialed, or
+// that it didn't prove any if the key is zero, and forgets the announcement of the relay if it was made with another
+// key. It returns true if the announcement of the relay was either proven or forgotten.
+func (px *peerExchange) prove(addr string, key crypto.SignatureVerifier, now time.Time) bool {
+	px.mu.Lock()
+	defer px.mu.Unlock()
+	relay, has := px.relays[addr]
+	if !has && ((key == crypto.SignatureVerifier{}) || len(px.proven) >= maxExchangedRelays) {
+		return false
+	}
+	px.proven[addr] = provenRelayKey{key: key, time: now}
+	if has && relay.Key != key {
+		px.removeLocked(addr)
+	}
+	return has
+}
+
+// proveKey signs the given challenge with the relay announcement key, binding the key to the public address of this node.
+func (px *peerExchange) proveKey(challenge relayKeyChallenge, genesisID, publicAddress string) (crypto.SignatureVerifier, crypto.Signature) {
+	px.mu.Lock()
+	defer px.mu.Unlock()
+	return px.secrets.SignatureVerifier, px.secrets.Sign(relayKeyProof{Challenge: challenge, Address: publicAddress, GenesisID: genesisID})
+}
+
+// addresses returns the addresses of the relays announced with a trusted key which proved they hold it, the addresses
+// of the relays announced with a trusted key which didn't prove it yet, and the addresses of the other relays.
+func (px *peerExchange) addresses(now time.Time) (trusted []string, pending []string, exchanged []string) {
+	px.mu.Lock()
+	defer px.mu.Unlock()
+	px.pruneLocked(now)
+	for addr, relay := range px.relays {
+		switch {
+		case px.trustedKeys[relay.Key] && px.provenLocked(relay):
+			trusted = append(trusted, addr)
+		case px.trustedKeys[relay.Key]:
+			pending = append(pending, addr)
+		default:
+			exchanged = append(exchanged, addr)
+		}
+	}
+	return
+}
+
+// message returns a peer exchange message with the self-announcement of this node, if it has a public address, and
+// the announcements of the relays it knows of which proved they hold their keys.
+func (px *peerExchange) message(genesisID, publicAddress string, now time.Time) []byte {
+	px.mu.Lock()
+	defer px.mu.Unlock()
+	px.pruneLocked(now)
+	var msg peerExchangeMessage
+	if publicAddress != "" {
+		announcement := relayAnnouncement{Address: publicAddress, GenesisID: genesisID, Timestamp: now.Unix()}
+		msg.Relays = append(msg.Relays, signedRelayAnnouncement{
+			Announcement: announcement,
+			Key:          px.secrets.SignatureVerifier,
+			Sig:          px.secrets.Sign(announcement),
+		})
+	}
+	for _, relay := range px.relays {
+		if len(msg.Relays) >= maxPeerExchangeRelays {
+			break
+		}
+		if px.provenLocked(relay) {
+			msg.Relays = append(msg.Relays, relay.signedRelayAnnouncement)
+		}
+	}
+	return protocol.EncodeReflect(&msg)
+}
+
+// refreshDue returns true, and restarts the interval, once every peerExchangeInterval.
+func (px *peerExchange) refreshDue(now time.Time) bool {
+	px.mu.Lock()
+	defer px.mu.Unlock()
+	if now.Sub(px.lastRefresh) < peerExchangeInterval {
+		return false
+	}
+	px.lastRefresh = now
+	return true
+}
+
+func peerExchangeHandler(message IncomingMessage) OutgoingMessage {
+	wn := message.Net.(*WebsocketNetwork)
+	var msg peerExchangeMessage
+	err := protocol.DecodeReflect(message.Data, &msg)
+	if err == nil && len(msg.Relays) > maxPeerExchangeRelays {
+		err = fmt.Errorf("%d relay announcements exceed the maximum of %d", len(msg.Relays), maxPeerExchangeRelays)
+	}
+	if err != nil {
+		wn.log.Warnf("peer exchange message from %s is invalid: %v", message.Sender.(*wsPeer).GetAddress(), err)
+		wn.ReportPeer(message.Sender, PeerOffenseInvalidMessage)
+		return OutgoingMessage{}
+	}
+	added, forged := wn.peerExchange.add(msg.Relays, message.Sender.(*wsPeer).remoteHost(), wn.GenesisID, wn.config.PublicAddress, time.Now())
+	if forged {
+		wn.log.Warnf("peer exchange message from %s has forged relay announcements", message.Sender.(*wsPeer).GetAddress())
+		wn.ReportPeer(message.Sender, PeerOffenseInvalidMessage)
+	}
+	if added > 0 {
+		wn.updateExchangedRelays()
+		// have the mesh thread connect to the relays announced with a trusted key, so that they prove they hold it.
+		select {
+		case wn.meshUpdateRequests <- meshRequest{false, nil}:
+		default:
+		}
+	}
+	return OutgoingMessage{}
+}
+
+var peerExchangeHandlers = []TaggedMessageHandler{
+	{protocol.PeerExchangeTag, HandlerFunc(peerExchangeHandler)},
+}
+
+// LoadPeerExchangeKey loads the seed of the key which the relay announcements of this node are signed with, creating
+// the file if it doesn't exist yet, so that the node keeps the same key across restarts. It does nothing unless the
+// peer exchange is enabled.
+func (wn *WebsocketNetwork) LoadPeerExchangeKey(filename string) error {
+	if wn.peerExchange == nil {
+		return nil
+	}
+	seed, err := loadOrCreateSeed(filename)
+	if err != nil {
+		return err
+	}
+	wn.peerExchange.mu.Lock()
+	wn.peerExchange.secrets = crypto.GenerateSignatureSecrets(seed)
+	wn.peerExchange.mu.Unlock()
+	return nil
+}
+
+// PeerExchangePublicKey returns the base64-encoded key which the relay announcements of this node are signed with, for
+// the operators of other nodes to list in their PeerExchangeTrustedKeys. It returns an empty string unless the peer
+// exchange is enabled.
+func (wn *WebsocketNetwork) PeerExchangePublicKey() string {
+	if wn.peerExchange == nil {
+		return ""
+	}
+	wn.peerExchange.mu.Lock()
+	defer wn.peerExchange.mu.Unlock()
+	return base64.StdEncoding.EncodeToString(wn.peerExchange.secrets.SignatureVerifier[:])
+}
+
+// sharesRelays returns true if this node shares the relays it knows of with its peers, which relays do when the
+// peer exchange is enabled.
+func (wn *WebsocketNetwork) sharesRelays() bool {
+	return wn.peerExchange != nil && wn.config.NetAddress != ""
+}
+
+// updateExchangedRelays feeds the relays learned through the peer exchange into the phonebook. The relays announced
+// with a trusted key are added as relays once they prove they hold it, and the others are added with the
+// PhoneBookEntryExchangedRole, which are connected to only if PeerExchangeDialUntrusted is set.
+func (wn *WebsocketNetwork) updateExchangedRelays() {
+	trusted, pending, exchanged := wn.peerExchange.addresses(time.Now())
+	wn.phonebook.ReplacePeerList(trusted, peerExchangeNetworkName, PhoneBookEntryRelayRole)
+	wn.phonebook.ReplacePeerList(append(pending, exchanged...), peerExchangeNetworkName, PhoneBookEntryExchangedRole)
+}
+
+// setPeerExchangeChallenge sets a random challenge in the request headers, for the dialed relay to prove it holds its
+// relay announcement key. It does nothing unless the peer exchange is enabled.
+func (wn *WebsocketNetwork) setPeerExchangeChallenge(requestHeader http.Header) (challenge relayKeyChallenge) {
+	if wn.peerExchange == nil {
+		return
+	}
+	crypto.RandBytes(challenge[:])
+	requestHeader.Set(PeerExchangeChallengeHeader, base64.StdEncoding.EncodeToString(challenge[:]))
+	return
+}
+
+// setPeerExchangeProof signs the challenge of the dialing peer with the relay announcement key, if this node announces
+// itself as a relay.
+func (wn *WebsocketNetwork) setPeerExchangeProof(requestHeader http.Header, responseHeader http.Header) {
+	if wn.peerExchange == nil || wn.config.PublicAddress == "" {
+		return
+	}
+	var challenge relayKeyChallenge
+	if !decodeHeader(requestHeader, PeerExchangeChallengeHeader, challenge[:]) {
+		return
+	}
+	key, sig := wn.peerExchange.proveKey(challenge, wn.GenesisID, wn.config.PublicAddress)
+	responseHeader.Set(PeerExchangeKeyHeader, base64.StdEncoding.EncodeToString(key[:]))
+	responseHeader.Set(PeerExchangeProofHeader, base64.StdEncoding.EncodeToString(sig[:]))
+}
+
+// checkPeerExchangeProof checks the proof of the dialed relay that it holds its relay announcement key, so that the
+// announcements of the relay made with another key are forgotten, and the ones made with a trusted key are connected to
+// as relays.
+func (wn *WebsocketNetwork) checkPeerExchangeProof(addr string, responseHeader http.Header, challenge relayKeyChallenge) {
+	if wn.peerExchange == nil {
+		return
+	}
+	var key crypto.SignatureVerifier
+	var sig crypto.Signature
+	proof := relayKeyProof{Challenge: challenge, Address: addr, GenesisID: wn.GenesisID}
+	if !decodeHeader(responseHeader, PeerExchangeKeyHeader, key[:]) || !decodeHeader(responseHeader, PeerExchangeProofHeader, sig[:]) || !key.Verify(proof, sig) {
+		key = crypto.SignatureVerifier{}
+	}
+	if wn.peerExchange.prove(addr, key, time.Now()) {
+		wn.updateExchangedRelays()
+	}
+}
+
+// refreshPeerExchange is called by the meshThread. Once every peerExchangeInterval, it forgets the expired relay
+// announcements, and relays share the relays they know of with all their peers.
+func (wn *WebsocketNetwork) refreshPeerExchange() {
+	now := time.Now()
+	if wn.peerExchange == nil || !wn.peerExchange.refreshDue(now) {
+		return
+	}
+	wn.updateExchangedRelays()
+	if wn.sharesRelays() {
+		wn.Broadcast(wn.ctx, protocol.PeerExchangeTag, wn.peerExchange.message(wn.GenesisID, wn.config.PublicAddress, now), false, nil)
+	}
+}
+
+// remoteHost returns the host of the peer's remote address, so that the relay announcements learned from the peer are
+// attributed to it across its connections.
+func (wp *wsPeer) remoteHost() string {
+	addr := wp.conn.RemoteAddr().String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// sendPeerExchange sends the peer the relays we know of.
+func (wp *wsPeer) sendPeerExchange() {
+	msg := wp.net.peerExchange.message(wp.net.GenesisID, wp.net.config.PublicAddress, time.Now())
+	err := wp.Unicast(wp.net.ctx, msg, protocol.PeerExchangeTag)
+	if err != nil {
+		wp.net.log.Debugf("wsPeer sendPeerExchange: unable to send the known relays to %s : %v", wp.GetAddress(), err)
+	}
+}
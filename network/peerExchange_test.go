@@ -0,0 +1,284 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func makeTestRelayAnnouncement(secrets *crypto.SignatureSecrets, address, genesisID string, timestamp time.Time) signedRelayAnnouncement {
+	announcement := relayAnnouncement{Address: address, GenesisID: genesisID, Timestamp: timestamp.Unix()}
+	return signedRelayAnnouncement{Announcement: announcement, Key: secrets.SignatureVerifier, Sig: secrets.Sign(announcement)}
+}
+
+func TestRelayAnnouncementVerify(t *testing.T) {
+	now := time.Now()
+	px := makePeerExchange(logging.TestingLog(t), "")
+	var msg peerExchangeMessage
+	require.NoError(t, protocol.DecodeReflect(px.message("test-v1", "relay.example.com:4160", now), &msg))
+	require.Len(t, msg.Relays, 1)
+	sa := msg.Relays[0]
+	require.Equal(t, "relay.example.com:4160", sa.Announcement.Address)
+	require.NoError(t, sa.verify("test-v1", now))
+	require.NoError(t, sa.verify("test-v1", now.Add(relayAnnouncementLifetime-time.Second)))
+
+	require.Equal(t, errRelayAnnouncementGenesis, sa.verify("test-v2", now))
+	require.Equal(t, errRelayAnnouncementExpired, sa.verify("test-v1", now.Add(relayAnnouncementLifetime+time.Second)))
+	require.Equal(t, errRelayAnnouncementExpired, sa.verify("test-v1", now.Add(-relayAnnouncementMaxClockSkew-2*time.Second)))
+
+	forged := sa
+	forged.Announcement.Address = "attacker.example.com:4160"
+	require.Equal(t, errRelayAnnouncementSignature, forged.verify("test-v1", now))
+
+	invalid := makeTestRelayAnnouncement(px.secrets, "://relay.example.com", "test-v1", now)
+	require.Error(t, invalid.verify("test-v1", now))
+}
+
+func TestPeerExchangeAdd(t *testing.T) {
+	now := time.Now()
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	trusted := crypto.GenerateSignatureSecrets(seed)
+	crypto.RandBytes(seed[:])
+	untrusted := crypto.GenerateSignatureSecrets(seed)
+	px := makePeerExchange(logging.TestingLog(t), "invalid;"+base64.StdEncoding.EncodeToString(trusted.SignatureVerifier[:]))
+	require.Len(t, px.trustedKeys, 1)
+
+	added, forged := px.add([]signedRelayAnnouncement{
+		makeTestRelayAnnouncement(trusted, "a.example.com:4160", "test-v1", now.Add(-time.Hour)),
+		makeTestRelayAnnouncement(untrusted, "b.example.com:4160", "test-v1", now.Add(-time.Hour)),
+		makeTestRelayAnnouncement(untrusted, "self.example.com:4160", "test-v1", now),
+		makeTestRelayAnnouncement(untrusted, "c.example.com:4160", "test-v2", now),
+	}, "peer", "test-v1", "self.example.com:4160", now)
+	require.Equal(t, 2, added)
+	require.False(t, forged)
+	trustedAddrs, pendingAddrs, exchangedAddrs := px.addresses(now)
+	require.Empty(t, trustedAddrs)
+	require.Equal(t, []string{"a.example.com:4160"}, pendingAddrs)
+	require.Equal(t, []string{"b.example.com:4160"}, exchangedAddrs)
+
+	// a relay announced with a trusted key is trusted once it proves it holds the key.
+	require.True(t, px.prove("a.example.com:4160", trusted.SignatureVerifier, now))
+	trustedAddrs, pendingAddrs, _ = px.addresses(now)
+	require.Equal(t, []string{"a.example.com:4160"}, trustedAddrs)
+	require.Empty(t, pendingAddrs)
+
+	// newer announcements replace the older ones unless the relay proved it holds another key, and forged ones are
+	// reported.
+	forgedAnnouncement := makeTestRelayAnnouncement(untrusted, "d.example.com:4160", "test-v1", now)
+	forgedAnnouncement.Sig[0]++
+	added, forged = px.add([]signedRelayAnnouncement{
+		makeTestRelayAnnouncement(untrusted, "a.example.com:4160", "test-v1", now),
+		makeTestRelayAnnouncement(trusted, "a.example.com:4160", "test-v1", now),
+		makeTestRelayAnnouncement(trusted, "b.example.com:4160", "test-v1", now.Add(-2*time.Hour)),
+		forgedAnnouncement,
+	}, "peer", "test-v1", "self.example.com:4160", now)
+	require.Equal(t, 1, added)
+	require.True(t, forged)
+	trustedAddrs, _, exchangedAddrs = px.addresses(now)
+	require.Equal(t, []string{"a.example.com:4160"}, trustedAddrs)
+	require.Equal(t, []string{"b.example.com:4160"}, exchangedAddrs)
+
+	// only the relays which proved they hold their keys are shared.
+	var msg peerExchangeMessage
+	require.NoError(t, protocol.DecodeReflect(px.message("test-v1", "", now), &msg))
+	require.Len(t, msg.Relays, 1)
+	require.Equal(t, "a.example.com:4160", msg.Relays[0].Announcement.Address)
+
+	// a relay which doesn't prove it holds the announced key is forgotten.
+	require.True(t, px.prove("b.example.com:4160", crypto.SignatureVerifier{}, now))
+	_, _, exchangedAddrs = px.addresses(now)
+	require.Empty(t, exchangedAddrs)
+	added, _ = px.add([]signedRelayAnnouncement{makeTestRelayAnnouncement(untrusted, "b.example.com:4160", "test-v1", now)}, "peer", "test-v1", "", now)
+	require.Equal(t, 0, added)
+	require.False(t, px.prove("e.example.com:4160", crypto.SignatureVerifier{}, now))
+
+	// expired announcements are forgotten, along with the proofs.
+	trustedAddrs, _, _ = px.addresses(now.Add(relayAnnouncementLifetime + time.Second))
+	require.Empty(t, trustedAddrs)
+	require.Empty(t, px.proven)
+}
+
+func TestRelayKeyProof(t *testing.T) {
+	conf := defaultConfig
+	conf.EnablePeerExchange = true
+	dialer := makeTestWebsocketNodeWithConfig(t, conf)
+	conf.PublicAddress = "relay.example.com:4160"
+	relay := makeTestWebsocketNodeWithConfig(t, conf)
+	now := time.Now()
+	dialer.peerExchange.add([]signedRelayAnnouncement{
+		makeTestRelayAnnouncement(relay.peerExchange.secrets, "relay.example.com:4160", dialer.GenesisID, now),
+		makeTestRelayAnnouncement(relay.peerExchange.secrets, "other.example.com:4160", dialer.GenesisID, now),
+	}, "peer", dialer.GenesisID, "", now)
+
+	prove := func(addr string) {
+		requestHeader := http.Header{}
+		challenge := dialer.setPeerExchangeChallenge(requestHeader)
+		responseHeader := http.Header{}
+		relay.setPeerExchangeProof(requestHeader, responseHeader)
+		dialer.checkPeerExchangeProof(addr, responseHeader, challenge)
+	}
+
+	// the relay proves it holds its key at its own address, but not at another one.
+	prove("relay.example.com:4160")
+	prove("other.example.com:4160")
+	require.Equal(t, relay.peerExchange.secrets.SignatureVerifier, dialer.peerExchange.proven["relay.example.com:4160"].key)
+	require.Equal(t, crypto.SignatureVerifier{}, dialer.peerExchange.proven["other.example.com:4160"].key)
+	_, _, exchanged := dialer.peerExchange.addresses(now)
+	require.Equal(t, []string{"relay.example.com:4160"}, exchanged)
+	require.Equal(t, []string{"relay.example.com:4160"}, dialer.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryExchangedRole))
+
+	// the nodes which don't announce themselves don't respond to the challenge.
+	requestHeader := http.Header{}
+	relay.setPeerExchangeChallenge(requestHeader)
+	responseHeader := http.Header{}
+	dialer.setPeerExchangeProof(requestHeader, responseHeader)
+	require.Empty(t, responseHeader)
+}
+
+func TestPeerExchangeLimits(t *testing.T) {
+	now := time.Now()
+	var seed crypto.Seed
+	crypto.RandBytes(seed[:])
+	trusted := crypto.GenerateSignatureSecrets(seed)
+	crypto.RandBytes(seed[:])
+	untrusted := crypto.GenerateSignatureSecrets(seed)
+	px := makePeerExchange(logging.TestingLog(t), base64.StdEncoding.EncodeToString(trusted.SignatureVerifier[:]))
+
+	announce := func(secrets *crypto.SignatureSecrets, source string, first, count int, timestamp time.Time) int {
+		var announcements []signedRelayAnnouncement
+		for i := first; i < first+count; i++ {
+			announcements = append(announcements, makeTestRelayAnnouncement(secrets, fmt.Sprintf("r%d.example.com:4160", i), "test-v1", timestamp))
+		}
+		added, forged := px.add(announcements, source, "test-v1", "", now)
+		require.False(t, forged)
+		return added
+	}
+
+	// a single peer can't add more than maxExchangedRelaysPerSource announcements.
+	require.Equal(t, maxExchangedRelaysPerSource, announce(untrusted, "peer0", 0, maxExchangedRelaysPerSource+1, now.Add(-time.Hour)))
+	require.Equal(t, 0, announce(untrusted, "peer0", maxExchangedRelaysPerSource+1, 1, now))
+	// but it can refresh the announcements it added.
+	require.Equal(t, 1, announce(untrusted, "peer0", 0, 1, now.Add(-time.Minute)))
+
+	// once the node keeps track of maxExchangedRelays announcements, the oldest ones are evicted for newer ones.
+	sources := maxExchangedRelays / maxExchangedRelaysPerSource
+	for i := 1; i < sources; i++ {
+		require.Equal(t, maxExchangedRelaysPerSource, announce(untrusted, fmt.Sprintf("peer%d", i), i*maxExchangedRelaysPerSource, maxExchangedRelaysPerSource, now.Add(-time.Hour)))
+	}
+	require.Len(t, px.relays, maxExchangedRelays)
+	require.Equal(t, 0, announce(untrusted, "other", maxExchangedRelays, 1, now.Add(-2*time.Hour)))
+	require.Equal(t, 1, announce(untrusted, "other", maxExchangedRelays, 1, now))
+	require.Len(t, px.relays, maxExchangedRelays)
+	require.Contains(t, px.relays, "r0.example.com:4160")
+
+	// trusted announcements evict untrusted ones even if they're older, but not the other way around.
+	require.Equal(t, 1, announce(trusted, "other", maxExchangedRelays+1, 1, now.Add(-2*time.Hour)))
+	require.Len(t, px.relays, maxExchangedRelays)
+
+	// announcements from the future are kept as if they were made now, so that they expire like the other ones.
+	px = makePeerExchange(logging.TestingLog(t), "")
+	require.Equal(t, 1, announce(untrusted, "peer", 0, 1, now.Add(relayAnnouncementMaxClockSkew/2)))
+	_, _, exchanged := px.addresses(now.Add(relayAnnouncementLifetime + time.Second))
+	require.Empty(t, exchanged)
+}
+
+func TestLoadPeerExchangeKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerexchange")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, PeerExchangeKeyFilename)
+
+	wn := makeTestWebsocketNode(t)
+	require.NoError(t, wn.LoadPeerExchangeKey(filename))
+	require.Equal(t, "", wn.PeerExchangePublicKey())
+
+	conf := defaultConfig
+	conf.EnablePeerExchange = true
+	wn = makeTestWebsocketNodeWithConfig(t, conf)
+	ephemeralKey := wn.PeerExchangePublicKey()
+	require.NoError(t, wn.LoadPeerExchangeKey(filename))
+	key := wn.PeerExchangePublicKey()
+	require.NotEqual(t, ephemeralKey, key)
+
+	// the key is kept across restarts.
+	wn = makeTestWebsocketNodeWithConfig(t, conf)
+	require.NoError(t, wn.LoadPeerExchangeKey(filename))
+	require.Equal(t, key, wn.PeerExchangePublicKey())
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("short"), 0600))
+	require.Error(t, wn.LoadPeerExchangeKey(filename))
+}
+
+// Set up three relays, A connecting to C and B connecting to A, and test that B learns about C from A.
+func TestWebsocketNetworkPeerExchange(t *testing.T) {
+	conf := defaultConfig
+	conf.EnablePeerExchange = true
+	conf.GossipFanout = 1
+
+	netC := makeTestWebsocketNodeWithConfig(t, conf)
+	netC.Start()
+	defer func() { t.Log("stopping C"); netC.Stop(); t.Log("C done") }()
+	addrC, postListen := netC.Address()
+	require.True(t, postListen)
+	netC.config.PublicAddress = addrC
+
+	netA := makeTestWebsocketNodeWithConfig(t, conf)
+	netA.phonebook.ReplacePeerList([]string{addrC}, "default", PhoneBookEntryRelayRole)
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	// A learns about C from C itself, which proved it holds its key when A connected to it; since C is already a relay in
+	// A's phonebook, it isn't demoted.
+	require.Eventually(t, func() bool {
+		_, _, exchanged := netA.peerExchange.addresses(time.Now())
+		return len(exchanged) == 1 && exchanged[0] == addrC
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{addrC}, netA.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryRelayRole))
+	require.Empty(t, netA.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryExchangedRole))
+
+	// B trusts C, so it gets C as a relay once it connects to C, and C proves it holds its key.
+	confB := conf
+	confB.NetAddress = ""
+	confB.GossipFanout = 2
+	confB.PeerExchangeTrustedKeys = netC.PeerExchangePublicKey()
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	require.Eventually(t, func() bool {
+		return len(netB.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryRelayRole)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []string{addrA, addrC}, netB.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryRelayRole))
+	require.Empty(t, netB.phonebook.GetAddresses(getAllAddresses, PhoneBookEntryExchangedRole))
+}
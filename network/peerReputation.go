@@ -0,0 +1,413 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// PeerBanListFilename is the name of the file, in the genesis directory, where the peer bans are persisted.
+const PeerBanListFilename = "peerbans.json"
+
+// PeerOffense is a kind of misbehavior which lowers the reputation of the peer committing it.
+type PeerOffense int
+
+const (
+	// PeerOffenseInvalidMessage is sending a message which failed validation.
+	PeerOffenseInvalidMessage PeerOffense = iota
+	// PeerOffenseSlowWriting is being too slow to read the messages we send, to the point of being disconnected.
+	PeerOffenseSlowWriting
+	// PeerOffenseBadBlock is serving an invalid block or catchpoint file to the catchup service.
+	PeerOffenseBadBlock
+	// PeerOffenseDuplicateFlood is sending mostly messages which were already received.
+	PeerOffenseDuplicateFlood
+)
+
+// String returns the name of the offense, as used for logging and metrics.
+func (o PeerOffense) String() string {
+	switch o {
+	case PeerOffenseInvalidMessage:
+		return "InvalidMessage"
+	case PeerOffenseSlowWriting:
+		return "SlowWriting"
+	case PeerOffenseBadBlock:
+		return "BadBlock"
+	case PeerOffenseDuplicateFlood:
+		return "DuplicateFlood"
+	default:
+		return "Unknown"
+	}
+}
+
+// peerOffensePenalty is the score added to the peer for each of the offenses. Once a peer score reaches the
+// PeerBanThreshold, the peer gets banned.
+var peerOffensePenalty = map[PeerOffense]float64{
+	PeerOffenseInvalidMessage: 50,
+	PeerOffenseSlowWriting:    20,
+	PeerOffenseBadBlock:       50,
+	PeerOffenseDuplicateFlood: 20,
+}
+
+// peerScoreHalfLife is the time it takes a peer score to decay to half of its value.
+const peerScoreHalfLife = 30 * time.Minute
+
+// maxTrackedPeerScores is the number of scored hosts above which the hosts with negligible scores are forgotten.
+const maxTrackedPeerScores = 10000
+
+// duplicate flood detection: a peer which sent at least duplicateFloodMinMessages messages within duplicateFloodWindow,
+// out of which at least duplicateFloodRatio were duplicates, is flooding us.
+const duplicateFloodWindow = 10 * time.Second
+const duplicateFloodMinMessages = 1000
+const duplicateFloodRatio = 0.99
+
+var networkPeerOffensesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peer_offenses_total", Description: "number of offenses reported against peers, by offense"})
+var networkPeersBannedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_peers_banned_total", Description: "number of peers banned"})
+var networkBannedConnectionsTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_banned_connections_total", Description: "number of connections to or from banned peers which were refused"})
+
+// PeerReporter is implemented by the networks which keep track of the reputation of their peers.
+type PeerReporter interface {
+	// ReportPeer lowers the reputation of the peer due to the given offense.
+	ReportPeer(peer Peer, offense PeerOffense)
+}
+
+// PeerBan describes a banned peer host.
+type PeerBan struct {
+	// Host is the IP address or the host name of the banned peer.
+	Host string `json:"host"`
+	// Reason is the offense the peer was banned for, or the reason given when banned manually.
+	Reason string `json:"reason"`
+	// Until is the time at which the ban expires.
+	Until time.Time `json:"until"`
+}
+
+// peerScore is the reputation score of a single host. The higher the score is, the worse the host behaved.
+type peerScore struct {
+	score   float64
+	updated time.Time
+	// bans is the number of times the host was banned, which is used to extend the duration of repeated bans.
+	bans uint
+}
+
+// decayedScore returns the score of the host at the given time.
+func (s *peerScore) decayedScore(now time.Time) float64 {
+	elapsed := now.Sub(s.updated)
+	if elapsed <= 0 {
+		return s.score
+	}
+	return s.score * math.Exp2(-float64(elapsed)/float64(peerScoreHalfLife))
+}
+
+// peerReputation keeps the reputation scores of the peer hosts, and the bans of the hosts whose score got too high.
+type peerReputation struct {
+	mu  deadlock.Mutex
+	log logging.Logger
+
+	// enableBans determines whether hosts are banned automatically once their score reaches banThreshold.
+	enableBans     bool
+	banThreshold   float64
+	banDuration    time.Duration
+	maxBanDuration time.Duration
+
+	scores map[string]*peerScore
+	bans   map[string]PeerBan
+
+	// banListFile is the file the bans are persisted into; the bans are kept only in memory if it's empty.
+	banListFile string
+}
+
+func makePeerReputation(log logging.Logger, enableBans bool, banThreshold uint64, banDuration, maxBanDuration time.Duration) *peerReputation {
+	return &peerReputation{
+		log:            log,
+		enableBans:     enableBans,
+		banThreshold:   float64(banThreshold),
+		banDuration:    banDuration,
+		maxBanDuration: maxBanDuration,
+		scores:         make(map[string]*peerScore),
+		bans:           make(map[string]PeerBan),
+	}
+}
+
+// report adds the penalty of the offense to the score of the host, banning it if its score got too high.
+// It returns true if the host was banned.
+func (r *peerReputation) report(host string, offense PeerOffense, now time.Time) bool {
+	if host == "" {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, has := r.scores[host]
+	if !has {
+		if len(r.scores) >= maxTrackedPeerScores {
+			r.pruneScores(now)
+		}
+		s = &peerScore{}
+		r.scores[host] = s
+	}
+	s.score = s.decayedScore(now) + peerOffensePenalty[offense]
+	s.updated = now
+	if !r.enableBans || s.score < r.banThreshold {
+		return false
+	}
+	if ban, banned := r.bans[host]; banned && now.Before(ban.Until) {
+		return false
+	}
+
+	// every repeated ban doubles the ban duration, up to maxBanDuration.
+	duration := r.banDuration
+	for i := uint(0); i < s.bans && duration < r.maxBanDuration; i++ {
+		duration *= 2
+	}
+	if duration > r.maxBanDuration {
+		duration = r.maxBanDuration
+	}
+	s.bans++
+	s.score = 0
+	r.bans[host] = PeerBan{Host: host, Reason: offense.String(), Until: now.Add(duration)}
+	r.log.Infof("peerReputation: banning %s for %v due to %s", host, duration, offense)
+	r.saveLocked()
+	return true
+}
+
+// pruneScores forgets the hosts whose scores decayed to a negligible value.
+func (r *peerReputation) pruneScores(now time.Time) {
+	for host, s := range r.scores {
+		if s.decayedScore(now) < 1 && s.bans == 0 {
+			delete(r.scores, host)
+		}
+	}
+}
+
+// ban bans the host until the given time.
+func (r *peerReputation) ban(host string, reason string, until time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bans[host] = PeerBan{Host: host, Reason: reason, Until: until}
+	r.saveLocked()
+}
+
+// unban lifts the ban of the host, and resets its score. It returns false if the host wasn't banned.
+func (r *peerReputation) unban(host string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, banned := r.bans[host]; !banned {
+		return false
+	}
+	delete(r.bans, host)
+	delete(r.scores, host)
+	r.saveLocked()
+	return true
+}
+
+// isBanned returns true if the host is banned at the given time.
+func (r *peerReputation) isBanned(host string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ban, banned := r.bans[host]
+	if !banned {
+		return false
+	}
+	if now.Before(ban.Until) {
+		return true
+	}
+	// the ban has expired; it would be removed from the persisted list the next time it's saved.
+	delete(r.bans, host)
+	return false
+}
+
+// activeBans returns the bans which are in effect at the given time, sorted by host.
+func (r *peerReputation) activeBans(now time.Time) []PeerBan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	bans := make([]PeerBan, 0, len(r.bans))
+	for host, ban := range r.bans {
+		if !now.Before(ban.Until) {
+			delete(r.bans, host)
+			continue
+		}
+		bans = append(bans, ban)
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Host < bans[j].Host })
+	return bans
+}
+
+// load loads the persisted bans from the given file, and keeps persisting the bans into it from now on.
+// A missing file is not an error.
+func (r *peerReputation) load(filename string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.banListFile = filename
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var bans []PeerBan
+	err = json.Unmarshal(data, &bans)
+	if err != nil {
+		return err
+	}
+	for _, ban := range bans {
+		if ban.Host != "" && now.Before(ban.Until) {
+			r.bans[ban.Host] = ban
+		}
+	}
+	return nil
+}
+
+// saveLocked persists the bans into the ban list file. The caller must hold the mutex.
+func (r *peerReputation) saveLocked() {
+	if r.banListFile == "" {
+		return
+	}
+	bans := make([]PeerBan, 0, len(r.bans))
+	for _, ban := range r.bans {
+		bans = append(bans, ban)
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Host < bans[j].Host })
+	data, err := json.MarshalIndent(bans, "", "\t")
+	if err != nil {
+		r.log.Warnf("peerReputation: unable to encode the ban list : %v", err)
+		return
+	}
+	// write to a temporary file first, so that a crash won't leave a truncated ban list behind.
+	tempFile := r.banListFile + ".tmp"
+	err = ioutil.WriteFile(tempFile, data, 0600)
+	if err == nil {
+		err = os.Rename(tempFile, r.banListFile)
+	}
+	if err != nil {
+		r.log.Warnf("peerReputation: unable to save the ban list to %s : %v", r.banListFile, err)
+	}
+}
+
+// peerHost returns the host by which the reputation of the peer is tracked: the remote address of an incoming
+// connection, and the host of the address we've connected to otherwise.
+func peerHost(peer Peer) string {
+	switch p := peer.(type) {
+	case *wsPeer:
+		if !p.outgoing && p.OriginAddress() != "" {
+			return p.OriginAddress()
+		}
+		return addressHost(p.GetAddress())
+	case HTTPPeer:
+		return addressHost(p.GetAddress())
+	}
+	return ""
+}
+
+// addressHost returns the host part of a phonebook address, which might be either a URL or a host:port pair.
+func addressHost(addr string) string {
+	parsedURL, err := ParseHostOrURL(addr)
+	if err != nil {
+		return ""
+	}
+	return parsedURL.Hostname()
+}
+
+// ReportPeer lowers the reputation of the peer due to the given offense. Peers which repeatedly misbehave are banned
+// and disconnected, if banning is enabled.
+func (wn *WebsocketNetwork) ReportPeer(peer Peer, offense PeerOffense) {
+	networkPeerOffensesTotal.Inc(map[string]string{"offense": offense.String()})
+	host := peerHost(peer)
+	if !wn.reputation.report(host, offense, time.Now()) {
+		return
+	}
+	networkPeersBannedTotal.Inc(nil)
+	// the peers are disconnected asynchronously, as the offense might have been reported while holding the peers lock.
+	wn.wg.Add(1)
+	go wn.disconnectBannedHost(host)
+}
+
+// BanPeerHost bans the given host, which is either an IP address or a host name, for the given duration,
+// disconnecting the peers connected from or to it.
+func (wn *WebsocketNetwork) BanPeerHost(host string, reason string, duration time.Duration) {
+	wn.reputation.ban(host, reason, time.Now().Add(duration))
+	networkPeersBannedTotal.Inc(nil)
+	wn.wg.Add(1)
+	go wn.disconnectBannedHost(host)
+}
+
+// UnbanPeerHost lifts the ban of the given host. It returns false if the host wasn't banned.
+func (wn *WebsocketNetwork) UnbanPeerHost(host string) bool {
+	return wn.reputation.unban(host)
+}
+
+// PeerBans returns the bans currently in effect.
+func (wn *WebsocketNetwork) PeerBans() []PeerBan {
+	return wn.reputation.activeBans(time.Now())
+}
+
+// LoadPeerBans loads the bans persisted in the given file, and keeps persisting the bans into it.
+func (wn *WebsocketNetwork) LoadPeerBans(filename string) error {
+	return wn.reputation.load(filename, time.Now())
+}
+
+// isBannedAddress returns true if the host of the given phonebook address is banned.
+func (wn *WebsocketNetwork) isBannedAddress(addr string) bool {
+	return wn.reputation.isBanned(addressHost(addr), time.Now())
+}
+
+// disconnectBannedHost disconnects all the peers connected from or to the banned host.
+func (wn *WebsocketNetwork) disconnectBannedHost(host string) {
+	defer wn.wg.Done()
+	var banned []*wsPeer
+	wn.peersLock.RLock()
+	for _, peer := range wn.peers {
+		if peerHost(peer) == host {
+			banned = append(banned, peer)
+		}
+	}
+	wn.peersLock.RUnlock()
+	for _, peer := range banned {
+		wn.disconnect(peer, disconnectBanned)
+	}
+}
+
+// checkDuplicateFlood updates the duplicate messages statistics of the peer, returning true if the peer is flooding
+// us with duplicates. It's called only from the read loop.
+func (wp *wsPeer) checkDuplicateFlood(duplicate bool, now time.Time) bool {
+	if now.Sub(wp.floodWindowStart) > duplicateFloodWindow {
+		wp.floodWindowStart = now
+		wp.floodWindowMessages = 0
+		wp.floodWindowDuplicates = 0
+	}
+	wp.floodWindowMessages++
+	if duplicate {
+		wp.floodWindowDuplicates++
+	}
+	if wp.floodWindowMessages < duplicateFloodMinMessages ||
+		float64(wp.floodWindowDuplicates) < duplicateFloodRatio*float64(wp.floodWindowMessages) {
+		return false
+	}
+	// start a new window, so that the offense would be reported at most once per window.
+	wp.floodWindowStart = now
+	wp.floodWindowMessages = 0
+	wp.floodWindowDuplicates = 0
+	return true
+}
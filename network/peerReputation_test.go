@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+)
+
+func TestPeerReputationBans(t *testing.T) {
+	now := time.Now()
+	r := makePeerReputation(logging.TestingLog(t), true, 100, time.Hour, 3*time.Hour)
+
+	require.False(t, r.report("1.2.3.4", PeerOffenseInvalidMessage, now))
+	require.False(t, r.report("1.2.3.4", PeerOffenseSlowWriting, now))
+	require.False(t, r.isBanned("1.2.3.4", now))
+	require.True(t, r.report("1.2.3.4", PeerOffenseBadBlock, now))
+	require.True(t, r.isBanned("1.2.3.4", now))
+	require.False(t, r.isBanned("1.2.3.5", now))
+	require.Equal(t, []PeerBan{{Host: "1.2.3.4", Reason: "BadBlock", Until: now.Add(time.Hour)}}, r.activeBans(now))
+	require.False(t, r.isBanned("1.2.3.4", now.Add(time.Hour)))
+	require.Empty(t, r.activeBans(now.Add(time.Hour)))
+
+	// repeated bans last longer, up to the maximal ban duration.
+	now = now.Add(time.Hour)
+	for _, duration := range []time.Duration{2 * time.Hour, 3 * time.Hour, 3 * time.Hour} {
+		r.report("1.2.3.4", PeerOffenseInvalidMessage, now)
+		require.True(t, r.report("1.2.3.4", PeerOffenseInvalidMessage, now))
+		require.Equal(t, now.Add(duration), r.activeBans(now)[0].Until)
+		now = now.Add(duration)
+	}
+
+	// the score decays over time.
+	r.report("1.2.3.5", PeerOffenseInvalidMessage, now)
+	require.False(t, r.report("1.2.3.5", PeerOffenseInvalidMessage, now.Add(peerScoreHalfLife)))
+	require.True(t, r.report("1.2.3.5", PeerOffenseInvalidMessage, now.Add(peerScoreHalfLife)))
+
+	require.True(t, r.unban("1.2.3.5"))
+	require.False(t, r.unban("1.2.3.5"))
+	require.False(t, r.isBanned("1.2.3.5", now))
+
+	// hosts are scored but never banned automatically when bans are disabled; manual bans still apply.
+	r = makePeerReputation(logging.TestingLog(t), false, 100, time.Hour, 3*time.Hour)
+	for i := 0; i < 10; i++ {
+		require.False(t, r.report("1.2.3.4", PeerOffenseInvalidMessage, now))
+	}
+	require.False(t, r.isBanned("1.2.3.4", now))
+	r.ban("1.2.3.4", "manual", now.Add(time.Minute))
+	require.True(t, r.isBanned("1.2.3.4", now))
+}
+
+func TestPeerReputationPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerbans")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, PeerBanListFilename)
+
+	now := time.Now()
+	r := makePeerReputation(logging.TestingLog(t), true, 100, time.Hour, 3*time.Hour)
+	require.NoError(t, r.load(filename, now))
+	r.ban("1.2.3.4", "manual", now.Add(time.Minute))
+	r.ban("relay.example.com", "manual", now.Add(time.Hour))
+	r.ban("1.2.3.5", "manual", now.Add(time.Hour))
+	require.True(t, r.unban("1.2.3.5"))
+
+	r = makePeerReputation(logging.TestingLog(t), true, 100, time.Hour, 3*time.Hour)
+	require.NoError(t, r.load(filename, now.Add(2*time.Minute)))
+	bans := r.activeBans(now.Add(2 * time.Minute))
+	require.Len(t, bans, 1)
+	require.Equal(t, "relay.example.com", bans[0].Host)
+	require.True(t, bans[0].Until.Equal(now.Add(time.Hour)))
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("not json"), 0600))
+	require.Error(t, r.load(filename, now))
+}
+
+func TestPeerHost(t *testing.T) {
+	require.Equal(t, "relay.example.com", addressHost("relay.example.com:4160"))
+	require.Equal(t, "1.2.3.4", addressHost("http://1.2.3.4:4160/"))
+
+	wn := makeTestWebsocketNode(t)
+	incoming := &wsPeer{wsPeerCore: makePeerCore(wn, "http://5.6.7.8:4160", nil, "1.2.3.4")}
+	require.Equal(t, "1.2.3.4", peerHost(incoming))
+	outgoing := &wsPeer{wsPeerCore: makePeerCore(wn, "5.6.7.8:4160", nil, ""), outgoing: true}
+	require.Equal(t, "5.6.7.8", peerHost(outgoing))
+	httpPeer := makePeerCore(wn, "relay.example.com:4160", nil, "")
+	require.Equal(t, "relay.example.com", peerHost(&httpPeer))
+}
+
+func TestDuplicateFloodDetection(t *testing.T) {
+	wp := &wsPeer{}
+	now := time.Now()
+	// a peer sending a few new messages among its duplicates isn't flooding.
+	for i := 0; i < 2*duplicateFloodMinMessages; i++ {
+		require.False(t, wp.checkDuplicateFlood(i%50 != 0, now))
+	}
+	// neither is a peer sending duplicates slowly.
+	for i := 0; i < duplicateFloodMinMessages; i++ {
+		require.False(t, wp.checkDuplicateFlood(true, now.Add(time.Duration(i)*duplicateFloodWindow/100)))
+	}
+	now = now.Add(2 * duplicateFloodWindow)
+	flooding := 0
+	for i := 0; i < 2*duplicateFloodMinMessages; i++ {
+		if wp.checkDuplicateFlood(true, now) {
+			flooding++
+		}
+	}
+	require.Equal(t, 2, flooding)
+}
+
+// Set up two nodes, B connecting to A, and test that once A bans B, B is disconnected and can't reconnect.
+func TestWebsocketNetworkBanPeer(t *testing.T) {
+	confA := defaultConfig
+	confA.EnablePeerBanning = true
+	netA := makeTestWebsocketNodeWithConfig(t, confA)
+	netA.config.GossipFanout = 1
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+	reconnected := func() bool {
+		netB.RequestConnectOutgoing(false, nil)
+		return len(netA.GetPeers(PeersConnectedIn)) == 1
+	}
+
+	peers := netA.GetPeers(PeersConnectedIn)
+	require.Len(t, peers, 1)
+	netA.ReportPeer(peers[0], PeerOffenseSlowWriting)
+	require.Empty(t, netA.PeerBans())
+	netA.Disconnect(peers[0])
+	// B reconnects, since its score is still below the ban threshold.
+	require.Eventually(t, reconnected, 5*time.Second, 10*time.Millisecond)
+
+	netA.Disconnect(netA.GetPeers(PeersConnectedIn)[0])
+	bans := netA.PeerBans()
+	require.Len(t, bans, 1)
+	require.Equal(t, "127.0.0.1", bans[0].Host)
+	require.Equal(t, PeerOffenseInvalidMessage.String(), bans[0].Reason)
+
+	// B keeps trying to reconnect, and keeps being refused.
+	require.Never(t, reconnected, 500*time.Millisecond, 10*time.Millisecond)
+
+	require.True(t, netA.UnbanPeerHost("127.0.0.1"))
+	require.Eventually(t, reconnected, 5*time.Second, 10*time.Millisecond)
+
+	// a manual ban disconnects the peer right away.
+	netA.BanPeerHost("127.0.0.1", "manual", time.Hour)
+	require.Eventually(t, func() bool { return len(netA.GetPeers(PeersConnectedIn)) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"sort"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// PeerStats is a snapshot of the state of a connected peer, for diagnostics.
+type PeerStats struct {
+	// Address is the address of the peer; for incoming connections, this is the remote host of the connection.
+	Address string
+	// Outgoing is true if we've connected to the peer, and false if the peer connected to us.
+	Outgoing bool
+	// Version is the network protocol version negotiated with the peer.
+	Version string
+	// InstanceName is the instance name the peer reported, if any.
+	InstanceName string
+	// ConnectedSince is the time the connection was established.
+	ConnectedSince time.Time
+	// PingRoundTripTime is the round trip time of the last ping answered by the peer, or zero if none was.
+	PingRoundTripTime time.Duration
+	// MessagesIn and MessagesOut are the number of messages received from and sent to the peer, by tag.
+	MessagesIn  map[protocol.Tag]uint64
+	MessagesOut map[protocol.Tag]uint64
+	// BytesIn and BytesOut are the number of message bytes received from and sent to the peer.
+	BytesIn  uint64
+	BytesOut uint64
+	// HighPriorityQueueLength and BulkQueueLength are the number of messages waiting to be sent to the peer.
+	HighPriorityQueueLength int
+	BulkQueueLength         int
+}
+
+// peerTrafficStats counts the messages and bytes exchanged with a peer. It's updated by the read and write loops of
+// the peer, and read when taking a PeerStats snapshot.
+type peerTrafficStats struct {
+	mu          deadlock.Mutex
+	messagesIn  map[protocol.Tag]uint64
+	messagesOut map[protocol.Tag]uint64
+	bytesIn     uint64
+	bytesOut    uint64
+}
+
+func (s *peerTrafficStats) received(tag protocol.Tag, length int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.messagesIn == nil {
+		s.messagesIn = make(map[protocol.Tag]uint64)
+	}
+	s.messagesIn[tag]++
+	s.bytesIn += uint64(length)
+}
+
+func (s *peerTrafficStats) sent(tag protocol.Tag, length int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.messagesOut == nil {
+		s.messagesOut = make(map[protocol.Tag]uint64)
+	}
+	s.messagesOut[tag]++
+	s.bytesOut += uint64(length)
+}
+
+// snapshot fills the traffic counters of the given PeerStats.
+func (s *peerTrafficStats) snapshot(stats *PeerStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats.MessagesIn = make(map[protocol.Tag]uint64, len(s.messagesIn))
+	for tag, count := range s.messagesIn {
+		stats.MessagesIn[tag] = count
+	}
+	stats.MessagesOut = make(map[protocol.Tag]uint64, len(s.messagesOut))
+	for tag, count := range s.messagesOut {
+		stats.MessagesOut[tag] = count
+	}
+	stats.BytesIn = s.bytesIn
+	stats.BytesOut = s.bytesOut
+}
+
+// stats returns a snapshot of the state of the peer.
+func (wp *wsPeer) stats() PeerStats {
+	_, pingRoundTripTime := wp.pingTimes()
+	address := wp.GetAddress()
+	if !wp.outgoing {
+		// the incoming peers report their own address, which isn't necessarily the one they've connected from.
+		address = wp.OriginAddress()
+	}
+	stats := PeerStats{
+		Address:                 address,
+		Outgoing:                wp.outgoing,
+		Version:                 wp.version,
+		InstanceName:            wp.InstanceName,
+		ConnectedSince:          wp.createTime,
+		PingRoundTripTime:       pingRoundTripTime,
+		HighPriorityQueueLength: len(wp.sendBufferHighPrio),
+		BulkQueueLength:         len(wp.sendBufferBulk),
+	}
+	wp.traffic.snapshot(&stats)
+	return stats
+}
+
+// PeerStats returns a snapshot of the state of all the connected peers, the outgoing ones first.
+func (wn *WebsocketNetwork) PeerStats() []PeerStats {
+	wn.peersLock.RLock()
+	stats := make([]PeerStats, 0, len(wn.peers))
+	for _, peer := range wn.peers {
+		stats = append(stats, peer.stats())
+	}
+	wn.peersLock.RUnlock()
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Outgoing != stats[j].Outgoing {
+			return stats[i].Outgoing
+		}
+		return stats[i].Address < stats[j].Address
+	})
+	return stats
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// Set up two nodes, B connecting to A, and test that the traffic A sends to B is accounted for on both sides.
+func TestWebsocketNetworkPeerStats(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	netB := makeTestWebsocketNode(t)
+	netB.config.GossipFanout = 1
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	received := make(chan struct{}, 3)
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+		received <- struct{}{}
+		return OutgoingMessage{}
+	})}})
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+
+	for i := 0; i < 3; i++ {
+		netA.Broadcast(context.Background(), protocol.TxnTag, []byte("foo"), true, nil)
+		select {
+		case <-received:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout, received %d messages", i)
+		}
+	}
+
+	statsA := netA.PeerStats()
+	require.Len(t, statsA, 1)
+	require.False(t, statsA[0].Outgoing)
+	require.Equal(t, "127.0.0.1", statsA[0].Address)
+	require.Equal(t, uint64(3), statsA[0].MessagesOut[protocol.TxnTag])
+	require.GreaterOrEqual(t, statsA[0].BytesOut, uint64(3*(len(protocol.TxnTag)+len("foo"))))
+	require.False(t, statsA[0].ConnectedSince.IsZero())
+
+	statsB := netB.PeerStats()
+	require.Len(t, statsB, 1)
+	require.True(t, statsB[0].Outgoing)
+	require.Equal(t, addrA, statsB[0].Address)
+	require.Equal(t, statsA[0].Version, statsB[0].Version)
+	require.Equal(t, uint64(3), statsB[0].MessagesIn[protocol.TxnTag])
+	require.Equal(t, statsA[0].MessagesOut[protocol.TxnTag], statsB[0].MessagesIn[protocol.TxnTag])
+}
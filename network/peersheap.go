@@ -16,6 +16,12 @@
 
 package network
 
+import (
+	"encoding/base64"
+
+	"github.com/algorand/go-algorand/crypto"
+)
+
 type peersHeap struct {
 	wn *WebsocketNetwork
 }
@@ -79,6 +85,10 @@ func checkPrioPeers(wn *WebsocketNetwork, wp *wsPeer) bool {
 		return false
 	}
 
+	if (wp.identity != crypto.SignatureVerifier{}) && pp[base64.StdEncoding.EncodeToString(wp.identity[:])] {
+		return true
+	}
+
 	addr := wp.OriginAddress()
 	if addr == "" {
 		return false
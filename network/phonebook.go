@@ -29,7 +29,7 @@ import (
 const getAllAddresses = math.MaxInt32
 
 // PhoneBookEntryRoles defines the roles that a single entry on the phonebook can take.
-// currently, we have two roles : relay role and archiver role, which are mutually exclusive.
+// currently, we have three roles : relay role, archiver role and exchanged role, which are mutually exclusive.
 type PhoneBookEntryRoles int
 
 // PhoneBookEntryRelayRole used for all the relays that are provided either via the algobootstrap SRV record
@@ -39,6 +39,10 @@ const PhoneBookEntryRelayRole = 1
 // PhoneBookEntryArchiverRole used for all the archivers that are provided via the archive SRV record.
 const PhoneBookEntryArchiverRole = 2
 
+// PhoneBookEntryExchangedRole used for the relays learned through the peer exchange, which are trusted less than the
+// relays provided via the SRV record or a configuration file.
+const PhoneBookEntryExchangedRole = 3
+
 // Phonebook stores or looks up addresses of nodes we might contact
 type Phonebook interface {
 	// GetAddresses(N) returns up to N addresses, but may return fewer
@@ -67,6 +71,13 @@ type Phonebook interface {
 
 	// ExtendPeerList adds unique addresses to this set of addresses
 	ExtendPeerList(more []string, networkName string, role PhoneBookEntryRoles)
+
+	// UpdateTransports records the gossip transports the peer at the given address reported it has enabled
+	UpdateTransports(addr string, transports []string)
+
+	// GetTransports returns the gossip transports the peer at the given address reported it has enabled,
+	// or nil if it hasn't reported them yet
+	GetTransports(addr string) []string
 }
 
 // addressData: holds the information associated with each phonebook address.
@@ -83,6 +94,9 @@ type addressData struct {
 
 	// role is the role that this address serves.
 	role PhoneBookEntryRoles
+
+	// transports: lists the gossip transports the peer reported it has enabled, in its order of preference.
+	transports []string
 }
 
 // makePhonebookEntryData creates a new addressData entry for provided network name and role.
@@ -172,6 +186,11 @@ func (e *phonebookImpl) ReplacePeerList(addressesThey []string, networkName stri
 			// we already have this.
 			// Update the networkName
 			pbData.networkNames[networkName] = true
+			if role == PhoneBookEntryRelayRole && pbData.role == PhoneBookEntryExchangedRole {
+				// a relay learned through the peer exchange was confirmed by a trusted source.
+				pbData.role = role
+				e.data[addr] = pbData
+			}
 
 			// do not remove this entry
 			delete(removeItems, addr)
@@ -201,6 +220,27 @@ func (e *phonebookImpl) UpdateRetryAfter(addr string, retryAfter time.Time) {
 	e.data[addr] = entry
 }
 
+// UpdateTransports records the gossip transports the peer at the given address reported it has enabled
+func (e *phonebookImpl) UpdateTransports(addr string, transports []string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	entry, found := e.data[addr]
+	if !found {
+		return
+	}
+	entry.transports = append([]string(nil), transports...)
+	e.data[addr] = entry
+}
+
+// GetTransports returns the gossip transports the peer at the given address reported it has enabled,
+// or nil if it hasn't reported them yet
+func (e *phonebookImpl) GetTransports(addr string) []string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.data[addr].transports
+}
+
 // GetConnectionWaitTime will calculate and return the wait
 // time to prevent exceeding connectionsRateLimitingCount.
 // The connection should be established when the waitTime is 0.
@@ -393,3 +393,18 @@ func TestPhonebookRoles(t *testing.T) {
 		}
 	}
 }
+
+func TestPhonebookExchangedRoleUpgrade(t *testing.T) {
+	ph := MakePhonebook(1, 1).(*phonebookImpl)
+	ph.ReplacePeerList([]string{"a:4160", "b:4160"}, "peerexchange", PhoneBookEntryExchangedRole)
+	require.ElementsMatch(t, []string{"a:4160", "b:4160"}, ph.GetAddresses(getAllAddresses, PhoneBookEntryExchangedRole))
+
+	// an exchanged relay listed by a trusted source becomes a relay.
+	ph.ReplacePeerList([]string{"a:4160"}, "default", PhoneBookEntryRelayRole)
+	require.Equal(t, []string{"a:4160"}, ph.GetAddresses(getAllAddresses, PhoneBookEntryRelayRole))
+	require.Equal(t, []string{"b:4160"}, ph.GetAddresses(getAllAddresses, PhoneBookEntryExchangedRole))
+
+	// but relays aren't demoted.
+	ph.ReplacePeerList([]string{"a:4160", "b:4160"}, "peerexchange", PhoneBookEntryExchangedRole)
+	require.Equal(t, []string{"a:4160"}, ph.GetAddresses(getAllAddresses, PhoneBookEntryRelayRole))
+}
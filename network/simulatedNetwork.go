@@ -0,0 +1,815 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build simulated
+
+package network
+
+import (
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+	"github.com/gorilla/mux"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// simulatedPeerQueueLength is the number of messages which can be on their way over a simulated connection. Messages
+// sent beyond that are dropped, as they're dropped by a wsPeer whose send buffer is full.
+const simulatedPeerQueueLength = 1000
+
+// simulatedReadBufferLength is the number of received messages which can wait to be handled by a simulated node.
+const simulatedReadBufferLength = 1000
+
+var errSimulatedNodeNotRunning = fmt.Errorf("simulated node isn't running")
+
+// SimulatedLink describes the conditions of the messages sent from one simulated node to another.
+type SimulatedLink struct {
+	// Latency is the time it takes a message to arrive once it has been transmitted.
+	Latency time.Duration
+
+	// BytesPerSecond limits the rate at which the messages are transmitted. Zero means an unlimited bandwidth.
+	BytesPerSecond uint64
+
+	// LossRate is the probability, between 0 and 1, of each message to get lost on the way.
+	LossRate float64
+}
+
+// transmitDuration returns the time it takes to transmit a message of the given length over the link.
+func (link SimulatedLink) transmitDuration(length int) time.Duration {
+	if link.BytesPerSecond == 0 {
+		return 0
+	}
+	return time.Duration(float64(length) / float64(link.BytesPerSecond) * float64(time.Second))
+}
+
+// SimulatedSwitchboard connects SimulatedNetwork nodes in memory, in place of sockets. It lets tests run several
+// nodes in a single process, and control the topology and the conditions of the links between them.
+//
+// The links are timed by a virtual clock, which only moves forward when Advance is called, and the messages due by
+// then are delivered in the order of their delivery times, and of their sending for equal times. The messages lost
+// over each link are picked by a pseudo-random generator seeded by the switchboard seed and the link endpoints, in the
+// order the messages are sent over it. Hence the same seed, and the same sends and clock advances, make the same run.
+type SimulatedSwitchboard struct {
+	log  logging.Logger
+	seed int64
+
+	// advanceMu serializes the advances of the clock, so that the events are fired in order.
+	advanceMu deadlock.Mutex
+
+	mu          deadlock.Mutex
+	nodes       map[string]*SimulatedNetwork
+	links       map[[2]string]SimulatedLink
+	defaultLink SimulatedLink
+
+	// now is the time on the virtual clock, and events are the events due on it, ordered by time and sequence number.
+	now      time.Time
+	events   simulatedEventHeap
+	eventSeq uint64
+}
+
+// simulatedEvent is something which happens at a given time on the virtual clock of a switchboard, such as the
+// delivery of a message.
+type simulatedEvent struct {
+	at   time.Time
+	seq  uint64
+	fire func()
+}
+
+// simulatedEventHeap orders the events by time, and by the order they were scheduled in for equal times.
+type simulatedEventHeap []simulatedEvent
+
+func (h simulatedEventHeap) Len() int { return len(h) }
+func (h simulatedEventHeap) Less(i, j int) bool {
+	return h[i].at.Before(h[j].at) || h[i].at.Equal(h[j].at) && h[i].seq < h[j].seq
+}
+func (h simulatedEventHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *simulatedEventHeap) Push(x interface{}) { *h = append(*h, x.(simulatedEvent)) }
+func (h *simulatedEventHeap) Pop() interface{} {
+	old := *h
+	event := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return event
+}
+
+// MakeSimulatedSwitchboard creates a switchboard whose links all have the given conditions, unless set otherwise with
+// SetLink.
+func MakeSimulatedSwitchboard(log logging.Logger, seed int64, defaultLink SimulatedLink) *SimulatedSwitchboard {
+	return &SimulatedSwitchboard{
+		log:         log,
+		seed:        seed,
+		nodes:       make(map[string]*SimulatedNetwork),
+		links:       make(map[[2]string]SimulatedLink),
+		defaultLink: defaultLink,
+		now:         time.Unix(0, 0),
+	}
+}
+
+// Now returns the time on the virtual clock of the switchboard.
+func (sb *SimulatedSwitchboard) Now() time.Time {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.now
+}
+
+// Advance moves the virtual clock forward by the given duration, and fires the events due by then in order,
+// including the ones scheduled by the events it fires. The messages are delivered into the read buffers of the nodes,
+// so it waits for the nodes to catch up with the messages they were sent.
+func (sb *SimulatedSwitchboard) Advance(d time.Duration) {
+	sb.advanceMu.Lock()
+	defer sb.advanceMu.Unlock()
+	sb.mu.Lock()
+	until := sb.now.Add(d)
+	for len(sb.events) > 0 && !sb.events[0].at.After(until) {
+		event := heap.Pop(&sb.events).(simulatedEvent)
+		sb.now = event.at
+		sb.mu.Unlock()
+		event.fire()
+		sb.mu.Lock()
+	}
+	sb.now = until
+	sb.mu.Unlock()
+}
+
+// RunClock advances the virtual clock by the given step once every step of the real clock, until the context is
+// done. It lets the nodes which time themselves by the real clock, such as the agreement service, run over the
+// switchboard, at the expense of the runs being deterministic.
+func (sb *SimulatedSwitchboard) RunClock(ctx context.Context, step time.Duration) {
+	ticker := time.NewTicker(step)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sb.Advance(step)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scheduleLocked schedules the given function to be called at the given time on the virtual clock. It's called while
+// holding the lock.
+func (sb *SimulatedSwitchboard) scheduleLocked(at time.Time, fire func()) {
+	if at.Before(sb.now) {
+		at = sb.now
+	}
+	sb.eventSeq++
+	heap.Push(&sb.events, simulatedEvent{at: at, seq: sb.eventSeq, fire: fire})
+}
+
+// sleep waits until the virtual clock advanced by the given duration, unless the context is done first.
+func (sb *SimulatedSwitchboard) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	woken := make(chan struct{})
+	sb.mu.Lock()
+	sb.scheduleLocked(sb.now.Add(d), func() { close(woken) })
+	sb.mu.Unlock()
+	select {
+	case <-woken:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// transmit schedules the delivery of a message sent over the given connection, timed on the virtual clock by the
+// conditions of the link: the messages are transmitted at the rate of the link one at a time, and are delivered once
+// the link latency passed since, in the order they were sent. It returns false if the message was dropped, since too
+// many messages are on their way over the connection.
+func (sb *SimulatedSwitchboard) transmit(sp *simulatedPeer, msg simulatedMessage) bool {
+	link := sb.link(sp.net.name, sp.remote.name)
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if sp.pending >= simulatedPeerQueueLength {
+		return false
+	}
+	if sp.transmitted.Before(sb.now) {
+		sp.transmitted = sb.now
+	}
+	sp.transmitted = sp.transmitted.Add(link.transmitDuration(len(msg.tag) + len(msg.data)))
+	if link.LossRate > 0 && sp.rand.Float64() < link.LossRate {
+		return true
+	}
+	deliverAt := sp.transmitted.Add(link.Latency)
+	if deliverAt.Before(sp.delivered) {
+		// the latency of the link was lowered since the previous message, which is still delivered first.
+		deliverAt = sp.delivered
+	}
+	sp.delivered = deliverAt
+	sp.pending++
+	sb.scheduleLocked(deliverAt, func() {
+		sb.mu.Lock()
+		sp.pending--
+		sb.mu.Unlock()
+		select {
+		case <-sp.closing:
+		default:
+			sp.remote.receive(sp.counterpart, msg)
+		}
+	})
+	return true
+}
+
+// MakeNode creates a simulated node with the given name, which serves as its host name as well. Only relays forward
+// the messages passed to Relay, as WebsocketNetwork relays do.
+func (sb *SimulatedSwitchboard) MakeNode(name string, genesisID string, relay bool) (*SimulatedNetwork, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if _, has := sb.nodes[name]; has {
+		return nil, fmt.Errorf("simulated node %s already exists", name)
+	}
+	n := &SimulatedNetwork{
+		switchboard: sb,
+		log:         sb.log.With("name", name),
+		name:        name,
+		genesisID:   genesisID,
+		relay:       relay,
+		router:      mux.NewRouter(),
+		readyChan:   make(chan struct{}),
+	}
+	n.handlers.log = n.log
+	n.handlers.ClearHandlers([]Tag{})
+	sb.nodes[name] = n
+	return n, nil
+}
+
+// SetLink sets the conditions of the messages sent from one node to another. It applies to the messages which are
+// sent from now on, including over the existing connections.
+func (sb *SimulatedSwitchboard) SetLink(from, to string, link SimulatedLink) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.links[[2]string{from, to}] = link
+}
+
+func (sb *SimulatedSwitchboard) link(from, to string) SimulatedLink {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if link, has := sb.links[[2]string{from, to}]; has {
+		return link
+	}
+	return sb.defaultLink
+}
+
+func (sb *SimulatedSwitchboard) node(name string) *SimulatedNetwork {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.nodes[name]
+}
+
+// linkRand creates the pseudo-random generator picking the messages lost from one node to another.
+func (sb *SimulatedSwitchboard) linkRand(from, to string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(from + "->" + to))
+	return rand.New(rand.NewSource(sb.seed ^ int64(h.Sum64())))
+}
+
+// Connect makes an outgoing connection from one running node to another.
+func (sb *SimulatedSwitchboard) Connect(from, to string) error {
+	fromNode, toNode := sb.node(from), sb.node(to)
+	if fromNode == nil || toNode == nil {
+		return fmt.Errorf("unable to connect %s to %s : unknown simulated node", from, to)
+	}
+	if from == to {
+		return fmt.Errorf("unable to connect %s to itself", from)
+	}
+	outgoing := fromNode.makePeer(toNode, true)
+	incoming := toNode.makePeer(fromNode, false)
+	outgoing.counterpart, incoming.counterpart = incoming, outgoing
+	if !fromNode.addPeer(outgoing) {
+		return fmt.Errorf("unable to connect %s to %s : %v", from, to, errSimulatedNodeNotRunning)
+	}
+	if !toNode.addPeer(incoming) {
+		outgoing.close()
+		return fmt.Errorf("unable to connect %s to %s : %v", from, to, errSimulatedNodeNotRunning)
+	}
+	return nil
+}
+
+// Disconnect closes the connections between the two nodes, in either direction.
+func (sb *SimulatedSwitchboard) Disconnect(a, b string) {
+	if node := sb.node(a); node != nil {
+		for _, peer := range node.connectedPeers() {
+			if peer.remote.name == b {
+				peer.close()
+			}
+		}
+	}
+}
+
+// SimulatedNetwork is a GossipNode whose peers are the other nodes of a SimulatedSwitchboard. Its connections are
+// made only through the switchboard, and it serves the HTTP requests of the other simulated nodes in memory.
+type SimulatedNetwork struct {
+	switchboard *SimulatedSwitchboard
+	log         logging.Logger
+	name        string
+	genesisID   string
+	relay       bool
+
+	handlers  Multiplexer
+	router    *mux.Router
+	readyChan chan struct{}
+
+	messagesOfInterestMu deadlock.Mutex
+	messagesOfInterest   map[protocol.Tag]bool
+
+	// peersMu protects the peers, and the state of the current run of the node. The run state is replaced every
+	// time the node is started, so it's read under the lock by the delivery threads of the remote peers.
+	peersMu    sync.Mutex
+	peers      []*simulatedPeer
+	running    bool
+	readBuffer chan IncomingMessage
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	wg sync.WaitGroup
+}
+
+// Name returns the name of the node on its switchboard.
+func (n *SimulatedNetwork) Name() string {
+	return n.name
+}
+
+// Address returns the URL at which the other simulated nodes reach this node.
+func (n *SimulatedNetwork) Address() (string, bool) {
+	n.peersMu.Lock()
+	defer n.peersMu.Unlock()
+	return "http://" + n.name, n.running
+}
+
+// Start starts handling the messages received by the node. The node is ready right away, as its connections are
+// made by the switchboard.
+func (n *SimulatedNetwork) Start() {
+	n.messagesOfInterestMu.Lock()
+	if n.messagesOfInterest == nil {
+		n.messagesOfInterest = make(map[protocol.Tag]bool)
+		for tag, flag := range defaultSendMessageTags {
+			n.messagesOfInterest[tag] = flag
+		}
+	}
+	n.messagesOfInterestMu.Unlock()
+
+	n.peersMu.Lock()
+	defer n.peersMu.Unlock()
+	if n.running {
+		return
+	}
+	n.running = true
+	n.readBuffer = make(chan IncomingMessage, simulatedReadBufferLength)
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	n.wg.Add(1)
+	go n.messageHandlerThread(n.ctx, n.readBuffer)
+	select {
+	case <-n.readyChan:
+	default:
+		close(n.readyChan)
+	}
+}
+
+// Stop closes the connections of the node, and stops handling messages.
+func (n *SimulatedNetwork) Stop() {
+	n.peersMu.Lock()
+	if !n.running {
+		n.peersMu.Unlock()
+		return
+	}
+	n.running = false
+	cancel := n.cancel
+	n.peersMu.Unlock()
+
+	n.DisconnectPeers()
+	cancel()
+	n.wg.Wait()
+}
+
+// Ready returns a channel which is closed once the node is started.
+func (n *SimulatedNetwork) Ready() chan struct{} {
+	return n.readyChan
+}
+
+// Broadcast sends a message to all the connected peers, except the given one. Messages sent to peers whose queue is
+// full are dropped.
+func (n *SimulatedNetwork) Broadcast(ctx context.Context, tag protocol.Tag, data []byte, wait bool, except Peer) error {
+	for _, peer := range n.connectedPeers() {
+		if Peer(peer) == except {
+			continue
+		}
+		if !peer.send(tag, data) {
+			networkPeerBroadcastDropped.Inc(nil)
+		}
+	}
+	return nil
+}
+
+// Relay broadcasts a message if this node is a relay.
+func (n *SimulatedNetwork) Relay(ctx context.Context, tag protocol.Tag, data []byte, wait bool, except Peer) error {
+	if n.relay {
+		return n.Broadcast(ctx, tag, data, wait, except)
+	}
+	return nil
+}
+
+// Disconnect closes the connection with the given peer.
+func (n *SimulatedNetwork) Disconnect(badnode Peer) {
+	if peer, ok := badnode.(*simulatedPeer); ok && peer.net == n {
+		peer.close()
+	}
+}
+
+// DisconnectPeers closes all the connections of the node.
+func (n *SimulatedNetwork) DisconnectPeers() {
+	for _, peer := range n.connectedPeers() {
+		peer.close()
+	}
+}
+
+// RegisterHTTPHandler registers a handler for the HTTP requests of the other simulated nodes.
+func (n *SimulatedNetwork) RegisterHTTPHandler(path string, handler http.Handler) {
+	n.router.Handle(path, handler)
+}
+
+// RequestConnectOutgoing does nothing, since the connections are made by the switchboard.
+func (n *SimulatedNetwork) RequestConnectOutgoing(replace bool, quit <-chan struct{}) {
+}
+
+// GetPeers returns the connected peers. The peers of outgoing connections serve as the phonebook relays as well.
+func (n *SimulatedNetwork) GetPeers(options ...PeerOption) []Peer {
+	peers := n.connectedPeers()
+	outPeers := make([]Peer, 0)
+	for _, option := range options {
+		for _, peer := range peers {
+			switch option {
+			case PeersConnectedOut, PeersPhonebookRelays:
+				if peer.outgoing {
+					outPeers = append(outPeers, Peer(peer))
+				}
+			case PeersConnectedIn:
+				if !peer.outgoing {
+					outPeers = append(outPeers, Peer(peer))
+				}
+			}
+		}
+	}
+	return outPeers
+}
+
+// RegisterHandlers adds to the set of given message handlers.
+func (n *SimulatedNetwork) RegisterHandlers(dispatch []TaggedMessageHandler) {
+	n.handlers.RegisterHandlers(dispatch)
+}
+
+// ClearHandlers deregisters all the existing message handlers.
+func (n *SimulatedNetwork) ClearHandlers() {
+	n.handlers.ClearHandlers([]Tag{})
+}
+
+// GetRoundTripper returns a Transport which routes the requests to the simulated nodes by their host name.
+func (n *SimulatedNetwork) GetRoundTripper() http.RoundTripper {
+	return &simulatedTransport{net: n}
+}
+
+// OnNetworkAdvance does nothing, since the simulated connections are never replaced.
+func (n *SimulatedNetwork) OnNetworkAdvance() {
+}
+
+// GetHTTPRequestConnection returns nil, since the simulated HTTP requests aren't made over a connection.
+func (n *SimulatedNetwork) GetHTTPRequestConnection(request *http.Request) (conn net.Conn) {
+	return nil
+}
+
+// RegisterMessageInterest adds a tag to the tags the node wants to receive from its peers.
+func (n *SimulatedNetwork) RegisterMessageInterest(t protocol.Tag) error {
+	n.messagesOfInterestMu.Lock()
+	defer n.messagesOfInterestMu.Unlock()
+	if n.readyChanClosed() {
+		return fmt.Errorf("network already started")
+	}
+	if n.messagesOfInterest == nil {
+		n.messagesOfInterest = make(map[protocol.Tag]bool)
+		for tag, flag := range defaultSendMessageTags {
+			n.messagesOfInterest[tag] = flag
+		}
+	}
+	n.messagesOfInterest[t] = true
+	return nil
+}
+
+// SubstituteGenesisID substitutes the "{genesisID}" with the genesis ID of the node.
+func (n *SimulatedNetwork) SubstituteGenesisID(rawURL string) string {
+	return strings.Replace(rawURL, "{genesisID}", n.genesisID, -1)
+}
+
+func (n *SimulatedNetwork) readyChanClosed() bool {
+	select {
+	case <-n.readyChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// wants returns true if the node wants to receive the messages of the given tag.
+func (n *SimulatedNetwork) wants(tag protocol.Tag) bool {
+	n.messagesOfInterestMu.Lock()
+	defer n.messagesOfInterestMu.Unlock()
+	return n.messagesOfInterest[tag]
+}
+
+func (n *SimulatedNetwork) makePeer(remote *SimulatedNetwork, outgoing bool) *simulatedPeer {
+	return &simulatedPeer{
+		net:              n,
+		remote:           remote,
+		outgoing:         outgoing,
+		closing:          make(chan struct{}),
+		rand:             n.switchboard.linkRand(n.name, remote.name),
+		responseChannels: make(map[uint64]chan *Response),
+	}
+}
+
+// addPeer adds a peer to a running node.
+func (n *SimulatedNetwork) addPeer(peer *simulatedPeer) bool {
+	n.peersMu.Lock()
+	defer n.peersMu.Unlock()
+	if !n.running {
+		return false
+	}
+	n.peers = append(n.peers, peer)
+	return true
+}
+
+func (n *SimulatedNetwork) removePeer(peer *simulatedPeer) {
+	n.peersMu.Lock()
+	defer n.peersMu.Unlock()
+	for i, p := range n.peers {
+		if p == peer {
+			n.peers = append(n.peers[:i], n.peers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (n *SimulatedNetwork) connectedPeers() []*simulatedPeer {
+	n.peersMu.Lock()
+	defer n.peersMu.Unlock()
+	return append([]*simulatedPeer(nil), n.peers...)
+}
+
+// receive handles a message which arrived from the given peer. The responses are passed on to the pending requests,
+// and the rest of the messages are queued for the message handlers.
+func (n *SimulatedNetwork) receive(sender *simulatedPeer, msg simulatedMessage) {
+	if msg.tag == protocol.TopicMsgRespTag {
+		sender.handleResponse(msg.data)
+		return
+	}
+	n.peersMu.Lock()
+	ctx, readBuffer := n.ctx, n.readBuffer
+	n.peersMu.Unlock()
+	select {
+	case readBuffer <- IncomingMessage{Sender: sender, Tag: msg.tag, Data: msg.data, Net: n, Received: time.Now().UnixNano()}:
+	case <-sender.closing:
+	case <-ctx.Done():
+	}
+}
+
+func (n *SimulatedNetwork) messageHandlerThread(ctx context.Context, readBuffer chan IncomingMessage) {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-readBuffer:
+			outmsg := n.handlers.Handle(msg)
+			switch outmsg.Action {
+			case Disconnect:
+				n.Disconnect(msg.Sender)
+			case Broadcast:
+				n.Broadcast(ctx, msg.Tag, msg.Data, false, msg.Sender)
+			case Respond:
+				err := msg.Sender.(*simulatedPeer).Respond(ctx, msg, outmsg.Topics)
+				if err != nil && err != ctx.Err() {
+					n.log.Warnf("SimulatedNetwork.messageHandlerThread: simulatedPeer.Respond returned unexpected error %v", err)
+				}
+			}
+		}
+	}
+}
+
+// simulatedMessage is a message on its way over a simulated connection.
+type simulatedMessage struct {
+	tag  protocol.Tag
+	data []byte
+}
+
+// simulatedPeer is one end of a simulated connection, through which the node it belongs to sends messages to the
+// remote node, by the switchboard.
+type simulatedPeer struct {
+	net         *SimulatedNetwork
+	remote      *SimulatedNetwork
+	outgoing    bool
+	counterpart *simulatedPeer
+
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	// rand picks the lost messages, and transmitted and delivered are the times at which the last message sent over
+	// the connection is done being transmitted and is delivered, and pending is the number of messages on their
+	// way. They're all used while holding the switchboard lock.
+	rand        *rand.Rand
+	transmitted time.Time
+	delivered   time.Time
+	pending     int
+
+	requestNonce          uint64
+	responseChannelsMutex deadlock.Mutex
+	responseChannels      map[uint64]chan *Response
+}
+
+// close closes both ends of the connection.
+func (sp *simulatedPeer) close() {
+	sp.closeEnd()
+	sp.counterpart.closeEnd()
+}
+
+func (sp *simulatedPeer) closeEnd() {
+	sp.closeOnce.Do(func() {
+		close(sp.closing)
+		sp.net.removePeer(sp)
+	})
+}
+
+// send queues a message to the remote node, unless it isn't interested in its tag. It returns false if the message
+// was dropped since the queue is full or the connection was closed.
+func (sp *simulatedPeer) send(tag protocol.Tag, data []byte) bool {
+	if !sp.remote.wants(tag) {
+		return true
+	}
+	select {
+	case <-sp.closing:
+		return false
+	default:
+	}
+	return sp.net.switchboard.transmit(sp, simulatedMessage{tag: tag, data: data})
+}
+
+// GetAddress returns the URL of the remote node.
+// (Implements HTTPPeer and UnicastPeer)
+func (sp *simulatedPeer) GetAddress() string {
+	return "http://" + sp.remote.name
+}
+
+// GetHTTPClient returns a client sending the requests to the remote node in memory.
+// (Implements HTTPPeer)
+func (sp *simulatedPeer) GetHTTPClient() *http.Client {
+	return &http.Client{Transport: sp.net.GetRoundTripper()}
+}
+
+// Unicast sends the given bytes to the remote node.
+// (Implements UnicastPeer)
+func (sp *simulatedPeer) Unicast(ctx context.Context, data []byte, tag protocol.Tag) error {
+	if !sp.send(tag, data) {
+		return fmt.Errorf("simulatedPeer failed to unicast: %v", sp.GetAddress())
+	}
+	return nil
+}
+
+// Version returns the latest supported network protocol version, which all the simulated nodes speak.
+// (Implements UnicastPeer)
+func (sp *simulatedPeer) Version() string {
+	return SupportedProtocolVersions[len(SupportedProtocolVersions)-1]
+}
+
+// Request sends a request to the remote node, and waits for its response.
+// (Implements UnicastPeer)
+func (sp *simulatedPeer) Request(ctx context.Context, tag Tag, topics Topics) (resp *Response, e error) {
+	nonce := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(nonce, atomic.AddUint64(&sp.requestNonce, 1))
+	topics = append(topics, Topic{key: "nonce", data: nonce})
+	serializedMsg := topics.MarshallTopics()
+	hash := hashTopics(serializedMsg)
+
+	responseChannel := make(chan *Response, 1)
+	sp.responseChannelsMutex.Lock()
+	sp.responseChannels[hash] = responseChannel
+	sp.responseChannelsMutex.Unlock()
+	defer func() {
+		sp.responseChannelsMutex.Lock()
+		delete(sp.responseChannels, hash)
+		sp.responseChannelsMutex.Unlock()
+	}()
+
+	if !sp.send(tag, serializedMsg) {
+		return nil, fmt.Errorf("simulatedPeer failed to send a request to %s", sp.GetAddress())
+	}
+	select {
+	case resp = <-responseChannel:
+		return resp, nil
+	case <-sp.closing:
+		return nil, fmt.Errorf("peer closing %s", sp.GetAddress())
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Respond sends the response of a request message to the remote node.
+// (Implements UnicastPeer)
+func (sp *simulatedPeer) Respond(ctx context.Context, reqMsg IncomingMessage, responseTopics Topics) (e error) {
+	requestHashData := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(requestHashData, hashTopics(reqMsg.Data))
+	responseTopics = append(responseTopics, Topic{key: requestHashKey, data: requestHashData})
+	if !sp.send(protocol.TopicMsgRespTag, responseTopics.MarshallTopics()) {
+		return fmt.Errorf("simulatedPeer failed to respond to %s", sp.GetAddress())
+	}
+	return nil
+}
+
+// handleResponse passes a response received over the connection on to the pending request it answers.
+func (sp *simulatedPeer) handleResponse(data []byte) {
+	topics, err := UnmarshallTopics(data)
+	if err != nil {
+		sp.net.log.Warnf("simulatedPeer: could not read the response from %s : %v", sp.remote.name, err)
+		return
+	}
+	requestHash, found := topics.GetValue(requestHashKey)
+	if !found {
+		sp.net.log.Warnf("simulatedPeer: the response from %s is missing the %s", sp.remote.name, requestHashKey)
+		return
+	}
+	hashKey, _ := binary.Uvarint(requestHash)
+	sp.responseChannelsMutex.Lock()
+	channel, found := sp.responseChannels[hashKey]
+	sp.responseChannelsMutex.Unlock()
+	if !found {
+		return
+	}
+	select {
+	case channel <- &Response{Topics: topics}:
+	default:
+	}
+}
+
+// simulatedTransport serves the HTTP requests of a simulated node with the handlers of the node named by the request
+// host. The requests and responses are delayed on the virtual clock by the latency of the links between the nodes, and
+// the responses by the bandwidth of the link as well.
+type simulatedTransport struct {
+	net *SimulatedNetwork
+}
+
+// RoundTrip implements http.RoundTripper.
+func (st *simulatedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	target := st.net.switchboard.node(request.URL.Host)
+	if target == nil {
+		return nil, fmt.Errorf("simulated node %s not found", request.URL.Host)
+	}
+	if _, running := target.Address(); !running {
+		return nil, fmt.Errorf("simulated node %s : %v", request.URL.Host, errSimulatedNodeNotRunning)
+	}
+	ctx := request.Context()
+	sb := st.net.switchboard
+	if err := sb.sleep(ctx, sb.link(st.net.name, target.name).Latency); err != nil {
+		return nil, err
+	}
+
+	serverRequest := request.Clone(ctx)
+	serverRequest.RemoteAddr = st.net.name + ":0"
+	serverRequest.RequestURI = request.URL.RequestURI()
+	if serverRequest.Body == nil {
+		serverRequest.Body = http.NoBody
+	}
+	recorder := httptest.NewRecorder()
+	target.router.ServeHTTP(recorder, serverRequest)
+	response := recorder.Result()
+	response.Request = request
+
+	link := sb.link(target.name, st.net.name)
+	if err := sb.sleep(ctx, link.Latency+link.transmitDuration(recorder.Body.Len())); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build simulated
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func makeTestSimulatedNode(t *testing.T, sb *SimulatedSwitchboard, name string, relay bool, received *uint32) *SimulatedNetwork {
+	n, err := sb.MakeNode(name, "go-test-network-genesis", relay)
+	require.NoError(t, err)
+	n.RegisterHandlers([]TaggedMessageHandler{
+		{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+			msg.Net.Relay(context.Background(), msg.Tag, msg.Data, false, msg.Sender)
+			atomic.AddUint32(received, 1)
+			return OutgoingMessage{}
+		})},
+	})
+	n.Start()
+	return n
+}
+
+// Set up A and C connected to a relay B, and test that B relays the messages of A to C, while C doesn't relay them
+// any further.
+func TestSimulatedNetworkRelay(t *testing.T) {
+	sb := MakeSimulatedSwitchboard(logging.TestingLog(t), 1, SimulatedLink{Latency: 10 * time.Millisecond})
+	var receivedA, receivedB, receivedC, receivedD uint32
+	netA := makeTestSimulatedNode(t, sb, "a", false, &receivedA)
+	defer netA.Stop()
+	netB := makeTestSimulatedNode(t, sb, "b", true, &receivedB)
+	defer netB.Stop()
+	netC := makeTestSimulatedNode(t, sb, "c", false, &receivedC)
+	defer netC.Stop()
+	netD := makeTestSimulatedNode(t, sb, "d", true, &receivedD)
+	defer netD.Stop()
+	require.NoError(t, sb.Connect("a", "b"))
+	require.NoError(t, sb.Connect("c", "b"))
+	require.NoError(t, sb.Connect("d", "c"))
+	require.Error(t, sb.Connect("a", "a"))
+	require.Error(t, sb.Connect("a", "unknown"))
+
+	require.Len(t, netA.GetPeers(PeersConnectedOut), 1)
+	require.Len(t, netA.GetPeers(PeersPhonebookRelays), 1)
+	require.Len(t, netB.GetPeers(PeersConnectedIn), 2)
+	require.Empty(t, netB.GetPeers(PeersConnectedOut))
+
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte("txn"), false, nil))
+	// the message reaches B after a single link latency, and C after two.
+	sb.Advance(9 * time.Millisecond)
+	require.Never(t, func() bool {
+		return atomic.LoadUint32(&receivedB) > 0
+	}, 50*time.Millisecond, 5*time.Millisecond)
+	sb.Advance(time.Millisecond)
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&receivedB) == 1
+	}, time.Second, 5*time.Millisecond)
+	sb.Advance(10 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&receivedC) == 1
+	}, time.Second, 5*time.Millisecond)
+	sb.Advance(time.Second)
+	require.Never(t, func() bool {
+		return atomic.LoadUint32(&receivedD) > 0 || atomic.LoadUint32(&receivedA) > 0
+	}, 100*time.Millisecond, 5*time.Millisecond)
+
+	// once disconnected, the messages of A don't reach B anymore.
+	sb.Disconnect("b", "a")
+	require.Empty(t, netA.GetPeers(PeersConnectedOut))
+	require.Len(t, netB.GetPeers(PeersConnectedIn), 1)
+	require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte("txn"), false, nil))
+	sb.Advance(time.Second)
+	require.Never(t, func() bool {
+		return atomic.LoadUint32(&receivedB) > 1
+	}, 100*time.Millisecond, 5*time.Millisecond)
+
+	// stopped nodes can't be connected to.
+	netD.Stop()
+	require.Empty(t, netC.GetPeers(PeersConnectedIn))
+	require.Error(t, sb.Connect("a", "d"))
+}
+
+// Test that the same seed makes the same run, with the messages from two nodes to a third one over lossy links of
+// different latencies delivered in the same order, and that the latency and the bandwidth delay the messages.
+func TestSimulatedNetworkLinkConditions(t *testing.T) {
+	const messages = 200
+	run := func(seed int64) []string {
+		sb := MakeSimulatedSwitchboard(logging.TestingLog(t), seed, SimulatedLink{LossRate: 0.5})
+		var receivedA, receivedB, receivedC uint32
+		netA := makeTestSimulatedNode(t, sb, "a", false, &receivedA)
+		defer netA.Stop()
+		netB := makeTestSimulatedNode(t, sb, "b", false, &receivedB)
+		defer netB.Stop()
+		netC, err := sb.MakeNode("c", "go-test-network-genesis", false)
+		require.NoError(t, err)
+		var delivered []string
+		netC.RegisterHandlers([]TaggedMessageHandler{
+			{Tag: protocol.TxnTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+				delivered = append(delivered, string(msg.Data))
+				atomic.AddUint32(&receivedC, 1)
+				return OutgoingMessage{}
+			})},
+		})
+		netC.Start()
+		defer netC.Stop()
+		sb.SetLink("a", "c", SimulatedLink{Latency: 3 * time.Millisecond, LossRate: 0.5})
+		sb.SetLink("b", "c", SimulatedLink{Latency: 5 * time.Millisecond, LossRate: 0.5})
+		require.NoError(t, sb.Connect("a", "c"))
+		require.NoError(t, sb.Connect("b", "c"))
+		for i := 0; i < messages; i++ {
+			require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, []byte(fmt.Sprintf("a%d", i)), false, nil))
+			require.NoError(t, netB.Broadcast(context.Background(), protocol.TxnTag, []byte(fmt.Sprintf("b%d", i)), false, nil))
+			sb.Advance(time.Millisecond)
+		}
+		sb.Advance(time.Second)
+		// wait for C to handle all the delivered messages.
+		require.Eventually(t, func() bool {
+			handled := atomic.LoadUint32(&receivedC)
+			time.Sleep(20 * time.Millisecond)
+			return atomic.LoadUint32(&receivedC) == handled
+		}, time.Second, time.Millisecond)
+		return delivered
+	}
+	delivered := run(1)
+	require.Greater(t, len(delivered), 2*messages/4)
+	require.Less(t, len(delivered), 2*3*messages/4)
+	require.Equal(t, delivered, run(1))
+	require.NotEqual(t, delivered, run(2))
+
+	sb := MakeSimulatedSwitchboard(logging.TestingLog(t), 1, SimulatedLink{Latency: 100 * time.Millisecond, BytesPerSecond: 10000})
+	var receivedA, receivedB uint32
+	netA := makeTestSimulatedNode(t, sb, "a", false, &receivedA)
+	defer netA.Stop()
+	netB := makeTestSimulatedNode(t, sb, "b", false, &receivedB)
+	defer netB.Stop()
+	require.NoError(t, sb.Connect("a", "b"))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, netA.Broadcast(context.Background(), protocol.TxnTag, make([]byte, 998), false, nil))
+	}
+	// each message takes 100ms to transmit, one after the other, and arrives 100ms later.
+	sb.Advance(299 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&receivedB) == 1
+	}, time.Second, 5*time.Millisecond)
+	sb.Advance(time.Millisecond)
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&receivedB) == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSimulatedNetworkRequest(t *testing.T) {
+	sb := MakeSimulatedSwitchboard(logging.TestingLog(t), 1, SimulatedLink{Latency: 10 * time.Millisecond})
+	var receivedA, receivedB uint32
+	netA := makeTestSimulatedNode(t, sb, "a", false, &receivedA)
+	defer netA.Stop()
+	netB := makeTestSimulatedNode(t, sb, "b", true, &receivedB)
+	defer netB.Stop()
+	netB.RegisterHandlers([]TaggedMessageHandler{
+		{Tag: protocol.UniEnsBlockReqTag, MessageHandler: HandlerFunc(func(msg IncomingMessage) OutgoingMessage {
+			topics, err := UnmarshallTopics(msg.Data)
+			require.NoError(t, err)
+			value, _ := topics.GetValue("question")
+			return OutgoingMessage{Action: Respond, Topics: Topics{MakeTopic("answer", append(value, '!'))}}
+		})},
+	})
+	netB.RegisterHTTPHandler("/v1/{genesisID}/echo/{value}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(mux.Vars(r)["genesisID"] + "/" + mux.Vars(r)["value"]))
+	}))
+	require.NoError(t, sb.Connect("a", "b"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sb.RunClock(ctx, time.Millisecond)
+
+	peer := netA.GetPeers(PeersConnectedOut)[0]
+	resp, err := peer.(UnicastPeer).Request(context.Background(), protocol.UniEnsBlockReqTag, Topics{MakeTopic("question", []byte("ping"))})
+	require.NoError(t, err)
+	answer, found := resp.Topics.GetValue("answer")
+	require.True(t, found)
+	require.Equal(t, "ping!", string(answer))
+
+	httpPeer := peer.(HTTPPeer)
+	require.Equal(t, "http://b", httpPeer.GetAddress())
+	response, err := httpPeer.GetHTTPClient().Get(httpPeer.GetAddress() + netA.SubstituteGenesisID("/v1/{genesisID}/echo/hello"))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "go-test-network-genesis/hello", string(body))
+
+	response, err = httpPeer.GetHTTPClient().Get(httpPeer.GetAddress() + "/missing")
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusNotFound, response.StatusCode)
+	_, err = httpPeer.GetHTTPClient().Get("http://unknown/")
+	require.Error(t, err)
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/algorand/websocket"
+
+	"github.com/algorand/go-algorand/logging"
+)
+
+// TransportHeader HTTP header for the gossip transports. The requesting peer lists the transports it has enabled
+// ( in order of preference ), and the responding peer returns the one the connection was made over.
+const TransportHeader = "X-Algorand-Transport"
+
+// websocketTransportName is the name of the websocket transport, in the GossipTransports config and the TransportHeader.
+// It's the transport of the peers which don't send the TransportHeader.
+const websocketTransportName = "websocket"
+
+// transportConn is a message oriented connection to a peer, made by a gossipTransport. The message types and the
+// close errors are the websocket ones. Every message starts with its tag, so that the transports multiplexing several
+// streams over a connection can carry the agreement messages on a stream of their own, unaffected by the head of line
+// blocking of the transaction traffic.
+type transportConn interface {
+	wsPeerWebsocketConn
+	ReadMessage() (int, []byte, error)
+	SetReadDeadline(time.Time) error
+	Close() error
+}
+
+// gossipTransport makes the connections the gossip messages are exchanged over. The handshake is the same HTTP
+// request and response headers exchange over all the transports, so that the peer management doesn't depend on the
+// transport.
+type gossipTransport interface {
+	// name returns the name of the transport, in the GossipTransports config and the TransportHeader.
+	name() string
+
+	// listen starts accepting incoming connections on the given address, until the network stops, for the transports
+	// whose connections don't come through the HTTP server of the network. Their handshake requests are served by the
+	// network's HTTP handler nonetheless, with an incomingHandshake of the transport in the request context.
+	listen(addr string) error
+
+	// dial connects to the given gossip address with the given request headers, and returns the response to the
+	// handshake. When the peer declines the handshake, the error is websocket.ErrBadHandshake and the response is
+	// returned along with it.
+	dial(ctx context.Context, gossipAddr string, requestHeader http.Header) (transportConn, *http.Response, error)
+}
+
+// incomingHandshake is the server side of the handshake of an incoming connection over a gossip transport.
+type incomingHandshake interface {
+	// transportName returns the name of the transport the connection is made over.
+	transportName() string
+
+	// accept completes the handshake with the given response headers, once the request headers were checked.
+	accept(responseHeader http.Header) (transportConn, error)
+}
+
+type incomingHandshakeContextKeyType struct{}
+
+// incomingHandshakeContextKey is the key of the incomingHandshake in the context of the handshake requests which
+// didn't come through the HTTP server of the network.
+var incomingHandshakeContextKey = incomingHandshakeContextKeyType{}
+
+// websocketTransport carries the gossip over websocket connections, upgraded from the HTTP handshake.
+type websocketTransport struct {
+	upgrader websocket.Upgrader
+	dialer   *Dialer
+}
+
+// websocketHandshake is the handshake of an incoming websocket connection, which is upgraded from the HTTP request.
+type websocketHandshake struct {
+	transport *websocketTransport
+	response  http.ResponseWriter
+	request   *http.Request
+}
+
+func makeWebsocketTransport(dialer *Dialer) *websocketTransport {
+	return &websocketTransport{
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:    4096,
+			WriteBufferSize:   4096,
+			EnableCompression: false,
+		},
+		dialer: dialer,
+	}
+}
+
+func (t *websocketTransport) name() string {
+	return websocketTransportName
+}
+
+// listen does nothing, since the websocket connections come through the HTTP server of the network.
+func (t *websocketTransport) listen(addr string) error {
+	return nil
+}
+
+func (h *websocketHandshake) transportName() string {
+	return websocketTransportName
+}
+
+func (h *websocketHandshake) accept(responseHeader http.Header) (transportConn, error) {
+	conn, err := h.transport.upgrader.Upgrade(h.response, h.request, responseHeader)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (t *websocketTransport) dial(ctx context.Context, gossipAddr string, requestHeader http.Header) (transportConn, *http.Response, error) {
+	var websocketDialer = websocket.Dialer{
+		Proxy:             http.ProxyFromEnvironment,
+		HandshakeTimeout:  45 * time.Second,
+		EnableCompression: false,
+		NetDialContext:    t.dialer.DialContext,
+		NetDial:           t.dialer.Dial,
+	}
+	conn, response, err := websocketDialer.DialContext(ctx, gossipAddr, requestHeader)
+	if err != nil {
+		return nil, response, err
+	}
+	return conn, response, nil
+}
+
+// parseGossipTransports returns the transports named in the semicolon-separated GossipTransports config, in order of
+// preference, out of the available ones. The unknown and repeated names are skipped with a warning, and the websocket
+// transport is used when none of the named transports is available.
+func parseGossipTransports(log logging.Logger, encoded string, available []gossipTransport) (transports []gossipTransport) {
+	enabled := make(map[string]bool)
+	for _, transportName := range strings.Split(encoded, ";") {
+		transportName = strings.TrimSpace(transportName)
+		if transportName == "" {
+			continue
+		}
+		var transport gossipTransport
+		for _, t := range available {
+			if t.name() == transportName {
+				transport = t
+				break
+			}
+		}
+		if transport == nil {
+			log.Warnf("GossipTransports: skipping unsupported transport '%s'", transportName)
+			continue
+		}
+		if enabled[transportName] {
+			log.Warnf("GossipTransports: skipping repeated transport '%s'", transportName)
+			continue
+		}
+		enabled[transportName] = true
+		transports = append(transports, transport)
+	}
+	if len(transports) == 0 {
+		for _, t := range available {
+			if t.name() == websocketTransportName {
+				log.Warnf("GossipTransports: none of the transports in '%s' are supported, using %s", encoded, websocketTransportName)
+				transports = append(transports, t)
+			}
+		}
+	}
+	return transports
+}
+
+// offersTransport checks that the given handshake headers list the given transport among the enabled transports of
+// the other peer. The peers which don't send the TransportHeader only support the websocket transport.
+func offersTransport(otherHeaders http.Header, transportName string) bool {
+	offered := otherHeaders[http.CanonicalHeaderKey(TransportHeader)]
+	if len(offered) == 0 {
+		return transportName == websocketTransportName
+	}
+	for _, otherTransport := range offered {
+		if otherTransport == transportName {
+			return true
+		}
+	}
+	return false
+}
+
+// dialTransports returns the transports to connect over to a peer which reported the given enabled transports, in
+// order of preference. The transports other than websocket are only dialed once the peer has reported them, rather than
+// blindly, since dialing a transport the peer doesn't listen on fails only after a timeout. If none of the enabled
+// transports is left to dial, all of them are, and the peer would report its own ones if it declines them.
+func (wn *WebsocketNetwork) dialTransports(reported []string) (transports []gossipTransport) {
+	for _, transport := range wn.gossipTransports {
+		if transport.name() == websocketTransportName {
+			transports = append(transports, transport)
+			continue
+		}
+		for _, transportName := range reported {
+			if transportName == transport.name() {
+				transports = append(transports, transport)
+				break
+			}
+		}
+	}
+	if len(transports) == 0 {
+		return wn.gossipTransports
+	}
+	return transports
+}
+
+// fallbackTransports returns the transports to retry a connection over, out of the given ones, after the peer declined
+// the handshake. If the peer declined the transport, listing the transports it enabled instead, only the ones enabled
+// by both peers are left. If it declined the handshake for any other reason, the connection isn't retried.
+func fallbackTransports(transports []gossipTransport, response *http.Response) (fallback []gossipTransport) {
+	if response == nil || response.StatusCode != http.StatusPreconditionFailed || len(response.Header[http.CanonicalHeaderKey(TransportHeader)]) == 0 {
+		return nil
+	}
+	for _, transport := range transports {
+		if offersTransport(response.Header, transport.name()) {
+			fallback = append(fallback, transport)
+		}
+	}
+	return fallback
+}
+
+// makeIncomingHandshake returns the handshake of the incoming connection which the given request was made for.
+func (wn *WebsocketNetwork) makeIncomingHandshake(response http.ResponseWriter, request *http.Request) incomingHandshake {
+	if handshake, ok := request.Context().Value(incomingHandshakeContextKey).(incomingHandshake); ok {
+		return handshake
+	}
+	transport, _ := wn.enabledTransport(websocketTransportName).(*websocketTransport)
+	if transport == nil {
+		transport = makeWebsocketTransport(&wn.dialer)
+	}
+	return &websocketHandshake{transport: transport, response: response, request: request}
+}
+
+// enabledTransport returns the enabled transport of the given name, or nil if it isn't enabled.
+func (wn *WebsocketNetwork) enabledTransport(transportName string) gossipTransport {
+	for _, transport := range wn.gossipTransports {
+		if transport.name() == transportName {
+			return transport
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/algorand/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/logging"
+)
+
+// renamedTransport is the websocket transport under another name, standing for a transport only some peers support.
+type renamedTransport struct {
+	*websocketTransport
+	transportName string
+}
+
+func (t renamedTransport) name() string {
+	return t.transportName
+}
+
+// unreachableTransport is a transport the peers can't be reached over, counting the dials over it.
+type unreachableTransport struct {
+	transportName string
+	dials         int32
+}
+
+func (t *unreachableTransport) name() string {
+	return t.transportName
+}
+
+func (t *unreachableTransport) listen(addr string) error {
+	return nil
+}
+
+func (t *unreachableTransport) dial(ctx context.Context, gossipAddr string, requestHeader http.Header) (transportConn, *http.Response, error) {
+	atomic.AddInt32(&t.dials, 1)
+	return nil, nil, errors.New("unreachable")
+}
+
+func transportNames(transports []gossipTransport) (names []string) {
+	for _, transport := range transports {
+		names = append(names, transport.name())
+	}
+	return
+}
+
+func TestParseGossipTransports(t *testing.T) {
+	log := logging.TestingLog(t)
+	var dialer Dialer
+	available := []gossipTransport{makeWebsocketTransport(&dialer), renamedTransport{makeWebsocketTransport(&dialer), "other"}}
+
+	require.Equal(t, []string{"websocket"}, transportNames(parseGossipTransports(log, "websocket", available)))
+	require.Equal(t, []string{"other", "websocket"}, transportNames(parseGossipTransports(log, " other ; unknown;websocket;other", available)))
+	// the websocket transport is used when none of the configured ones is available.
+	require.Equal(t, []string{"websocket"}, transportNames(parseGossipTransports(log, "unknown", available)))
+	require.Equal(t, []string{"websocket"}, transportNames(parseGossipTransports(log, "", available)))
+}
+
+func TestOffersTransport(t *testing.T) {
+	header := make(http.Header)
+	// the peers which don't list their transports only support websockets.
+	require.True(t, offersTransport(header, websocketTransportName))
+	require.False(t, offersTransport(header, "other"))
+
+	header.Add(TransportHeader, "other")
+	require.False(t, offersTransport(header, websocketTransportName))
+	require.True(t, offersTransport(header, "other"))
+	header.Add(TransportHeader, websocketTransportName)
+	require.True(t, offersTransport(header, websocketTransportName))
+}
+
+// Set up a node A, and test that B, which only enables a transport A doesn't have, can't connect to it while C, which
+// enables the websocket transport along with it, can.
+func TestWebsocketNetworkTransportMismatch(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	confB := defaultConfig
+	confB.NetAddress = ""
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+	netB.gossipTransports = []gossipTransport{renamedTransport{makeWebsocketTransport(&netB.dialer), "other"}}
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+	require.Never(t, func() bool {
+		return len(netB.GetPeers(PeersConnectedOut)) > 0 || len(netA.GetPeers(PeersConnectedIn)) > 0
+	}, time.Second, 50*time.Millisecond)
+
+	confC := defaultConfig
+	confC.NetAddress = ""
+	netC := makeTestWebsocketNodeWithConfig(t, confC)
+	netC.gossipTransports = append(netC.gossipTransports, renamedTransport{makeWebsocketTransport(&netC.dialer), "other"})
+	netC.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netC.Start()
+	defer func() { t.Log("stopping C"); netC.Stop(); t.Log("C done") }()
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netC, readyTimeout.C)
+	require.Eventually(t, func() bool {
+		return len(netA.GetPeers(PeersConnectedIn)) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDialTransports(t *testing.T) {
+	wn := makeTestWebsocketNode(t)
+	wn.gossipTransports = []gossipTransport{&unreachableTransport{transportName: "other"}, makeWebsocketTransport(&wn.dialer)}
+
+	// the other transport isn't dialed until the peer has reported it.
+	require.Equal(t, []string{"websocket"}, transportNames(wn.dialTransports(nil)))
+	require.Equal(t, []string{"websocket"}, transportNames(wn.dialTransports([]string{"websocket", "unknown"})))
+	require.Equal(t, []string{"other", "websocket"}, transportNames(wn.dialTransports([]string{"websocket", "other"})))
+
+	// without websockets, the enabled transports are dialed regardless.
+	wn.gossipTransports = wn.gossipTransports[:1]
+	require.Equal(t, []string{"other"}, transportNames(wn.dialTransports(nil)))
+}
+
+func TestFallbackTransports(t *testing.T) {
+	var dialer Dialer
+	untried := []gossipTransport{renamedTransport{makeWebsocketTransport(&dialer), "other"}, makeWebsocketTransport(&dialer)}
+
+	// the peer declined the transport, so only the ones it enabled are retried.
+	response := &http.Response{StatusCode: http.StatusPreconditionFailed, Header: make(http.Header)}
+	response.Header.Add(TransportHeader, websocketTransportName)
+	require.Equal(t, []string{"websocket"}, transportNames(fallbackTransports(untried, response)))
+	response.Header.Set(TransportHeader, "unknown")
+	require.Empty(t, fallbackTransports(untried, response))
+
+	// the peer declined the handshake for another reason, so it isn't retried.
+	response.Header.Del(TransportHeader)
+	require.Empty(t, fallbackTransports(untried, response))
+	response = &http.Response{StatusCode: http.StatusTooManyRequests, Header: make(http.Header)}
+	response.Header.Add(TransportHeader, websocketTransportName)
+	require.Empty(t, fallbackTransports(untried, response))
+}
+
+// Set up a node A, and test that B, which prefers a transport A can't be reached over, connects to it over websockets
+// without dialing that transport blindly, and dials it first only once A has reported it.
+func TestWebsocketNetworkTransportLearning(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.gossipTransports = append(netA.gossipTransports, &unreachableTransport{transportName: "other"})
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	confB := defaultConfig
+	confB.NetAddress = ""
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+	otherB := &unreachableTransport{transportName: "other"}
+	netB.gossipTransports = append([]gossipTransport{otherB}, netB.gossipTransports...)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netB, readyTimeout.C)
+	require.Eventually(t, func() bool {
+		return len(netA.GetPeers(PeersConnectedIn)) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Zero(t, atomic.LoadInt32(&otherB.dials))
+
+	// A has reported the transport the connection was made over first, followed by its other ones.
+	require.Equal(t, []string{"websocket", "other"}, netB.phonebook.GetTransports(addrA))
+	require.Equal(t, []string{"other", "websocket"}, transportNames(netB.dialTransports(netB.phonebook.GetTransports(addrA))))
+}
+
+// Test that the transport mismatch response lists the transports of the declining peer, for the other peer to retry
+// over one of them.
+func TestWebsocketNetworkTransportMismatchResponse(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.gossipTransports = []gossipTransport{&unreachableTransport{transportName: "other"}}
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	netB := makeTestWebsocketNode(t)
+	requestHeader := make(http.Header)
+	netB.setHeaders(requestHeader)
+	requestHeader.Set(ProtocolVersionHeader, ProtocolVersion)
+	gossipAddr, err := netB.addrToGossipAddr(addrA)
+	require.NoError(t, err)
+	_, response, err := makeWebsocketTransport(&netB.dialer).dial(context.Background(), gossipAddr, requestHeader)
+	require.Equal(t, websocket.ErrBadHandshake, err)
+	require.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
+	require.Equal(t, []string{"other"}, response.Header[http.CanonicalHeaderKey(TransportHeader)])
+}
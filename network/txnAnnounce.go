@@ -0,0 +1,419 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-deadlock"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/metrics"
+)
+
+// txnAnnounceProtocolVersion is the network protocol version in which peers can announce the digests of the transaction
+// groups they relay instead of sending them in full. A peer would get announcements only if the connection was negotiated
+// at this version and the peer listed the TxnAnnounceTag in its message-of-interest.
+const txnAnnounceProtocolVersion = "2.2"
+
+// txnAnnounceBatchInterval is the maximal time a transaction group digest would wait before being announced to a peer.
+const txnAnnounceBatchInterval = 50 * time.Millisecond
+
+// maxTxnAnnounceDigests is the maximal number of digests in a single announcement or request message.
+const maxTxnAnnounceDigests = 1024
+
+// txnCacheGenerationSize is the number of transaction groups kept by each of the two generations of the txnGroupCache.
+const txnCacheGenerationSize = 5000
+
+// txnRequestTimeout is the time a requested transaction group is waited for before it's requested from the next peer
+// which announced it.
+const txnRequestTimeout = 2 * time.Second
+
+// maxTxnRequestAnnouncers is the maximal number of peers kept for each requested transaction group, in addition to the
+// one it was requested from, to request it from if the pending request times out.
+const maxTxnRequestAnnouncers = 4
+
+// maxPendingTxnRequests is the maximal number of transaction groups waiting for a response. Announced transaction groups
+// beyond that aren't requested until some of the pending requests are answered or time out.
+const maxPendingTxnRequests = txnCacheGenerationSize
+
+// maxPendingTxnRequestsPerPeer is the maximal number of transaction groups waiting for a response from a single peer, so
+// that a peer announcing more transaction groups than it would send can't keep the ones announced by others from being
+// requested.
+const maxPendingTxnRequestsPerPeer = maxPendingTxnRequests / 4
+
+var networkTxnAnnouncedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_txn_announced_total", Description: "number of relayed transaction groups announced to peers instead of being sent in full"})
+var networkTxnAnnouncedBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_txn_announced_bytes_total", Description: "bytes of relayed transaction groups announced to peers instead of being sent in full"})
+var networkTxnRequestedTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_txn_requested_total", Description: "number of announced transaction groups sent in full upon a peer request"})
+var networkTxnRequestedBytesTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_txn_requested_bytes_total", Description: "bytes of announced transaction groups sent in full upon a peer request"})
+var networkTxnAnnounceKnownTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_txn_announce_known_total", Description: "number of transaction groups announced by peers which were not requested since they were already known"})
+var networkTxnRequestTimeoutsTotal = metrics.MakeCounter(metrics.MetricName{Name: "algod_network_txn_request_timeouts_total", Description: "number of requested transaction groups which were not received in time"})
+
+// txnGroupCache keeps the recently seen transaction groups, indexed by their message digest. The relayed transaction groups
+// are kept along with their encoding so that they could be sent to the peers requesting them, while the ones which were only
+// received are kept without it, so that they won't be requested again.
+type txnGroupCache struct {
+	mu       deadlock.Mutex
+	current  map[crypto.Digest][]byte
+	previous map[crypto.Digest][]byte
+}
+
+func makeTxnGroupCache() *txnGroupCache {
+	return &txnGroupCache{
+		current:  make(map[crypto.Digest][]byte),
+		previous: make(map[crypto.Digest][]byte),
+	}
+}
+
+// add adds the given transaction group digest to the cache. data is the encoded transaction group, or nil if it isn't needed.
+func (c *txnGroupCache) add(digest crypto.Digest, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, has := c.current[digest]; has && (existing != nil || data == nil) {
+		return
+	}
+	c.current[digest] = data
+	if len(c.current) >= txnCacheGenerationSize {
+		c.previous = c.current
+		c.current = make(map[crypto.Digest][]byte)
+	}
+}
+
+// has returns true if the transaction group is in the cache.
+func (c *txnGroupCache) has(digest crypto.Digest) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, has := c.current[digest]; has {
+		return true
+	}
+	_, has := c.previous[digest]
+	return has
+}
+
+// get returns the encoded transaction group, if it's in the cache.
+func (c *txnGroupCache) get(digest crypto.Digest) (data []byte, has bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if data = c.current[digest]; data != nil {
+		return data, true
+	}
+	data = c.previous[digest]
+	return data, data != nil
+}
+
+// txnRequests keeps the announced transaction groups which were requested and not received yet, so that each of them is
+// requested from a single peer at a time, and requested from another peer which announced it if the request times out.
+type txnRequests struct {
+	mu      deadlock.Mutex
+	pending map[crypto.Digest]*txnRequest
+
+	// perPeer is the number of the pending transaction groups requested from each peer.
+	perPeer map[*wsPeer]int
+}
+
+// txnRequest is a transaction group which was requested and not received yet.
+type txnRequest struct {
+	// deadline is the time after which the transaction group is requested from the next announcer.
+	deadline time.Time
+
+	// requestedFrom is the peer the transaction group was last requested from.
+	requestedFrom *wsPeer
+
+	// announcers are the other peers which announced the transaction group, in the order of their announcements.
+	announcers []*wsPeer
+}
+
+func makeTxnRequests() *txnRequests {
+	return &txnRequests{
+		pending: make(map[crypto.Digest]*txnRequest),
+		perPeer: make(map[*wsPeer]int),
+	}
+}
+
+// setRequestedFrom records that the transaction group is now requested from the given peer, or from none if it's nil.
+func (r *txnRequests) setRequestedFrom(req *txnRequest, peer *wsPeer) {
+	if req.requestedFrom != nil {
+		r.perPeer[req.requestedFrom]--
+		if r.perPeer[req.requestedFrom] <= 0 {
+			delete(r.perPeer, req.requestedFrom)
+		}
+	}
+	req.requestedFrom = peer
+	if peer != nil {
+		r.perPeer[peer]++
+	}
+}
+
+// announced records that the peer announced the transaction group, and returns true if it should be requested from the peer
+// now, since it isn't pending a response from another peer.
+func (r *txnRequests) announced(digest crypto.Digest, peer *wsPeer, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req, has := r.pending[digest]
+	if !has {
+		if len(r.pending) >= maxPendingTxnRequests || r.perPeer[peer] >= maxPendingTxnRequestsPerPeer {
+			return false
+		}
+		req = &txnRequest{deadline: now.Add(txnRequestTimeout)}
+		r.setRequestedFrom(req, peer)
+		r.pending[digest] = req
+		return true
+	}
+	if req.requestedFrom == peer || len(req.announcers) >= maxTxnRequestAnnouncers {
+		return false
+	}
+	for _, announcer := range req.announcers {
+		if announcer == peer {
+			return false
+		}
+	}
+	req.announcers = append(req.announcers, peer)
+	return false
+}
+
+// requestedFrom returns true if the transaction group is pending a response from the given peer.
+func (r *txnRequests) requestedFrom(digest crypto.Digest, peer *wsPeer) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req, has := r.pending[digest]
+	return has && req.requestedFrom == peer
+}
+
+// received marks the transaction group as no longer pending.
+func (r *txnRequests) received(digest crypto.Digest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req, has := r.pending[digest]; has {
+		r.setRequestedFrom(req, nil)
+		delete(r.pending, digest)
+	}
+}
+
+// expired returns the transaction groups whose requests timed out, grouped by the next peer which announced them and which
+// they should be requested from. The announcers which have too many pending requests already are skipped. Timed out
+// transaction groups with no other announcers are dropped, so that they would be requested again when they're announced
+// anew.
+func (r *txnRequests) expired(now time.Time) map[*wsPeer][]crypto.Digest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var next map[*wsPeer][]crypto.Digest
+	for digest, req := range r.pending {
+		if now.Before(req.deadline) {
+			continue
+		}
+		networkTxnRequestTimeoutsTotal.Inc(nil)
+		r.setRequestedFrom(req, nil)
+		for len(req.announcers) > 0 && r.perPeer[req.announcers[0]] >= maxPendingTxnRequestsPerPeer {
+			req.announcers = req.announcers[1:]
+		}
+		if len(req.announcers) == 0 {
+			delete(r.pending, digest)
+			continue
+		}
+		if next == nil {
+			next = make(map[*wsPeer][]crypto.Digest)
+		}
+		r.setRequestedFrom(req, req.announcers[0])
+		req.announcers = req.announcers[1:]
+		req.deadline = now.Add(txnRequestTimeout)
+		next[req.requestedFrom] = append(next[req.requestedFrom], digest)
+	}
+	return next
+}
+
+// marshallTxnDigests encodes a list of transaction group digests as the body of an announcement or request message.
+func marshallTxnDigests(digests []crypto.Digest) []byte {
+	data := make([]byte, 0, len(digests)*crypto.DigestSize)
+	for _, digest := range digests {
+		data = append(data, digest[:]...)
+	}
+	return data
+}
+
+// unmarshallTxnDigests decodes the body of an announcement or request message.
+func unmarshallTxnDigests(data []byte) ([]crypto.Digest, bool) {
+	if len(data) == 0 || len(data)%crypto.DigestSize != 0 || len(data)/crypto.DigestSize > maxTxnAnnounceDigests {
+		return nil, false
+	}
+	digests := make([]crypto.Digest, len(data)/crypto.DigestSize)
+	for i := range digests {
+		copy(digests[i][:], data[i*crypto.DigestSize:])
+	}
+	return digests, true
+}
+
+// wantsTxnAnnouncements returns true if the peer asked to get the digests of the transaction groups we relay instead
+// of the transaction groups themselves.
+func (wp *wsPeer) wantsTxnAnnouncements() bool {
+	return atomic.LoadInt32(&wp.txnAnnounce) != 0
+}
+
+// updateTxnAnnouncements updates whether the peer wants transaction group announcements, given its message-of-interest.
+func (wp *wsPeer) updateTxnAnnouncements(msgTags map[protocol.Tag]bool) {
+	var txnAnnounce int32
+	if msgTags[protocol.TxnAnnounceTag] && wp.version == txnAnnounceProtocolVersion {
+		txnAnnounce = 1
+	}
+	atomic.StoreInt32(&wp.txnAnnounce, txnAnnounce)
+}
+
+// queueTxnAnnouncement adds the transaction group digest to the announcements pending for the peer. It's called only from
+// the broadcastThread, which owns the pending announcements.
+func (wn *WebsocketNetwork) queueTxnAnnouncement(peer *wsPeer, digest crypto.Digest, dataLength int) {
+	peer.txnAnnouncePending = append(peer.txnAnnouncePending, digest)
+	networkTxnAnnouncedTotal.Inc(nil)
+	networkTxnAnnouncedBytesTotal.AddUint64(uint64(dataLength), nil)
+	if len(peer.txnAnnouncePending) >= maxTxnAnnounceDigests {
+		peer.sendTxnAnnouncements()
+		return
+	}
+	wn.txnAnnouncePending = true
+}
+
+// flushTxnAnnouncements sends the pending announcements to the given peers.
+func (wn *WebsocketNetwork) flushTxnAnnouncements(peers []*wsPeer) {
+	for _, peer := range peers {
+		if len(peer.txnAnnouncePending) > 0 {
+			peer.sendTxnAnnouncements()
+		}
+	}
+	wn.txnAnnouncePending = false
+}
+
+// sendTxnAnnouncements sends the peer its pending announcements.
+func (wp *wsPeer) sendTxnAnnouncements() {
+	mbytes := append([]byte(protocol.TxnAnnounceTag), marshallTxnDigests(wp.txnAnnouncePending)...)
+	wp.txnAnnouncePending = wp.txnAnnouncePending[:0]
+	if !wp.writeNonBlock(mbytes, false, crypto.Digest{}, time.Now()) {
+		networkPeerBroadcastDropped.Inc(nil)
+	}
+}
+
+// handleTxnAnnouncement requests the announced transaction groups which this node doesn't know yet. A transaction group
+// which is already pending a response from another peer isn't requested, but the peer is remembered as one to request it
+// from if the pending request times out.
+func (wp *wsPeer) handleTxnAnnouncement(msg IncomingMessage) {
+	digests, ok := unmarshallTxnDigests(msg.Data)
+	if !ok {
+		wp.net.log.Warnf("wsPeer handleTxnAnnouncement: bad announcement message size %d from %s", len(msg.Data), wp.conn.RemoteAddr().String())
+		return
+	}
+	now := time.Now()
+	requested := digests[:0]
+	for _, digest := range digests {
+		if wp.net.txnCache.has(digest) {
+			networkTxnAnnounceKnownTotal.Inc(nil)
+			continue
+		}
+		if wp.net.txnRequests.announced(digest, wp, now) {
+			requested = append(requested, digest)
+		}
+	}
+	if len(requested) > 0 {
+		wp.requestTxnGroups(requested)
+	}
+}
+
+// handleTxnGroup marks a transaction group received from the peer as known, so that it won't be requested when it's
+// announced by other peers.
+func (wp *wsPeer) handleTxnGroup(msg IncomingMessage) {
+	digest := generateMessageDigest(msg.Tag, msg.Data)
+	wp.net.txnCache.add(digest, nil)
+	wp.net.txnRequests.received(digest)
+}
+
+// requestedTxnGroup returns true if the message is a transaction group this node requested from the peer, which the
+// incoming bandwidth limit doesn't apply to, since dropping it would leave the request hanging until it times out.
+func (wp *wsPeer) requestedTxnGroup(msg IncomingMessage) bool {
+	if msg.Tag != protocol.TxnTag || !wp.net.config.EnableTxAnnounceGossip {
+		return false
+	}
+	return wp.net.txnRequests.requestedFrom(generateMessageDigest(msg.Tag, msg.Data), wp)
+}
+
+// sendRequestedTxnGroup sends the peer a transaction group it requested, exempt from the outgoing bandwidth limit.
+func (wp *wsPeer) sendRequestedTxnGroup(data []byte) bool {
+	var mbytes []byte
+	if wp.compression && compressibleTags[protocol.TxnTag] {
+		mbytes = compressMessage(protocol.TxnTag, data)
+	} else {
+		mbytes = append([]byte(protocol.TxnTag), data...)
+	}
+	now := time.Now()
+	select {
+	case wp.sendBufferBulk <- sendMessage{data: mbytes, enqueued: now, peerEnqueued: now, requested: true}:
+		return true
+	default:
+		networkBroadcastsDropped.Inc(nil)
+		return false
+	}
+}
+
+// requestTxnGroups asks the peer to send the given transaction groups.
+func (wp *wsPeer) requestTxnGroups(digests []crypto.Digest) {
+	err := wp.Unicast(wp.net.ctx, marshallTxnDigests(digests), protocol.TxnRequestTag)
+	if err != nil {
+		wp.net.log.Debugf("wsPeer requestTxnGroups: unable to request transaction groups from %s : %v", wp.conn.RemoteAddr().String(), err)
+	}
+}
+
+// txnRequestThread requests the transaction groups whose pending requests timed out from the next peers which announced them.
+func (wn *WebsocketNetwork) txnRequestThread() {
+	defer wn.wg.Done()
+	ticker := time.NewTicker(txnRequestTimeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-wn.ctx.Done():
+			return
+		}
+		for peer, digests := range wn.txnRequests.expired(time.Now()) {
+			for len(digests) > 0 {
+				n := len(digests)
+				if n > maxTxnAnnounceDigests {
+					n = maxTxnAnnounceDigests
+				}
+				peer.requestTxnGroups(digests[:n])
+				digests = digests[n:]
+			}
+		}
+	}
+}
+
+// handleTxnRequest sends the peer the transaction groups it requested after we announced them.
+func (wp *wsPeer) handleTxnRequest(msg IncomingMessage) {
+	digests, ok := unmarshallTxnDigests(msg.Data)
+	if !ok {
+		wp.net.log.Warnf("wsPeer handleTxnRequest: bad request message size %d from %s", len(msg.Data), wp.conn.RemoteAddr().String())
+		return
+	}
+	for _, digest := range digests {
+		data, has := wp.net.txnCache.get(digest)
+		if !has {
+			continue
+		}
+		if !wp.sendRequestedTxnGroup(data) {
+			wp.net.log.Debugf("wsPeer handleTxnRequest: unable to send transaction group to %s", wp.conn.RemoteAddr().String())
+			return
+		}
+		networkTxnRequestedTotal.Inc(nil)
+		networkTxnRequestedBytesTotal.AddUint64(uint64(len(data)), nil)
+	}
+}
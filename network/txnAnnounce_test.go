@@ -0,0 +1,286 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+func TestTxnGroupCache(t *testing.T) {
+	cache := makeTxnGroupCache()
+	var digests []crypto.Digest
+	for i := 0; i < txnCacheGenerationSize*3/2; i++ {
+		var digest crypto.Digest
+		crypto.RandBytes(digest[:])
+		digests = append(digests, digest)
+		cache.add(digest, []byte{byte(i)})
+	}
+	// adding a known transaction group without its encoding keeps the encoding.
+	cache.add(digests[len(digests)-1], nil)
+
+	for i, digest := range digests {
+		require.True(t, cache.has(digest))
+		data, has := cache.get(digest)
+		require.True(t, has)
+		require.Equal(t, []byte{byte(i)}, data)
+	}
+
+	// a known transaction group without an encoding can't be served.
+	var digest crypto.Digest
+	crypto.RandBytes(digest[:])
+	cache.add(digest, nil)
+	require.True(t, cache.has(digest))
+	_, has := cache.get(digest)
+	require.False(t, has)
+
+	// the oldest generation is dropped once the current one is full.
+	for i := 0; i < txnCacheGenerationSize; i++ {
+		var digest crypto.Digest
+		crypto.RandBytes(digest[:])
+		cache.add(digest, nil)
+	}
+	require.False(t, cache.has(digests[0]))
+}
+
+func TestTxnRequests(t *testing.T) {
+	requests := makeTxnRequests()
+	peerA, peerB, peerC := &wsPeer{}, &wsPeer{}, &wsPeer{}
+	var digest crypto.Digest
+	crypto.RandBytes(digest[:])
+	now := time.Now()
+
+	// the transaction group is requested only from the first peer announcing it.
+	require.True(t, requests.announced(digest, peerA, now))
+	require.False(t, requests.announced(digest, peerA, now))
+	require.False(t, requests.announced(digest, peerB, now))
+	require.False(t, requests.announced(digest, peerC, now))
+	require.False(t, requests.announced(digest, peerB, now))
+	require.Empty(t, requests.expired(now.Add(txnRequestTimeout/2)))
+
+	require.True(t, requests.requestedFrom(digest, peerA))
+	require.False(t, requests.requestedFrom(digest, peerB))
+
+	// once the request times out, it's requested from the next announcers, in order.
+	now = now.Add(txnRequestTimeout)
+	require.Equal(t, map[*wsPeer][]crypto.Digest{peerB: {digest}}, requests.expired(now))
+	require.True(t, requests.requestedFrom(digest, peerB))
+	require.Empty(t, requests.expired(now))
+	now = now.Add(txnRequestTimeout)
+	require.Equal(t, map[*wsPeer][]crypto.Digest{peerC: {digest}}, requests.expired(now))
+
+	// with no announcers left, it's dropped, so that it's requested again when it's announced anew.
+	now = now.Add(txnRequestTimeout)
+	require.Empty(t, requests.expired(now))
+	require.True(t, requests.announced(digest, peerA, now))
+
+	// a received transaction group isn't requested again.
+	require.False(t, requests.announced(digest, peerB, now))
+	requests.received(digest)
+	require.Empty(t, requests.expired(now.Add(txnRequestTimeout)))
+
+	// the number of pending requests is bounded for each peer, so that a single peer can't starve the others.
+	var digests []crypto.Digest
+	for i := 0; i < maxPendingTxnRequestsPerPeer; i++ {
+		var digest crypto.Digest
+		crypto.RandBytes(digest[:])
+		require.True(t, requests.announced(digest, peerA, now))
+		digests = append(digests, digest)
+	}
+	crypto.RandBytes(digest[:])
+	require.False(t, requests.announced(digest, peerA, now))
+	require.True(t, requests.announced(digest, peerB, now))
+	requests.received(digests[1])
+	crypto.RandBytes(digest[:])
+	require.True(t, requests.announced(digest, peerA, now))
+
+	// the timed out requests aren't moved to the announcers which have too many pending requests already.
+	var fromB crypto.Digest
+	crypto.RandBytes(fromB[:])
+	require.True(t, requests.announced(fromB, peerB, now.Add(-txnRequestTimeout/2)))
+	require.False(t, requests.announced(fromB, peerA, now))
+	require.False(t, requests.announced(fromB, peerC, now))
+	next := requests.expired(now.Add(txnRequestTimeout / 2))
+	require.Equal(t, []crypto.Digest{fromB}, next[peerC])
+	require.Len(t, next, 1)
+
+	// the number of pending requests is bounded overall.
+	requests = makeTxnRequests()
+	for i := 0; i < maxPendingTxnRequests; i++ {
+		var digest crypto.Digest
+		crypto.RandBytes(digest[:])
+		require.True(t, requests.announced(digest, &wsPeer{}, now))
+	}
+	require.False(t, requests.announced(digest, peerA, now))
+}
+
+func TestTxnDigestsEncoding(t *testing.T) {
+	digests := make([]crypto.Digest, 3)
+	for i := range digests {
+		crypto.RandBytes(digests[i][:])
+	}
+	decoded, ok := unmarshallTxnDigests(marshallTxnDigests(digests))
+	require.True(t, ok)
+	require.Equal(t, digests, decoded)
+
+	_, ok = unmarshallTxnDigests(nil)
+	require.False(t, ok)
+	_, ok = unmarshallTxnDigests(make([]byte, crypto.DigestSize+1))
+	require.False(t, ok)
+	_, ok = unmarshallTxnDigests(make([]byte, crypto.DigestSize*(maxTxnAnnounceDigests+1)))
+	require.False(t, ok)
+}
+
+// Set up a relay A and two nodes connected to it: B, which asks for transaction announcements, and C, which sends
+// transactions. Test that B gets each transaction relayed by A exactly once.
+func TestWebsocketNetworkTxnAnnounce(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.config.EnableTxAnnounceGossip = true
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	confB := defaultConfig
+	confB.GossipFanout = 1
+	confB.NetAddress = ""
+	confB.EnableTxAnnounceGossip = true
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	netC := makeTestWebsocketNode(t)
+	netC.config.GossipFanout = 1
+	netC.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netC.Start()
+	defer func() { t.Log("stopping C"); netC.Stop(); t.Log("C done") }()
+
+	relay := newMessageCounter(t, 0)
+	relay.action = Broadcast
+	netA.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: relay}})
+	counter := newMessageCounter(t, 3)
+	counterDone := counter.done
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: counter}})
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+	waitReady(t, netC, readyTimeout.C)
+
+	// wait until A gets the message-of-interest of B.
+	require.Eventually(t, func() bool {
+		for _, peer := range netA.GetPeers(PeersConnectedIn) {
+			if peer.(*wsPeer).wantsTxnAnnouncements() {
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+
+	txns := [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")}
+	for _, txn := range txns {
+		netC.Broadcast(context.Background(), protocol.TxnTag, txn, true, nil)
+	}
+	select {
+	case <-counterDone:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout, count=%d, wanted 3", counter.Count())
+	}
+
+	// announcing the same transactions again shouldn't make B request them.
+	counter.lock.Lock()
+	counter.done = make(chan struct{})
+	counter.target = 4
+	counterDone = counter.done
+	counter.lock.Unlock()
+	for _, txn := range txns {
+		netC.Broadcast(context.Background(), protocol.TxnTag, txn, true, nil)
+	}
+	netC.Broadcast(context.Background(), protocol.TxnTag, []byte("qux"), true, nil)
+	select {
+	case <-counterDone:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout, count=%d, wanted 4", counter.Count())
+	}
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 4, counter.Count())
+}
+
+// Set up a relay A and two nodes connected to it: B, which asks for transaction announcements, and C, which sends
+// transactions, where B limits the transaction gossip to a rate that drops all but the first transaction. Test that B
+// still gets all the transactions it requests from A.
+func TestWebsocketNetworkTxnAnnounceRateLimited(t *testing.T) {
+	netA := makeTestWebsocketNode(t)
+	netA.config.GossipFanout = 1
+	netA.config.EnableTxAnnounceGossip = true
+	netA.Start()
+	defer func() { t.Log("stopping A"); netA.Stop(); t.Log("A done") }()
+	addrA, postListen := netA.Address()
+	require.True(t, postListen)
+
+	confB := defaultConfig
+	confB.GossipFanout = 1
+	confB.NetAddress = ""
+	confB.EnableTxAnnounceGossip = true
+	confB.PeerTagBytesPerSecond = "TX:1"
+	netB := makeTestWebsocketNodeWithConfig(t, confB)
+	netB.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netB.Start()
+	defer func() { t.Log("stopping B"); netB.Stop(); t.Log("B done") }()
+
+	netC := makeTestWebsocketNode(t)
+	netC.config.GossipFanout = 1
+	netC.phonebook.ReplacePeerList([]string{addrA}, "default", PhoneBookEntryRelayRole)
+	netC.Start()
+	defer func() { t.Log("stopping C"); netC.Stop(); t.Log("C done") }()
+
+	relay := newMessageCounter(t, 0)
+	relay.action = Broadcast
+	netA.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: relay}})
+	counter := newMessageCounter(t, 3)
+	netB.RegisterHandlers([]TaggedMessageHandler{{Tag: protocol.TxnTag, MessageHandler: counter}})
+
+	readyTimeout := time.NewTimer(2 * time.Second)
+	waitReady(t, netA, readyTimeout.C)
+	waitReady(t, netB, readyTimeout.C)
+	waitReady(t, netC, readyTimeout.C)
+	require.Eventually(t, func() bool {
+		for _, peer := range netA.GetPeers(PeersConnectedIn) {
+			if peer.(*wsPeer).wantsTxnAnnouncements() {
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+
+	for _, txn := range [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")} {
+		netC.Broadcast(context.Background(), protocol.TxnTag, txn, true, nil)
+	}
+	select {
+	case <-counter.done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout, count=%d, wanted 3", counter.Count())
+	}
+}
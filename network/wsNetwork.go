@@ -292,7 +292,8 @@ type WebsocketNetwork struct {
 	router   *mux.Router
 	scheme   string // are we serving http or https ?
 
-	upgrader websocket.Upgrader
+	// gossipTransports are the enabled transports of the gossip connections, in order of preference.
+	gossipTransports []gossipTransport
 
 	config config.Local
 
@@ -388,6 +389,33 @@ type WebsocketNetwork struct {
 	// that messagesOfInterestEnc does not change once it is set during
 	// network start.
 	messagesOfInterestMu deadlock.Mutex
+
+	// txnCache keeps the recently seen transaction groups, so that the relayed ones could be sent to the peers requesting
+	// them after they were announced, and the known ones won't be requested.
+	txnCache *txnGroupCache
+
+	// txnRequests keeps the announced transaction groups which were requested and not received yet.
+	txnRequests *txnRequests
+
+	// txnAnnouncePending is set when some of the peers have pending transaction group announcements. It is accessed
+	// only by the broadcastThread.
+	txnAnnouncePending bool
+
+	// peerExchange keeps track of the relays learned through the peer exchange. It's nil unless the peer exchange is enabled.
+	peerExchange *peerExchange
+
+	// identity holds the node identity key, and the identities of the peers which are allowed to connect. It's nil
+	// unless the node identity is enabled.
+	identity *nodeIdentity
+
+	// messageTracer traces the sampled messages across the relays. It's nil unless the message tracing is enabled.
+	messageTracer *messageTracer
+
+	// reputation keeps the reputation scores of the peers, and the bans of the misbehaving ones.
+	reputation *peerReputation
+
+	// tagBytesPerSecond holds the per-tag bandwidth limits of the peer connections, parsed from PeerTagBytesPerSecond.
+	tagBytesPerSecond map[protocol.Tag]uint64
 }
 
 type broadcastRequest struct {
@@ -444,6 +472,7 @@ func (wn *WebsocketNetwork) Broadcast(ctx context.Context, tag protocol.Tag, dat
 		case broadcastQueue <- request:
 			// ok, enqueued
 			//wn.log.Debugf("broadcast enqueued")
+			wn.traceBroadcast(tag, data, request.except)
 		case <-wn.ctx.Done():
 			return errNetworkClosing
 		case <-ctx.Done():
@@ -463,6 +492,7 @@ func (wn *WebsocketNetwork) Broadcast(ctx context.Context, tag protocol.Tag, dat
 	select {
 	case broadcastQueue <- request:
 		//wn.log.Debugf("broadcast enqueued nowait")
+		wn.traceBroadcast(tag, data, request.except)
 		return nil
 	default:
 		wn.log.Debugf("broadcast queue full")
@@ -498,6 +528,9 @@ func (wn *WebsocketNetwork) disconnect(badnode Peer, reason disconnectReason) {
 	peer := badnode.(*wsPeer)
 	peer.CloseAndWait()
 	wn.removePeer(peer, reason)
+	if reason == disconnectBadData {
+		wn.ReportPeer(peer, PeerOffenseInvalidMessage)
+	}
 }
 
 func closeWaiter(wg *sync.WaitGroup, peer *wsPeer) {
@@ -567,6 +600,9 @@ func (wn *WebsocketNetwork) GetPeers(options ...PeerOption) []Peer {
 			var addrs []string
 			addrs = wn.phonebook.GetAddresses(1000, PhoneBookEntryRelayRole)
 			for _, addr := range addrs {
+				if wn.isBannedAddress(addr) {
+					continue
+				}
 				peerCore := makePeerCore(wn, addr, wn.GetRoundTripper(), "" /*origin address*/)
 				outPeers = append(outPeers, &peerCore)
 			}
@@ -575,6 +611,9 @@ func (wn *WebsocketNetwork) GetPeers(options ...PeerOption) []Peer {
 			var addrs []string
 			addrs = wn.phonebook.GetAddresses(1000, PhoneBookEntryArchiverRole)
 			for _, addr := range addrs {
+				if wn.isBannedAddress(addr) {
+					continue
+				}
 				peerCore := makePeerCore(wn, addr, wn.GetRoundTripper(), "" /*origin address*/)
 				outPeers = append(outPeers, &peerCore)
 			}
@@ -600,9 +639,7 @@ func (wn *WebsocketNetwork) setup() {
 	wn.dialer = makeRateLimitingDialer(wn.phonebook, preferredResolver)
 	wn.transport = makeRateLimitingTransport(wn.phonebook, 10*time.Second, &wn.dialer, maxIdleConnsPerHost)
 
-	wn.upgrader.ReadBufferSize = 4096
-	wn.upgrader.WriteBufferSize = 4096
-	wn.upgrader.EnableCompression = false
+	wn.gossipTransports = parseGossipTransports(wn.log, wn.config.GossipTransports, []gossipTransport{makeWebsocketTransport(&wn.dialer)})
 	wn.lastPeerConnectionsSent = time.Now()
 	wn.router = mux.NewRouter()
 	wn.router.Handle(GossipNetworkPath, wn)
@@ -656,6 +693,10 @@ func (wn *WebsocketNetwork) setup() {
 		wn.incomingMsgFilter = makeMessageFilter(wn.config.IncomingMessageFilterBucketCount, wn.config.IncomingMessageFilterBucketSize)
 	}
 	wn.connPerfMonitor = makeConnectionPerformanceMonitor([]Tag{protocol.AgreementVoteTag, protocol.TxnTag})
+	wn.txnCache = makeTxnGroupCache()
+	wn.txnRequests = makeTxnRequests()
+	wn.reputation = makePeerReputation(wn.log, wn.config.EnablePeerBanning, wn.config.PeerBanThreshold,
+		time.Duration(wn.config.PeerBanDurationSeconds)*time.Second, time.Duration(wn.config.PeerMaxBanDurationSeconds)*time.Second)
 	wn.lastNetworkAdvance = time.Now().UTC()
 	wn.handlers.log = wn.log
 
@@ -666,6 +707,27 @@ func (wn *WebsocketNetwork) setup() {
 	if wn.relayMessages {
 		wn.RegisterMessageInterest(protocol.CompactCertSigTag)
 	}
+	if wn.config.EnableTxAnnounceGossip {
+		wn.RegisterMessageInterest(protocol.TxnAnnounceTag)
+	}
+	if wn.config.EnablePeerExchange {
+		wn.peerExchange = makePeerExchange(wn.log, wn.config.PeerExchangeTrustedKeys)
+		wn.RegisterMessageInterest(protocol.PeerExchangeTag)
+	}
+	wn.tagBytesPerSecond = parseTagBytesPerSecond(wn.log, wn.config.PeerTagBytesPerSecond)
+	if wn.config.EnableNodeIdentity {
+		wn.identity = makeNodeIdentity(parsePublicKeys(wn.log, "AllowedPeerIdentities", wn.config.AllowedPeerIdentities))
+	} else if wn.config.AllowedPeerIdentities != "" {
+		wn.log.Warnf("AllowedPeerIdentities is ignored since EnableNodeIdentity is disabled")
+	}
+	if wn.config.MessageTraceSampleRate > 0 {
+		if wn.identity != nil {
+			wn.messageTracer = makeMessageTracer(wn.config.MessageTraceSampleRate, wn.identity)
+			wn.RegisterMessageInterest(protocol.MsgTraceTag)
+		} else {
+			wn.log.Warnf("MessageTraceSampleRate is ignored since EnableNodeIdentity is disabled")
+		}
+	}
 }
 
 // Start makes network connections and threads
@@ -702,6 +764,12 @@ func (wn *WebsocketNetwork) Start() {
 		// wrap the limited connection listener with a requests tracker listener
 		wn.listener = wn.requestsTracker.Listener(listener)
 		wn.log.Debugf("listening on %s", wn.listener.Addr().String())
+		for _, transport := range wn.gossipTransports {
+			err = transport.listen(wn.listener.Addr().String())
+			if err != nil {
+				wn.log.Warnf("network could not listen over %s on %v: %s", transport.name(), wn.listener.Addr().String(), err)
+			}
+		}
 		wn.throttledOutgoingConnections = int32(wn.config.GossipFanout / 2)
 	} else {
 		// on non-relay, all the outgoing connections are throttled.
@@ -722,6 +790,17 @@ func (wn *WebsocketNetwork) Start() {
 	if wn.prioScheme != nil {
 		wn.RegisterHandlers(prioHandlers)
 	}
+	if wn.peerExchange != nil {
+		wn.RegisterHandlers(peerExchangeHandlers)
+		wn.log.Infof("peer exchange enabled, relay announcements are signed with key %s", wn.PeerExchangePublicKey())
+	}
+	if wn.identity != nil {
+		wn.log.Infof("node identity enabled, identity=%s", wn.NodeIdentity())
+	}
+	if wn.messageTracer != nil {
+		wn.wg.Add(1)
+		go wn.messageTraceThread()
+	}
 	if wn.listener != nil {
 		wn.wg.Add(1)
 		go wn.httpdThread()
@@ -738,6 +817,10 @@ func (wn *WebsocketNetwork) Start() {
 	}
 	wn.wg.Add(1)
 	go wn.broadcastThread()
+	if wn.config.EnableTxAnnounceGossip {
+		wn.wg.Add(1)
+		go wn.txnRequestThread()
+	}
 	if wn.prioScheme != nil {
 		wn.wg.Add(1)
 		go wn.prioWeightRefresh()
@@ -790,6 +873,10 @@ func (wn *WebsocketNetwork) Stop() {
 	if wn.listener != nil {
 		wn.log.Debugf("closed %s", listenAddr)
 	}
+	if wn.messageTracer != nil && wn.messageTracer.store != nil {
+		wn.messageTracer.store.Close()
+		wn.messageTracer.store = nil
+	}
 
 	wn.messagesOfInterestMu.Lock()
 	defer wn.messagesOfInterestMu.Unlock()
@@ -816,6 +903,14 @@ func (wn *WebsocketNetwork) setHeaders(header http.Header) {
 	header.Set(InstanceNameHeader, localInstanceName)
 	header.Set(AddressHeader, wn.PublicAddress())
 	header.Set(NodeRandomHeader, wn.RandomID)
+	if wn.config.EnableGossipCompression {
+		for _, codec := range supportedCompressionCodecs {
+			header.Add(CompressionHeader, codec)
+		}
+	}
+	for _, transport := range wn.gossipTransports {
+		header.Add(TransportHeader, transport.name())
+	}
 }
 
 // checkServerResponseVariables check that the version and random-id in the request headers matches the server ones.
@@ -846,6 +941,10 @@ func (wn *WebsocketNetwork) checkServerResponseVariables(otherHeader http.Header
 		}
 		return false, ""
 	}
+	if otherCodec := otherHeader.Get(CompressionHeader); otherCodec != "" && wn.negotiateCompression(otherHeader) != otherCodec {
+		wn.log.Warn(filterASCII(fmt.Sprintf("new peer %#v picked a compression codec we didn't offer: %#v, headers %#v", addr, otherCodec, otherHeader)))
+		return false, ""
+	}
 	return true, matchingVersion
 }
 
@@ -859,6 +958,21 @@ func getCommonHeaders(headers http.Header) (otherTelemetryGUID, otherInstanceNam
 
 // checkIncomingConnectionLimits perform the connection limits counting for the incoming connections.
 func (wn *WebsocketNetwork) checkIncomingConnectionLimits(response http.ResponseWriter, request *http.Request, remoteHost, otherTelemetryGUID, otherInstanceName string) int {
+	if wn.reputation.isBanned(remoteHost, time.Now()) {
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "banned"})
+		networkBannedConnectionsTotal.Inc(nil)
+		wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerFailEvent,
+			telemetryspec.ConnectPeerFailEventDetails{
+				Address:      remoteHost,
+				HostName:     otherTelemetryGUID,
+				Incoming:     true,
+				InstanceName: otherInstanceName,
+				Reason:       "Banned",
+			})
+		response.WriteHeader(http.StatusForbidden)
+		return http.StatusForbidden
+	}
+
 	if wn.numIncomingPeers() >= wn.config.IncomingConnectionsLimit {
 		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "incoming_connection_limit"})
 		wn.log.EventWithDetails(telemetryspec.Network, telemetryspec.ConnectPeerFailEvent,
@@ -1001,6 +1115,24 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 		return
 	}
 
+	handshake := wn.makeIncomingHandshake(response, request)
+	transportName := handshake.transportName()
+	if wn.enabledTransport(transportName) == nil || !offersTransport(request.Header, transportName) {
+		wn.log.Info(filterASCII(fmt.Sprintf("new peer %s transport mismatch, connected over %s, mine=%s theirs=%v", request.RemoteAddr, transportName, wn.config.GossipTransports, request.Header[http.CanonicalHeaderKey(TransportHeader)])))
+		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "mismatching transport"})
+		// list our enabled transports, so that the peer could retry over one of them.
+		for _, transport := range wn.gossipTransports {
+			response.Header().Add(TransportHeader, transport.name())
+		}
+		response.WriteHeader(http.StatusPreconditionFailed)
+		message := fmt.Sprintf("Transport %s isn't enabled by both peers", transportName)
+		n, err := response.Write([]byte(message))
+		if err != nil {
+			wn.log.Warnf("ws failed to write response '%s' : n = %d err = %v", message, n, err)
+		}
+		return
+	}
+
 	if wn.checkIncomingConnectionVariables(response, request) != http.StatusOK {
 		// we've already logged and written all response(s).
 		return
@@ -1013,17 +1145,41 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 	wn.setHeaders(responseHeader)
 	responseHeader.Set(ProtocolVersionHeader, matchingVersion)
 	responseHeader.Set(GenesisHeader, wn.GenesisID)
+	wn.setPeerExchangeProof(request.Header, responseHeader)
+	// respond with the compression codec picked for the connection, instead of the list of the supported ones.
+	compressionCodec := wn.negotiateCompression(request.Header)
+	responseHeader.Del(CompressionHeader)
+	if compressionCodec != "" {
+		responseHeader.Set(CompressionHeader, compressionCodec)
+	}
+	// respond with the transport the connection is made over first, followed by the other enabled ones, so that the
+	// peer could connect over its preferred one of them next time.
+	responseHeader.Set(TransportHeader, transportName)
+	for _, transport := range wn.gossipTransports {
+		if transport.name() != transportName {
+			responseHeader.Add(TransportHeader, transport.name())
+		}
+	}
+	identityTranscript, verifyIdentity, status := wn.checkIncomingIdentity(response, request, responseHeader)
+	if status != http.StatusOK {
+		// we've already logged and written all response(s).
+		return
+	}
 	var challenge string
 	if wn.prioScheme != nil {
 		challenge = wn.prioScheme.NewPrioChallenge()
 		responseHeader.Set(PriorityChallengeHeader, challenge)
 	}
-	conn, err := wn.upgrader.Upgrade(response, request, responseHeader)
+	conn, err := handshake.accept(responseHeader)
 	if err != nil {
 		wn.log.Info("ws upgrade fail ", err)
 		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "ws upgrade fail"})
 		return
 	}
+	if verifyIdentity && !wn.verifyIncomingIdentity(conn, identityTranscript) {
+		conn.Close()
+		return
+	}
 
 	// we want to tell the response object that the status was changed to 101 ( switching protocols ) so that it will be logged.
 	if wn.requestsLogger != nil {
@@ -1039,6 +1195,8 @@ func (wn *WebsocketNetwork) ServeHTTP(response http.ResponseWriter, request *htt
 		prioChallenge:     challenge,
 		createTime:        trackedRequest.created,
 		version:           matchingVersion,
+		compression:       compressionCodec != "",
+		identity:          identityTranscript.ClientIdentity,
 	}
 	peer.TelemetryGUID = trackedRequest.otherTelemetryGUID
 	peer.init(wn.config, wn.outgoingMessagesBufferSize)
@@ -1148,6 +1306,7 @@ func (wn *WebsocketNetwork) checkSlowWritingPeers() {
 			wn.wg.Add(1)
 			go wn.disconnectThread(peer, disconnectSlowConn)
 			networkSlowPeerDrops.Inc(nil)
+			wn.ReportPeer(peer, PeerOffenseSlowWriting)
 		}
 	}
 }
@@ -1219,6 +1378,9 @@ func (wn *WebsocketNetwork) broadcastThread() {
 		return true
 	}
 
+	// txnAnnounceTimer fires when the pending transaction group announcements need to be sent.
+	var txnAnnounceTimer <-chan time.Time
+
 	// load the peers list
 	updatePeers()
 
@@ -1238,6 +1400,11 @@ func (wn *WebsocketNetwork) broadcastThread() {
 		default:
 		}
 
+		// arm the announcements timer once some transaction group announcements are pending.
+		if txnAnnounceTimer == nil && wn.txnAnnouncePending {
+			txnAnnounceTimer = time.After(txnAnnounceBatchInterval)
+		}
+
 		// if nothing high prio, try to sample from either queques in a non-blocking fashion.
 		select {
 		case request := <-wn.broadcastQueueHighPrio:
@@ -1246,6 +1413,10 @@ func (wn *WebsocketNetwork) broadcastThread() {
 		case request := <-wn.broadcastQueueBulk:
 			wn.innerBroadcast(request, false, peers)
 			continue
+		case <-txnAnnounceTimer:
+			txnAnnounceTimer = nil
+			wn.flushTxnAnnouncements(peers)
+			continue
 		case <-wn.ctx.Done():
 			return
 		default:
@@ -1263,6 +1434,10 @@ func (wn *WebsocketNetwork) broadcastThread() {
 		case <-slowWritingPeerCheckTicker.C:
 			wn.checkSlowWritingPeers()
 			continue
+		case <-txnAnnounceTimer:
+			txnAnnounceTimer = nil
+			wn.flushTxnAnnouncements(peers)
+			continue
 		case request := <-wn.broadcastQueueBulk:
 			// check if peers need to be updated, since we've been waiting a while.
 			updatePeers()
@@ -1324,6 +1499,20 @@ func (wn *WebsocketNetwork) innerBroadcast(request broadcastRequest, prio bool,
 		digest = crypto.Hash(mbytes)
 	}
 
+	// relayed transaction groups are announced to the peers which asked for it, and sent in full only if they request them.
+	// the ones originating from this node are always sent in full, so that they would propagate as fast as possible.
+	var txnDigest crypto.Digest
+	announceTxn := false
+	if request.tag == protocol.TxnTag && wn.config.EnableTxAnnounceGossip {
+		txnDigest = generateMessageDigest(request.tag, request.data)
+		wn.txnCache.add(txnDigest, request.data)
+		announceTxn = request.except != nil
+	}
+
+	// the compressed encoding is made at most once, for the first of the peers which negotiated compression.
+	var compressedBytes []byte
+	compressible := compressibleTags[request.tag]
+
 	// first send to all the easy outbound peers who don't block, get them started.
 	sentMessageCount := 0
 	for _, peer := range peers {
@@ -1333,7 +1522,19 @@ func (wn *WebsocketNetwork) innerBroadcast(request broadcastRequest, prio bool,
 		if peer == request.except {
 			continue
 		}
-		ok := peer.writeNonBlock(mbytes, prio, digest, request.enqueueTime)
+		if announceTxn && peer.wantsTxnAnnouncements() {
+			wn.queueTxnAnnouncement(peer, txnDigest, len(request.data))
+			sentMessageCount++
+			continue
+		}
+		data := mbytes
+		if compressible && peer.compression {
+			if compressedBytes == nil {
+				compressedBytes = compressMessage(request.tag, request.data)
+			}
+			data = compressedBytes
+		}
+		ok := peer.writeNonBlock(data, prio, digest, request.enqueueTime)
 		if ok {
 			sentMessageCount++
 			continue
@@ -1463,6 +1664,7 @@ func (wn *WebsocketNetwork) meshThread() {
 				wn.phonebook.ReplacePeerList(archiveAddrs, dnsBootstrap, PhoneBookEntryArchiverRole)
 			}
 		}
+		wn.refreshPeerExchange()
 
 		// as long as the call to checkExistingConnectionsNeedDisconnecting is deleting existing connections, we want to
 		// kick off the creation of new connections.
@@ -1502,11 +1704,34 @@ func (wn *WebsocketNetwork) checkNewConnectionsNeeded() bool {
 	}
 	// get more than we need so that we can ignore duplicates
 	newAddrs := wn.phonebook.GetAddresses(desired+numOutgoingTotal, PhoneBookEntryRelayRole)
+	need = wn.connectToAddresses(newAddrs, need)
+	if need > 0 && wn.peerExchange != nil {
+		// the relays learned through the peer exchange are trusted less than the ones from the DNS bootstrap and the
+		// phonebook, so they are connected to only when the operator opted in, and the latter aren't enough. The ones
+		// announced with a trusted key are connected to regardless, to have them prove they hold the key.
+		var exchangedAddrs []string
+		if wn.config.PeerExchangeDialUntrusted {
+			exchangedAddrs = wn.phonebook.GetAddresses(desired+numOutgoingTotal, PhoneBookEntryExchangedRole)
+		} else {
+			_, exchangedAddrs, _ = wn.peerExchange.addresses(time.Now())
+		}
+		wn.connectToAddresses(exchangedAddrs, need)
+	}
+	return true
+}
+
+// connectToAddresses spins async connection go routines to up to need of the given addresses, skipping the ones we're
+// already connected to. It returns the number of connections still needed.
+func (wn *WebsocketNetwork) connectToAddresses(newAddrs []string, need int) int {
 	for _, na := range newAddrs {
 		if na == wn.config.PublicAddress {
 			// filter out self-public address, so we won't try to connect to outselves.
 			continue
 		}
+		if wn.isBannedAddress(na) {
+			networkBannedConnectionsTotal.Inc(nil)
+			continue
+		}
 		gossipAddr, ok := wn.tryConnectReserveAddr(na)
 		if ok {
 			wn.wg.Add(1)
@@ -1517,7 +1742,7 @@ func (wn *WebsocketNetwork) checkNewConnectionsNeeded() bool {
 			}
 		}
 	}
-	return true
+	return need
 }
 
 // checkExistingConnectionsNeedDisconnecting check to see if existing connection need to be dropped due to
@@ -1783,12 +2008,13 @@ const ProtocolVersionHeader = "X-Algorand-Version"
 const ProtocolAcceptVersionHeader = "X-Algorand-Accept-Version"
 
 // SupportedProtocolVersions contains the list of supported protocol versions by this node ( in order of preference ).
-var SupportedProtocolVersions = []string{"2.1"}
+var SupportedProtocolVersions = []string{txnAnnounceProtocolVersion, "2.1"}
 
 // ProtocolVersion is the current version attached to the ProtocolVersionHeader header
 /* Version history:
  *  1   Catchup service over websocket connections with unicast messages between peers
  *  2.1 Introducted topic key/data pairs and enabled services over the gossip connections
+ *  2.2 Peers listing TxnAnnounceTag in their message-of-interest get the digests of the relayed transaction groups, and request the ones they lack
 */
 const ProtocolVersion = "2.1"
 
@@ -1938,15 +2164,40 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 	SetUserAgentHeader(requestHeader)
 	myInstanceName := wn.log.GetInstanceName()
 	requestHeader.Set(InstanceNameHeader, myInstanceName)
-	var websocketDialer = websocket.Dialer{
-		Proxy:             http.ProxyFromEnvironment,
-		HandshakeTimeout:  45 * time.Second,
-		EnableCompression: false,
-		NetDialContext:    wn.dialer.DialContext,
-		NetDial:           wn.dialer.Dial,
-	}
+	pxChallenge := wn.setPeerExchangeChallenge(requestHeader)
 
-	conn, response, err := websocketDialer.DialContext(wn.ctx, gossipAddr, requestHeader)
+	var transport gossipTransport
+	var idChallenge identityChallenge
+	var conn transportConn
+	var response *http.Response
+	var err error
+	// connect over the preferred transport out of the ones the peer has reported, and retry over the next ones if the
+	// peer can't be reached over it, or over the ones the peer lists if it declines it.
+	tried := make(map[string]bool)
+	for transports := wn.dialTransports(wn.phonebook.GetTransports(addr)); len(transports) > 0; {
+		transport, transports = transports[0], transports[1:]
+		tried[transport.name()] = true
+		idChallenge = wn.setIdentityHeaders(requestHeader)
+		conn, response, err = transport.dial(wn.ctx, gossipAddr, requestHeader)
+		if response != nil && len(response.Header[http.CanonicalHeaderKey(TransportHeader)]) > 0 {
+			wn.phonebook.UpdateTransports(addr, response.Header[http.CanonicalHeaderKey(TransportHeader)])
+		}
+		if err == nil {
+			break
+		}
+		if err == websocket.ErrBadHandshake {
+			var untried []gossipTransport
+			for _, otherTransport := range wn.gossipTransports {
+				if !tried[otherTransport.name()] {
+					untried = append(untried, otherTransport)
+				}
+			}
+			transports = fallbackTransports(untried, response)
+		}
+		if len(transports) > 0 {
+			wn.log.Infof("ws connect(%s) over %s failed, retrying over %s: %v", gossipAddr, transport.name(), transports[0].name(), err)
+		}
+	}
 	if err != nil {
 		if err == websocket.ErrBadHandshake {
 			// reading here from ioutil is safe only because it came from DialContext above, which alredy finsihed reading all the data from the network
@@ -1989,6 +2240,17 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 		// The error was already logged, so no need to log again.
 		return
 	}
+	wn.checkPeerExchangeProof(addr, response.Header, pxChallenge)
+	if otherTransport := response.Header.Get(TransportHeader); otherTransport != "" && otherTransport != transport.name() {
+		wn.log.Warn(filterASCII(fmt.Sprintf("new peer %#v reported a transport other than %s: %#v", gossipAddr, transport.name(), otherTransport)))
+		conn.Close()
+		return
+	}
+	peerIdentity, ok := wn.checkServerIdentity(conn, response.Header, idChallenge, gossipAddr)
+	if !ok {
+		conn.Close()
+		return
+	}
 
 	throttledConnection := false
 	if atomic.AddInt32(&wn.throttledOutgoingConnections, int32(-1)) >= 0 {
@@ -2006,6 +2268,8 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 		connMonitor:                 wn.connPerfMonitor,
 		throttledOutgoingConnection: throttledConnection,
 		version:                     matchingVersion,
+		compression:                 response.Header.Get(CompressionHeader) != "",
+		identity:                    peerIdentity,
 	}
 	peer.TelemetryGUID, peer.InstanceName, _ = getCommonHeaders(response.Header)
 	peer.init(wn.config, wn.outgoingMessagesBufferSize)
@@ -2024,6 +2288,16 @@ func (wn *WebsocketNetwork) tryConnect(addr, gossipAddr string) {
 	peers.Set(float64(wn.NumPeers()), nil)
 	outgoingPeers.Set(float64(wn.numOutgoingPeers()), nil)
 
+	// the message-of-interest is sent to the outgoing peers only to ask them for the transaction group announcements,
+	// the relays they know of, or the message traces; otherwise, it's sent to the incoming peers alone.
+	askAnnouncements := wn.config.EnableTxAnnounceGossip && matchingVersion == txnAnnounceProtocolVersion
+	if (askAnnouncements || wn.peerExchange != nil || wn.messageTracer != nil) && wn.messagesOfInterestEnc != nil {
+		err = peer.Unicast(wn.ctx, wn.messagesOfInterestEnc, protocol.MsgOfInterestTag)
+		if err != nil {
+			wn.log.Infof("ws send msgOfInterest: %v", err)
+		}
+	}
+
 	if wn.prioScheme != nil {
 		challenge := response.Header.Get(PriorityChallengeHeader)
 		if challenge != "" {
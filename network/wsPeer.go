@@ -72,6 +72,7 @@ var defaultSendMessageTags = map[protocol.Tag]bool{
 	protocol.TopicMsgRespTag:    true,
 	protocol.MsgOfInterestTag:   true,
 	protocol.TxnTag:             true,
+	protocol.TxnRequestTag:      true,
 	protocol.UniCatchupReqTag:   true,
 	protocol.UniEnsBlockReqTag:  true,
 	protocol.VoteBundleTag:      true,
@@ -92,6 +93,7 @@ type sendMessage struct {
 	enqueued     time.Time             // the time at which the message was first generated
 	peerEnqueued time.Time             // the time at which the peer was attempting to enqueue the message
 	msgTags      map[protocol.Tag]bool // when msgTags is speficied ( i.e. non-nil ), the send goroutine is to replace the message tag filter with this one. No data would be accompanied to this message.
+	requested    bool                  // the message responds to a request of the peer, so it's exempt from the bandwidth limits.
 }
 
 // wsPeerCore also works for non-connected peers we want to do HTTP GET from
@@ -115,6 +117,7 @@ const disconnectLeastPerformingPeer disconnectReason = "LeastPerformingPeer"
 const disconnectCliqueResolve disconnectReason = "CliqueResolving"
 const disconnectRequestReceived disconnectReason = "DisconnectRequest"
 const disconnectStaleWrite disconnectReason = "DisconnectStaleWrite"
+const disconnectBanned disconnectReason = "Banned"
 
 // Response is the structure holding the response from the server
 type Response struct {
@@ -156,6 +159,11 @@ type wsPeer struct {
 	incomingMsgFilter *messageFilter
 	outgoingMsgFilter *messageFilter
 
+	// incomingLimiter and outgoingLimiter enforce the bandwidth limits of the connection. They're nil when no limit is
+	// configured, and are used only by the readLoop and the writeLoop, respectively.
+	incomingLimiter *bandwidthLimiter
+	outgoingLimiter *bandwidthLimiter
+
 	processed chan struct{}
 
 	pingLock              deadlock.Mutex
@@ -180,6 +188,10 @@ type wsPeer struct {
 	// peer version ( this is one of the version supported by the current node and listed in SupportedProtocolVersions )
 	version string
 
+	// compression is set when a compression codec was negotiated for the connection. The messages of the compressibleTags
+	// sent over such a connection carry a compression flag byte ahead of their payload.
+	compression bool
+
 	// Nonce used to uniquely identify requests
 	requestNonce uint64
 
@@ -204,6 +216,27 @@ type wsPeer struct {
 	// throttledOutgoingConnection determines if this outgoing connection will be throttled bassed on it's
 	// performance or not. Throttled connections are more likely to be short-lived connections.
 	throttledOutgoingConnection bool
+
+	// txnAnnounce is set to a non-zero value when the peer asked to get announcements of the transaction groups we relay
+	// instead of the transaction groups themselves. It's written by the read loop and read atomically by the broadcastThread.
+	txnAnnounce int32
+
+	// traffic counts the messages and bytes exchanged with the peer.
+	traffic peerTrafficStats
+
+	// floodWindowStart, floodWindowMessages and floodWindowDuplicates keep the number of messages, and the number of duplicate
+	// messages, received from the peer since the start of the current duplicate flood detection window. These are
+	// accessed only by the read loop.
+	floodWindowStart      time.Time
+	floodWindowMessages   int
+	floodWindowDuplicates int
+
+	// txnAnnouncePending are the digests of the transaction groups waiting to be announced to the peer. It is accessed only
+	// by the broadcastThread.
+	txnAnnouncePending []crypto.Digest
+
+	// identity is the node identity the peer proved during the handshake, or a zero identity if it didn't authenticate.
+	identity crypto.SignatureVerifier
 }
 
 // HTTPPeer is what the opaque Peer might be.
@@ -265,6 +298,9 @@ func (wp *wsPeer) Unicast(ctx context.Context, msg []byte, tag protocol.Tag) err
 	if tag != protocol.MsgDigestSkipTag && len(msg) >= messageFilterSize {
 		digest = crypto.Hash(mbytes)
 	}
+	if wp.compression && compressibleTags[tag] {
+		mbytes = compressMessage(tag, msg)
+	}
 
 	ok := wp.writeNonBlock(mbytes, false, digest, time.Now())
 	if !ok {
@@ -327,6 +363,10 @@ func (wp *wsPeer) init(config config.Local, sendBufferLength int) {
 		wp.outgoingMsgFilter = makeMessageFilter(config.OutgoingMessageFilterBucketCount, config.OutgoingMessageFilterBucketSize)
 	}
 
+	now := time.Now()
+	wp.incomingLimiter = makeBandwidthLimiter(bandwidthIncoming, config.PeerIncomingBytesPerSecond, wp.net.tagBytesPerSecond, now)
+	wp.outgoingLimiter = makeBandwidthLimiter(bandwidthOutgoing, config.PeerOutgoingBytesPerSecond, wp.net.tagBytesPerSecond, now)
+
 	wp.wg.Add(2)
 	go wp.readLoop()
 	go wp.writeLoop()
@@ -401,7 +441,23 @@ func (wp *wsPeer) readLoop() {
 		atomic.StoreInt64(&wp.lastPacketTime, msg.Received)
 		networkReceivedBytesTotal.AddUint64(uint64(len(msg.Data)+2), nil)
 		networkMessageReceivedTotal.AddUint64(1, nil)
+		wp.traffic.received(msg.Tag, len(msg.Data)+2)
 		msg.Sender = wp
+		wireLength := len(msg.Data) + 2
+
+		if wp.compression && compressibleTags[msg.Tag] {
+			msg.Data, err = decompressMessage(msg.Tag, msg.Data)
+			if err != nil {
+				wp.net.log.Warnf("wsPeer readLoop: unable to decompress the %s message from %s : %v", msg.Tag, wp.conn.RemoteAddr().String(), err)
+				networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "decompression"})
+				return
+			}
+		}
+
+		if wp.incomingLimiter != nil && !wp.requestedTxnGroup(msg) && !wp.incomingLimiter.allow(msg.Tag, wireLength, time.Now()) {
+			// the message was read off the wire already, but it isn't processed nor relayed any further.
+			continue
+		}
 
 		// for outgoing connections, we want to notify the connection monitor that we've received
 		// a message. The connection monitor would update it's statistics accordingly.
@@ -445,9 +501,28 @@ func (wp *wsPeer) readLoop() {
 			// network maintenance message handled immediately instead of handing off to general handlers
 			wp.handleFilterMessage(msg)
 			continue
+		case protocol.TxnAnnounceTag:
+			wp.handleTxnAnnouncement(msg)
+			continue
+		case protocol.TxnRequestTag:
+			wp.handleTxnRequest(msg)
+			continue
+		case protocol.MsgTraceTag:
+			wp.handleMessageTrace(msg)
+			continue
+		case protocol.TxnTag:
+			if wp.net.config.EnableTxAnnounceGossip {
+				wp.handleTxnGroup(msg)
+			}
 		}
+		// the arrivals are traced before the duplicates are dropped, since every path the message took is traced.
+		wp.net.traceArrival(wp, msg.Tag, msg.Data, msg.Received)
 		if len(msg.Data) > 0 && wp.incomingMsgFilter != nil && dedupSafeTag(msg.Tag) {
-			if wp.incomingMsgFilter.CheckIncomingMessage(msg.Tag, msg.Data, true, true) {
+			duplicate := wp.incomingMsgFilter.CheckIncomingMessage(msg.Tag, msg.Data, true, true)
+			if wp.checkDuplicateFlood(duplicate, time.Now()) {
+				wp.net.ReportPeer(wp, PeerOffenseDuplicateFlood)
+			}
+			if duplicate {
 				//wp.net.log.Debugf("dropped incoming duplicate %s(%d)", msg.Tag, len(msg.Data))
 				duplicateNetworkMessageReceivedTotal.Inc(nil)
 				duplicateNetworkMessageReceivedBytesTotal.AddUint64(uint64(len(msg.Data)+len(msg.Tag)), nil)
@@ -483,6 +558,11 @@ func (wp *wsPeer) handleMessageOfInterest(msg IncomingMessage) (shutdown bool) {
 		wp.net.log.Warnf("wsPeer handleMessageOfInterest: could not unmarshall message from: %s %v", wp.conn.RemoteAddr().String(), err)
 		return
 	}
+	wp.updateTxnAnnouncements(msgTagsMap)
+	if msgTagsMap[protocol.PeerExchangeTag] && wp.net.sharesRelays() {
+		// share the relays we know of once the write loop applied the new message-of-interest of the peer.
+		defer wp.sendPeerExchange()
+	}
 	sm := sendMessage{
 		data:         nil,
 		enqueued:     time.Now(),
@@ -557,6 +637,9 @@ func (wp *wsPeer) writeLoopSend(msg sendMessage) disconnectReason {
 		networkConnectionsDroppedTotal.Inc(map[string]string{"reason": "stale message"})
 		return disconnectStaleWrite
 	}
+	if wp.outgoingLimiter != nil && !msg.requested && !wp.outgoingLimiter.allow(tag, len(msg.data), time.Now()) {
+		return disconnectReasonNone
+	}
 	atomic.StoreInt64(&wp.intermittentOutgoingMessageEnqueueTime, msg.enqueued.UnixNano())
 	defer atomic.StoreInt64(&wp.intermittentOutgoingMessageEnqueueTime, 0)
 	err := wp.conn.WriteMessage(websocket.BinaryMessage, msg.data)
@@ -570,6 +653,7 @@ func (wp *wsPeer) writeLoopSend(msg sendMessage) disconnectReason {
 	atomic.StoreInt64(&wp.lastPacketTime, time.Now().UnixNano())
 	networkSentBytesTotal.AddUint64(uint64(len(msg.data)), nil)
 	networkMessageSentTotal.AddUint64(1, nil)
+	wp.traffic.sent(tag, len(msg.data))
 	networkMessageQueueMicrosTotal.AddUint64(uint64(time.Now().Sub(msg.peerEnqueued).Nanoseconds()/1000), nil)
 	return disconnectReasonNone
 }
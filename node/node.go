@@ -57,6 +57,14 @@ import (
 
 const participationKeyCheckSecs = 60
 
+const (
+	// automaticFastCatchupAttempts is the number of times the automatic fast catchup would attempt to discover a catchpoint label
+	automaticFastCatchupAttempts = 5
+	// automaticFastCatchupRetryInterval is the time the automatic fast catchup waits before each discovery attempt, giving
+	// the node a chance to connect to the relays
+	automaticFastCatchupRetryInterval = 30 * time.Second
+)
+
 // StatusReport represents the current basic status of the node
 type StatusReport struct {
 	LastRound                          basics.Round
@@ -87,6 +95,15 @@ func (status StatusReport) TimeSinceLastRound() time.Duration {
 	return time.Since(status.LastRoundTimestamp)
 }
 
+// ErrDevModeDisabled is returned when using a development mode functionality on a node that doesn't run in development mode.
+var ErrDevModeDisabled = fmt.Errorf("the node is not running in development mode")
+
+// ErrPeerStatsUnsupported is returned when querying the peers of a node whose network doesn't report them.
+var ErrPeerStatsUnsupported = fmt.Errorf("the node network does not report its peers")
+
+// ErrPeerBansUnsupported is returned when managing the peer bans of a node whose network doesn't support them.
+var ErrPeerBansUnsupported = fmt.Errorf("the node network does not support peer bans")
+
 // AlgorandFullNode specifies and implements a full Algorand node.
 type AlgorandFullNode struct {
 	nodeContextData
@@ -135,6 +152,12 @@ type AlgorandFullNode struct {
 	tracer messagetracer.MessageTracer
 
 	compactCert *compactcert.Worker
+
+	// devMode indicates that the node runs on a development mode network, where a block is written for each
+	// transaction group the node receives, instead of running the agreement.
+	devMode bool
+	// timestampOffset is the number of seconds added to the wall clock when timestamping development mode blocks.
+	timestampOffset uint64
 }
 
 // TxnWithStatus represents information about a single transaction,
@@ -159,21 +182,29 @@ type TxnWithStatus struct {
 // MakeFull sets up an Algorand full node
 // (i.e., it returns a node that participates in consensus)
 func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAddresses []string, genesis bookkeeping.Genesis) (*AlgorandFullNode, error) {
+	p2pNode, err := network.NewWebsocketNetwork(log.With("name", cfg.NetAddress), cfg, phonebookAddresses, genesis.ID(), genesis.Network)
+	if err != nil {
+		log.Errorf("could not create websocket node: %v", err)
+		return nil, err
+	}
+	return MakeFullWithNetwork(log, rootDir, cfg, p2pNode, genesis)
+}
 
+// MakeFullWithNetwork sets up an Algorand full node over the given gossip network, such as a network.SimulatedNetwork.
+func MakeFullWithNetwork(log logging.Logger, rootDir string, cfg config.Local, p2pNode network.GossipNode, genesis bookkeeping.Genesis) (*AlgorandFullNode, error) {
 	node := new(AlgorandFullNode)
 	node.rootDir = rootDir
 	node.config = cfg
 	node.log = log.With("name", cfg.NetAddress)
 	node.genesisID = genesis.ID()
 	node.genesisHash = crypto.HashObj(genesis)
+	node.devMode = genesis.DevMode
 
 	// tie network, block fetcher, and agreement services together
-	p2pNode, err := network.NewWebsocketNetwork(node.log, node.config, phonebookAddresses, genesis.ID(), genesis.Network)
-	if err != nil {
-		log.Errorf("could not create websocket node: %v", err)
-		return nil, err
+	wsNet, isWebsocketNetwork := p2pNode.(*network.WebsocketNetwork)
+	if isWebsocketNetwork {
+		wsNet.SetPrioScheme(node)
 	}
-	p2pNode.SetPrioScheme(node)
 	node.net = p2pNode
 	node.accountManager = data.MakeAccountManager(log)
 
@@ -184,11 +215,29 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 	ledgerPathnamePrefix := filepath.Join(genesisDir, config.LedgerFilenamePrefix)
 
 	// create initial ledger, if it doesn't exist
-	err = os.Mkdir(genesisDir, 0700)
+	err := os.Mkdir(genesisDir, 0700)
 	if err != nil && !os.IsExist(err) {
 		log.Errorf("Unable to create genesis directroy: %v", err)
 		return nil, err
 	}
+	if isWebsocketNetwork {
+		err = wsNet.LoadPeerBans(filepath.Join(genesisDir, network.PeerBanListFilename))
+		if err != nil {
+			log.Warnf("Unable to load the peer bans: %v", err)
+		}
+		err = wsNet.LoadPeerExchangeKey(filepath.Join(genesisDir, network.PeerExchangeKeyFilename))
+		if err != nil {
+			log.Warnf("Unable to load the peer exchange key: %v", err)
+		}
+		err = wsNet.LoadNodeIdentityKey(filepath.Join(genesisDir, network.NodeIdentityKeyFilename))
+		if err != nil {
+			log.Warnf("Unable to load the node identity key: %v", err)
+		}
+		err = wsNet.LoadMessageTraceStore(filepath.Join(genesisDir, network.MessageTraceFilename))
+		if err != nil {
+			log.Warnf("Unable to load the message trace store: %v", err)
+		}
+	}
 	var genalloc data.GenesisBalances
 	genalloc, err = bootstrapData(genesis, log)
 	if err != nil {
@@ -206,6 +255,9 @@ func MakeFull(log logging.Logger, rootDir string, cfg config.Local, phonebookAdd
 	}
 
 	node.transactionPool = pools.MakeTransactionPool(node.ledger.Ledger, cfg, node.log)
+	if node.devMode {
+		node.transactionPool.SetRememberedCallback(node.writeDevmodeTxGroup)
+	}
 
 	blockListeners := []ledger.BlockListener{
 		node.transactionPool,
@@ -350,7 +402,9 @@ func (node *AlgorandFullNode) Start() {
 		node.catchpointCatchupService.Start(node.ctx)
 	} else {
 		node.catchupService.Start()
-		node.agreementService.Start()
+		if !node.devMode {
+			node.agreementService.Start()
+		}
 		node.txPoolSyncerService.Start(node.catchupService.InitialSyncDone)
 		node.blockService.Start()
 		node.ledgerService.Start()
@@ -371,6 +425,11 @@ func (node *AlgorandFullNode) Start() {
 		}
 
 		node.startMonitoringRoutines()
+
+		if node.config.EnableAutomaticFastCatchup && !node.devMode {
+			node.monitoringRoutinesWaitGroup.Add(1)
+			go node.automaticFastCatchup(node.ctx)
+		}
 	}
 
 }
@@ -410,6 +469,8 @@ func (node *AlgorandFullNode) Stop() {
 	defer func() {
 		node.mu.Unlock()
 		node.waitMonitoringRoutines()
+		// the old keys deletion routine reads the compact cert database, so it's closed only once the routine exits.
+		node.compactCert.Shutdown()
 	}()
 
 	node.net.ClearHandlers()
@@ -418,7 +479,9 @@ func (node *AlgorandFullNode) Stop() {
 		node.catchpointCatchupService.Stop()
 	} else {
 		node.txHandler.Stop()
-		node.agreementService.Shutdown()
+		if !node.devMode {
+			node.agreementService.Shutdown()
+		}
 		node.catchupService.Stop()
 		node.txPoolSyncerService.Stop()
 		node.blockService.Stop()
@@ -429,7 +492,6 @@ func (node *AlgorandFullNode) Stop() {
 	node.lowPriorityCryptoVerificationPool.Shutdown()
 	node.cryptoPool.Shutdown()
 	node.cancelCtx()
-	node.compactCert.Shutdown()
 	if node.indexer != nil {
 		node.indexer.Shutdown()
 	}
@@ -472,6 +534,12 @@ func (node *AlgorandFullNode) BroadcastSignedTxGroup(txgroup []transactions.Sign
 		return err
 	}
 
+	if node.devMode {
+		// the pool had the transaction group written into a block as it remembered it, and there is no network to
+		// broadcast it to.
+		return nil
+	}
+
 	err = node.ledger.VerifiedTransactionCache().Pin(txgroup)
 	if err != nil {
 		logging.Base().Infof("unable to pin transaction: %v", err)
@@ -492,6 +560,127 @@ func (node *AlgorandFullNode) BroadcastSignedTxGroup(txgroup []transactions.Sign
 	return nil
 }
 
+// writeDevmodeTxGroup writes a block holding the transaction group the pool just remembered: there is no agreement
+// to propose it on a development mode network. The transaction group is written this way whether it came from the
+// API or from a peer.
+func (node *AlgorandFullNode) writeDevmodeTxGroup(txgroup []transactions.SignedTxn) {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	_, err := node.writeDevmodeBlock()
+	if err != nil {
+		node.log.Warnf("unable to write a development mode block: %v - transaction group was %+v", err, txgroup)
+	}
+}
+
+// writeDevmodeBlock assembles a block out of the transactions in the pool and adds it to the ledger, timestamped
+// with the wall clock shifted by the node's timestamp offset. The node.mu lock is expected to be taken.
+func (node *AlgorandFullNode) writeDevmodeBlock() (basics.Round, error) {
+	vb, err := node.transactionPool.AssembleDevModeBlock()
+	if err != nil {
+		return 0, err
+	}
+	rnd := vb.Block().Round()
+	prev, err := node.ledger.BlockHdr(rnd - 1)
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix() + int64(node.timestampOffset)
+	if timestamp < prev.TimeStamp {
+		timestamp = prev.TimeStamp
+	}
+
+	// there is no agreement, so the block is added with an empty certificate.
+	err = node.ledger.AddValidatedBlock(vb.WithTimestamp(timestamp), agreement.Certificate{Round: rnd})
+	if err != nil {
+		return 0, err
+	}
+	return rnd, nil
+}
+
+// AdvanceDevModeRounds writes count blocks to the ledger of a development mode node, the first of which holds
+// any transaction still in the pool. It returns the round of the last block written.
+func (node *AlgorandFullNode) AdvanceDevModeRounds(count uint64) (rnd basics.Round, err error) {
+	if !node.devMode {
+		return 0, ErrDevModeDisabled
+	}
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	rnd = node.ledger.Latest()
+	for i := uint64(0); i < count; i++ {
+		rnd, err = node.writeDevmodeBlock()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// SetBlockTimeStampOffset sets the number of seconds added to the wall clock when timestamping the blocks of a
+// development mode node.
+func (node *AlgorandFullNode) SetBlockTimeStampOffset(offset uint64) error {
+	if !node.devMode {
+		return ErrDevModeDisabled
+	}
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	node.timestampOffset = offset
+	return nil
+}
+
+// GetBlockTimeStampOffset returns the number of seconds added to the wall clock when timestamping the blocks of a
+// development mode node.
+func (node *AlgorandFullNode) GetBlockTimeStampOffset() (uint64, error) {
+	if !node.devMode {
+		return 0, ErrDevModeDisabled
+	}
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	return node.timestampOffset, nil
+}
+
+// PendingCompactCerts returns the signature collection progress of the
+// compact certificates that were not formed yet.
+func (node *AlgorandFullNode) PendingCompactCerts() ([]compactcert.PendingCert, error) {
+	return node.compactCert.PendingCerts()
+}
+
+// NetworkPeers returns a snapshot of the state of the peers the node is connected to.
+func (node *AlgorandFullNode) NetworkPeers() ([]network.PeerStats, error) {
+	wsNet, ok := node.net.(*network.WebsocketNetwork)
+	if !ok {
+		return nil, ErrPeerStatsUnsupported
+	}
+	return wsNet.PeerStats(), nil
+}
+
+// PeerBans returns the peer hosts currently banned by the gossip network.
+func (node *AlgorandFullNode) PeerBans() ([]network.PeerBan, error) {
+	wsNet, ok := node.net.(*network.WebsocketNetwork)
+	if !ok {
+		return nil, ErrPeerBansUnsupported
+	}
+	return wsNet.PeerBans(), nil
+}
+
+// BanPeer bans the given peer host, which is either an IP address or a host name, for the given duration.
+func (node *AlgorandFullNode) BanPeer(host string, duration time.Duration) error {
+	wsNet, ok := node.net.(*network.WebsocketNetwork)
+	if !ok {
+		return ErrPeerBansUnsupported
+	}
+	wsNet.BanPeerHost(host, "manual", duration)
+	return nil
+}
+
+// UnbanPeer lifts the ban of the given peer host. It returns false if the host wasn't banned.
+func (node *AlgorandFullNode) UnbanPeer(host string) (bool, error) {
+	wsNet, ok := node.net.(*network.WebsocketNetwork)
+	if !ok {
+		return false, ErrPeerBansUnsupported
+	}
+	return wsNet.UnbanPeerHost(host), nil
+}
+
 // ListTxns returns SignedTxns associated with a specific account in a range of Rounds (inclusive).
 // TxnWithStatus returns the round in which a particular transaction appeared,
 // since that information is not part of the SignedTxn itself.
@@ -915,6 +1104,40 @@ func (node *AlgorandFullNode) StartCatchup(catchpoint string) error {
 	return nil
 }
 
+// automaticFastCatchup discovers the latest catchpoint label advertised by a quorum of the relays, and starts a catchpoint
+// catchup toward it if the node is at least AutomaticFastCatchupMinRounds behind it.
+func (node *AlgorandFullNode) automaticFastCatchup(ctx context.Context) {
+	defer node.monitoringRoutinesWaitGroup.Done()
+	for attempt := 0; attempt < automaticFastCatchupAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(automaticFastCatchupRetryInterval):
+		}
+		label, err := catchup.DiscoverCatchpointLabel(ctx, node.log, node.net, node.config)
+		if err != nil {
+			node.log.Infof("automaticFastCatchup: unable to discover a catchpoint label : %v", err)
+			continue
+		}
+		round, _, err := ledgercore.ParseCatchpointLabel(label)
+		if err != nil {
+			node.log.Warnf("automaticFastCatchup: unable to parse discovered catchpoint label '%s' : %v", label, err)
+			return
+		}
+		latest := node.ledger.Latest()
+		if round <= latest+basics.Round(node.config.AutomaticFastCatchupMinRounds) {
+			node.log.Infof("automaticFastCatchup: ledger round %d is close enough to the latest catchpoint %s; skipping fast catchup", latest, label)
+			return
+		}
+		node.log.Infof("automaticFastCatchup: ledger round %d is far behind the latest catchpoint; catching up toward %s", latest, label)
+		err = node.StartCatchup(label)
+		if err != nil {
+			node.log.Warnf("automaticFastCatchup: unable to start catching up toward %s : %v", label, err)
+		}
+		return
+	}
+}
+
 // AbortCatchup aborts the given catchpoint
 // this function is intended to be called externally via the REST api interface.
 func (node *AlgorandFullNode) AbortCatchup(catchpoint string) error {
@@ -958,7 +1181,9 @@ func (node *AlgorandFullNode) SetCatchpointCatchupMode(catchpointCatchupMode boo
 			}()
 			node.net.ClearHandlers()
 			node.txHandler.Stop()
-			node.agreementService.Shutdown()
+			if !node.devMode {
+				node.agreementService.Shutdown()
+			}
 			node.catchupService.Stop()
 			node.txPoolSyncerService.Stop()
 			node.blockService.Stop()
@@ -977,7 +1202,9 @@ func (node *AlgorandFullNode) SetCatchpointCatchupMode(catchpointCatchupMode boo
 		// start
 		node.transactionPool.Reset()
 		node.catchupService.Start()
-		node.agreementService.Start()
+		if !node.devMode {
+			node.agreementService.Start()
+		}
 		node.txPoolSyncerService.Start(node.catchupService.InitialSyncDone)
 		node.blockService.Start()
 		node.ledgerService.Start()
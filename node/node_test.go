@@ -35,6 +35,7 @@ import (
 	"github.com/algorand/go-algorand/data/account"
 	"github.com/algorand/go-algorand/data/basics"
 	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/util"
@@ -496,3 +497,95 @@ func TestMismatchingGenesisDirectoryPermissions(t *testing.T) {
 	require.NoError(t, os.Chmod(testDirectroy, 1700))
 	require.NoError(t, os.RemoveAll(testDirectroy))
 }
+
+func TestDevModeBlocks(t *testing.T) {
+	testDirectory, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(testDirectory)
+
+	secret := crypto.GenerateSignatureSecrets(crypto.Seed{0x1})
+	sender := basics.Address(secret.SignatureVerifier)
+	receiver := basics.Address{0x2}
+	genesis := bookkeeping.Genesis{
+		SchemaID:    "go-test-node-genesis",
+		Proto:       protocol.ConsensusCurrentVersion,
+		Network:     config.Devtestnet,
+		FeeSink:     sinkAddr.String(),
+		RewardsPool: poolAddr.String(),
+		DevMode:     true,
+		Allocation: []bookkeeping.GenesisAllocation{
+			{Address: sender.String(), State: basics.AccountData{MicroAlgos: basics.MicroAlgos{Raw: 1000000000}}},
+			{Address: sinkAddr.String(), State: basics.AccountData{Status: basics.NotParticipating, MicroAlgos: basics.MicroAlgos{Raw: 1000000}}},
+			{Address: poolAddr.String(), State: basics.AccountData{Status: basics.NotParticipating, MicroAlgos: basics.MicroAlgos{Raw: 1000000}}},
+		},
+	}
+	cfg := config.GetDefaultLocal()
+	cfg.NetAddress = ""
+	cfg.DNSBootstrapID = ""
+	node, err := MakeFull(logging.TestingLog(t), testDirectory, cfg, []string{}, genesis)
+	require.NoError(t, err)
+	node.Start()
+	defer node.Stop()
+
+	// a transaction group is written into a block right away.
+	proto := config.Consensus[protocol.ConsensusCurrentVersion]
+	tx := transactions.Transaction{
+		Type: protocol.PaymentTx,
+		Header: transactions.Header{
+			Sender:      sender,
+			Fee:         basics.MicroAlgos{Raw: proto.MinTxnFee},
+			FirstValid:  1,
+			LastValid:   basics.Round(proto.MaxTxnLife),
+			GenesisID:   genesis.ID(),
+			GenesisHash: crypto.HashObj(genesis),
+		},
+		PaymentTxnFields: transactions.PaymentTxnFields{
+			Receiver: receiver,
+			Amount:   basics.MicroAlgos{Raw: proto.MinBalance},
+		},
+	}
+	err = node.BroadcastSignedTxGroup([]transactions.SignedTxn{tx.Sign(secret)})
+	require.NoError(t, err)
+	require.Equal(t, basics.Round(1), node.ledger.Latest())
+	blk, err := node.ledger.Block(1)
+	require.NoError(t, err)
+	payset, err := blk.DecodePaysetFlat()
+	require.NoError(t, err)
+	require.Len(t, payset, 1)
+	require.Equal(t, tx.ID(), payset[0].ID())
+
+	// so is a transaction group the pool remembers from a peer, through the transaction handler.
+	tx.Amount = basics.MicroAlgos{Raw: 1}
+	require.NoError(t, node.transactionPool.RememberOne(tx.Sign(secret)))
+	require.Equal(t, basics.Round(2), node.ledger.Latest())
+	blk, err = node.ledger.Block(2)
+	require.NoError(t, err)
+	payset, err = blk.DecodePaysetFlat()
+	require.NoError(t, err)
+	require.Len(t, payset, 1)
+	require.Equal(t, tx.ID(), payset[0].ID())
+
+	// blocks are timestamped with the wall clock shifted by the offset.
+	const offset = 1000000
+	require.NoError(t, node.SetBlockTimeStampOffset(offset))
+	currentOffset, err := node.GetBlockTimeStampOffset()
+	require.NoError(t, err)
+	require.Equal(t, uint64(offset), currentOffset)
+	before := time.Now().Unix()
+	rnd, err := node.AdvanceDevModeRounds(2)
+	require.NoError(t, err)
+	require.Equal(t, basics.Round(4), rnd)
+	require.Equal(t, basics.Round(4), node.ledger.Latest())
+	hdr, err := node.ledger.BlockHdr(4)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, hdr.TimeStamp, before+offset)
+	require.LessOrEqual(t, hdr.TimeStamp, time.Now().Unix()+offset)
+
+	// block timestamps never go backward.
+	require.NoError(t, node.SetBlockTimeStampOffset(0))
+	_, err = node.AdvanceDevModeRounds(1)
+	require.NoError(t, err)
+	nextHdr, err := node.ledger.BlockHdr(5)
+	require.NoError(t, err)
+	require.Equal(t, hdr.TimeStamp, nextHdr.TimeStamp)
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build simulated
+
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/data/account"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/algorand/go-algorand/util/db"
+)
+
+// simulatedClusterKeyRounds is the number of rounds the participation keys of the simulated cluster accounts are
+// valid for.
+const simulatedClusterKeyRounds = 1000
+
+// simulatedClusterStake is the stake of each one of the simulated cluster accounts.
+const simulatedClusterStake = 1000000000
+
+// simulatedClusterClockStep is the step by which the virtual clock of the switchboard follows the real one.
+const simulatedClusterClockStep = time.Millisecond
+
+// SimulatedClusterConfig describes the nodes of a SimulatedCluster, and the network connecting them.
+type SimulatedClusterConfig struct {
+	// Nodes is the number of nodes. Each one of them has a participating account, all with the same stake.
+	Nodes int
+
+	// Relays is the number of relays among the nodes. The relays are connected with each other, and the rest of the
+	// nodes are connected to all the relays. When zero, all the nodes are relays.
+	Relays int
+
+	// Seed seeds the generation of the accounts and of the messages lost by the simulated network. The clusters made
+	// with the same seed have the same accounts, and the switchboard delivers and loses the messages the same way for
+	// the same sends on its virtual clock. The runs of the cluster may still differ, since the nodes are timed by the
+	// real clock, which the virtual one follows while the cluster runs.
+	Seed int64
+
+	// Link is the default conditions of the links between the nodes. The conditions of specific links can be set
+	// on the Switchboard.
+	Link network.SimulatedLink
+
+	// Proto is the consensus protocol of the genesis block.
+	Proto protocol.ConsensusVersion
+
+	// Local is the configuration of all the nodes.
+	Local config.Local
+}
+
+// SimulatedCluster is a set of full nodes running in a single process, connected by a network.SimulatedSwitchboard
+// instead of sockets. It allows writing fast multi-node tests, which control the topology and the conditions of the
+// network.
+type SimulatedCluster struct {
+	Switchboard *network.SimulatedSwitchboard
+	Genesis     bookkeeping.Genesis
+	Nodes       []*AlgorandFullNode
+	Networks    []*network.SimulatedNetwork
+
+	relays     int
+	stopClock  context.CancelFunc
+	clockGroup sync.WaitGroup
+}
+
+// SimulatedClusterNodeName returns the name of the i-th node of a SimulatedCluster on its switchboard.
+func SimulatedClusterNodeName(i int) string {
+	return fmt.Sprintf("node%d", i)
+}
+
+// simulatedClusterSecrets derives the secrets of an account of the cluster from the cluster seed.
+func simulatedClusterSecrets(clusterSeed int64, i int) *crypto.SignatureSecrets {
+	var buf [16]byte
+	binary.LittleEndian.PutUint64(buf[:8], uint64(clusterSeed))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(i))
+	return crypto.GenerateSignatureSecrets(crypto.Seed(crypto.Hash(buf[:])))
+}
+
+// MakeSimulatedCluster creates the nodes of a simulated cluster, with their data directories under the given root
+// directory. The nodes aren't started until Start is called.
+func MakeSimulatedCluster(log logging.Logger, rootDir string, cfg SimulatedClusterConfig) (*SimulatedCluster, error) {
+	if cfg.Nodes <= 0 || cfg.Relays < 0 || cfg.Relays > cfg.Nodes {
+		return nil, fmt.Errorf("invalid simulated cluster of %d nodes and %d relays", cfg.Nodes, cfg.Relays)
+	}
+	proto, ok := config.Consensus[cfg.Proto]
+	if !ok {
+		return nil, fmt.Errorf("unknown consensus protocol %s", cfg.Proto)
+	}
+
+	cluster := &SimulatedCluster{
+		Switchboard: network.MakeSimulatedSwitchboard(log, cfg.Seed, cfg.Link),
+		Genesis: bookkeeping.Genesis{
+			SchemaID:    fmt.Sprintf("simulated-cluster-%d", cfg.Seed),
+			Proto:       cfg.Proto,
+			Network:     config.Devtestnet,
+			FeeSink:     basics.Address(crypto.Hash([]byte("simulated cluster fee sink"))).String(),
+			RewardsPool: basics.Address(crypto.Hash([]byte("simulated cluster rewards pool"))).String(),
+		},
+		Nodes:    make([]*AlgorandFullNode, cfg.Nodes),
+		Networks: make([]*network.SimulatedNetwork, cfg.Nodes),
+		relays:   cfg.Relays,
+	}
+	if cluster.relays == 0 {
+		cluster.relays = cfg.Nodes
+	}
+	for _, special := range []string{cluster.Genesis.FeeSink, cluster.Genesis.RewardsPool} {
+		cluster.Genesis.Allocation = append(cluster.Genesis.Allocation, bookkeeping.GenesisAllocation{
+			Address: special,
+			State:   basics.AccountData{Status: basics.NotParticipating, MicroAlgos: basics.MicroAlgos{Raw: proto.MinBalance}},
+		})
+	}
+
+	// generate the participation keys first, since they're needed for the genesis allocation.
+	for i := 0; i < cfg.Nodes; i++ {
+		name := SimulatedClusterNodeName(i)
+		genesisDir := filepath.Join(rootDir, name, cluster.Genesis.ID())
+		err := os.MkdirAll(genesisDir, 0700)
+		if err != nil {
+			return nil, err
+		}
+		address := basics.Address(simulatedClusterSecrets(cfg.Seed, i).SignatureVerifier)
+		access, err := db.MakeAccessor(filepath.Join(genesisDir, config.PartKeyFilename(name, 0, simulatedClusterKeyRounds)), false, false)
+		if err != nil {
+			return nil, err
+		}
+		part, err := account.FillDBWithParticipationKeys(access, address, 0, simulatedClusterKeyRounds, proto.DefaultKeyDilution)
+		access.Close()
+		if err != nil {
+			return nil, err
+		}
+		cluster.Genesis.Allocation = append(cluster.Genesis.Allocation, bookkeeping.GenesisAllocation{
+			Address: address.String(),
+			Comment: name,
+			State: basics.AccountData{
+				Status:          basics.Online,
+				MicroAlgos:      basics.MicroAlgos{Raw: simulatedClusterStake},
+				SelectionID:     part.VRFSecrets().PK,
+				VoteID:          part.VotingSecrets().OneTimeSignatureVerifier,
+				VoteFirstValid:  0,
+				VoteLastValid:   simulatedClusterKeyRounds,
+				VoteKeyDilution: proto.DefaultKeyDilution,
+			},
+		})
+	}
+
+	for i := 0; i < cfg.Nodes; i++ {
+		name := SimulatedClusterNodeName(i)
+		relay := i < cluster.relays
+		net, err := cluster.Switchboard.MakeNode(name, cluster.Genesis.ID(), relay)
+		if err != nil {
+			return nil, err
+		}
+		nodeCfg := cfg.Local
+		nodeCfg.NetAddress = ""
+		// the cadaver file is named after the process rather than the node, so the nodes would interleave their traces.
+		nodeCfg.CadaverSizeTarget = 0
+		if relay {
+			// relays are archival and serve the blocks and the ledgers, as when they're configured with a NetAddress.
+			nodeCfg.NetAddress = name
+			nodeCfg.Archival = true
+			nodeCfg.EnableLedgerService = true
+			nodeCfg.EnableBlockService = true
+		}
+		node, err := MakeFullWithNetwork(log.With("node", name), filepath.Join(rootDir, name), nodeCfg, net, cluster.Genesis)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create simulated node %s : %v", name, err)
+		}
+		cluster.Nodes[i] = node
+		cluster.Networks[i] = net
+	}
+	return cluster, nil
+}
+
+// Start connects the nodes, the relays with each other and the rest of the nodes to all the relays, and then starts
+// them, along with the virtual clock of the switchboard. The networks are connected before the nodes start, so that
+// the first proposals reach all the nodes.
+func (c *SimulatedCluster) Start() error {
+	for _, net := range c.Networks {
+		net.Start()
+	}
+	for i := range c.Nodes {
+		for relay := 0; relay < c.relays && relay < i; relay++ {
+			err := c.Switchboard.Connect(SimulatedClusterNodeName(i), SimulatedClusterNodeName(relay))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	var ctx context.Context
+	ctx, c.stopClock = context.WithCancel(context.Background())
+	c.clockGroup.Add(1)
+	go func() {
+		defer c.clockGroup.Done()
+		c.Switchboard.RunClock(ctx, simulatedClusterClockStep)
+	}()
+	for _, node := range c.Nodes {
+		node.Start()
+	}
+	return nil
+}
+
+// Stop stops all the nodes, and then the virtual clock of the switchboard.
+func (c *SimulatedCluster) Stop() {
+	for _, node := range c.Nodes {
+		node.Stop()
+	}
+	if c.stopClock != nil {
+		c.stopClock()
+		c.clockGroup.Wait()
+	}
+}
+
+// WaitForRound waits until all the nodes have the given round in their ledger, or returns an error once the timeout
+// expires.
+func (c *SimulatedCluster) WaitForRound(round basics.Round, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for i, node := range c.Nodes {
+		select {
+		case <-node.Ledger().Wait(round):
+		case <-deadline:
+			return fmt.Errorf("%s didn't reach round %d within %v; it's at round %d", SimulatedClusterNodeName(i), round, timeout, node.Ledger().Latest())
+		}
+	}
+	return nil
+}
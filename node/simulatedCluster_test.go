@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2021 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build simulated
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/config"
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/logging"
+	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// Run a cluster of two relays and three other nodes, and test that it makes progress, that it keeps making progress
+// while one of the nodes is partitioned away, and that the node catches up once the partition heals.
+func TestSimulatedCluster(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the simulated cluster runs in real time")
+	}
+	testDirectory, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(testDirectory)
+
+	const fastProto = protocol.ConsensusVersion("test-simulated-cluster")
+	params := config.Consensus[protocol.ConsensusCurrentVersion]
+	params.AgreementFilterTimeout = 500 * time.Millisecond
+	params.AgreementFilterTimeoutPeriod0 = 500 * time.Millisecond
+	config.Consensus[fastProto] = params
+	defer delete(config.Consensus, fastProto)
+
+	cluster, err := MakeSimulatedCluster(logging.TestingLog(t), testDirectory, SimulatedClusterConfig{
+		Nodes:  5,
+		Relays: 2,
+		Seed:   1,
+		Link:   network.SimulatedLink{Latency: 10 * time.Millisecond},
+		Proto:  fastProto,
+		Local:  config.GetDefaultLocal(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, cluster.Start())
+	defer cluster.Stop()
+	require.Len(t, cluster.Networks[0].GetPeers(network.PeersConnectedIn), 4)
+	require.Len(t, cluster.Networks[4].GetPeers(network.PeersConnectedOut), 2)
+
+	require.NoError(t, cluster.WaitForRound(3, time.Minute))
+
+	isolated := cluster.Nodes[4]
+	for relay := 0; relay < 2; relay++ {
+		cluster.Switchboard.Disconnect(SimulatedClusterNodeName(4), SimulatedClusterNodeName(relay))
+	}
+	isolatedRound := isolated.Ledger().Latest()
+	target := isolatedRound + 3
+	select {
+	case <-cluster.Nodes[0].Ledger().Wait(target):
+	case <-time.After(time.Minute):
+		require.Fail(t, "the cluster didn't make progress without the isolated node")
+	}
+	require.Less(t, uint64(isolated.Ledger().Latest()), uint64(target))
+
+	for relay := 0; relay < 2; relay++ {
+		require.NoError(t, cluster.Switchboard.Connect(SimulatedClusterNodeName(4), SimulatedClusterNodeName(relay)))
+	}
+	require.NoError(t, cluster.WaitForRound(target+basics.Round(2), time.Minute))
+}
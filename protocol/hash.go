@@ -37,7 +37,9 @@ const (
 	BalanceRecord     HashID = "BR"
 	Credential        HashID = "CR"
 	Genesis           HashID = "GE"
+	IdentityChallenge HashID = "IC"
 	MerkleArrayNode   HashID = "MA"
+	MessageTraceHop   HashID = "MH"
 	Message           HashID = "MX"
 	NetPrioResponse   HashID = "NPR"
 	OneTimeSigKey1    HashID = "OT1"
@@ -47,6 +49,8 @@ const (
 	Program           HashID = "Program"
 	ProgramData       HashID = "ProgData"
 	ProposerSeed      HashID = "PS"
+	RelayAnnouncement HashID = "RA"
+	RelayKeyProof     HashID = "RK"
 	Seed              HashID = "SD"
 	SpecialAddr       HashID = "SpecialAddr"
 	SignedTxnInBlock  HashID = "STIB"
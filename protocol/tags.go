@@ -27,12 +27,17 @@ const (
 	UnknownMsgTag      Tag = "??"
 	AgreementVoteTag   Tag = "AV"
 	CompactCertSigTag  Tag = "CS"
+	IdentityVerifyTag  Tag = "IV"
 	MsgOfInterestTag   Tag = "MI"
 	MsgDigestSkipTag   Tag = "MS"
+	MsgTraceTag        Tag = "MT"
 	NetPrioResponseTag Tag = "NP"
 	PingTag            Tag = "pi"
 	PingReplyTag       Tag = "pj"
 	ProposalPayloadTag Tag = "PP"
+	PeerExchangeTag    Tag = "PX"
+	TxnAnnounceTag     Tag = "TA"
+	TxnRequestTag      Tag = "TQ"
 	TopicMsgRespTag    Tag = "TS"
 	TxnTag             Tag = "TX"
 	UniCatchupReqTag   Tag = "UC" //Replaced by UniEnsBlockReqTag. Only for backward compatibility.
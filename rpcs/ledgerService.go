@@ -35,6 +35,7 @@ import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/logging"
 	"github.com/algorand/go-algorand/network"
+	"github.com/algorand/go-algorand/protocol"
 )
 
 const (
@@ -47,6 +48,16 @@ const (
 	// e.g. .Handle(LedgerServiceLedgerPath, &ls)
 	LedgerServiceLedgerPath = "/v{version:[0-9.]+}/{genesisID}/ledger/{round:[0-9a-z]+}"
 
+	// LedgerServiceCatchpointLabelsPath is the path to register the handler listing the labels of the catchpoint files
+	// the LedgerService serves
+	LedgerServiceCatchpointLabelsPath = "/v{version:[0-9.]+}/{genesisID}/catchpoints"
+
+	// CatchpointLabelsResponseContentType is the HTTP Content-Type header for the catchpoint labels response
+	CatchpointLabelsResponseContentType = "application/x-algorand-catchpoints-v1"
+
+	// MaxCatchpointLabelsResponseCount is the maximum number of catchpoint labels listed in a single catchpoint labels response
+	MaxCatchpointLabelsResponseCount = 16
+
 	// maxCatchpointFileSize is the default catchpoint file size, if we can't get a concreate number from the ledger.
 	maxCatchpointFileSize = 512 * 1024 * 1024 // 512MB
 
@@ -54,6 +65,13 @@ const (
 	expectedWorstUploadSpeedBytesPerSecond = 20 * 1024
 )
 
+// CatchpointLabelsResponse lists the labels of the latest catchpoint files a LedgerService serves, latest first.
+type CatchpointLabelsResponse struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Labels []string `codec:"labels,allocbound=MaxCatchpointLabelsResponseCount"`
+}
+
 // LedgerService represents the Ledger RPC API
 type LedgerService struct {
 	// running is non-zero once the service is running, and zero when it's not running. it needs to be at a 32-bit aligned address for RasPI support.
@@ -76,6 +94,7 @@ func MakeLedgerService(config config.Local, ledger *data.Ledger, net network.Gos
 	// the underlying gorilla/mux doesn't support "unregister", so we're forced to implement it ourselves.
 	if service.enableService {
 		net.RegisterHTTPHandler(LedgerServiceLedgerPath, service)
+		net.RegisterHTTPHandler(LedgerServiceCatchpointLabelsPath, http.HandlerFunc(service.ServeCatchpointLabels))
 	}
 	return service
 }
@@ -98,6 +117,7 @@ func (ls *LedgerService) Stop() {
 // ServerHTTP returns ledgers for a particular round
 // Either /v{version}/{genesisID}/ledger/{round} or ?r={round}&v={version}
 // Uses gorilla/mux for path argument parsing.
+// Requests carrying a Range header are served the requested bytes of the compressed catchpoint file.
 func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.Request) {
 	ls.stopping.Add(1)
 	defer ls.stopping.Done()
@@ -206,6 +226,14 @@ func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.R
 	}
 
 	response.Header().Set("Content-Type", LedgerResponseContentType)
+	if request.Header.Get("Range") != "" {
+		// byte ranges are always served out of the compressed file, so that a client could download
+		// different parts of the same file from different peers and resume interrupted downloads.
+		if seeker, ok := cs.(io.ReadSeeker); ok {
+			http.ServeContent(response, request, "", time.Time{}, seeker)
+			return
+		}
+	}
 	requestedCompressedResponse := strings.Contains(request.Header.Get("Accept-Encoding"), "gzip")
 	if requestedCompressedResponse {
 		response.Header().Set("Content-Encoding", "gzip")
@@ -228,3 +256,39 @@ func (ls *LedgerService) ServeHTTP(response http.ResponseWriter, request *http.R
 		logging.Base().Infof("LedgerService.ServeHTTP : unable to write decompressed catchpoint file for round %d, written bytes %d : %v", round, written, err)
 	}
 }
+
+// ServeCatchpointLabels returns the labels of the latest catchpoint files this service serves
+// /v{version}/{genesisID}/catchpoints
+func (ls *LedgerService) ServeCatchpointLabels(response http.ResponseWriter, request *http.Request) {
+	ls.stopping.Add(1)
+	defer ls.stopping.Done()
+	if atomic.AddInt32(&ls.running, 0) == 0 {
+		response.WriteHeader(http.StatusNotFound)
+		return
+	}
+	pathVars := mux.Vars(request)
+	if versionStr := pathVars["version"]; versionStr != "1" {
+		logging.Base().Debugf("http catchpoint labels bad version '%s'", versionStr)
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(fmt.Sprintf("unsupported version '%s'", versionStr)))
+		return
+	}
+	if genesisID := pathVars["genesisID"]; genesisID != ls.genesisID {
+		logging.Base().Debugf("http catchpoint labels bad genesisID mine=%#v theirs=%#v", ls.genesisID, genesisID)
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(fmt.Sprintf("mismatching genesisID '%s'", genesisID)))
+		return
+	}
+	labels, err := ls.ledger.GetCatchpointLabels(MaxCatchpointLabelsResponseCount)
+	if err != nil {
+		logging.Base().Warnf("ServeCatchpointLabels : failed to retrieve catchpoint labels %v", err)
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(fmt.Sprintf("catchpoint labels could not be retrieved due to internal error : %v", err)))
+		return
+	}
+	encodedResponse := protocol.Encode(&CatchpointLabelsResponse{Labels: labels})
+	response.Header().Set("Content-Type", CatchpointLabelsResponseContentType)
+	response.Header().Set("Content-Length", strconv.Itoa(len(encodedResponse)))
+	response.WriteHeader(http.StatusOK)
+	response.Write(encodedResponse)
+}
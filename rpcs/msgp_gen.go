@@ -7,6 +7,14 @@ import (
 )
 
 // The following msgp objects are implemented in this file:
+// CatchpointLabelsResponse
+//             |-----> (*) MarshalMsg
+//             |-----> (*) CanMarshalMsg
+//             |-----> (*) UnmarshalMsg
+//             |-----> (*) CanUnmarshalMsg
+//             |-----> (*) Msgsize
+//             |-----> (*) MsgIsZero
+//
 // EncodedBlockCert
 //         |-----> (*) MarshalMsg
 //         |-----> (*) CanMarshalMsg
@@ -16,6 +24,164 @@ import (
 //         |-----> (*) MsgIsZero
 //
 
+// MarshalMsg implements msgp.Marshaler
+func (z *CatchpointLabelsResponse) MarshalMsg(b []byte) (o []byte) {
+	o = msgp.Require(b, z.Msgsize())
+	// omitempty: check for empty values
+	zb0002Len := uint32(1)
+	var zb0002Mask uint8 /* 2 bits */
+	if len((*z).Labels) == 0 {
+		zb0002Len--
+		zb0002Mask |= 0x2
+	}
+	// variable map header, size zb0002Len
+	o = append(o, 0x80|uint8(zb0002Len))
+	if zb0002Len != 0 {
+		if (zb0002Mask & 0x2) == 0 { // if not empty
+			// string "labels"
+			o = append(o, 0xa6, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73)
+			if (*z).Labels == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o = msgp.AppendArrayHeader(o, uint32(len((*z).Labels)))
+			}
+			for zb0001 := range (*z).Labels {
+				o = msgp.AppendString(o, (*z).Labels[zb0001])
+			}
+		}
+	}
+	return
+}
+
+func (_ *CatchpointLabelsResponse) CanMarshalMsg(z interface{}) bool {
+	_, ok := (z).(*CatchpointLabelsResponse)
+	return ok
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CatchpointLabelsResponse) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0002 int
+	var zb0003 bool
+	zb0002, zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if _, ok := err.(msgp.TypeError); ok {
+		zb0002, zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		if zb0002 > 0 {
+			zb0002--
+			var zb0004 int
+			var zb0005 bool
+			zb0004, zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "struct-from-array", "Labels")
+				return
+			}
+			if zb0004 > MaxCatchpointLabelsResponseCount {
+				err = msgp.ErrOverflow(uint64(zb0004), uint64(MaxCatchpointLabelsResponseCount))
+				err = msgp.WrapError(err, "struct-from-array", "Labels")
+				return
+			}
+			if zb0005 {
+				(*z).Labels = nil
+			} else if (*z).Labels != nil && cap((*z).Labels) >= zb0004 {
+				(*z).Labels = ((*z).Labels)[:zb0004]
+			} else {
+				(*z).Labels = make([]string, zb0004)
+			}
+			for zb0001 := range (*z).Labels {
+				(*z).Labels[zb0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "struct-from-array", "Labels", zb0001)
+					return
+				}
+			}
+		}
+		if zb0002 > 0 {
+			err = msgp.ErrTooManyArrayFields(zb0002)
+			if err != nil {
+				err = msgp.WrapError(err, "struct-from-array")
+				return
+			}
+		}
+	} else {
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		if zb0003 {
+			(*z) = CatchpointLabelsResponse{}
+		}
+		for zb0002 > 0 {
+			zb0002--
+			field, bts, err = msgp.ReadMapKeyZC(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+			switch string(field) {
+			case "labels":
+				var zb0006 int
+				var zb0007 bool
+				zb0006, zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Labels")
+					return
+				}
+				if zb0006 > MaxCatchpointLabelsResponseCount {
+					err = msgp.ErrOverflow(uint64(zb0006), uint64(MaxCatchpointLabelsResponseCount))
+					err = msgp.WrapError(err, "Labels")
+					return
+				}
+				if zb0007 {
+					(*z).Labels = nil
+				} else if (*z).Labels != nil && cap((*z).Labels) >= zb0006 {
+					(*z).Labels = ((*z).Labels)[:zb0006]
+				} else {
+					(*z).Labels = make([]string, zb0006)
+				}
+				for zb0001 := range (*z).Labels {
+					(*z).Labels[zb0001], bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Labels", zb0001)
+						return
+					}
+				}
+			default:
+				err = msgp.ErrNoField(string(field))
+				if err != nil {
+					err = msgp.WrapError(err)
+					return
+				}
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+func (_ *CatchpointLabelsResponse) CanUnmarshalMsg(z interface{}) bool {
+	_, ok := (z).(*CatchpointLabelsResponse)
+	return ok
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *CatchpointLabelsResponse) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize
+	for zb0001 := range (*z).Labels {
+		s += msgp.StringPrefixSize + len((*z).Labels[zb0001])
+	}
+	return
+}
+
+// MsgIsZero returns whether this is a zero value
+func (z *CatchpointLabelsResponse) MsgIsZero() bool {
+	return (len((*z).Labels) == 0)
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *EncodedBlockCert) MarshalMsg(b []byte) (o []byte) {
 	o = msgp.Require(b, z.Msgsize())
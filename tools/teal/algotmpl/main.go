@@ -137,11 +137,10 @@ func initCommandsFromDir(dirname string) error {
 		if err != nil {
 			return err
 		}
-		data, err := ioutil.ReadFile(fullpath)
+		filedata[i], err = readTemplate(fullpath, 0)
 		if err != nil {
 			return err
 		}
-		filedata[i] = string(data)
 	}
 
 	for i := range fnames {
@@ -179,3 +178,35 @@ func initCommandsFromDir(dirname string) error {
 	}
 	return nil
 }
+
+// maxIncludeDepth limits nesting of #include in templates
+const maxIncludeDepth = 16
+
+// readTemplate reads a template, replacing each #include "file" line with
+// the contents of the file, relative to the including template
+func readTemplate(path string, depth int) (string, error) {
+	if depth > maxIncludeDepth {
+		return "", fmt.Errorf("%s: #include nests too deeply", path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "#include" {
+			continue
+		}
+		name := strings.Trim(fields[1], "\"")
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(filepath.Dir(path), name)
+		}
+		included, err := readTemplate(name, depth+1)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = strings.TrimSuffix(included, "\n")
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -401,6 +401,97 @@ func (db *Accessor) Vacuum(ctx context.Context) (stats VacuumStats, err error) {
 	return
 }
 
+// backupPagesPerStep is the number of database pages copied in each step of a backup.
+const backupPagesPerStep = 1024
+
+// Snapshot keeps a read transaction open on a connection to the database, so that it keeps seeing the content
+// the database had when the snapshot was taken while the database is written through other connections. The
+// database is expected to be in WAL mode, so that the snapshot doesn't block the writers.
+type Snapshot struct {
+	conn *sql.Conn
+}
+
+// Snapshot takes a snapshot of the database. The caller is expected to close the returned snapshot once done with it.
+func (db *Accessor) Snapshot(ctx context.Context) (*Snapshot, error) {
+	conn, err := db.Handle.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.ExecContext(ctx, "BEGIN")
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// the read transaction only starts, and picks the content it sees, on its first read.
+	var count int
+	err = conn.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master").Scan(&count)
+	if err != nil {
+		conn.ExecContext(context.Background(), "ROLLBACK")
+		conn.Close()
+		return nil, err
+	}
+	return &Snapshot{conn: conn}, nil
+}
+
+// Close ends the read transaction of the snapshot.
+func (s *Snapshot) Close() error {
+	_, err := s.conn.ExecContext(context.Background(), "ROLLBACK")
+	err2 := s.conn.Close()
+	if err == nil {
+		err = err2
+	}
+	return err
+}
+
+// Backup writes a copy of the database, as of the snapshot, into the given file using the sqlite online backup API.
+// The database is copied a few pages at a time, so that the backup could be interrupted through the context. Any
+// existing content of the destination file is overwritten.
+func (s *Snapshot) Backup(ctx context.Context, destFileName string) error {
+	destDB, err := sql.Open("sqlite3", URI(destFileName, false, false))
+	if err != nil {
+		return err
+	}
+	defer destDB.Close()
+	destConn, err := destDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer destConn.Close()
+
+	return destConn.Raw(func(destDriverConn interface{}) error {
+		return s.conn.Raw(func(srcDriverConn interface{}) error {
+			destSQLiteConn, ok := destDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected destination database connection type %T", destDriverConn)
+			}
+			srcSQLiteConn, ok := srcDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected source database connection type %T", srcDriverConn)
+			}
+			backup, err := destSQLiteConn.Backup("main", srcSQLiteConn, "main")
+			if err != nil {
+				return err
+			}
+			for {
+				// the steps read the source through the read transaction of the snapshot, so the copy isn't
+				// restarted by the writes made to the database in between the steps.
+				done, err := backup.Step(backupPagesPerStep)
+				if err != nil {
+					backup.Close()
+					return err
+				}
+				if done {
+					return backup.Close()
+				}
+				if ctx.Err() != nil {
+					backup.Close()
+					return ctx.Err()
+				}
+			}
+		})
+	})
+}
+
 // URI returns the sqlite URI given a db filename as an input.
 func URI(filename string, readOnly bool, memory bool) string {
 	uri := fmt.Sprintf("file:%s?_busy_timeout=%d&_synchronous=full", filename, busy)
@@ -462,3 +462,46 @@ func TestReadingWhileWriting(t *testing.T) {
 	require.Equal(t, 2, count)
 
 }
+
+func TestSnapshotBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbbackup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	acc, err := MakeAccessor(filepath.Join(dir, "src.db"), false, false)
+	require.NoError(t, err)
+	defer acc.Close()
+	_, err = acc.Handle.Exec("CREATE TABLE foo (a INTEGER, b BLOB)")
+	require.NoError(t, err)
+	// enough rows to take several backup steps.
+	for i := 0; i < 4*backupPagesPerStep; i++ {
+		_, err = acc.Handle.Exec("INSERT INTO foo(a,b) VALUES (?,?)", i, make([]byte, 1024))
+		require.NoError(t, err)
+	}
+
+	snapshot, err := acc.Snapshot(context.Background())
+	require.NoError(t, err)
+	defer snapshot.Close()
+
+	// the snapshot doesn't hold back the writers, and doesn't see their changes.
+	_, err = acc.Handle.Exec("DELETE FROM foo WHERE a < 100")
+	require.NoError(t, err)
+
+	backupFileName := filepath.Join(dir, "backup.db")
+	err = snapshot.Backup(context.Background(), backupFileName)
+	require.NoError(t, err)
+
+	backupAcc, err := MakeAccessor(backupFileName, true, false)
+	require.NoError(t, err)
+	defer backupAcc.Close()
+	var count int
+	err = backupAcc.Handle.QueryRow("SELECT count(*) FROM foo").Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 4*backupPagesPerStep, count)
+
+	// a canceled backup is interrupted.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = snapshot.Backup(ctx, filepath.Join(dir, "canceled.db"))
+	require.Equal(t, context.Canceled, err)
+}
@@ -17,6 +17,7 @@
 package s3
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -41,6 +42,7 @@ const (
 	s3UploadBucketEnvVariable  = "S3_UPLOAD_BUCKET"
 	s3ReleaseBucketEnvVariable = "S3_RELEASE_BUCKET"
 	s3RegionEnvVariable        = "S3_REGION"
+	s3EndpointEnvVariable      = "S3_ENDPOINT"
 
 	s3DefaultReleaseBucket = "algorand-releases"
 	s3DefaultUploadBucket  = "algorand-uploads"
@@ -50,6 +52,9 @@ const (
 	uploadAction   = "upload"
 )
 
+// ErrObjectNotFound is returned by GetObject when the bucket has no file with the requested name
+var ErrObjectNotFound = errors.New("object not found")
+
 // Helper encapsulates the s3 session state for interactive with our default S3 bucket with appropriate credentials
 type Helper struct {
 	session *session.Session
@@ -82,6 +87,12 @@ func getS3Region() (region string) {
 	return
 }
 
+// getS3Endpoint returns the endpoint of an S3-compatible store to use instead of AWS, if one was configured
+func getS3Endpoint() (endpoint string) {
+	endpoint, _ = os.LookupEnv(s3EndpointEnvVariable)
+	return
+}
+
 // MakeS3SessionForUploadWithBucket upload to bucket
 func MakeS3SessionForUploadWithBucket(awsBucket string) (helper Helper, err error) {
 	creds, err := getCredentials(uploadAction, awsBucket)
@@ -187,8 +198,14 @@ func makeS3Session(credentials *credentials.Credentials, bucket string) (helper
 	if err != nil {
 		return
 	}
-	sess, err := session.NewSession(&aws.Config{Region: aws.String(getS3Region()),
-		Credentials: credentials})
+	awsConfig := &aws.Config{Region: aws.String(getS3Region()),
+		Credentials: credentials}
+	if endpoint := getS3Endpoint(); endpoint != "" {
+		// S3-compatible stores are typically addressed by path rather than by a bucket subdomain.
+		awsConfig.Endpoint = aws.String(endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(awsConfig)
 	if err != nil {
 		return
 	}
@@ -231,6 +248,22 @@ func (helper *Helper) DownloadFile(name string, writer io.WriterAt) error {
 	return err
 }
 
+// GetObject returns a stream of the content of the specified file; the caller is expected to close it
+func (helper *Helper) GetObject(ctx context.Context, name string) (io.ReadCloser, error) {
+	output, err := s3.New(helper.session).GetObjectWithContext(ctx,
+		&s3.GetObjectInput{
+			Bucket: &helper.bucket,
+			Key:    aws.String(name),
+		})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return output.Body, nil
+}
+
 // UploadChannelFiles uploads the provided set of package files in a batch
 func (helper *Helper) UploadChannelFiles(channel string, files []string) error {
 	subFolder := filepath.Join("channel", channel)
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,6 +100,20 @@ func Test_getS3Region(t *testing.T) {
 	}
 }
 
+func Test_getS3Endpoint(t *testing.T) {
+	os.Unsetenv("S3_ENDPOINT")
+	require.Equal(t, "", getS3Endpoint())
+
+	os.Setenv("S3_ENDPOINT", "http://localhost:9000")
+	defer os.Unsetenv("S3_ENDPOINT")
+	require.Equal(t, "http://localhost:9000", getS3Endpoint())
+
+	helper, err := makeS3Session(credentials.AnonymousCredentials, "bucket")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:9000", *helper.session.Config.Endpoint)
+	require.True(t, *helper.session.Config.S3ForcePathStyle)
+}
+
 func TestMakeS3SessionForUploadWithBucket(t *testing.T) {
 	const bucket1 = "test-bucket"
 	const publicUploadBucket = "algorand-uploads"